@@ -0,0 +1,124 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+)
+
+// AccessNormalizer normalises the decoded fields of an access specification in place,
+// so that equivalent access specifications of the same type have equal fields.
+type AccessNormalizer func(access map[string]interface{}) error
+
+// AccessNormalizers contains access normalizers identified by the access type.
+type AccessNormalizers map[string]AccessNormalizer
+
+// Register adds a normalizer for a specific access type.
+// It returns if the operation has overwritten an already registered normalizer.
+func (an *AccessNormalizers) Register(ttype string, normalizer AccessNormalizer) (overwritten bool) {
+	if _, ok := (*an)[ttype]; ok {
+		overwritten = true
+	}
+	(*an)[ttype] = normalizer
+	return
+}
+
+// KnownAccessNormalizers contains the normalizers of all known access types.
+var KnownAccessNormalizers = AccessNormalizers{
+	OCIRegistryType:  normalizeOCIRegistryAccess,
+	LocalOCIBlobType: normalizeLocalOCIBlobAccess,
+	WebType:          normalizeWebAccess,
+}
+
+// AccessSpecEqual returns whether both access specifications are equivalent.
+// The access specifications are normalised with the known access normalizer of their type before they are compared.
+// Access specifications that cannot be normalised are compared as they are.
+func AccessSpecEqual(a, b *UnstructuredTypedObject) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.GetType() != b.GetType() {
+		return false
+	}
+	aObj, err := normalizedAccessObject(a)
+	if err != nil {
+		return false
+	}
+	bObj, err := normalizedAccessObject(b)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(aObj, bObj)
+}
+
+// normalizedAccessObject decodes the access specification and normalises it with the known normalizer of its type.
+func normalizedAccessObject(access *UnstructuredTypedObject) (map[string]interface{}, error) {
+	raw, err := access.GetRaw()
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+	normalizer, ok := KnownAccessNormalizers[access.GetType()]
+	if !ok {
+		return obj, nil
+	}
+	normalized := map[string]interface{}{}
+	for key, value := range obj {
+		normalized[key] = value
+	}
+	if err := normalizer(normalized); err != nil {
+		return obj, nil
+	}
+	return normalized, nil
+}
+
+// normalizeOCIRegistryAccess normalises the image reference to its fully qualified form
+// including the default registry and the default tag.
+func normalizeOCIRegistryAccess(access map[string]interface{}) error {
+	ref, ok := access["imageReference"].(string)
+	if !ok {
+		return nil
+	}
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return fmt.Errorf("unable to parse image reference %q: %w", ref, err)
+	}
+	access["imageReference"] = reference.TagNameOnly(named).String()
+	return nil
+}
+
+// normalizeLocalOCIBlobAccess lowercases the hex encoded digest.
+func normalizeLocalOCIBlobAccess(access map[string]interface{}) error {
+	if dig, ok := access["digest"].(string); ok {
+		access["digest"] = strings.ToLower(dig)
+	}
+	return nil
+}
+
+// normalizeWebAccess removes trailing slashes of the url.
+func normalizeWebAccess(access map[string]interface{}) error {
+	if url, ok := access["url"].(string); ok {
+		access["url"] = strings.TrimRight(url, "/")
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+var _ = Describe("access spec equality", func() {
+
+	newAccess := func(obj v2.TypedObjectAccessor) *v2.UnstructuredTypedObject {
+		u, err := v2.NewUnstructured(obj)
+		Expect(err).ToNot(HaveOccurred())
+		return &u
+	}
+
+	It("should consider equivalent oci image references as equal", func() {
+		Expect(v2.AccessSpecEqual(
+			newAccess(v2.NewOCIRegistryAccess("nginx")),
+			newAccess(v2.NewOCIRegistryAccess("docker.io/library/nginx:latest")),
+		)).To(BeTrue())
+		Expect(v2.AccessSpecEqual(
+			newAccess(v2.NewOCIRegistryAccess("eu.gcr.io/gardener-project/image:v1.0.0")),
+			newAccess(v2.NewOCIRegistryAccess("eu.gcr.io/gardener-project/image:v1.0.0")),
+		)).To(BeTrue())
+	})
+
+	It("should consider different oci image references as not equal", func() {
+		Expect(v2.AccessSpecEqual(
+			newAccess(v2.NewOCIRegistryAccess("nginx:1.0.0")),
+			newAccess(v2.NewOCIRegistryAccess("nginx:1.0.1")),
+		)).To(BeFalse())
+	})
+
+	It("should compare local blob digests case insensitive", func() {
+		Expect(v2.AccessSpecEqual(
+			newAccess(v2.NewLocalOCIBlobAccess("sha256:ABCDEF")),
+			newAccess(v2.NewLocalOCIBlobAccess("sha256:abcdef")),
+		)).To(BeTrue())
+	})
+
+	It("should ignore trailing slashes of web urls", func() {
+		Expect(v2.AccessSpecEqual(
+			newAccess(v2.NewWebAccess("https://example.com/artifact/")),
+			newAccess(v2.NewWebAccess("https://example.com/artifact")),
+		)).To(BeTrue())
+	})
+
+	It("should consider access specs of different types as not equal", func() {
+		Expect(v2.AccessSpecEqual(
+			newAccess(v2.NewWebAccess("https://example.com/artifact")),
+			newAccess(v2.NewHTTPSAccess("https://example.com/artifact")),
+		)).To(BeFalse())
+	})
+
+	It("should compare access specs without normalizer as they are", func() {
+		Expect(v2.AccessSpecEqual(
+			newAccess(v2.NewS3Access("bucket", "key")),
+			newAccess(v2.NewS3Access("bucket", "key")),
+		)).To(BeTrue())
+		Expect(v2.AccessSpecEqual(
+			newAccess(v2.NewS3Access("bucket", "key")),
+			newAccess(v2.NewS3Access("bucket", "other")),
+		)).To(BeFalse())
+	})
+})
@@ -0,0 +1,151 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// accessSchemas contains the json schemas of the built-in access types.
+// The title of each schema is the access type it describes.
+//
+//go:embed accessschemas/*.json
+var accessSchemas embed.FS
+
+// AccessSchemaRegistry contains json schemas for access specifications, identified by the access type.
+type AccessSchemaRegistry struct {
+	mux     sync.RWMutex
+	raw     map[string][]byte
+	schemas map[string]*gojsonschema.Schema
+}
+
+// NewAccessSchemaRegistry creates a new empty access schema registry.
+func NewAccessSchemaRegistry() *AccessSchemaRegistry {
+	return &AccessSchemaRegistry{
+		raw:     map[string][]byte{},
+		schemas: map[string]*gojsonschema.Schema{},
+	}
+}
+
+// DefaultAccessSchemaRegistry contains the schemas of all built-in access types.
+var DefaultAccessSchemaRegistry = NewAccessSchemaRegistry()
+
+func init() {
+	if err := registerEmbeddedAccessSchemas(DefaultAccessSchemaRegistry); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterAccessTypeSchema registers the json schema of an access type in the default access schema registry.
+func RegisterAccessTypeSchema(typeName string, schema []byte) error {
+	return DefaultAccessSchemaRegistry.Register(typeName, schema)
+}
+
+// GetAccessTypeSchema returns the json schema of an access type from the default access schema registry.
+func GetAccessTypeSchema(typeName string) ([]byte, error) {
+	return DefaultAccessSchemaRegistry.Get(typeName)
+}
+
+// Register registers the json schema for access specifications of the given type.
+func (r *AccessSchemaRegistry) Register(typeName string, schema []byte) error {
+	if len(typeName) == 0 {
+		return errors.New("a access type must not be empty")
+	}
+	s, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema))
+	if err != nil {
+		return fmt.Errorf("unable to load schema for access type %q: %w", typeName, err)
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.raw[typeName] = schema
+	r.schemas[typeName] = s
+	return nil
+}
+
+// Get returns the raw json schema for the given access type.
+// A NotFound error is returned if no schema is registered for the type.
+func (r *AccessSchemaRegistry) Get(typeName string) ([]byte, error) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	schema, ok := r.raw[typeName]
+	if !ok {
+		return nil, fmt.Errorf("no schema registered for access type %q: %w", typeName, NotFound)
+	}
+	return schema, nil
+}
+
+func (r *AccessSchemaRegistry) getSchema(typeName string) (*gojsonschema.Schema, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	s, ok := r.schemas[typeName]
+	return s, ok
+}
+
+// ValidateAccessSpec validates a raw access specification against the schema that is registered for its type.
+// Access specifications without a registered schema are always valid.
+func ValidateAccessSpec(raw json.RawMessage, registry *AccessSchemaRegistry) error {
+	objType := ObjectType{}
+	if err := json.Unmarshal(raw, &objType); err != nil {
+		return fmt.Errorf("unable to decode access type: %w", err)
+	}
+	schema, ok := registry.getSchema(objType.GetType())
+	if !ok {
+		return nil
+	}
+	res, err := schema.Validate(gojsonschema.NewBytesLoader(raw))
+	if err != nil {
+		return fmt.Errorf("unable to validate access of type %q: %w", objType.GetType(), err)
+	}
+	if !res.Valid() {
+		errs := make([]string, len(res.Errors()))
+		for i, resErr := range res.Errors() {
+			errs[i] = resErr.String()
+		}
+		return fmt.Errorf("invalid access of type %q: %s", objType.GetType(), strings.Join(errs, ";"))
+	}
+	return nil
+}
+
+// registerEmbeddedAccessSchemas registers all embedded access schemas in the given registry.
+func registerEmbeddedAccessSchemas(registry *AccessSchemaRegistry) error {
+	files, err := accessSchemas.ReadDir("accessschemas")
+	if err != nil {
+		return fmt.Errorf("unable to read embedded access schemas: %w", err)
+	}
+	for _, file := range files {
+		data, err := accessSchemas.ReadFile(path.Join("accessschemas", file.Name()))
+		if err != nil {
+			return fmt.Errorf("unable to read embedded access schema %q: %w", file.Name(), err)
+		}
+		meta := struct {
+			Title string `json:"title"`
+		}{}
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return fmt.Errorf("unable to decode embedded access schema %q: %w", file.Name(), err)
+		}
+		if err := registry.Register(meta.Title, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
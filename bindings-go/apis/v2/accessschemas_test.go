@@ -0,0 +1,61 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+var _ = Describe("access schemas", func() {
+
+	It("should ship the embedded schemas of the built-in access types", func() {
+		_, err := v2.GetAccessTypeSchema(v2.LocalFilesystemBlobType)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = v2.GetAccessTypeSchema(v2.OCIRegistryType)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = v2.GetAccessTypeSchema("unknown")
+		Expect(errors.Is(err, v2.NotFound)).To(BeTrue())
+	})
+
+	It("should validate a valid local blob access", func() {
+		raw := json.RawMessage(`{"type": "localFilesystemBlob", "filename": "sha256:abc", "mediaType": "application/json"}`)
+		Expect(v2.ValidateAccessSpec(raw, v2.DefaultAccessSchemaRegistry)).To(Succeed())
+	})
+
+	It("should reject a local blob access without a filename", func() {
+		raw := json.RawMessage(`{"type": "localFilesystemBlob", "mediaType": "application/json"}`)
+		Expect(v2.ValidateAccessSpec(raw, v2.DefaultAccessSchemaRegistry)).ToNot(Succeed())
+	})
+
+	It("should accept accesses of types without a registered schema", func() {
+		raw := json.RawMessage(`{"type": "custom", "any": 1}`)
+		Expect(v2.ValidateAccessSpec(raw, v2.NewAccessSchemaRegistry())).To(Succeed())
+	})
+
+	It("should validate against custom registered schemas", func() {
+		registry := v2.NewAccessSchemaRegistry()
+		Expect(registry.Register("custom", []byte(`{"type": "object", "required": ["url"]}`))).To(Succeed())
+		Expect(v2.ValidateAccessSpec(json.RawMessage(`{"type": "custom", "url": "a"}`), registry)).To(Succeed())
+		Expect(v2.ValidateAccessSpec(json.RawMessage(`{"type": "custom"}`), registry)).ToNot(Succeed())
+	})
+
+})
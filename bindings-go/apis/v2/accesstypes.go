@@ -22,6 +22,42 @@ var KnownAccessTypes = KnownTypes{
 	GitHubAccessType:         DefaultJSONTypedObjectCodec,
 	WebType:                  DefaultJSONTypedObjectCodec,
 	LocalFilesystemBlobType:  DefaultJSONTypedObjectCodec,
+	MavenAccessType:          DefaultJSONTypedObjectCodec,
+	HTTPSPinnedCertType:      DefaultJSONTypedObjectCodec,
+	NPMPackageType:           DefaultJSONTypedObjectCodec,
+	AuthenticatedOCIType:     DefaultJSONTypedObjectCodec,
+	PyPIPackageType:          DefaultJSONTypedObjectCodec,
+	GitHubReleaseType:        DefaultJSONTypedObjectCodec,
+	GHCRAccessType:           DefaultJSONTypedObjectCodec,
+	AzureBlobAccessType:      DefaultJSONTypedObjectCodec,
+	ArtifactoryAccessType:    DefaultJSONTypedObjectCodec,
+	GCRAccessType:            DefaultJSONTypedObjectCodec,
+	NexusAccessType:          DefaultJSONTypedObjectCodec,
+	DockerHubAccessType:      DefaultJSONTypedObjectCodec,
+}
+
+// KnownAccessTypeObjects contains constructors for the typed objects of all known access types.
+var KnownAccessTypeObjects = map[string]func() TypedObjectAccessor{
+	OCIRegistryType:          func() TypedObjectAccessor { return &OCIRegistryAccess{} },
+	OCIBlobType:              func() TypedObjectAccessor { return &OCIBlobAccess{} },
+	LocalOCIBlobType:         func() TypedObjectAccessor { return &LocalOCIBlobAccess{} },
+	RelativeOciReferenceType: func() TypedObjectAccessor { return &RelativeOciAccess{} },
+	GitHubAccessType:         func() TypedObjectAccessor { return &GitHubAccess{} },
+	WebType:                  func() TypedObjectAccessor { return &Web{} },
+	LocalFilesystemBlobType:  func() TypedObjectAccessor { return &LocalFilesystemBlobAccess{} },
+	S3AccessType:             func() TypedObjectAccessor { return &S3Access{} },
+	MavenAccessType:          func() TypedObjectAccessor { return &MavenAccess{} },
+	HTTPSPinnedCertType:      func() TypedObjectAccessor { return &HTTPSAccess{} },
+	NPMPackageType:           func() TypedObjectAccessor { return &NPMAccess{} },
+	AuthenticatedOCIType:     func() TypedObjectAccessor { return &AuthenticatedOCIAccess{} },
+	PyPIPackageType:          func() TypedObjectAccessor { return &PyPIAccess{} },
+	GitHubReleaseType:        func() TypedObjectAccessor { return &GitHubReleaseAccess{} },
+	GHCRAccessType:           func() TypedObjectAccessor { return &GHCRAccess{} },
+	AzureBlobAccessType:      func() TypedObjectAccessor { return &AzureBlobAccess{} },
+	ArtifactoryAccessType:    func() TypedObjectAccessor { return &ArtifactoryAccess{} },
+	GCRAccessType:            func() TypedObjectAccessor { return &GCRAccess{} },
+	NexusAccessType:          func() TypedObjectAccessor { return &NexusAccess{} },
+	DockerHubAccessType:      func() TypedObjectAccessor { return &DockerHubAccess{} },
 }
 
 // OCIRegistryType is the access type of a oci registry.
@@ -202,6 +238,10 @@ type GitHubAccess struct {
 	// Commit describes the git commit of the referenced repository.
 	// +optional
 	Commit string `json:"commit,omitempty"`
+	// SignedCommitBody is the raw gpg-signed commit object of the commit.
+	// It can be used to verify the commit hash and the signature of the commit, which requires the commit to be set.
+	// +optional
+	SignedCommitBody string `json:"signedCommitBody,omitempty"`
 }
 
 // NewGitHubAccess creates a new Web accessor
@@ -247,3 +287,387 @@ func NewS3Access(bucketName, objectKey string) *S3Access {
 func (a S3Access) GetType() string {
 	return S3AccessType
 }
+
+// MavenAccessType is the type of a maven artifact access.
+const MavenAccessType = "maven"
+
+// MavenAccess describes the access to an artifact in a maven repository.
+type MavenAccess struct {
+	ObjectType `json:",inline"`
+
+	// Repository is the base url of the maven repository.
+	Repository string `json:"repository"`
+	// GroupID is the group id of the artifact.
+	GroupID string `json:"groupId"`
+	// ArtifactID is the artifact id of the artifact.
+	ArtifactID string `json:"artifactId"`
+	// Version is the version of the artifact.
+	Version string `json:"version"`
+	// Classifier is the optional classifier of the artifact.
+	// +optional
+	Classifier string `json:"classifier,omitempty"`
+	// Extension is the file extension of the artifact.
+	// Defaults to "jar".
+	// +optional
+	Extension string `json:"extension,omitempty"`
+}
+
+// NewMavenAccess creates a new maven accessor
+func NewMavenAccess(repo, groupID, artifactID, version string) *MavenAccess {
+	return &MavenAccess{
+		ObjectType: ObjectType{
+			Type: MavenAccessType,
+		},
+		Repository: repo,
+		GroupID:    groupID,
+		ArtifactID: artifactID,
+		Version:    version,
+	}
+}
+
+func (a MavenAccess) GetType() string {
+	return MavenAccessType
+}
+
+// HTTPSPinnedCertType is the type of a https access with pinned tls certificates.
+const HTTPSPinnedCertType = "httpsWithPinnedCert"
+
+// HTTPSAccess describes the access to a blob via https
+// where the server certificate has to match one of the pinned certificates.
+type HTTPSAccess struct {
+	ObjectType `json:",inline"`
+
+	// URL is the https url of the blob.
+	URL string `json:"url"`
+	// PinnedCertificates is a list of base64 encoded DER x509 certificates.
+	// The leaf certificate of the server has to match one of them.
+	PinnedCertificates []string `json:"pinnedCertificates"`
+	// Headers are optional http headers that are sent with the request.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// NewHTTPSAccess creates a new https accessor with pinned certificates
+func NewHTTPSAccess(url string, pinnedCertificates ...string) *HTTPSAccess {
+	return &HTTPSAccess{
+		ObjectType: ObjectType{
+			Type: HTTPSPinnedCertType,
+		},
+		URL:                url,
+		PinnedCertificates: pinnedCertificates,
+	}
+}
+
+func (a HTTPSAccess) GetType() string {
+	return HTTPSPinnedCertType
+}
+
+// NPMPackageType is the type of a npm package access.
+const NPMPackageType = "npmPackage"
+
+// NPMAccess describes the access to a package in a npm registry.
+type NPMAccess struct {
+	ObjectType `json:",inline"`
+
+	// Registry is the base url of the npm registry.
+	Registry string `json:"registry"`
+	// Package is the name of the package, including its scope for scoped packages.
+	Package string `json:"package"`
+	// Version is the version of the package.
+	Version string `json:"version"`
+}
+
+// NewNPMAccess creates a new npm package accessor
+func NewNPMAccess(registry, pkg, version string) *NPMAccess {
+	return &NPMAccess{
+		ObjectType: ObjectType{
+			Type: NPMPackageType,
+		},
+		Registry: registry,
+		Package:  pkg,
+		Version:  version,
+	}
+}
+
+func (a NPMAccess) GetType() string {
+	return NPMPackageType
+}
+
+// AuthenticatedOCIType is the access type of a oci registry that requires authentication.
+const AuthenticatedOCIType = "authenticatedOciRegistry"
+
+// AuthenticatedOCIAccess describes the access for a oci registry that requires authentication.
+type AuthenticatedOCIAccess struct {
+	ObjectType `json:",inline"`
+
+	// ImageReference is the actual reference to the oci image repository and tag.
+	ImageReference string `json:"imageReference"`
+	// AuthSecretRef is the name of the credentials in the credential store that are used to access the registry.
+	AuthSecretRef string `json:"authSecretRef"`
+}
+
+// NewAuthenticatedOCIAccess creates a new AuthenticatedOCIAccess accessor
+func NewAuthenticatedOCIAccess(ref, authSecretRef string) *AuthenticatedOCIAccess {
+	return &AuthenticatedOCIAccess{
+		ObjectType: ObjectType{
+			Type: AuthenticatedOCIType,
+		},
+		ImageReference: ref,
+		AuthSecretRef:  authSecretRef,
+	}
+}
+
+func (a AuthenticatedOCIAccess) GetType() string {
+	return AuthenticatedOCIType
+}
+
+// PyPIPackageType is the type of a python package access.
+const PyPIPackageType = "pypiPackage"
+
+// PyPIAccess describes the access to a package in a PyPI compatible package index.
+type PyPIAccess struct {
+	ObjectType `json:",inline"`
+
+	// Index is the base url of the json api of the package index, e.g. "https://pypi.org/pypi".
+	Index string `json:"index"`
+	// Package is the name of the python package.
+	Package string `json:"package"`
+	// Version is the version of the python package.
+	Version string `json:"version"`
+}
+
+// NewPyPIAccess creates a new python package accessor
+func NewPyPIAccess(index, pkg, version string) *PyPIAccess {
+	return &PyPIAccess{
+		ObjectType: ObjectType{
+			Type: PyPIPackageType,
+		},
+		Index:   index,
+		Package: pkg,
+		Version: version,
+	}
+}
+
+func (a PyPIAccess) GetType() string {
+	return PyPIPackageType
+}
+
+// GitHubReleaseType is the type of a github release asset access.
+const GitHubReleaseType = "githubRelease"
+
+// GitHubReleaseAccess describes the access to an asset of a github release.
+type GitHubReleaseAccess struct {
+	ObjectType `json:",inline"`
+
+	// Owner is the owner of the github repository.
+	Owner string `json:"owner"`
+	// Repository is the name of the github repository.
+	Repository string `json:"repository"`
+	// Tag is the tag of the release.
+	Tag string `json:"tag"`
+	// AssetName is the name of the release asset.
+	AssetName string `json:"assetName"`
+}
+
+// NewGitHubReleaseAccess creates a new github release asset accessor
+func NewGitHubReleaseAccess(owner, repository, tag, assetName string) *GitHubReleaseAccess {
+	return &GitHubReleaseAccess{
+		ObjectType: ObjectType{
+			Type: GitHubReleaseType,
+		},
+		Owner:      owner,
+		Repository: repository,
+		Tag:        tag,
+		AssetName:  assetName,
+	}
+}
+
+func (a GitHubReleaseAccess) GetType() string {
+	return GitHubReleaseType
+}
+
+// GHCRAccessType is the type of a GitHub Container Registry access.
+const GHCRAccessType = "ghcr"
+
+// GHCRAccess describes the access for an oci image in the GitHub Container Registry.
+type GHCRAccess struct {
+	ObjectType `json:",inline"`
+
+	// ImageReference is the actual reference to the oci image repository and tag.
+	ImageReference string `json:"imageReference"`
+	// GitHubToken is the token that is used to authenticate with the GitHub Packages API.
+	// The token is read from the GITHUB_TOKEN environment variable if it is not set.
+	GitHubToken string `json:"githubToken,omitempty"`
+}
+
+// NewGHCRAccess creates a new GHCRAccess accessor
+func NewGHCRAccess(ref, token string) *GHCRAccess {
+	return &GHCRAccess{
+		ObjectType: ObjectType{
+			Type: GHCRAccessType,
+		},
+		ImageReference: ref,
+		GitHubToken:    token,
+	}
+}
+
+func (a GHCRAccess) GetType() string {
+	return GHCRAccessType
+}
+
+// AzureBlobAccessType is the type of a Azure Blob Storage access.
+const AzureBlobAccessType = "azureBlob"
+
+// AzureBlobAccess describes the access to a blob in the Azure Blob Storage.
+type AzureBlobAccess struct {
+	ObjectType `json:",inline"`
+
+	// AccountName is the name of the storage account.
+	AccountName string `json:"accountName"`
+	// ContainerName is the name of the container that contains the blob.
+	ContainerName string `json:"containerName"`
+	// BlobName is the name of the blob.
+	BlobName string `json:"blobName"`
+	// SASToken is an optional shared access signature that is used to authenticate the requests.
+	// +optional
+	SASToken string `json:"sasToken,omitempty"`
+}
+
+// NewAzureBlobAccess creates a new AzureBlobAccess accessor
+func NewAzureBlobAccess(accountName, containerName, blobName, sasToken string) *AzureBlobAccess {
+	return &AzureBlobAccess{
+		ObjectType: ObjectType{
+			Type: AzureBlobAccessType,
+		},
+		AccountName:   accountName,
+		ContainerName: containerName,
+		BlobName:      blobName,
+		SASToken:      sasToken,
+	}
+}
+
+func (a AzureBlobAccess) GetType() string {
+	return AzureBlobAccessType
+}
+
+// ArtifactoryAccessType is the type of an access to a generic JFrog Artifactory repository.
+const ArtifactoryAccessType = "artifactory"
+
+// ArtifactoryAccess describes the access to a file in a generic JFrog Artifactory repository.
+type ArtifactoryAccess struct {
+	ObjectType `json:",inline"`
+
+	// URL is the base url of the Artifactory instance.
+	URL string `json:"url"`
+	// Repository is the name of the repository that contains the file.
+	Repository string `json:"repository"`
+	// Path is the path of the file in the repository.
+	Path string `json:"path"`
+	// APIKey is an optional api key that is used to authenticate the requests.
+	// +optional
+	APIKey string `json:"apiKey,omitempty"`
+}
+
+// NewArtifactoryAccess creates a new ArtifactoryAccess accessor
+func NewArtifactoryAccess(url, repository, path string) *ArtifactoryAccess {
+	return &ArtifactoryAccess{
+		ObjectType: ObjectType{
+			Type: ArtifactoryAccessType,
+		},
+		URL:        url,
+		Repository: repository,
+		Path:       path,
+	}
+}
+
+func (a ArtifactoryAccess) GetType() string {
+	return ArtifactoryAccessType
+}
+
+// GCRAccessType is the type of a Google Container Registry access.
+const GCRAccessType = "gcr"
+
+// GCRAccess describes the access for an oci image in the Google Container Registry.
+type GCRAccess struct {
+	ObjectType `json:",inline"`
+
+	// ImageReference is the actual reference to the oci image repository and tag.
+	ImageReference string `json:"imageReference"`
+	// ServiceAccountKey is the base64 encoded json key file of the service account that is used to pull the image.
+	// The application default credentials are used if it is not set,
+	// e.g. the credentials file that is referenced by the GOOGLE_APPLICATION_CREDENTIALS environment variable.
+	// +optional
+	ServiceAccountKey string `json:"serviceAccountKey,omitempty"`
+}
+
+// NewGCRAccess creates a new GCRAccess accessor
+func NewGCRAccess(ref, serviceAccountKey string) *GCRAccess {
+	return &GCRAccess{
+		ObjectType: ObjectType{
+			Type: GCRAccessType,
+		},
+		ImageReference:    ref,
+		ServiceAccountKey: serviceAccountKey,
+	}
+}
+
+func (a GCRAccess) GetType() string {
+	return GCRAccessType
+}
+
+// NexusAccessType is the type of an access to a Sonatype Nexus Repository Manager.
+const NexusAccessType = "nexus"
+
+// NexusAccess describes the access to an asset in a raw repository of a Sonatype Nexus Repository Manager.
+type NexusAccess struct {
+	ObjectType `json:",inline"`
+
+	// Server is the base url of the Nexus instance.
+	Server string `json:"server"`
+	// Repository is the name of the repository that contains the asset.
+	Repository string `json:"repository"`
+	// Path is the path of the asset in the repository.
+	Path string `json:"path"`
+}
+
+// NewNexusAccess creates a new NexusAccess accessor
+func NewNexusAccess(server, repository, path string) *NexusAccess {
+	return &NexusAccess{
+		ObjectType: ObjectType{
+			Type: NexusAccessType,
+		},
+		Server:     server,
+		Repository: repository,
+		Path:       path,
+	}
+}
+
+func (a NexusAccess) GetType() string {
+	return NexusAccessType
+}
+
+// DockerHubAccessType is the type of a Docker Hub access.
+const DockerHubAccessType = "dockerHub"
+
+// DockerHubAccess describes the access for an oci image in Docker Hub.
+type DockerHubAccess struct {
+	ObjectType `json:",inline"`
+
+	// ImageReference is the actual reference to the oci image repository and tag, e.g. "nginx:1.23" or "docker.io/org/image:1.0.0".
+	// Official images without an organization are resolved in the "library" organization.
+	ImageReference string `json:"imageReference"`
+}
+
+// NewDockerHubAccess creates a new DockerHubAccess accessor
+func NewDockerHubAccess(ref string) *DockerHubAccess {
+	return &DockerHubAccess{
+		ObjectType: ObjectType{
+			Type: DockerHubAccessType,
+		},
+		ImageReference: ref,
+	}
+}
+
+func (a DockerHubAccess) GetType() string {
+	return DockerHubAccessType
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// AlternateAccessLabel is the name of the label that contains alternate accesses of a resource.
+// The label value is expected to be a list of access objects that define their "mediaType".
+const AlternateAccessLabel = "gardener.cloud/alternate-access"
+
+// NegotiateAccess returns the access of the resource that best matches the given media types.
+// The supported media types are evaluated in the given priority order whereas for every media type
+// the primary access of the resource is preferred over the alternate accesses defined by the AlternateAccessLabel.
+func NegotiateAccess(res cdv2.Resource, supported []string) (*cdv2.UnstructuredTypedObject, error) {
+	accesses := make([]*cdv2.UnstructuredTypedObject, 0)
+	if res.Access != nil {
+		accesses = append(accesses, res.Access)
+	}
+	if label, ok := GetLabel(res.Labels, AlternateAccessLabel); ok {
+		alternates := make([]*cdv2.UnstructuredTypedObject, 0)
+		if err := json.Unmarshal(label.Value, &alternates); err != nil {
+			return nil, fmt.Errorf("unable to decode alternate accesses of resource %q: %w", res.Name, err)
+		}
+		accesses = append(accesses, alternates...)
+	}
+
+	for _, mediaType := range supported {
+		for _, access := range accesses {
+			if GetAccessMediaType(access) == mediaType {
+				return access, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no access of resource %q matches the supported media types %v: %w", res.Name, supported, cdv2.NotFound)
+}
+
+// GetAccessMediaType returns the media type that is defined by the access.
+// An empty string is returned if no media type is defined.
+func GetAccessMediaType(access *cdv2.UnstructuredTypedObject) string {
+	if access == nil || access.Object == nil {
+		return ""
+	}
+	mediaType, _ := access.Object["mediaType"].(string)
+	return mediaType
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+var _ = Describe("access utils", func() {
+
+	Context("#NegotiateAccess", func() {
+
+		newResource := func() cdv2.Resource {
+			primary, err := cdv2.NewUnstructured(cdv2.NewLocalFilesystemBlobAccess("sha256:primary", "application/tar+gzip"))
+			Expect(err).ToNot(HaveOccurred())
+			alternate, err := cdv2.NewUnstructured(cdv2.NewLocalFilesystemBlobAccess("sha256:alternate", "application/zip"))
+			Expect(err).ToNot(HaveOccurred())
+			alternates, err := json.Marshal([]cdv2.UnstructuredTypedObject{alternate})
+			Expect(err).ToNot(HaveOccurred())
+			return cdv2.Resource{
+				IdentityObjectMeta: cdv2.IdentityObjectMeta{
+					Name: "res",
+					Labels: cdv2.Labels{
+						{Name: cdutils.AlternateAccessLabel, Value: alternates},
+					},
+				},
+				Access: &primary,
+			}
+		}
+
+		It("should return the primary access if it is supported", func() {
+			access, err := cdutils.NegotiateAccess(newResource(), []string{"application/tar+gzip", "application/zip"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(access.Object).To(HaveKeyWithValue("filename", "sha256:primary"))
+		})
+
+		It("should return the alternate access if the primary access is not supported", func() {
+			access, err := cdutils.NegotiateAccess(newResource(), []string{"application/zip"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(access.GetType()).To(Equal(cdv2.LocalFilesystemBlobType))
+			Expect(access.Object).To(HaveKeyWithValue("filename", "sha256:alternate"))
+		})
+
+		It("should prefer the access with the media type of the highest priority", func() {
+			access, err := cdutils.NegotiateAccess(newResource(), []string{"application/zip", "application/tar+gzip"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(access.Object).To(HaveKeyWithValue("filename", "sha256:alternate"))
+		})
+
+		It("should return a not found error if no access is supported", func() {
+			_, err := cdutils.NegotiateAccess(newResource(), []string{"text/plain"})
+			Expect(errors.Is(err, cdv2.NotFound)).To(BeTrue())
+		})
+	})
+})
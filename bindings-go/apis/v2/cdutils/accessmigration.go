@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils
+
+import (
+	"errors"
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// AccessMigration upgrades the access of a resource from one schema version of its access type to another.
+type AccessMigration struct {
+	// AccessType is the type of the accesses that are migrated.
+	AccessType string
+	// From is the schema version that is migrated.
+	// An empty version matches resources without a recorded schema version.
+	From string
+	// To is the schema version the access is migrated to.
+	To string
+	// Migrate converts the access.
+	// The given access is a copy and may be modified.
+	Migrate func(old *cdv2.UnstructuredTypedObject) (*cdv2.UnstructuredTypedObject, error)
+}
+
+// MigrationRegistry contains the known access migrations by access type.
+type MigrationRegistry struct {
+	migrations map[string]map[string][]AccessMigration
+}
+
+// NewMigrationRegistry creates a new empty migration registry.
+func NewMigrationRegistry() *MigrationRegistry {
+	return &MigrationRegistry{
+		migrations: map[string]map[string][]AccessMigration{},
+	}
+}
+
+// Register adds a migration to the registry.
+// Only one migration can be registered for the same access type and versions.
+func (r *MigrationRegistry) Register(migration AccessMigration) error {
+	if len(migration.AccessType) == 0 {
+		return errors.New("the access type of a migration has to be defined")
+	}
+	if migration.From == migration.To {
+		return fmt.Errorf("migration of %q must change the schema version", migration.AccessType)
+	}
+	if migration.Migrate == nil {
+		return fmt.Errorf("migration of %q from %q to %q has no migrate function", migration.AccessType, migration.From, migration.To)
+	}
+	byVersion, ok := r.migrations[migration.AccessType]
+	if !ok {
+		byVersion = map[string][]AccessMigration{}
+		r.migrations[migration.AccessType] = byVersion
+	}
+	for _, m := range byVersion[migration.From] {
+		if m.To == migration.To {
+			return fmt.Errorf("a migration of %q from %q to %q is already registered", migration.AccessType, migration.From, migration.To)
+		}
+	}
+	byVersion[migration.From] = append(byVersion[migration.From], migration)
+	return nil
+}
+
+// path returns the shortest chain of migrations for the access type from one schema version to another.
+func (r *MigrationRegistry) path(accessType, from, to string) ([]AccessMigration, bool) {
+	byVersion := r.migrations[accessType]
+	prev := map[string]AccessMigration{}
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) != 0 {
+		version := queue[0]
+		queue = queue[1:]
+		if version == to {
+			chain := make([]AccessMigration, 0)
+			for version != from {
+				m := prev[version]
+				chain = append([]AccessMigration{m}, chain...)
+				version = m.From
+			}
+			return chain, true
+		}
+		for _, m := range byVersion[version] {
+			if visited[m.To] {
+				continue
+			}
+			visited[m.To] = true
+			prev[m.To] = m
+			queue = append(queue, m.To)
+		}
+	}
+	return nil, false
+}
+
+// MigrateAllAccessSpecs migrates the accesses of all resources to the target schema version.
+// Registered migrations are chained through intermediate versions.
+// Resources that are already at the target version or whose access type has no registered migrations are left untouched.
+// The component descriptor is only modified if all migrations succeed.
+// The number of migrated resources is returned.
+func MigrateAllAccessSpecs(cd *cdv2.ComponentDescriptor, registry *MigrationRegistry, targetVersion string) (int, error) {
+	migrated := map[int]*cdv2.UnstructuredTypedObject{}
+	for i, res := range cd.Resources {
+		if res.Access == nil || res.SchemaVersion == targetVersion {
+			continue
+		}
+		accessType := res.Access.GetType()
+		if _, ok := registry.migrations[accessType]; !ok {
+			continue
+		}
+		chain, ok := registry.path(accessType, res.SchemaVersion, targetVersion)
+		if !ok {
+			return 0, fmt.Errorf("no migration of %q from %q to %q found for resource %q", accessType, res.SchemaVersion, targetVersion, res.Name)
+		}
+		access := res.Access.DeepCopy()
+		for _, m := range chain {
+			var err error
+			access, err = m.Migrate(access.DeepCopy())
+			if err != nil {
+				return 0, fmt.Errorf("unable to migrate access of resource %q from %q to %q: %w", res.Name, m.From, m.To, err)
+			}
+			if access == nil {
+				return 0, fmt.Errorf("migration of resource %q from %q to %q returned no access", res.Name, m.From, m.To)
+			}
+		}
+		migrated[i] = access
+	}
+	for i, access := range migrated {
+		cd.Resources[i].Access = access
+		cd.Resources[i].SchemaVersion = targetVersion
+	}
+	return len(migrated), nil
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+var _ = Describe("access migrations", func() {
+
+	// renameURL migrates v1 web accesses to v2 that uses "location" instead of "url".
+	renameURL := cdutils.AccessMigration{
+		AccessType: cdv2.WebType,
+		From:       "v1",
+		To:         "v2",
+		Migrate: func(old *cdv2.UnstructuredTypedObject) (*cdv2.UnstructuredTypedObject, error) {
+			return cdv2.NewUnstructuredType(cdv2.WebType, map[string]interface{}{
+				"location": old.Object["url"],
+			}), nil
+		},
+	}
+	// addScheme migrates v2 web accesses to v3 that defines the scheme of the location.
+	addScheme := cdutils.AccessMigration{
+		AccessType: cdv2.WebType,
+		From:       "v2",
+		To:         "v3",
+		Migrate: func(old *cdv2.UnstructuredTypedObject) (*cdv2.UnstructuredTypedObject, error) {
+			return cdv2.NewUnstructuredType(cdv2.WebType, map[string]interface{}{
+				"location": old.Object["location"],
+				"scheme":   "https",
+			}), nil
+		},
+	}
+
+	newDescriptor := func() *cdv2.ComponentDescriptor {
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Resources = []cdv2.Resource{
+			{
+				IdentityObjectMeta: cdv2.IdentityObjectMeta{Name: "web"},
+				Access:             cdv2.NewUnstructuredType(cdv2.WebType, map[string]interface{}{"url": "https://example.com/blob"}),
+				SchemaVersion:      "v1",
+			},
+			{
+				IdentityObjectMeta: cdv2.IdentityObjectMeta{Name: "image"},
+				Access:             cdv2.NewUnstructuredType(cdv2.OCIRegistryType, map[string]interface{}{"imageReference": "example.com/image:v1"}),
+				SchemaVersion:      "v1",
+			},
+		}
+		return cd
+	}
+
+	It("should chain migrations through intermediate versions", func() {
+		registry := cdutils.NewMigrationRegistry()
+		Expect(registry.Register(renameURL)).To(Succeed())
+		Expect(registry.Register(addScheme)).To(Succeed())
+
+		cd := newDescriptor()
+		count, err := cdutils.MigrateAllAccessSpecs(cd, registry, "v3")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(1))
+		Expect(cd.Resources[0].SchemaVersion).To(Equal("v3"))
+		Expect(cd.Resources[0].Access.Object).To(Equal(map[string]interface{}{
+			"type":     cdv2.WebType,
+			"location": "https://example.com/blob",
+			"scheme":   "https",
+		}))
+		// access types without migrations are not touched
+		Expect(cd.Resources[1].SchemaVersion).To(Equal("v1"))
+
+		count, err = cdutils.MigrateAllAccessSpecs(cd, registry, "v3")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(0))
+	})
+
+	It("should not register a migration twice", func() {
+		registry := cdutils.NewMigrationRegistry()
+		Expect(registry.Register(renameURL)).To(Succeed())
+		Expect(registry.Register(renameURL)).ToNot(Succeed())
+	})
+
+	It("should fail if no migration path exists", func() {
+		registry := cdutils.NewMigrationRegistry()
+		Expect(registry.Register(addScheme)).To(Succeed())
+
+		cd := newDescriptor()
+		_, err := cdutils.MigrateAllAccessSpecs(cd, registry, "v3")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not modify the component descriptor if a migration fails", func() {
+		registry := cdutils.NewMigrationRegistry()
+		Expect(registry.Register(renameURL)).To(Succeed())
+		Expect(registry.Register(cdutils.AccessMigration{
+			AccessType: cdv2.WebType,
+			From:       "v2",
+			To:         "v3",
+			Migrate: func(old *cdv2.UnstructuredTypedObject) (*cdv2.UnstructuredTypedObject, error) {
+				return nil, errors.New("failed")
+			},
+		})).To(Succeed())
+
+		cd := newDescriptor()
+		_, err := cdutils.MigrateAllAccessSpecs(cd, registry, "v3")
+		Expect(err).To(HaveOccurred())
+		Expect(cd).To(Equal(newDescriptor()))
+	})
+})
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// LabelChange describes a label that is part of both label lists but with different values.
+type LabelChange struct {
+	Name string
+	// OldValue is the json value of the label in the old labels.
+	OldValue string
+	// NewValue is the json value of the label in the new labels.
+	NewValue string
+}
+
+// LabelDiff describes the changes between two label lists.
+type LabelDiff struct {
+	// Added contains the labels that are only part of the new labels.
+	Added []cdv2.Label
+	// Removed contains the labels that are only part of the old labels.
+	Removed []cdv2.Label
+	// Modified contains the labels that are part of both but with different values.
+	Modified []LabelChange
+}
+
+// IsEmpty returns whether the diff contains no changes.
+func (d LabelDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// DiffLabels compares two label lists by the label names.
+// Values are compared as json, so that formatting differences are not reported as modification.
+// All changes are sorted by the label name.
+func DiffLabels(a, b cdv2.Labels) LabelDiff {
+	oldLabels := labelsByName(a)
+	newLabels := labelsByName(b)
+
+	diff := LabelDiff{}
+	for name, oldLabel := range oldLabels {
+		newLabel, ok := newLabels[name]
+		if !ok {
+			diff.Removed = append(diff.Removed, oldLabel)
+			continue
+		}
+		if !jsonEqual(oldLabel.Value, newLabel.Value) {
+			diff.Modified = append(diff.Modified, LabelChange{
+				Name:     name,
+				OldValue: string(oldLabel.Value),
+				NewValue: string(newLabel.Value),
+			})
+		}
+	}
+	for name, newLabel := range newLabels {
+		if _, ok := oldLabels[name]; !ok {
+			diff.Added = append(diff.Added, newLabel)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Name < diff.Added[j].Name })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Name < diff.Removed[j].Name })
+	sort.Slice(diff.Modified, func(i, j int) bool { return diff.Modified[i].Name < diff.Modified[j].Name })
+	return diff
+}
+
+// DiffResourceLabels compares the labels of the resource with the given identity in both component descriptors.
+// A resource that is only part of one component descriptor is compared against empty labels.
+// A NotFound error is returned if neither component descriptor contains the resource.
+func DiffResourceLabels(old, new *cdv2.ComponentDescriptor, identity cdv2.Identity) (*LabelDiff, error) {
+	oldRes, oldErr := old.GetResourceByIdentity(identity)
+	if oldErr != nil && !errors.Is(oldErr, cdv2.NotFound) {
+		return nil, oldErr
+	}
+	newRes, newErr := new.GetResourceByIdentity(identity)
+	if newErr != nil && !errors.Is(newErr, cdv2.NotFound) {
+		return nil, newErr
+	}
+	if oldErr != nil && newErr != nil {
+		return nil, fmt.Errorf("resource %v is not part of the component descriptors: %w", identity, cdv2.NotFound)
+	}
+	diff := DiffLabels(oldRes.Labels, newRes.Labels)
+	return &diff, nil
+}
+
+// DiffComponentLabels compares the labels of both component descriptors.
+func DiffComponentLabels(old, new *cdv2.ComponentDescriptor) *LabelDiff {
+	diff := DiffLabels(old.Labels, new.Labels)
+	return &diff
+}
+
+// labelsByName returns the labels indexed by their name.
+// If a name is used multiple times, the last label wins.
+func labelsByName(labels cdv2.Labels) map[string]cdv2.Label {
+	byName := make(map[string]cdv2.Label, len(labels))
+	for _, label := range labels {
+		byName[label.Name] = label
+	}
+	return byName
+}
+
+// jsonEqual checks whether both json values are equal ignoring insignificant whitespace.
+func jsonEqual(a, b json.RawMessage) bool {
+	var compactA, compactB bytes.Buffer
+	if json.Compact(&compactA, a) != nil || json.Compact(&compactB, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(compactA.Bytes(), compactB.Bytes())
+}
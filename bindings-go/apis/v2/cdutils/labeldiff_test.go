@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+var _ = Describe("label diff", func() {
+
+	label := func(name, value string) cdv2.Label {
+		return cdv2.Label{Name: name, Value: json.RawMessage(value)}
+	}
+
+	It("should report added, removed and modified labels", func() {
+		diff := cdutils.DiffLabels(
+			cdv2.Labels{label("unchanged", `"a"`), label("removed", `"b"`), label("modified", `"old"`), label("formatted", `{"a": 1}`)},
+			cdv2.Labels{label("unchanged", `"a"`), label("modified", `"new"`), label("added", `true`), label("formatted", `{"a":1}`)},
+		)
+		Expect(diff.Added).To(ConsistOf(label("added", `true`)))
+		Expect(diff.Removed).To(ConsistOf(label("removed", `"b"`)))
+		Expect(diff.Modified).To(ConsistOf(cdutils.LabelChange{
+			Name:     "modified",
+			OldValue: `"old"`,
+			NewValue: `"new"`,
+		}))
+		Expect(diff.IsEmpty()).To(BeFalse())
+	})
+
+	It("should return an empty diff for equal labels", func() {
+		labels := cdv2.Labels{label("a", `"a"`), label("b", `[1,2]`)}
+		Expect(cdutils.DiffLabels(labels, labels).IsEmpty()).To(BeTrue())
+	})
+
+	Context("component descriptors", func() {
+
+		newComponentDescriptor := func(componentLabels, resourceLabels cdv2.Labels) *cdv2.ComponentDescriptor {
+			cd := &cdv2.ComponentDescriptor{}
+			cd.Labels = componentLabels
+			cd.Resources = []cdv2.Resource{
+				{IdentityObjectMeta: cdv2.IdentityObjectMeta{Name: "image", Version: "v1.0.0", Labels: resourceLabels}},
+			}
+			return cd
+		}
+
+		It("should diff the labels of a resource", func() {
+			oldCd := newComponentDescriptor(nil, cdv2.Labels{label("role", `"main"`), label("removed", `1`)})
+			newCd := newComponentDescriptor(nil, cdv2.Labels{label("role", `"sidecar"`), label("added", `2`)})
+
+			diff, err := cdutils.DiffResourceLabels(oldCd, newCd, cdv2.Identity{"name": "image"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff.Added).To(ConsistOf(label("added", `2`)))
+			Expect(diff.Removed).To(ConsistOf(label("removed", `1`)))
+			Expect(diff.Modified).To(ConsistOf(cdutils.LabelChange{
+				Name:     "role",
+				OldValue: `"main"`,
+				NewValue: `"sidecar"`,
+			}))
+		})
+
+		It("should report all labels as added if the resource is new", func() {
+			oldCd := &cdv2.ComponentDescriptor{}
+			newCd := newComponentDescriptor(nil, cdv2.Labels{label("role", `"main"`)})
+
+			diff, err := cdutils.DiffResourceLabels(oldCd, newCd, cdv2.Identity{"name": "image"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff.Added).To(ConsistOf(label("role", `"main"`)))
+		})
+
+		It("should return a NotFound error if the resource is not part of any component descriptor", func() {
+			cd := newComponentDescriptor(nil, nil)
+			_, err := cdutils.DiffResourceLabels(cd, cd, cdv2.Identity{"name": "unknown"})
+			Expect(errors.Is(err, cdv2.NotFound)).To(BeTrue())
+		})
+
+		It("should diff the labels of the components", func() {
+			oldCd := newComponentDescriptor(cdv2.Labels{label("owner", `"team-a"`)}, nil)
+			newCd := newComponentDescriptor(cdv2.Labels{label("owner", `"team-b"`)}, nil)
+
+			diff := cdutils.DiffComponentLabels(oldCd, newCd)
+			Expect(diff.Added).To(BeEmpty())
+			Expect(diff.Removed).To(BeEmpty())
+			Expect(diff.Modified).To(ConsistOf(cdutils.LabelChange{
+				Name:     "owner",
+				OldValue: `"team-a"`,
+				NewValue: `"team-b"`,
+			}))
+		})
+	})
+
+})
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils
+
+import (
+	"errors"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// LabelInheritanceOptions defines which labels a referenced component descriptor inherits from the referencing one.
+type LabelInheritanceOptions struct {
+	// Keys are the names of the labels that are inherited.
+	Keys []string
+	// InheritAll inherits all labels of the referencing component descriptor and ignores the keys.
+	InheritAll bool
+}
+
+// ApplyReferenceLabels copies the labels with the given names from the parent to the child component descriptor.
+// Labels that are already defined by the child are not overwritten.
+func ApplyReferenceLabels(parent *cdv2.ComponentDescriptor, child *cdv2.ComponentDescriptor, keys []string) error {
+	return ApplyReferenceLabelsWithOptions(parent, child, LabelInheritanceOptions{Keys: keys})
+}
+
+// ApplyReferenceLabelsWithOptions copies the labels that are selected by the options from the parent to the child component descriptor.
+// Labels that are already defined by the child are not overwritten.
+func ApplyReferenceLabelsWithOptions(parent *cdv2.ComponentDescriptor, child *cdv2.ComponentDescriptor, opts LabelInheritanceOptions) error {
+	if parent == nil || child == nil {
+		return errors.New("the parent and the child component descriptor must not be nil")
+	}
+	keys := map[string]struct{}{}
+	for _, key := range opts.Keys {
+		keys[key] = struct{}{}
+	}
+	for _, label := range parent.Labels {
+		if _, ok := keys[label.Name]; !ok && !opts.InheritAll {
+			continue
+		}
+		if _, ok := child.Labels.Get(label.Name); ok {
+			continue
+		}
+		inherited := label.DeepCopy()
+		child.Labels = append(child.Labels, *inherited)
+	}
+	return nil
+}
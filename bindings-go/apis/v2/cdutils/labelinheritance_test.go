@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+var _ = Describe("label inheritance", func() {
+
+	newLabel := func(name, value string) cdv2.Label {
+		return cdv2.Label{Name: name, Value: json.RawMessage(value)}
+	}
+
+	newComponent := func(labels ...cdv2.Label) *cdv2.ComponentDescriptor {
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Labels = labels
+		return cd
+	}
+
+	It("should copy the labels with the given keys without overwriting the child's labels", func() {
+		parent := newComponent(newLabel("a", `"parent"`), newLabel("b", `"parent"`), newLabel("c", `"parent"`))
+		child := newComponent(newLabel("b", `"child"`))
+
+		Expect(cdutils.ApplyReferenceLabels(parent, child, []string{"a", "b"})).To(Succeed())
+		Expect(child.Labels).To(Equal(cdv2.Labels{
+			newLabel("b", `"child"`),
+			newLabel("a", `"parent"`),
+		}))
+	})
+
+	It("should copy all labels if configured", func() {
+		parent := newComponent(newLabel("a", `"parent"`), newLabel("b", `"parent"`))
+		child := newComponent(newLabel("b", `"child"`))
+
+		Expect(cdutils.ApplyReferenceLabelsWithOptions(parent, child, cdutils.LabelInheritanceOptions{InheritAll: true})).To(Succeed())
+		Expect(child.Labels).To(Equal(cdv2.Labels{
+			newLabel("b", `"child"`),
+			newLabel("a", `"parent"`),
+		}))
+	})
+
+	It("should not share label values with the parent", func() {
+		parent := newComponent(newLabel("a", `"parent"`))
+		child := newComponent()
+
+		Expect(cdutils.ApplyReferenceLabels(parent, child, []string{"a"})).To(Succeed())
+		child.Labels[0].Value[1] = 'P'
+		Expect(string(parent.Labels[0].Value)).To(Equal(`"parent"`))
+	})
+
+	It("should fail for nil component descriptors", func() {
+		Expect(cdutils.ApplyReferenceLabels(nil, newComponent(), []string{"a"})).ToNot(Succeed())
+		Expect(cdutils.ApplyReferenceLabels(newComponent(), nil, []string{"a"})).ToNot(Succeed())
+	})
+
+})
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+var (
+	providerDomainsMux sync.RWMutex
+	providerDomains    = map[string]string{}
+)
+
+// RegisterProviderDomain registers the domain that has to be used as namespace of the labels
+// of all components of the given provider.
+// An already registered domain of the provider is replaced.
+func RegisterProviderDomain(provider, domain string) error {
+	if len(provider) == 0 {
+		return errors.New("a provider must not be empty")
+	}
+	domain = strings.TrimSuffix(strings.TrimSpace(domain), "/")
+	if len(domain) == 0 {
+		return fmt.Errorf("a domain for provider %q must not be empty", provider)
+	}
+	if strings.Contains(domain, "/") {
+		return fmt.Errorf("domain %q for provider %q must not contain a path", domain, provider)
+	}
+	providerDomainsMux.Lock()
+	defer providerDomainsMux.Unlock()
+	providerDomains[provider] = domain
+	return nil
+}
+
+// ProviderDomain returns the domain that is registered for the given provider.
+func ProviderDomain(provider string) (string, bool) {
+	providerDomainsMux.RLock()
+	defer providerDomainsMux.RUnlock()
+	domain, ok := providerDomains[provider]
+	return domain, ok
+}
+
+// LabelNamespaceViolation describes a label that is not namespaced with the domain of the component's provider.
+type LabelNamespaceViolation struct {
+	// Path is the path of the labels list that contains the label, e.g. "resources[0].labels".
+	Path string
+	// LabelName is the name of the violating label.
+	LabelName string
+	// Domain is the domain that is registered for the component's provider.
+	Domain string
+}
+
+func (v LabelNamespaceViolation) String() string {
+	return fmt.Sprintf("label %q in %s is not namespaced with the provider domain %q", v.LabelName, v.Path, v.Domain)
+}
+
+// ValidateLabelNamespace checks that all labels of the component and its resources are namespaced
+// with the domain that is registered for the component's provider.
+// A label is namespaced if its name is prefixed with "<domain>/" or "<subdomain>.<domain>/".
+// Components of providers without a registered domain are not checked.
+func ValidateLabelNamespace(cd *cdv2.ComponentDescriptor) []LabelNamespaceViolation {
+	domain, ok := ProviderDomain(string(cd.Provider))
+	if !ok {
+		return nil
+	}
+	violations := validateLabelNamespace("labels", cd.Labels, domain)
+	for i, res := range cd.Resources {
+		violations = append(violations, validateLabelNamespace(fmt.Sprintf("resources[%d].labels", i), res.Labels, domain)...)
+	}
+	return violations
+}
+
+func validateLabelNamespace(path string, labels cdv2.Labels, domain string) []LabelNamespaceViolation {
+	var violations []LabelNamespaceViolation
+	for _, label := range labels {
+		if !isNamespacedLabel(label.Name, domain) {
+			violations = append(violations, LabelNamespaceViolation{
+				Path:      path,
+				LabelName: label.Name,
+				Domain:    domain,
+			})
+		}
+	}
+	return violations
+}
+
+// isNamespacedLabel returns whether the label name is prefixed with the domain or one of its subdomains.
+func isNamespacedLabel(name, domain string) bool {
+	namespace, _, ok := strings.Cut(name, "/")
+	if !ok {
+		return false
+	}
+	return namespace == domain || strings.HasSuffix(namespace, "."+domain)
+}
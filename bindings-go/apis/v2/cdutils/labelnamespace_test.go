@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+var _ = Describe("label namespaces", func() {
+
+	newLabel := func(name string) cdv2.Label {
+		return cdv2.Label{Name: name, Value: json.RawMessage(`true`)}
+	}
+
+	newComponent := func(provider string, labels cdv2.Labels, resourceLabels cdv2.Labels) *cdv2.ComponentDescriptor {
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Provider = cdv2.ProviderType(provider)
+		cd.Labels = labels
+		cd.Resources = []cdv2.Resource{
+			{IdentityObjectMeta: cdv2.IdentityObjectMeta{Name: "res", Labels: resourceLabels}},
+		}
+		return cd
+	}
+
+	BeforeEach(func() {
+		Expect(cdutils.RegisterProviderDomain("my-org", "my-org.example.com")).To(Succeed())
+	})
+
+	It("should accept labels in the provider's namespace", func() {
+		cd := newComponent("my-org",
+			cdv2.Labels{newLabel("my-org.example.com/key")},
+			cdv2.Labels{newLabel("team.my-org.example.com/key")})
+		Expect(cdutils.ValidateLabelNamespace(cd)).To(BeEmpty())
+	})
+
+	It("should report labels outside of the provider's namespace", func() {
+		cd := newComponent("my-org",
+			cdv2.Labels{newLabel("my-org.example.com/key"), newLabel("other.example.com/key")},
+			cdv2.Labels{newLabel("key"), newLabel("evil-my-org.example.com/key")})
+		Expect(cdutils.ValidateLabelNamespace(cd)).To(Equal([]cdutils.LabelNamespaceViolation{
+			{Path: "labels", LabelName: "other.example.com/key", Domain: "my-org.example.com"},
+			{Path: "resources[0].labels", LabelName: "key", Domain: "my-org.example.com"},
+			{Path: "resources[0].labels", LabelName: "evil-my-org.example.com/key", Domain: "my-org.example.com"},
+		}))
+	})
+
+	It("should not check components of providers without a registered domain", func() {
+		cd := newComponent("unregistered", cdv2.Labels{newLabel("other.example.com/key")}, nil)
+		Expect(cdutils.ValidateLabelNamespace(cd)).To(BeEmpty())
+	})
+
+	It("should reject invalid registrations", func() {
+		Expect(cdutils.RegisterProviderDomain("", "my-org.example.com")).ToNot(Succeed())
+		Expect(cdutils.RegisterProviderDomain("my-org", "")).ToNot(Succeed())
+		Expect(cdutils.RegisterProviderDomain("my-org", "example.com/path")).ToNot(Succeed())
+	})
+
+})
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// LegacyOCIImageType is the access type that was used by old component descriptors for oci images.
+const LegacyOCIImageType = "ociImage"
+
+// UpgradeLegacyResourceAccess converts a legacy oci image access of the resource to an ociRegistry access.
+// An access is considered legacy if it defines an "imageReference" but has no type or the type LegacyOCIImageType.
+// It returns whether the access has been converted.
+func UpgradeLegacyResourceAccess(res *cdv2.Resource) (bool, error) {
+	if res.Access == nil {
+		return false, nil
+	}
+	accessType := res.Access.GetType()
+	if len(accessType) != 0 && accessType != LegacyOCIImageType {
+		return false, nil
+	}
+	raw, err := res.Access.GetRaw()
+	if err != nil {
+		return false, fmt.Errorf("unable to get access of resource %q: %w", res.Name, err)
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return false, fmt.Errorf("unable to decode access of resource %q: %w", res.Name, err)
+	}
+	imageReference, ok := fields["imageReference"]
+	if !ok {
+		return false, nil
+	}
+	ref, ok := imageReference.(string)
+	if !ok || len(ref) == 0 {
+		return false, fmt.Errorf("legacy access of resource %q has an invalid image reference", res.Name)
+	}
+	access, err := cdv2.NewUnstructured(cdv2.NewOCIRegistryAccess(ref))
+	if err != nil {
+		return false, fmt.Errorf("unable to convert access of resource %q: %w", res.Name, err)
+	}
+	res.Access = &access
+	return true, nil
+}
+
+// UpgradeLegacyResourceAccesses converts the legacy oci image accesses of all resources to ociRegistry accesses.
+// The component descriptor is only modified if all conversions succeed.
+// The number of converted resources is returned.
+func UpgradeLegacyResourceAccesses(cd *cdv2.ComponentDescriptor) (int, error) {
+	upgraded := map[int]*cdv2.UnstructuredTypedObject{}
+	for i := range cd.Resources {
+		res := cd.Resources[i]
+		res.Access = res.Access.DeepCopy()
+		ok, err := UpgradeLegacyResourceAccess(&res)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			upgraded[i] = res.Access
+		}
+	}
+	for i, access := range upgraded {
+		cd.Resources[i].Access = access
+	}
+	return len(upgraded), nil
+}
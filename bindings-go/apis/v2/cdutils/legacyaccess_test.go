@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+var _ = Describe("legacy resource accesses", func() {
+
+	newResource := func(data string) cdv2.Resource {
+		res := cdv2.Resource{}
+		Expect(json.Unmarshal([]byte(data), &res)).To(Succeed())
+		return res
+	}
+
+	expectOCIRegistryAccess := func(res cdv2.Resource, ref string) {
+		Expect(res.Access.GetType()).To(Equal(cdv2.OCIRegistryType))
+		access := &cdv2.OCIRegistryAccess{}
+		Expect(res.Access.DecodeInto(access)).To(Succeed())
+		Expect(access.ImageReference).To(Equal(ref))
+	}
+
+	It("should convert untyped accesses with an image reference", func() {
+		res := newResource(`{"name": "image", "access": {"imageReference": "example.com/image:v1"}}`)
+		ok, err := cdutils.UpgradeLegacyResourceAccess(&res)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		expectOCIRegistryAccess(res, "example.com/image:v1")
+	})
+
+	It("should convert legacy oci image accesses", func() {
+		res := newResource(`{"name": "image", "access": {"type": "ociImage", "imageReference": "example.com/image:v1"}}`)
+		ok, err := cdutils.UpgradeLegacyResourceAccess(&res)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		expectOCIRegistryAccess(res, "example.com/image:v1")
+	})
+
+	It("should not convert current accesses", func() {
+		res := newResource(`{"name": "image", "access": {"type": "ociRegistry", "imageReference": "example.com/image:v1"}}`)
+		ok, err := cdutils.UpgradeLegacyResourceAccess(&res)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		res = newResource(`{"name": "blob", "access": {"type": "web", "url": "https://example.com/blob"}}`)
+		ok, err = cdutils.UpgradeLegacyResourceAccess(&res)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should fail for invalid image references", func() {
+		res := newResource(`{"name": "image", "access": {"type": "ociImage", "imageReference": 5}}`)
+		_, err := cdutils.UpgradeLegacyResourceAccess(&res)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should convert the accesses of all resources", func() {
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Resources = []cdv2.Resource{
+			newResource(`{"name": "image-1", "access": {"imageReference": "example.com/image-1:v1"}}`),
+			newResource(`{"name": "image-2", "access": {"type": "ociRegistry", "imageReference": "example.com/image-2:v1"}}`),
+			newResource(`{"name": "image-3", "access": {"type": "ociImage", "imageReference": "example.com/image-3:v1"}}`),
+		}
+		count, err := cdutils.UpgradeLegacyResourceAccesses(cd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(2))
+		expectOCIRegistryAccess(cd.Resources[0], "example.com/image-1:v1")
+		expectOCIRegistryAccess(cd.Resources[1], "example.com/image-2:v1")
+		expectOCIRegistryAccess(cd.Resources[2], "example.com/image-3:v1")
+	})
+
+	It("should not modify the component descriptor if a conversion fails", func() {
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Resources = []cdv2.Resource{
+			newResource(`{"name": "image-1", "access": {"imageReference": "example.com/image-1:v1"}}`),
+			newResource(`{"name": "image-2", "access": {"type": "ociImage", "imageReference": ""}}`),
+		}
+		_, err := cdutils.UpgradeLegacyResourceAccesses(cd)
+		Expect(err).To(HaveOccurred())
+		Expect(cd.Resources[0].Access.GetType()).To(BeEmpty())
+	})
+
+})
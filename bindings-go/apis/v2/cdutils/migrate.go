@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// AccessURLFields defines the attribute of an access type that contains its url or reference.
+var AccessURLFields = map[string]string{
+	cdv2.OCIRegistryType:          "imageReference",
+	cdv2.OCIBlobType:              "ref",
+	cdv2.RelativeOciReferenceType: "reference",
+	cdv2.WebType:                  "url",
+	cdv2.GitHubAccessType:         "repoUrl",
+	cdv2.MavenAccessType:          "repository",
+}
+
+// URLMigrationRule replaces all matches of the pattern in a access url with the replacement.
+// The replacement may reference submatches as described by regexp.Regexp.ReplaceAllString.
+type URLMigrationRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// MigrationPreview describes the migration of the access url of a resource.
+type MigrationPreview struct {
+	// Resource is the name of the migrated resource.
+	Resource string
+	// ExtraIdentity is the extra identity of the migrated resource.
+	ExtraIdentity cdv2.Identity
+	// Before is the url before the migration.
+	Before string
+	// After is the url after the migration.
+	After string
+}
+
+// MigrateResourceURLs applies the migration rules in the given order to the access urls of all resources.
+// Only access types that are defined in AccessURLFields are migrated.
+// The number of modified resources is returned.
+func MigrateResourceURLs(cd *cdv2.ComponentDescriptor, rules []URLMigrationRule) (int, error) {
+	modified := 0
+	for i, res := range cd.Resources {
+		field, _, after, ok := migrateResourceURL(res, rules)
+		if !ok {
+			continue
+		}
+		access := cd.Resources[i].Access
+		access.Object[field] = after
+		raw, err := json.Marshal(access.Object)
+		if err != nil {
+			return modified, fmt.Errorf("unable to encode access of resource %q: %w", res.Name, err)
+		}
+		access.Raw = raw
+		modified++
+	}
+	return modified, nil
+}
+
+// DryRunMigrateResourceURLs returns the changes that MigrateResourceURLs would apply without modifying the component descriptor.
+func DryRunMigrateResourceURLs(cd *cdv2.ComponentDescriptor, rules []URLMigrationRule) []MigrationPreview {
+	previews := make([]MigrationPreview, 0)
+	for _, res := range cd.Resources {
+		_, before, after, ok := migrateResourceURL(res, rules)
+		if !ok {
+			continue
+		}
+		previews = append(previews, MigrationPreview{
+			Resource:      res.Name,
+			ExtraIdentity: res.ExtraIdentity,
+			Before:        before,
+			After:         after,
+		})
+	}
+	return previews
+}
+
+// migrateResourceURL applies the rules to the access url of the resource.
+// It returns the url field of the access and the url before and after the migration.
+// False is returned if the access url is not changed by the rules.
+func migrateResourceURL(res cdv2.Resource, rules []URLMigrationRule) (string, string, string, bool) {
+	if res.Access == nil || res.Access.Object == nil {
+		return "", "", "", false
+	}
+	field, ok := AccessURLFields[res.Access.GetType()]
+	if !ok {
+		return "", "", "", false
+	}
+	before, ok := res.Access.Object[field].(string)
+	if !ok {
+		return "", "", "", false
+	}
+	after := before
+	for _, rule := range rules {
+		after = rule.Pattern.ReplaceAllString(after, rule.Replacement)
+	}
+	return field, before, after, after != before
+}
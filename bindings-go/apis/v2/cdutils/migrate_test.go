@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils_test
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+var _ = Describe("url migration", func() {
+
+	var (
+		cd    *cdv2.ComponentDescriptor
+		rules []cdutils.URLMigrationRule
+	)
+
+	newResource := func(name string, access cdv2.TypedObjectAccessor) cdv2.Resource {
+		unstructured, err := cdv2.NewUnstructured(access)
+		Expect(err).ToNot(HaveOccurred())
+		return cdv2.Resource{
+			IdentityObjectMeta: cdv2.IdentityObjectMeta{Name: name},
+			Access:             &unstructured,
+		}
+	}
+
+	BeforeEach(func() {
+		cd = &cdv2.ComponentDescriptor{}
+		cd.Resources = []cdv2.Resource{
+			newResource("image", cdv2.NewOCIRegistryAccess("nexus.example.com/images/app:1.0.0")),
+			newResource("chart", cdv2.NewWebAccess("https://nexus.example.com/repository/charts/app-1.0.0.tgz")),
+			newResource("lib", cdv2.NewMavenAccess("https://nexus.example.com/repository/maven", "org.example", "lib", "1.0.0")),
+			newResource("other", cdv2.NewOCIRegistryAccess("ghcr.io/example/other:1.0.0")),
+			newResource("local", cdv2.NewLocalFilesystemBlobAccess("sha256:abc", "txt")),
+		}
+		rules = []cdutils.URLMigrationRule{
+			{Pattern: regexp.MustCompile(`^https://nexus\.example\.com/repository/`), Replacement: "https://jfrog.example.com/artifactory/"},
+			{Pattern: regexp.MustCompile(`^nexus\.example\.com/(.*)$`), Replacement: "jfrog.example.com/docker/$1"},
+		}
+	})
+
+	It("should apply all rules to the resource urls and count the modified resources", func() {
+		count, err := cdutils.MigrateResourceURLs(cd, rules)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(3))
+
+		ociAccess := &cdv2.OCIRegistryAccess{}
+		Expect(cd.Resources[0].Access.DecodeInto(ociAccess)).To(Succeed())
+		Expect(ociAccess.ImageReference).To(Equal("jfrog.example.com/docker/images/app:1.0.0"))
+		webAccess := &cdv2.Web{}
+		Expect(cd.Resources[1].Access.DecodeInto(webAccess)).To(Succeed())
+		Expect(webAccess.URL).To(Equal("https://jfrog.example.com/artifactory/charts/app-1.0.0.tgz"))
+		mavenAccess := &cdv2.MavenAccess{}
+		Expect(cd.Resources[2].Access.DecodeInto(mavenAccess)).To(Succeed())
+		Expect(mavenAccess.Repository).To(Equal("https://jfrog.example.com/artifactory/maven"))
+		Expect(cd.Resources[3].Access.Object).To(HaveKeyWithValue("imageReference", "ghcr.io/example/other:1.0.0"))
+	})
+
+	It("should preview the migration without modifying the component descriptor", func() {
+		previews := cdutils.DryRunMigrateResourceURLs(cd, rules)
+		Expect(previews).To(HaveLen(3))
+		Expect(previews[0].Resource).To(Equal("image"))
+		Expect(previews[0].Before).To(Equal("nexus.example.com/images/app:1.0.0"))
+		Expect(previews[0].After).To(Equal("jfrog.example.com/docker/images/app:1.0.0"))
+		Expect(cd.Resources[0].Access.Object).To(HaveKeyWithValue("imageReference", "nexus.example.com/images/app:1.0.0"))
+	})
+
+})
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// RegionalMirrorsLabel is the name of the label that contains the regional mirrors of a component descriptor.
+const RegionalMirrorsLabel = "gardener.cloud/regional-mirrors"
+
+// RegionalMirror describes a repository context in a specific region where the component is mirrored to.
+type RegionalMirror struct {
+	// Region is the name of the region of the mirror.
+	Region string `json:"region"`
+	// RepositoryContext is the repository context of the mirror.
+	// The repository context is unstructured so that it can be decoded into any known repository type.
+	RepositoryContext *cdv2.UnstructuredTypedObject `json:"repositoryContext"`
+}
+
+// GetRegionalMirrors returns the regional mirrors that are defined by the RegionalMirrorsLabel of the component descriptor.
+// An empty list is returned if no mirrors are defined.
+func GetRegionalMirrors(cd *cdv2.ComponentDescriptor) ([]RegionalMirror, error) {
+	mirrors := make([]RegionalMirror, 0)
+	label, ok := GetLabel(cd.Labels, RegionalMirrorsLabel)
+	if !ok {
+		return mirrors, nil
+	}
+	if err := json.Unmarshal(label.Value, &mirrors); err != nil {
+		return nil, fmt.Errorf("unable to decode regional mirrors of component %q: %w", cd.Name, err)
+	}
+	return mirrors, nil
+}
+
+// SetRegionalMirrors sets the regional mirrors as RegionalMirrorsLabel of the component descriptor.
+func SetRegionalMirrors(cd *cdv2.ComponentDescriptor, mirrors []RegionalMirror) error {
+	labels, err := SetLabel(cd.Labels, RegionalMirrorsLabel, mirrors)
+	if err != nil {
+		return fmt.Errorf("unable to encode regional mirrors of component %q: %w", cd.Name, err)
+	}
+	cd.Labels = labels
+	return nil
+}
+
+// NearestMirror returns the mirror of the preferred region.
+// If no mirror is defined for the preferred region, the first mirror is returned as fallback.
+func NearestMirror(cd *cdv2.ComponentDescriptor, preferredRegion string) (*RegionalMirror, error) {
+	mirrors, err := GetRegionalMirrors(cd)
+	if err != nil {
+		return nil, err
+	}
+	if len(mirrors) == 0 {
+		return nil, fmt.Errorf("no regional mirrors defined for component %q: %w", cd.Name, cdv2.NotFound)
+	}
+	for _, mirror := range mirrors {
+		if mirror.Region == preferredRegion {
+			return &mirror, nil
+		}
+	}
+	return &mirrors[0], nil
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+var _ = Describe("regional mirrors", func() {
+
+	newMirror := func(region, baseURL string) cdutils.RegionalMirror {
+		repoCtx, err := cdv2.NewUnstructured(cdv2.NewOCIRegistryRepository(baseURL, ""))
+		Expect(err).ToNot(HaveOccurred())
+		return cdutils.RegionalMirror{
+			Region:            region,
+			RepositoryContext: &repoCtx,
+		}
+	}
+
+	It("should set and get the regional mirrors", func() {
+		cd := &cdv2.ComponentDescriptor{}
+		mirrors := []cdutils.RegionalMirror{
+			newMirror("eu", "eu.example.com"),
+			newMirror("us", "us.example.com"),
+		}
+		Expect(cdutils.SetRegionalMirrors(cd, mirrors)).To(Succeed())
+
+		result, err := cdutils.GetRegionalMirrors(cd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveLen(2))
+		Expect(result[1].Region).To(Equal("us"))
+		repoCtx := &cdv2.OCIRegistryRepository{}
+		Expect(result[1].RepositoryContext.DecodeInto(repoCtx)).To(Succeed())
+		Expect(repoCtx.BaseURL).To(Equal("us.example.com"))
+	})
+
+	It("should return the mirror of the preferred region", func() {
+		cd := &cdv2.ComponentDescriptor{}
+		Expect(cdutils.SetRegionalMirrors(cd, []cdutils.RegionalMirror{
+			newMirror("eu", "eu.example.com"),
+			newMirror("us", "us.example.com"),
+		})).To(Succeed())
+
+		mirror, err := cdutils.NearestMirror(cd, "us")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mirror.Region).To(Equal("us"))
+	})
+
+	It("should fall back to the first mirror if the preferred region is not available", func() {
+		cd := &cdv2.ComponentDescriptor{}
+		Expect(cdutils.SetRegionalMirrors(cd, []cdutils.RegionalMirror{
+			newMirror("eu", "eu.example.com"),
+			newMirror("us", "us.example.com"),
+		})).To(Succeed())
+
+		mirror, err := cdutils.NearestMirror(cd, "ap")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mirror.Region).To(Equal("eu"))
+	})
+
+	It("should return a not found error if no mirrors are defined", func() {
+		_, err := cdutils.NearestMirror(&cdv2.ComponentDescriptor{}, "eu")
+		Expect(errors.Is(err, cdv2.NotFound)).To(BeTrue())
+	})
+})
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// NormalizeResource returns a normalized copy of the resource that can be compared to other normalized resources.
+// The labels are sorted by name and their values are re-encoded with sorted keys,
+// nil slices are replaced with empty slices, and empty maps as well as null and empty string attributes of the access are removed.
+func NormalizeResource(res cdv2.Resource) cdv2.Resource {
+	out := *res.DeepCopy()
+	if len(out.ExtraIdentity) == 0 {
+		out.ExtraIdentity = nil
+	}
+	out.Labels = normalizeLabels(out.Labels)
+	if out.SourceRef == nil {
+		out.SourceRef = []cdv2.SourceRef{}
+	}
+	for i, ref := range out.SourceRef {
+		if len(ref.IdentitySelector) == 0 {
+			out.SourceRef[i].IdentitySelector = nil
+		}
+		out.SourceRef[i].Labels = normalizeLabels(ref.Labels)
+	}
+	if res.Access != nil {
+		out.Access = normalizeAccess(res.Access)
+	}
+	return out
+}
+
+// ResourcesSemanticEqual returns whether both resources are equal after they have been normalized.
+func ResourcesSemanticEqual(a, b cdv2.Resource) bool {
+	return reflect.DeepEqual(NormalizeResource(a), NormalizeResource(b))
+}
+
+func normalizeLabels(labels cdv2.Labels) cdv2.Labels {
+	out := make(cdv2.Labels, len(labels))
+	for i, label := range labels {
+		out[i] = cdv2.Label{
+			Name:  label.Name,
+			Value: normalizeJSON(label.Value),
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// normalizeJSON re-encodes the json value so that object keys are sorted and insignificant whitespace is removed.
+// Invalid json is returned unchanged.
+func normalizeJSON(data json.RawMessage) json.RawMessage {
+	var obj interface{}
+	if err := decodeJSON(data, &obj); err != nil {
+		return data
+	}
+	normalized, err := json.Marshal(obj)
+	if err != nil {
+		return data
+	}
+	return normalized
+}
+
+func normalizeAccess(access *cdv2.UnstructuredTypedObject) *cdv2.UnstructuredTypedObject {
+	out := access.DeepCopy()
+	data, err := json.Marshal(access.Object)
+	if err != nil {
+		return out
+	}
+	obj := map[string]interface{}{}
+	if err := decodeJSON(data, &obj); err != nil {
+		return out
+	}
+	obj = removeZeroValues(obj).(map[string]interface{})
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return out
+	}
+	out.Object = obj
+	out.Raw = raw
+	return out
+}
+
+// removeZeroValues recursively removes null values, empty strings and empty maps from objects.
+func removeZeroValues(in interface{}) interface{} {
+	switch castIn := in.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(castIn))
+		for key, val := range castIn {
+			val = removeZeroValues(val)
+			if val == nil || val == "" {
+				continue
+			}
+			if m, ok := val.(map[string]interface{}); ok && len(m) == 0 {
+				continue
+			}
+			out[key] = val
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(castIn))
+		for i, val := range castIn {
+			out[i] = removeZeroValues(val)
+		}
+		return out
+	default:
+		return in
+	}
+}
+
+func decodeJSON(data []byte, into interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(into)
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+var _ = Describe("resource normalization", func() {
+
+	newResource := func(access map[string]interface{}, labels ...cdv2.Label) cdv2.Resource {
+		return cdv2.Resource{
+			IdentityObjectMeta: cdv2.IdentityObjectMeta{
+				Name:    "image",
+				Version: "v0.0.1",
+				Type:    cdv2.OCIImageType,
+				Labels:  labels,
+			},
+			Relation: cdv2.ExternalRelation,
+			Access:   cdv2.NewUnstructuredType(cdv2.OCIRegistryType, access),
+		}
+	}
+
+	It("should consider resources with reordered labels as equal", func() {
+		a := newResource(map[string]interface{}{"imageReference": "example.com/image:v0.0.1"},
+			cdv2.Label{Name: "a", Value: json.RawMessage(`{"x": 1, "y": 2}`)},
+			cdv2.Label{Name: "b", Value: json.RawMessage(`"val"`)},
+		)
+		b := newResource(map[string]interface{}{"imageReference": "example.com/image:v0.0.1", "mediaType": nil},
+			cdv2.Label{Name: "b", Value: json.RawMessage(`"val"`)},
+			cdv2.Label{Name: "a", Value: json.RawMessage(`{"y":2,"x":1}`)},
+		)
+		b.SourceRef = nil
+		a.SourceRef = []cdv2.SourceRef{}
+		Expect(cdutils.ResourcesSemanticEqual(a, b)).To(BeTrue())
+
+		normalized := cdutils.NormalizeResource(b)
+		Expect(normalized.Labels[0].Name).To(Equal("a"))
+		Expect(normalized.SourceRef).ToNot(BeNil())
+		Expect(normalized.Access.Object).ToNot(HaveKey("mediaType"))
+		Expect(b.Labels[0].Name).To(Equal("b"), "the original resource should not be modified")
+	})
+
+	It("should not consider resources with different accesses as equal", func() {
+		a := newResource(map[string]interface{}{"imageReference": "example.com/image:v0.0.1"})
+		b := newResource(map[string]interface{}{"imageReference": "example.com/image:v0.0.2"})
+		Expect(cdutils.ResourcesSemanticEqual(a, b)).To(BeFalse())
+	})
+
+	It("should not consider resources with different label values as equal", func() {
+		a := newResource(nil, cdv2.Label{Name: "a", Value: json.RawMessage(`1`)})
+		b := newResource(nil, cdv2.Label{Name: "a", Value: json.RawMessage(`2`)})
+		Expect(cdutils.ResourcesSemanticEqual(a, b)).To(BeFalse())
+	})
+})
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// ComponentRoleLabel is the name of the resource label that describes the role of the resource within its component.
+const ComponentRoleLabel = "gardener.cloud/component-role"
+
+// Well-known component roles of resources.
+const (
+	MainImageRole     = "main-image"
+	InitContainerRole = "init-container"
+	SidecarRole       = "sidecar"
+	CLIToolRole       = "cli-tool"
+)
+
+// ComponentRoleRegistry contains the set of allowed component roles.
+type ComponentRoleRegistry struct {
+	mux   sync.RWMutex
+	roles map[string]struct{}
+}
+
+// NewComponentRoleRegistry creates a new registry that allows the given roles.
+func NewComponentRoleRegistry(roles ...string) *ComponentRoleRegistry {
+	r := &ComponentRoleRegistry{
+		roles: map[string]struct{}{},
+	}
+	for _, role := range roles {
+		r.roles[role] = struct{}{}
+	}
+	return r
+}
+
+// DefaultComponentRoleRegistry contains the well-known component roles.
+var DefaultComponentRoleRegistry = NewComponentRoleRegistry(MainImageRole, InitContainerRole, SidecarRole, CLIToolRole)
+
+// Register adds the given role to the allowed roles.
+func (r *ComponentRoleRegistry) Register(role string) error {
+	if len(role) == 0 {
+		return fmt.Errorf("a component role must not be empty")
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.roles[role] = struct{}{}
+	return nil
+}
+
+// IsKnown returns whether the given role is allowed.
+func (r *ComponentRoleRegistry) IsKnown(role string) bool {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	_, ok := r.roles[role]
+	return ok
+}
+
+// Roles returns all allowed roles in alphabetical order.
+func (r *ComponentRoleRegistry) Roles() []string {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	roles := make([]string, 0, len(r.roles))
+	for role := range r.roles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// SetResourceRole sets the ComponentRoleLabel of the resource.
+// An error is returned if the role is not known to the registry.
+func SetResourceRole(res *cdv2.Resource, role string, registry *ComponentRoleRegistry) error {
+	if !registry.IsKnown(role) {
+		return fmt.Errorf("unknown component role %q, expected one of %v", role, registry.Roles())
+	}
+	labels, err := SetLabel(res.Labels, ComponentRoleLabel, role)
+	if err != nil {
+		return fmt.Errorf("unable to set component role of resource %q: %w", res.Name, err)
+	}
+	res.Labels = labels
+	return nil
+}
+
+// GetResourceRole returns the role that is defined by the ComponentRoleLabel of the resource.
+// An empty string is returned if no role is defined.
+func GetResourceRole(res cdv2.Resource) (string, error) {
+	label, ok := GetLabel(res.Labels, ComponentRoleLabel)
+	if !ok {
+		return "", nil
+	}
+	var role string
+	if err := json.Unmarshal(label.Value, &role); err != nil {
+		return "", fmt.Errorf("unable to decode component role of resource %q: %w", res.Name, err)
+	}
+	return role, nil
+}
+
+// GroupResourcesByRole groups the resources of the component descriptor by their component role.
+// Resources without a role or with a role that is not known to the registry are not included.
+func GroupResourcesByRole(cd *cdv2.ComponentDescriptor, registry *ComponentRoleRegistry) map[string][]cdv2.Resource {
+	groups := map[string][]cdv2.Resource{}
+	for _, res := range cd.Resources {
+		role, err := GetResourceRole(res)
+		if err != nil || !registry.IsKnown(role) {
+			continue
+		}
+		groups[role] = append(groups[role], res)
+	}
+	return groups
+}
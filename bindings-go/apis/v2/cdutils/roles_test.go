@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+var _ = Describe("component roles", func() {
+
+	newResource := func(name string) cdv2.Resource {
+		return cdv2.Resource{
+			IdentityObjectMeta: cdv2.IdentityObjectMeta{
+				Name:    name,
+				Version: "v0.0.1",
+			},
+		}
+	}
+
+	It("should not set an unknown role", func() {
+		res := newResource("image")
+		Expect(cdutils.SetResourceRole(&res, "unknown", cdutils.DefaultComponentRoleRegistry)).ToNot(Succeed())
+		Expect(res.Labels).To(BeEmpty())
+	})
+
+	It("should set and get a known role", func() {
+		res := newResource("image")
+		Expect(cdutils.SetResourceRole(&res, cdutils.MainImageRole, cdutils.DefaultComponentRoleRegistry)).To(Succeed())
+		role, err := cdutils.GetResourceRole(res)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(role).To(Equal(cdutils.MainImageRole))
+	})
+
+	It("should group resources by their role", func() {
+		registry := cdutils.NewComponentRoleRegistry(cdutils.MainImageRole, cdutils.SidecarRole)
+		Expect(registry.Register("docs")).To(Succeed())
+
+		main := newResource("main")
+		sidecar1 := newResource("sidecar1")
+		sidecar2 := newResource("sidecar2")
+		docs := newResource("docs")
+		Expect(cdutils.SetResourceRole(&main, cdutils.MainImageRole, registry)).To(Succeed())
+		Expect(cdutils.SetResourceRole(&sidecar1, cdutils.SidecarRole, registry)).To(Succeed())
+		Expect(cdutils.SetResourceRole(&sidecar2, cdutils.SidecarRole, registry)).To(Succeed())
+		Expect(cdutils.SetResourceRole(&docs, "docs", registry)).To(Succeed())
+
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Resources = []cdv2.Resource{main, sidecar1, newResource("none"), sidecar2, docs}
+
+		groups := cdutils.GroupResourcesByRole(cd, registry)
+		Expect(groups).To(HaveLen(3))
+		Expect(groups[cdutils.MainImageRole]).To(ConsistOf(main))
+		Expect(groups[cdutils.SidecarRole]).To(ConsistOf(sidecar1, sidecar2))
+		Expect(groups["docs"]).To(ConsistOf(docs))
+
+		groups = cdutils.GroupResourcesByRole(cd, cdutils.DefaultComponentRoleRegistry)
+		Expect(groups).To(HaveLen(2))
+	})
+})
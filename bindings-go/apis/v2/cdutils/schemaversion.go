@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils
+
+import (
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// AccessTypeRegistry maps access types to their current schema version.
+type AccessTypeRegistry map[string]string
+
+// SchemaVersion returns the current schema version of the given access type.
+func (r AccessTypeRegistry) SchemaVersion(accessType string) (string, bool) {
+	version, ok := r[accessType]
+	return version, ok
+}
+
+// ResourceUpgrade describes a resource whose access schema version differs from the current one.
+type ResourceUpgrade struct {
+	// Resource is the identity of the resource.
+	Resource cdv2.Identity `json:"resource"`
+	// AccessType is the type of the resource's access.
+	AccessType string `json:"accessType"`
+	// From is the schema version that is currently recorded for the resource.
+	// It is empty if no schema version has been recorded yet.
+	From string `json:"from"`
+	// To is the current schema version of the access type.
+	To string `json:"to"`
+}
+
+// SetResourceSchemaVersions records the current schema version of each resource's access type.
+// Resources without access or with an access type that is unknown to the registry are left untouched.
+func SetResourceSchemaVersions(cd *cdv2.ComponentDescriptor, registry AccessTypeRegistry) {
+	for i, res := range cd.Resources {
+		if res.Access == nil {
+			continue
+		}
+		version, ok := registry.SchemaVersion(res.Access.GetType())
+		if !ok {
+			continue
+		}
+		cd.Resources[i].SchemaVersion = version
+	}
+}
+
+// FindResourceSchemaUpgrades returns all resources whose recorded schema version
+// differs from the current schema version of their access type.
+func FindResourceSchemaUpgrades(cd *cdv2.ComponentDescriptor, registry AccessTypeRegistry) []ResourceUpgrade {
+	upgrades := make([]ResourceUpgrade, 0)
+	for _, res := range cd.Resources {
+		if res.Access == nil {
+			continue
+		}
+		version, ok := registry.SchemaVersion(res.Access.GetType())
+		if !ok || version == res.SchemaVersion {
+			continue
+		}
+		upgrades = append(upgrades, ResourceUpgrade{
+			Resource:   res.GetIdentity(),
+			AccessType: res.Access.GetType(),
+			From:       res.SchemaVersion,
+			To:         version,
+		})
+	}
+	return upgrades
+}
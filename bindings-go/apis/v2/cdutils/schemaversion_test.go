@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+var _ = Describe("resource schema versions", func() {
+
+	newResource := func(name string, access cdv2.TypedObjectAccessor) cdv2.Resource {
+		acc, err := cdv2.NewUnstructured(access)
+		Expect(err).ToNot(HaveOccurred())
+		return cdv2.Resource{
+			IdentityObjectMeta: cdv2.IdentityObjectMeta{
+				Name:    name,
+				Version: "v0.0.1",
+			},
+			Access: &acc,
+		}
+	}
+
+	registry := cdutils.AccessTypeRegistry{
+		cdv2.OCIRegistryType: "v2",
+		cdv2.WebType:         "v1",
+	}
+
+	It("should set the schema version of all known access types", func() {
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Resources = []cdv2.Resource{
+			newResource("image", cdv2.NewOCIRegistryAccess("example.com/image:v0.0.1")),
+			newResource("blob", cdv2.NewLocalFilesystemBlobAccess("blob", "application/json")),
+			{IdentityObjectMeta: cdv2.IdentityObjectMeta{Name: "none"}},
+		}
+		cdutils.SetResourceSchemaVersions(cd, registry)
+		Expect(cd.Resources[0].SchemaVersion).To(Equal("v2"))
+		Expect(cd.Resources[1].SchemaVersion).To(BeEmpty())
+		Expect(cd.Resources[2].SchemaVersion).To(BeEmpty())
+	})
+
+	It("should find resources with outdated schema versions", func() {
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Resources = []cdv2.Resource{
+			newResource("image", cdv2.NewOCIRegistryAccess("example.com/image:v0.0.1")),
+			newResource("current", cdv2.NewOCIRegistryAccess("example.com/image:v0.0.2")),
+			newResource("blob", cdv2.NewLocalFilesystemBlobAccess("blob", "application/json")),
+		}
+		cd.Resources[0].SchemaVersion = "v1"
+		cd.Resources[1].SchemaVersion = "v2"
+
+		upgrades := cdutils.FindResourceSchemaUpgrades(cd, registry)
+		Expect(upgrades).To(ConsistOf(cdutils.ResourceUpgrade{
+			Resource:   cdv2.Identity{"name": "image"},
+			AccessType: cdv2.OCIRegistryType,
+			From:       "v1",
+			To:         "v2",
+		}))
+	})
+})
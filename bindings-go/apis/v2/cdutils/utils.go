@@ -6,6 +6,8 @@ package cdutils
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
 
 	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
 )
@@ -85,6 +87,39 @@ func GetLabelIdx(labels []v2.Label, name string) int {
 	return -1
 }
 
+// InheritComponentLabels copies the labels with the given names from the component descriptor
+// to all resources, sources and component references that do not define the label themselves.
+func InheritComponentLabels(cd *v2.ComponentDescriptor, keys []string) {
+	for _, key := range keys {
+		label, ok := GetLabel(cd.Labels, key)
+		if !ok {
+			continue
+		}
+		for i := range cd.Resources {
+			cd.Resources[i].Labels = inheritLabel(cd.Resources[i].Labels, label)
+		}
+		for i := range cd.Sources {
+			cd.Sources[i].Labels = inheritLabel(cd.Sources[i].Labels, label)
+		}
+		for i := range cd.ComponentReferences {
+			cd.ComponentReferences[i].Labels = inheritLabel(cd.ComponentReferences[i].Labels, label)
+		}
+	}
+}
+
+// inheritLabel adds a copy of the label to the labels if no label with the same name is defined.
+func inheritLabel(labels v2.Labels, label v2.Label) v2.Labels {
+	if GetLabelIdx(labels, label.Name) != -1 {
+		return labels
+	}
+	value := make([]byte, len(label.Value))
+	copy(value, label.Value)
+	return append(labels, v2.Label{
+		Name:  label.Name,
+		Value: value,
+	})
+}
+
 // StringDefined validates if a string is defined
 func StringDefined(s string) bool {
 	return len(s) != 0
@@ -113,6 +148,52 @@ func SetRawLabel(labels []v2.Label, name string, val []byte) []v2.Label {
 	})
 }
 
+// SetResourceLabels sets the given labels of the resource with the given identity.
+// The values are set as json strings, existing labels with the same name are updated in place.
+func SetResourceLabels(cd *v2.ComponentDescriptor, identity v2.Identity, labels map[string]string) error {
+	res, err := cd.GetResourceByIdentity(identity)
+	if err != nil {
+		return fmt.Errorf("unable to get resource: %w", err)
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		res.Labels, err = SetLabel(res.Labels, name, labels[name])
+		if err != nil {
+			return fmt.Errorf("unable to set label %q: %w", name, err)
+		}
+	}
+	cd.Resources[cd.GetResourceIndex(res)] = res
+	return nil
+}
+
+// RemoveResourceLabels removes the labels with the given names from the resource with the given identity.
+// The number of removed labels is returned.
+func RemoveResourceLabels(cd *v2.ComponentDescriptor, identity v2.Identity, names []string) (int, error) {
+	res, err := cd.GetResourceByIdentity(identity)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get resource: %w", err)
+	}
+	remove := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		remove[name] = struct{}{}
+	}
+	labels := make(v2.Labels, 0, len(res.Labels))
+	for _, label := range res.Labels {
+		if _, ok := remove[label.Name]; ok {
+			continue
+		}
+		labels = append(labels, label)
+	}
+	removed := len(res.Labels) - len(labels)
+	res.Labels = labels
+	cd.Resources[cd.GetResourceIndex(res)] = res
+	return removed, nil
+}
+
 // SetExtraIdentityField sets a extra identity field of a identity object.
 func SetExtraIdentityField(o *v2.IdentityObjectMeta, key, val string) {
 	if o.ExtraIdentity == nil {
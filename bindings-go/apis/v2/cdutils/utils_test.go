@@ -5,6 +5,8 @@
 package cdutils
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -41,4 +43,84 @@ var _ = Describe("resource utils", func() {
 			))
 		})
 	})
+
+	Context("#InheritComponentLabels", func() {
+
+		It("should copy the component labels to all objects that do not define them", func() {
+			cd := &cdv2.ComponentDescriptor{}
+			cd.Labels = cdv2.Labels{
+				{Name: "gardener.cloud/environment", Value: []byte(`"prod"`)},
+				{Name: "gardener.cloud/team", Value: []byte(`"a"`)},
+				{Name: "gardener.cloud/other", Value: []byte(`"x"`)},
+			}
+			cd.Resources = []cdv2.Resource{
+				{IdentityObjectMeta: cdv2.IdentityObjectMeta{Name: "res1"}},
+				{IdentityObjectMeta: cdv2.IdentityObjectMeta{
+					Name:   "res2",
+					Labels: cdv2.Labels{{Name: "gardener.cloud/environment", Value: []byte(`"dev"`)}},
+				}},
+			}
+			cd.Sources = []cdv2.Source{
+				{IdentityObjectMeta: cdv2.IdentityObjectMeta{Name: "src1"}},
+			}
+			cd.ComponentReferences = []cdv2.ComponentReference{
+				{Name: "ref1"},
+			}
+
+			InheritComponentLabels(cd, []string{"gardener.cloud/environment", "gardener.cloud/team", "gardener.cloud/missing"})
+
+			Expect(cd.Resources[0].Labels).To(ConsistOf(
+				cdv2.Label{Name: "gardener.cloud/environment", Value: []byte(`"prod"`)},
+				cdv2.Label{Name: "gardener.cloud/team", Value: []byte(`"a"`)},
+			))
+			Expect(cd.Resources[1].Labels).To(ConsistOf(
+				cdv2.Label{Name: "gardener.cloud/environment", Value: []byte(`"dev"`)},
+				cdv2.Label{Name: "gardener.cloud/team", Value: []byte(`"a"`)},
+			), "the resource should keep its own value")
+			Expect(cd.Sources[0].Labels).To(HaveLen(2))
+			Expect(cd.ComponentReferences[0].Labels).To(HaveLen(2))
+		})
+	})
+
+	Context("#SetResourceLabels", func() {
+
+		newComponentDescriptor := func() *cdv2.ComponentDescriptor {
+			cd := &cdv2.ComponentDescriptor{}
+			cd.Resources = []cdv2.Resource{
+				{
+					IdentityObjectMeta: cdv2.IdentityObjectMeta{
+						Name:   "res",
+						Labels: cdv2.Labels{{Name: "a", Value: []byte(`"old"`)}},
+					},
+				},
+			}
+			return cd
+		}
+
+		It("should set and remove labels of the resource", func() {
+			cd := newComponentDescriptor()
+			id := cd.Resources[0].GetIdentity()
+			Expect(SetResourceLabels(cd, id, map[string]string{"a": "new", "b": "value"})).To(Succeed())
+			Expect(cd.Resources[0].Labels).To(Equal(cdv2.Labels{
+				{Name: "a", Value: []byte(`"new"`)},
+				{Name: "b", Value: []byte(`"value"`)},
+			}))
+
+			removed, err := RemoveResourceLabels(cd, id, []string{"a", "unknown"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(removed).To(Equal(1))
+			Expect(cd.Resources[0].Labels).To(Equal(cdv2.Labels{
+				{Name: "b", Value: []byte(`"value"`)},
+			}))
+		})
+
+		It("should return an error if the resource does not exist", func() {
+			cd := newComponentDescriptor()
+			id := cdv2.Identity{"name": "unknown"}
+			err := SetResourceLabels(cd, id, map[string]string{"a": "new"})
+			Expect(errors.Is(err, cdv2.NotFound)).To(BeTrue())
+			_, err = RemoveResourceLabels(cd, id, []string{"a"})
+			Expect(errors.Is(err, cdv2.NotFound)).To(BeTrue())
+		})
+	})
 })
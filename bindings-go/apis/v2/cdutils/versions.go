@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils
+
+import (
+	"fmt"
+	"regexp"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// VersionRegexp is the regexp that new versions have to match when versions are bumped.
+// It defaults to the relaxed semver definition of the component descriptor json schema.
+var VersionRegexp = regexp.MustCompile(`^[v]?(0|[1-9]\d*)(?:\.(0|[1-9]\d*))?(?:\.(0|[1-9]\d*))?(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// BumpReferenceVersion updates all component references to the given component name and old version to the new version.
+// The number of updated component references is returned.
+func BumpReferenceVersion(cd *cdv2.ComponentDescriptor, componentName, oldVersion, newVersion string) (int, error) {
+	if err := validateVersion(newVersion); err != nil {
+		return 0, err
+	}
+	count := 0
+	for i, ref := range cd.ComponentReferences {
+		if ref.ComponentName != componentName || ref.Version != oldVersion {
+			continue
+		}
+		cd.ComponentReferences[i].Version = newVersion
+		count++
+	}
+	return count, nil
+}
+
+// BumpResourceVersion updates all resources with the given name and old version to the new version.
+// The number of updated resources is returned.
+func BumpResourceVersion(cd *cdv2.ComponentDescriptor, resourceName, oldVersion, newVersion string) (int, error) {
+	if err := validateVersion(newVersion); err != nil {
+		return 0, err
+	}
+	count := 0
+	for i, res := range cd.Resources {
+		if res.Name != resourceName || res.Version != oldVersion {
+			continue
+		}
+		cd.Resources[i].Version = newVersion
+		count++
+	}
+	return count, nil
+}
+
+func validateVersion(version string) error {
+	if !VersionRegexp.MatchString(version) {
+		return fmt.Errorf("invalid version %q: must match %q", version, VersionRegexp.String())
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cdutils_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+var _ = Describe("version bumping", func() {
+
+	var cd *cdv2.ComponentDescriptor
+
+	BeforeEach(func() {
+		cd = &cdv2.ComponentDescriptor{}
+		cd.ComponentReferences = []cdv2.ComponentReference{
+			{Name: "a", ComponentName: "example.com/a", Version: "v1.0.0"},
+			{Name: "a-legacy", ComponentName: "example.com/a", Version: "v0.9.0"},
+			{Name: "b", ComponentName: "example.com/b", Version: "v1.0.0"},
+		}
+		cd.Resources = []cdv2.Resource{
+			{IdentityObjectMeta: cdv2.IdentityObjectMeta{Name: "image", Version: "v1.0.0"}},
+			{IdentityObjectMeta: cdv2.IdentityObjectMeta{Name: "chart", Version: "v1.0.0"}},
+		}
+	})
+
+	It("should only bump matching component references", func() {
+		count, err := cdutils.BumpReferenceVersion(cd, "example.com/a", "v1.0.0", "v1.1.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(1))
+		Expect(cd.ComponentReferences[0].Version).To(Equal("v1.1.0"))
+		Expect(cd.ComponentReferences[1].Version).To(Equal("v0.9.0"))
+		Expect(cd.ComponentReferences[2].Version).To(Equal("v1.0.0"))
+	})
+
+	It("should only bump matching resources", func() {
+		count, err := cdutils.BumpResourceVersion(cd, "image", "v1.0.0", "v1.1.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(1))
+		Expect(cd.Resources[0].Version).To(Equal("v1.1.0"))
+		Expect(cd.Resources[1].Version).To(Equal("v1.0.0"))
+	})
+
+	It("should reject invalid new versions", func() {
+		count, err := cdutils.BumpReferenceVersion(cd, "example.com/a", "v1.0.0", "not a version")
+		Expect(err).To(HaveOccurred())
+		Expect(count).To(Equal(0))
+		Expect(cd.ComponentReferences[0].Version).To(Equal("v1.0.0"))
+
+		_, err = cdutils.BumpResourceVersion(cd, "image", "v1.0.0", "1.0.0.0")
+		Expect(err).To(HaveOccurred())
+	})
+
+})
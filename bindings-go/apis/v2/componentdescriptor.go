@@ -17,6 +17,7 @@ package v2
 import (
 	"encoding/json"
 	"errors"
+	"time"
 )
 
 const SchemaVersion = "v2"
@@ -65,6 +66,18 @@ type ComponentDescriptor struct {
 
 	// Signatures contains a list of signatures for the ComponentDescriptor
 	Signatures []Signature `json:"signatures,omitempty"`
+
+	// ProviderCertificatePin contains the hex encoded SHA-256 fingerprint of the provider's signing certificate.
+	// The pin is not part of the normalised component descriptor and therefore not protected by signatures.
+	ProviderCertificatePin string `json:"providerCertificatePin,omitempty"`
+
+	// TrustDomain is the default trust domain of the signatures of the component descriptor.
+	// Signatures of a trust domain are named "<trustDomain>/<signatureName>".
+	TrustDomain string `json:"trustDomain,omitempty"`
+
+	// Extensions contains additional typed data that is attached to the component descriptor.
+	// The raw extensions are decoded using the ExtensionRegistry.
+	Extensions map[string]json.RawMessage `json:"extensions,omitempty"`
 }
 
 // ComponentSpec defines a virtual component with
@@ -351,6 +364,10 @@ type Resource struct {
 	// +optional
 	Digest *DigestSpec `json:"digest,omitempty"`
 
+	// AdditionalDigests are optional digests of the referenced resource that are calculated with other hash algorithms.
+	// +optional
+	AdditionalDigests []DigestSpec `json:"additionalDigests,omitempty"`
+
 	// Relation describes the relation of the resource to the component.
 	// Can be a local or external resource
 	Relation ResourceRelation `json:"relation,omitempty"`
@@ -362,6 +379,11 @@ type Resource struct {
 	// Access describes the type specific method to
 	// access the defined resource.
 	Access *UnstructuredTypedObject `json:"access"`
+
+	// SchemaVersion is the schema version of the access type
+	// that was used when the resource was added to the component descriptor.
+	// +optional
+	SchemaVersion string `json:"schemaVersion,omitempty"`
 }
 
 // ComponentReference describes the reference to another component in the registry.
@@ -438,6 +460,9 @@ type DigestSpec struct {
 	HashAlgorithm          string `json:"hashAlgorithm"`
 	NormalisationAlgorithm string `json:"normalisationAlgorithm"`
 	Value                  string `json:"value"`
+	// Size is the size of the digested blob in bytes.
+	// It is not part of the normalised component descriptor.
+	Size int64 `json:"size,omitempty"`
 }
 
 // SignatureSpec defines a signature.
@@ -447,6 +472,13 @@ type SignatureSpec struct {
 	Algorithm string `json:"algorithm"`
 	Value     string `json:"value"`
 	MediaType string `json:"mediaType"`
+	// Certificate contains the pem encoded certificate chain of the signing key.
+	// The certificate is optional and only set by signers that sign with a certificate bound key.
+	Certificate string `json:"certificate,omitempty"`
+	// NotBefore defines the time from which on the signature is valid.
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+	// NotAfter defines the time until which the signature is valid.
+	NotAfter *time.Time `json:"notAfter,omitempty"`
 }
 
 const (
@@ -465,6 +497,12 @@ const (
 	// RSAPKCS1v15 defines the type for the RSA PKCS #1 v1.5 signature algorithm
 	RSAPKCS1v15 = "RSASSA-PKCS1-V1_5"
 
+	// MediaTypeECDSASignature defines the media type for a plain ASN.1 encoded ECDSA signature.
+	MediaTypeECDSASignature = "application/vnd.ocm.signature.ecdsa"
+
+	// ECDSAASN1 defines the type for the ECDSA signature algorithm with ASN.1 encoded signatures
+	ECDSAASN1 = "ECDSA-ASN1"
+
 	// ExcludeFromSignature used in digest field for normalisationAlgorithm (in combination with NoDigest for hashAlgorithm and value)
 	// to indicate the resource content should not be part of the signature
 	ExcludeFromSignature = "EXCLUDE-FROM-SIGNATURE"
@@ -488,8 +526,10 @@ type NormalisationAlgorithm string
 
 const (
 	JsonNormalisationV1 NormalisationAlgorithm = "jsonNormalisation/v1"
+	JsonNormalisationV2 NormalisationAlgorithm = "jsonNormalisation/v2"
 	OciArtifactDigestV1 NormalisationAlgorithm = "ociArtifactDigest/v1"
 	GenericBlobDigestV1 NormalisationAlgorithm = "genericBlobDigest/v1"
+	ResourceIdentityV1  NormalisationAlgorithm = "resourceIdentity/v1"
 )
 
 // Signature defines a digest and corresponding signature, identifyable by name.
@@ -0,0 +1,172 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RedactedValue replaces the values of credential fields in debug output.
+const RedactedValue = "[REDACTED]"
+
+// credentialAccessFields contains the json names of the access fields that contain credentials.
+var credentialAccessFields = map[string]struct{}{
+	"githubToken":       {},
+	"sasToken":          {},
+	"apiKey":            {},
+	"serviceAccountKey": {},
+}
+
+// PrettyPrintAccessSpec returns a human readable representation of a json encoded access specification.
+// If the access type is known, the access is decoded into its typed object and printed with the name of the go type.
+// Otherwise, or if the access contains fields that are unknown to the typed object, the indented json is returned.
+// The values of credential fields are replaced by RedactedValue.
+func PrettyPrintAccessSpec(raw json.RawMessage) (string, error) {
+	typedObj := ObjectType{}
+	if err := json.Unmarshal(raw, &typedObj); err != nil {
+		return "", fmt.Errorf("unable to decode access: %w", err)
+	}
+	raw, err := redactCredentials(raw)
+	if err != nil {
+		return "", err
+	}
+	if newObj, ok := KnownAccessTypeObjects[typedObj.GetType()]; ok {
+		obj := newObj()
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(obj); err == nil {
+			data, err := json.MarshalIndent(obj, "", "  ")
+			if err != nil {
+				return "", fmt.Errorf("unable to encode access: %w", err)
+			}
+			return fmt.Sprintf("%s (%s) %s", typedObj.GetType(), reflect.TypeOf(obj).Elem().String(), data), nil
+		}
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		return "", fmt.Errorf("unable to indent access: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// DebugString returns a human readable representation of the component descriptor including all access specifications.
+// It allocates heavily and should only be used for debugging.
+func (c ComponentDescriptor) DebugString() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "component %s:%s\n", c.GetName(), c.GetVersion())
+
+	meta := c.DeepCopy()
+	meta.Resources = nil
+	meta.Sources = nil
+	writeDebugJSON(&sb, meta)
+
+	for _, src := range c.Sources {
+		fmt.Fprintf(&sb, "source %s:%s (type %s)\n", src.GetName(), src.GetVersion(), src.GetType())
+		writeDebugAccess(&sb, src.Access)
+	}
+	for _, res := range c.Resources {
+		sb.WriteString(res.DebugString())
+	}
+	return sb.String()
+}
+
+// DebugString returns a human readable representation of the resource including its access specification.
+// It allocates heavily and should only be used for debugging.
+func (r Resource) DebugString() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "resource %s:%s (type %s, relation %s)\n", r.GetName(), r.GetVersion(), r.GetType(), r.Relation)
+
+	meta := r.DeepCopy()
+	meta.Access = nil
+	writeDebugJSON(&sb, meta)
+	writeDebugAccess(&sb, r.Access)
+	return sb.String()
+}
+
+// writeDebugJSON writes the indented json of the object.
+// Encoding errors are written instead of the object.
+func writeDebugJSON(sb *strings.Builder, obj interface{}) {
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		fmt.Fprintf(sb, "<unable to encode: %s>\n", err.Error())
+		return
+	}
+	sb.Write(data)
+	sb.WriteString("\n")
+}
+
+// writeDebugAccess writes the pretty printed access.
+// Errors are written instead of the access.
+func writeDebugAccess(sb *strings.Builder, access *UnstructuredTypedObject) {
+	if access == nil {
+		sb.WriteString("access: none\n")
+		return
+	}
+	raw, err := access.GetRaw()
+	if err != nil {
+		fmt.Fprintf(sb, "access: <unable to encode: %s>\n", err.Error())
+		return
+	}
+	pretty, err := PrettyPrintAccessSpec(raw)
+	if err != nil {
+		fmt.Fprintf(sb, "access: <%s>\n", err.Error())
+		return
+	}
+	fmt.Fprintf(sb, "access: %s\n", pretty)
+}
+
+// redactCredentials replaces the values of all credential fields of the json object.
+// The json is returned unchanged if it contains no credentials.
+func redactCredentials(raw json.RawMessage) (json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var obj interface{}
+	if err := dec.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("unable to decode access: %w", err)
+	}
+	if !redactValues(obj) {
+		return raw, nil
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode access: %w", err)
+	}
+	return data, nil
+}
+
+// redactValues recursively replaces the non-empty values of credential fields and returns whether a value was replaced.
+func redactValues(obj interface{}) bool {
+	redacted := false
+	switch typed := obj.(type) {
+	case map[string]interface{}:
+		for key, val := range typed {
+			if _, ok := credentialAccessFields[key]; ok && val != nil && val != "" {
+				typed[key] = RedactedValue
+				redacted = true
+				continue
+			}
+			redacted = redactValues(val) || redacted
+		}
+	case []interface{}:
+		for _, val := range typed {
+			redacted = redactValues(val) || redacted
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+var _ = Describe("debug output", func() {
+
+	newResource := func() v2.Resource {
+		access, err := v2.NewUnstructured(v2.NewOCIRegistryAccess("example.com/image:v1.0.0"))
+		Expect(err).ToNot(HaveOccurred())
+		return v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{Name: "image", Version: "v1.0.0", Type: v2.OCIImageType},
+			Relation:           v2.ExternalRelation,
+			Access:             &access,
+		}
+	}
+
+	It("should pretty print known access types with their typed object", func() {
+		out, err := v2.PrettyPrintAccessSpec(json.RawMessage(`{"type":"ociRegistry","imageReference":"example.com/image:v1.0.0"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(ContainSubstring("ociRegistry"))
+		Expect(out).To(ContainSubstring("v2.OCIRegistryAccess"))
+		Expect(out).To(ContainSubstring(`"imageReference": "example.com/image:v1.0.0"`))
+	})
+
+	It("should fall back to indented json for unknown access types and fields", func() {
+		out, err := v2.PrettyPrintAccessSpec(json.RawMessage(`{"type":"custom","location":"somewhere"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(Equal("{\n  \"type\": \"custom\",\n  \"location\": \"somewhere\"\n}"))
+
+		out, err = v2.PrettyPrintAccessSpec(json.RawMessage(`{"type":"ociRegistry","imageReference":"example.com/image:v1.0.0","extra":"value"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).ToNot(ContainSubstring("v2.OCIRegistryAccess"))
+		Expect(out).To(ContainSubstring(`"extra": "value"`))
+	})
+
+	It("should redact credentials of accesses", func() {
+		accesses := []v2.TypedObjectAccessor{
+			v2.NewGHCRAccess("ghcr.io/org/image:v1.0.0", "secret-github-token"),
+			v2.NewAzureBlobAccess("account", "container", "blob", "secret-sas-token"),
+			v2.NewGCRAccess("gcr.io/project/image:v1.0.0", "secret-service-account-key"),
+		}
+		artifactory := v2.NewArtifactoryAccess("https://example.com/artifactory", "repo", "path")
+		artifactory.APIKey = "secret-api-key"
+		accesses = append(accesses, artifactory)
+
+		for _, access := range accesses {
+			obj, err := v2.NewUnstructured(access)
+			Expect(err).ToNot(HaveOccurred())
+			res := newResource()
+			res.Access = &obj
+			out := res.DebugString()
+			Expect(out).ToNot(ContainSubstring("secret-"))
+			Expect(out).To(ContainSubstring(v2.RedactedValue))
+		}
+
+		out, err := v2.PrettyPrintAccessSpec(json.RawMessage(`{"type":"custom","nested":{"apiKey":"secret-api-key"}}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).ToNot(ContainSubstring("secret-"))
+	})
+
+	It("should fail for invalid json", func() {
+		_, err := v2.PrettyPrintAccessSpec(json.RawMessage(`{`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should print resources including their access", func() {
+		out := newResource().DebugString()
+		Expect(out).To(ContainSubstring("resource image:v1.0.0"))
+		Expect(out).To(ContainSubstring(v2.OCIImageType))
+		Expect(out).To(ContainSubstring("access: ociRegistry (v2.OCIRegistryAccess)"))
+		Expect(out).To(ContainSubstring(`"imageReference": "example.com/image:v1.0.0"`))
+	})
+
+	It("should print component descriptors including all accesses", func() {
+		web, err := v2.NewUnstructured(v2.NewWebAccess("https://example.com/source.tar"))
+		Expect(err).ToNot(HaveOccurred())
+		cd := v2.ComponentDescriptor{}
+		cd.Name = "example.com/comp"
+		cd.Version = "v1.0.0"
+		cd.Provider = "internal"
+		cd.Resources = []v2.Resource{newResource()}
+		cd.Sources = []v2.Source{{
+			IdentityObjectMeta: v2.IdentityObjectMeta{Name: "src", Version: "v1.0.0", Type: "git"},
+			Access:             &web,
+		}}
+
+		out := cd.DebugString()
+		Expect(out).To(ContainSubstring("component example.com/comp:v1.0.0"))
+		Expect(out).To(ContainSubstring(`"provider": "internal"`))
+		Expect(out).To(ContainSubstring("source src:v1.0.0"))
+		Expect(out).To(ContainSubstring("access: web (v2.Web)"))
+		Expect(out).To(ContainSubstring(`"url": "https://example.com/source.tar"`))
+		Expect(out).To(ContainSubstring("access: ociRegistry (v2.OCIRegistryAccess)"))
+		Expect(out).To(ContainSubstring(`"imageReference": "example.com/image:v1.0.0"`))
+	})
+
+})
@@ -0,0 +1,102 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// ExtensionFactory creates a new empty instance of a typed extension.
+type ExtensionFactory func() interface{}
+
+// ExtensionRegistry is a registry of typed component descriptor extensions.
+// Every extension is identified by a unique key.
+type ExtensionRegistry struct {
+	mux       sync.RWMutex
+	factories map[string]ExtensionFactory
+}
+
+// DefaultExtensionRegistry is the registry that is used to decode the extensions of a component descriptor.
+var DefaultExtensionRegistry = NewExtensionRegistry()
+
+// NewExtensionRegistry creates a new empty extension registry.
+func NewExtensionRegistry() *ExtensionRegistry {
+	return &ExtensionRegistry{
+		factories: map[string]ExtensionFactory{},
+	}
+}
+
+// Register registers a factory for the extension with the given key.
+// The factory is expected to return a pointer to the typed extension.
+func (r *ExtensionRegistry) Register(key string, factory func() interface{}) error {
+	if len(key) == 0 {
+		return fmt.Errorf("an extension key must not be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("no factory defined for extension %q", key)
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if _, ok := r.factories[key]; ok {
+		return fmt.Errorf("extension %q is already registered", key)
+	}
+	r.factories[key] = factory
+	return nil
+}
+
+// Decode decodes the raw extension with the given key into its registered type.
+func (r *ExtensionRegistry) Decode(key string, raw json.RawMessage) (interface{}, error) {
+	r.mux.RLock()
+	factory, ok := r.factories[key]
+	r.mux.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not registered", key)
+	}
+	obj := factory()
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return nil, fmt.Errorf("unable to decode extension %q: %w", key, err)
+	}
+	return obj, nil
+}
+
+// SetExtension sets the extension with the given key.
+// An already existing extension with the same key is overwritten.
+func (c *ComponentDescriptor) SetExtension(key string, value interface{}) error {
+	if len(key) == 0 {
+		return fmt.Errorf("an extension key must not be empty")
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("unable to encode extension %q: %w", key, err)
+	}
+	if c.Extensions == nil {
+		c.Extensions = map[string]json.RawMessage{}
+	}
+	c.Extensions[key] = raw
+	return nil
+}
+
+// GetExtension returns the typed extension with the given key.
+// The extension is decoded using the DefaultExtensionRegistry.
+// A NotFound error is returned if the component descriptor has no extension with the given key.
+func (c *ComponentDescriptor) GetExtension(key string) (interface{}, error) {
+	raw, ok := c.Extensions[key]
+	if !ok {
+		return nil, NotFound
+	}
+	return DefaultExtensionRegistry.Decode(key, raw)
+}
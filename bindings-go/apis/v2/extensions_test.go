@@ -0,0 +1,94 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/codec"
+)
+
+type testExtension struct {
+	Owner    string   `json:"owner"`
+	Channels []string `json:"channels"`
+}
+
+var _ = Describe("extensions", func() {
+
+	BeforeEach(func() {
+		_ = v2.DefaultExtensionRegistry.Register("example.com/test", func() interface{} { return &testExtension{} })
+	})
+
+	It("should not register the same extension twice", func() {
+		reg := v2.NewExtensionRegistry()
+		Expect(reg.Register("example.com/test", func() interface{} { return &testExtension{} })).To(Succeed())
+		Expect(reg.Register("example.com/test", func() interface{} { return &testExtension{} })).ToNot(Succeed())
+	})
+
+	It("should fail to decode an unregistered extension", func() {
+		reg := v2.NewExtensionRegistry()
+		_, err := reg.Decode("example.com/unknown", []byte(`{}`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return a NotFound error if the extension is not set", func() {
+		cd := &v2.ComponentDescriptor{}
+		_, err := cd.GetExtension("example.com/test")
+		Expect(errors.Is(err, v2.NotFound)).To(BeTrue())
+	})
+
+	It("should recover a typed extension after a yaml round-trip", func() {
+		cd := &v2.ComponentDescriptor{}
+		cd.Name = "example.com/a"
+		cd.Version = "v0.0.1"
+		cd.Provider = "internal"
+		Expect(v2.InjectRepositoryContext(cd, v2.NewOCIRegistryRepository("example.com", ""))).To(Succeed())
+		Expect(cd.SetExtension("example.com/test", testExtension{
+			Owner:    "team-a",
+			Channels: []string{"stable", "beta"},
+		})).To(Succeed())
+
+		data, err := codec.Encode(cd)
+		Expect(err).ToNot(HaveOccurred())
+		data, err = yaml.JSONToYAML(data)
+		Expect(err).ToNot(HaveOccurred())
+
+		decoded := &v2.ComponentDescriptor{}
+		Expect(codec.Decode(data, decoded, codec.StrictMode(true))).To(Succeed())
+
+		ext, err := decoded.GetExtension("example.com/test")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ext).To(Equal(&testExtension{
+			Owner:    "team-a",
+			Channels: []string{"stable", "beta"},
+		}))
+	})
+
+	It("should deep copy extensions", func() {
+		cd := &v2.ComponentDescriptor{}
+		Expect(cd.SetExtension("example.com/test", testExtension{Owner: "team-a"})).To(Succeed())
+		cp := cd.DeepCopy()
+		Expect(cd.SetExtension("example.com/test", testExtension{Owner: "team-b"})).To(Succeed())
+
+		ext, err := cp.GetExtension("example.com/test")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ext.(*testExtension).Owner).To(Equal("team-a"))
+	})
+})
@@ -16,7 +16,9 @@ package v2
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/gardener/component-spec/bindings-go/utils/selector"
 )
@@ -113,8 +115,9 @@ func (c ComponentDescriptor) GetComponentReferences(selectors ...IdentitySelecto
 
 // GetResourceByIdentity returns resource that match the given identity.
 func (c ComponentDescriptor) GetResourceByIdentity(id Identity) (Resource, error) {
+	normalized := NormalizeExtraIdentity(id)
 	for _, res := range c.Resources {
-		if bytes.Equal(res.GetIdentityDigest(), id.Digest()) {
+		if NormalizeExtraIdentity(res.GetIdentity()) == normalized {
 			return res, nil
 		}
 	}
@@ -260,9 +263,9 @@ func (c ComponentDescriptor) GetResourcesByName(name string, selectors ...Identi
 // GetResourceIndex returns the index of a given resource.
 // If the index is not found -1 is returned.
 func (c ComponentDescriptor) GetResourceIndex(res Resource) int {
-	id := res.GetIdentityDigest()
+	id := NormalizeExtraIdentity(res.GetIdentity())
 	for i, cur := range c.Resources {
-		if bytes.Equal(cur.GetIdentityDigest(), id) {
+		if NormalizeExtraIdentity(cur.GetIdentity()) == id {
 			return i
 		}
 	}
@@ -272,9 +275,9 @@ func (c ComponentDescriptor) GetResourceIndex(res Resource) int {
 // GetComponentReferenceIndex returns the index of a given component reference.
 // If the index is not found -1 is returned.
 func (c ComponentDescriptor) GetComponentReferenceIndex(ref ComponentReference) int {
-	id := ref.GetIdentityDigest()
+	id := NormalizeExtraIdentity(ref.GetIdentity())
 	for i, cur := range c.ComponentReferences {
-		if bytes.Equal(cur.GetIdentityDigest(), id) {
+		if NormalizeExtraIdentity(cur.GetIdentity()) == id {
 			return i
 		}
 	}
@@ -284,9 +287,124 @@ func (c ComponentDescriptor) GetComponentReferenceIndex(ref ComponentReference)
 // GetSourceIndex returns the index of a given source.
 // If the index is not found -1 is returned.
 func (c ComponentDescriptor) GetSourceIndex(src Source) int {
-	id := src.GetIdentityDigest()
+	id := NormalizeExtraIdentity(src.GetIdentity())
 	for i, cur := range c.Sources {
-		if bytes.Equal(cur.GetIdentityDigest(), id) {
+		if NormalizeExtraIdentity(cur.GetIdentity()) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetLabelValues sets the given labels of the component descriptor.
+// The values are set as json strings, existing labels with the same name are updated in place.
+func (c *ComponentDescriptor) SetLabelValues(labels map[string]string) error {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	// add new labels in a deterministic order
+	sort.Strings(names)
+	for _, name := range names {
+		value, err := json.Marshal(labels[name])
+		if err != nil {
+			return fmt.Errorf("unable to encode value of label %q: %w", name, err)
+		}
+		if i := c.Labels.index(name); i != -1 {
+			c.Labels[i].Value = value
+			continue
+		}
+		c.Labels = append(c.Labels, Label{
+			Name:  name,
+			Value: value,
+		})
+	}
+	return nil
+}
+
+// RemoveLabels removes the labels with the given names from the component descriptor.
+// The number of removed labels is returned.
+func (c *ComponentDescriptor) RemoveLabels(names []string) (removed int) {
+	remove := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		remove[name] = struct{}{}
+	}
+	labels := make(Labels, 0, len(c.Labels))
+	for _, label := range c.Labels {
+		if _, ok := remove[label.Name]; ok {
+			removed++
+			continue
+		}
+		labels = append(labels, label)
+	}
+	c.Labels = labels
+	return removed
+}
+
+// ComponentReferencesByLabel returns all component references that have a label with the given name and value.
+// The value matches json string labels by their unquoted value and all other labels by their raw json value.
+func (c ComponentDescriptor) ComponentReferencesByLabel(name, value string) []ComponentReference {
+	refs := make([]ComponentReference, 0)
+	for _, ref := range c.ComponentReferences {
+		if ref.Labels.hasValue(name, value) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// FirstComponentReferenceByLabel returns the first component reference that has a label with the given name and value.
+// A NotFound error is returned if no component reference matches.
+func (c ComponentDescriptor) FirstComponentReferenceByLabel(name, value string) (*ComponentReference, error) {
+	for i := range c.ComponentReferences {
+		if c.ComponentReferences[i].Labels.hasValue(name, value) {
+			return &c.ComponentReferences[i], nil
+		}
+	}
+	return nil, NotFound
+}
+
+// ResourcesByLabel returns all resources that have a label with the given name and value.
+// The value matches json string labels by their unquoted value and all other labels by their raw json value.
+func (c ComponentDescriptor) ResourcesByLabel(name, value string) []Resource {
+	resources := make([]Resource, 0)
+	for _, res := range c.Resources {
+		if res.Labels.hasValue(name, value) {
+			resources = append(resources, res)
+		}
+	}
+	return resources
+}
+
+// FirstResourceByLabel returns the first resource that has a label with the given name and value.
+// A NotFound error is returned if no resource matches.
+func (c ComponentDescriptor) FirstResourceByLabel(name, value string) (*Resource, error) {
+	for i := range c.Resources {
+		if c.Resources[i].Labels.hasValue(name, value) {
+			return &c.Resources[i], nil
+		}
+	}
+	return nil, NotFound
+}
+
+// hasValue checks whether the labels contain a label with the given name and value.
+func (l Labels) hasValue(name, value string) bool {
+	data, ok := l.Get(name)
+	if !ok {
+		return false
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		return str == value
+	}
+	return string(bytes.TrimSpace(data)) == value
+}
+
+// index returns the index of the label with the given name.
+// If the label is not found -1 is returned.
+func (l Labels) index(name string) int {
+	for i, label := range l {
+		if label.Name == name {
 			return i
 		}
 	}
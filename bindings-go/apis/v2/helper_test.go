@@ -15,6 +15,8 @@
 package v2_test
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -39,4 +41,103 @@ var _ = Describe("helper", func() {
 		Expect(cd.RepositoryContexts).To(HaveLen(2))
 	})
 
+	It("should set multiple labels and update existing labels in place", func() {
+		cd := &v2.ComponentDescriptor{}
+		cd.Labels = v2.Labels{
+			{Name: "a", Value: []byte(`"old"`)},
+		}
+		Expect(cd.SetLabelValues(map[string]string{"a": "new", "b": "value"})).To(Succeed())
+		Expect(cd.Labels).To(Equal(v2.Labels{
+			{Name: "a", Value: []byte(`"new"`)},
+			{Name: "b", Value: []byte(`"value"`)},
+		}))
+
+		var accessor v2.ObjectMetaAccessor = cd
+		accessor.SetLabels([]v2.Label{{Name: "c", Value: []byte(`"c"`)}})
+		Expect(cd.Labels).To(HaveLen(1))
+	})
+
+	It("should remove multiple labels and return the number of removed labels", func() {
+		cd := &v2.ComponentDescriptor{}
+		cd.Labels = v2.Labels{
+			{Name: "a", Value: []byte(`"a"`)},
+			{Name: "b", Value: []byte(`"b"`)},
+			{Name: "c", Value: []byte(`"c"`)},
+		}
+		Expect(cd.RemoveLabels([]string{"a", "c", "unknown"})).To(Equal(2))
+		Expect(cd.Labels).To(Equal(v2.Labels{
+			{Name: "b", Value: []byte(`"b"`)},
+		}))
+		Expect(cd.RemoveLabels([]string{"unknown"})).To(Equal(0))
+	})
+
+	Context("lookup by label", func() {
+
+		var cd *v2.ComponentDescriptor
+
+		BeforeEach(func() {
+			cd = &v2.ComponentDescriptor{}
+			cd.Resources = []v2.Resource{
+				{IdentityObjectMeta: v2.IdentityObjectMeta{Name: "apiserver", Labels: v2.Labels{
+					{Name: "role", Value: []byte(`"apiserver"`)},
+				}}},
+				{IdentityObjectMeta: v2.IdentityObjectMeta{Name: "controller", Labels: v2.Labels{
+					{Name: "role", Value: []byte(`"controller"`)},
+					{Name: "replicas", Value: []byte(`3`)},
+				}}},
+				{IdentityObjectMeta: v2.IdentityObjectMeta{Name: "apiserver-proxy", Labels: v2.Labels{
+					{Name: "team", Value: []byte(`"network"`)},
+					{Name: "role", Value: []byte(`"apiserver"`)},
+				}}},
+				{IdentityObjectMeta: v2.IdentityObjectMeta{Name: "unlabelled"}},
+			}
+			cd.ComponentReferences = []v2.ComponentReference{
+				{Name: "etcd", ComponentName: "example.com/etcd", Labels: v2.Labels{
+					{Name: "role", Value: []byte(`"storage"`)},
+				}},
+				{Name: "apiserver", ComponentName: "example.com/apiserver", Labels: v2.Labels{
+					{Name: "role", Value: []byte(`"apiserver"`)},
+				}},
+			}
+		})
+
+		It("should return all resources with the label", func() {
+			resources := cd.ResourcesByLabel("role", "apiserver")
+			Expect(resources).To(HaveLen(2))
+			Expect(resources[0].Name).To(Equal("apiserver"))
+			Expect(resources[1].Name).To(Equal("apiserver-proxy"))
+
+			resources = cd.ResourcesByLabel("replicas", "3")
+			Expect(resources).To(HaveLen(1))
+			Expect(resources[0].Name).To(Equal("controller"))
+
+			Expect(cd.ResourcesByLabel("role", "unknown")).To(BeEmpty())
+		})
+
+		It("should return the first resource with the label", func() {
+			res, err := cd.FirstResourceByLabel("role", "apiserver")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Name).To(Equal("apiserver"))
+
+			_, err = cd.FirstResourceByLabel("team", "unknown")
+			Expect(errors.Is(err, v2.NotFound)).To(BeTrue())
+		})
+
+		It("should return all component references with the label", func() {
+			refs := cd.ComponentReferencesByLabel("role", "storage")
+			Expect(refs).To(HaveLen(1))
+			Expect(refs[0].Name).To(Equal("etcd"))
+			Expect(cd.ComponentReferencesByLabel("team", "network")).To(BeEmpty())
+		})
+
+		It("should return the first component reference with the label", func() {
+			ref, err := cd.FirstComponentReferenceByLabel("role", "apiserver")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ref.ComponentName).To(Equal("example.com/apiserver"))
+
+			_, err = cd.FirstComponentReferenceByLabel("role", "controller")
+			Expect(errors.Is(err, v2.NotFound)).To(BeTrue())
+		})
+	})
+
 })
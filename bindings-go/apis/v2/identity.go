@@ -0,0 +1,53 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// NormalizeExtraIdentity returns the canonical json representation of an identity.
+// The keys are sorted so that identities with the same key-value pairs have the same representation.
+// Nil and empty identities are both represented as "{}".
+func NormalizeExtraIdentity(ei Identity) string {
+	keys := make([]string, 0, len(ei))
+	for key := range ei {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i, key := range keys {
+		if i != 0 {
+			sb.WriteString(",")
+		}
+		// marshaling a string cannot fail
+		k, _ := json.Marshal(key)
+		v, _ := json.Marshal(ei[key])
+		sb.Write(k)
+		sb.WriteString(":")
+		sb.Write(v)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// ExtraIdentityEqual returns whether both identities contain the same key-value pairs.
+func ExtraIdentityEqual(a, b Identity) bool {
+	return NormalizeExtraIdentity(a) == NormalizeExtraIdentity(b)
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+var _ = Describe("identity normalization", func() {
+
+	newIdentity := func(keys ...string) v2.Identity {
+		identity := v2.Identity{}
+		for _, key := range keys {
+			identity[key] = "value-" + key
+		}
+		return identity
+	}
+
+	It("should normalize identities with the same key-value pairs in different insertion orders", func() {
+		a := newIdentity("a", "b", "c", "d", "e")
+		b := newIdentity("e", "d", "c", "b", "a")
+		Expect(v2.NormalizeExtraIdentity(a)).To(Equal(`{"a":"value-a","b":"value-b","c":"value-c","d":"value-d","e":"value-e"}`))
+		Expect(v2.NormalizeExtraIdentity(b)).To(Equal(v2.NormalizeExtraIdentity(a)))
+		Expect(v2.ExtraIdentityEqual(a, b)).To(BeTrue())
+	})
+
+	It("should not consider different identities equal", func() {
+		Expect(v2.ExtraIdentityEqual(newIdentity("a"), newIdentity("a", "b"))).To(BeFalse())
+		Expect(v2.ExtraIdentityEqual(newIdentity("a"), v2.Identity{"a": "other"})).To(BeFalse())
+	})
+
+	It("should consider nil and empty identities equal", func() {
+		Expect(v2.NormalizeExtraIdentity(nil)).To(Equal("{}"))
+		Expect(v2.ExtraIdentityEqual(nil, v2.Identity{})).To(BeTrue())
+	})
+
+	It("should find a resource by an identity with a different insertion order", func() {
+		res := v2.Resource{}
+		res.Name = "res"
+		res.ExtraIdentity = newIdentity("a", "b", "c")
+		cd := v2.ComponentDescriptor{}
+		cd.Resources = []v2.Resource{res}
+
+		id := newIdentity("c", "b", "a")
+		id[v2.SystemIdentityName] = "res"
+		found, err := cd.GetResourceByIdentity(id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found.Name).To(Equal("res"))
+
+		other := v2.Resource{}
+		other.Name = "res"
+		other.ExtraIdentity = newIdentity("b", "c", "a")
+		Expect(cd.GetResourceIndex(other)).To(Equal(0))
+	})
+
+})
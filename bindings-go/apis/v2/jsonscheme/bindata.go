@@ -76,7 +76,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _LanguageIndependentComponentDescriptorV2SchemaYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x1a\x4b\x6f\xe3\xb8\xf9\xee\x5f\xf1\x61\x13\x80\xce\x24\xb2\x13\x17\x7b\x18\x5f\x82\x74\x17\x2d\x16\x2d\x36\x40\x66\xda\x43\x13\x77\x40\x4b\x9f\x6d\xa6\x12\xe9\x92\x94\x13\xcd\xe3\xbf\x17\x24\x45\x3d\x6c\x49\x7e\x25\x99\x2e\x30\x73\x98\x88\xd4\xf7\x7e\x93\xf2\x29\x8b\xc6\x40\x16\x5a\x2f\xd5\x78\x38\x9c\x53\x19\x21\x47\x39\x08\x63\x91\x46\x43\x15\x2e\x30\xa1\x6a\x18\x8a\x64\x29\x38\x72\x1d\x44\xa8\x42\xc9\x96\x5a\xc8\x60\x35\x22\xbd\x53\x07\x51\xa1\xf0\xa8\x04\x0f\xdc\xee\x40\xc8\xf9\x30\x92\x74\xa6\x87\xa3\xcb\xd1\x65\x70\x35\xca\x09\x92\x9e\x27\xc3\x04\x1f\x03\xf9\x6b\xce\x15\x7e\xf1\x7c\xe0\xd7\x82\x0f\xac\x46\x50\xa2\xcd\x18\x67\x06\x4b\x8d\x7b\x00\x09\x6a\x6a\xfe\x02\xe8\x6c\x89\x63\x20\x62\xfa\x88\xa1\x26\x76\xab\xce\xa2\xd0\x00\x4a\x0d\x2c\x7e\x44\x35\x75\x08\x12\xff\x9b\x32\x89\x91\xa3\x08\x10\x00\x71\x7c\xff\x89\x52\x31\xc1\x1d\xd4\x52\x8a\x25\x4a\xcd\x50\x79\xb8\x1a\x90\xdf\x2c\x44\x52\x5a\x32\x3e\x27\xbd\x1e\x40\x4c\xa7\x18\xb7\xca\xdb\xc0\x9e\xd3\x04\x49\xb9\x5c\xd1\x38\x45\x4b\xa9\xd0\xe6\x77\x9a\x60\x8d\xa2\x67\x67\xb6\x12\xfa\xfc\x77\xe4\x73\xbd\x18\xc3\xe8\xe7\x9f\x9d\xf4\x54\x6b\x94\xc6\x20\xff\xbe\xa7\xc1\xe7\xcb\xe0\xfd\xe0\x21\x98\x9c\xdf\x0f\x26\x66\xe9\xfe\x3b\x1f\xde\x07\xee\xdd\xf0\xd3\x60\xf2\xee\xd4\x72\x64\x11\x72\xcd\x74\x76\xa3\xb5\x64\xd3\x54\xe3\xdf\x30\x73\x8c\x13\xc6\x0b\x2e\x2d\x3c\x26\xfd\xfb\xe0\xd3\x79\xfe\xfc\xce\x6f\x9e\x5d\x3b\xd2\x12\x63\xfa\x8c\xd1\x07\x4c\x56\x28\x1d\xcd\x13\xd0\xf4\x3f\xc8\x61\x26\x45\x02\xca\xbe\x30\xc1\x04\x94\x47\x40\xa3\xc7\x54\x69\x8c\x40\x0b\xa0\x71\x2c\x9e\x80\x72\x10\xd6\xcf\x34\x86\x18\x69\xc4\xf8\x1c\xc8\x8a\x5c\x40\x42\x1f\x85\x0c\x04\x8f\xb3\x0b\x8b\x6a\xd7\x83\x84\xf1\x7c\xd7\xf3\x5a\x30\x05\x09\x52\xae\x40\x2f\x10\x66\xc2\x50\x35\x44\x9c\x31\x15\x50\x89\x86\x15\xac\x68\xcc\xa2\xba\xbc\xca\x0b\x7c\x35\x18\x0d\xfe\x54\x7d\x0e\x66\x42\x9c\x4f\xa9\xcc\xf7\x56\x55\x80\x55\x13\xc4\xd5\x60\xe4\x9f\x0a\xb0\x0a\x7c\xf1\x58\x43\xab\x1a\x7b\x35\xb9\xee\x5f\x7e\xbd\xbf\x0a\xde\x4f\x1e\xa2\x77\x67\xfd\xeb\xf1\xc3\xa0\xba\x71\x76\xdd\xbc\x15\xf4\xfb\xd7\xe3\x72\xf3\xeb\x43\x64\x7d\x74\x13\xfc\x2b\x98\xdc\x5f\x06\xef\xfd\xb3\x27\xb9\x23\xf0\x99\xe7\x78\xde\xaf\xbe\x38\xb7\x44\x6a\x3b\x16\xf2\x94\x34\xc5\x71\x53\xe8\xb5\xa6\x50\x9e\x9b\x99\xc9\x0a\x35\x86\x2f\x70\x2a\x71\x36\x06\x72\x32\xac\x14\x8e\x61\x53\x28\x13\xf8\xe6\x42\x71\x29\x14\xd3\x42\x66\xbf\x08\xae\xf1\x59\xef\x93\xad\x06\xaa\xad\x46\x58\x0a\x1d\xa5\x41\x84\xec\xae\x99\x37\x8d\xe3\xdb\x59\xc9\xa5\x51\xa3\x0d\xb1\xcb\xa2\xb1\x2e\xa7\x95\x74\x4a\x15\xfe\x43\xc6\xa4\xd8\xdb\x14\xd8\xfc\xcb\xc1\xaa\x5b\x8d\x75\xa6\x49\xc5\x66\x35\x69\x18\xa2\x52\x3b\x96\x6c\xc3\xde\x42\xc1\x4c\xc8\x1c\x15\x15\xf4\xcd\x0a\x9f\x35\x72\x53\x6f\xd5\xd9\x16\x7f\xf4\x00\xe6\x4c\x2f\xd2\xe9\x4d\x37\xef\x4e\x87\xda\xa5\xb1\x72\xc5\x6a\x76\x67\x76\x90\xc3\xfd\x36\xf2\x34\x19\xc3\x3d\x71\x02\x92\x49\xfe\x22\x67\xb4\x05\xdd\x04\x42\x37\x44\x28\x92\x84\xe9\xae\xb0\xe3\x82\xe3\x31\x76\x39\x52\xef\xdf\x05\x47\x32\x31\x82\x28\x91\xca\x10\x7f\x2d\x62\x7a\x0f\x71\x4c\x93\x2c\x16\x2b\xd7\x85\x8b\xb5\xa1\x50\x2c\x5c\x08\xb5\x08\xce\x8b\x4e\xda\x21\xf8\xee\xf5\x24\x47\xc1\x67\x2d\xe9\x6f\x39\xc0\x78\x4f\x3a\x9e\xc8\x6a\x7d\xb4\x68\x29\x02\x95\xb6\x44\x0e\x0c\xc3\x22\x06\xed\xac\xa2\x36\x50\xa9\x94\x34\x2b\x31\x99\xc6\xa4\x56\x32\x1a\x25\xb3\xb4\x3c\x52\xb5\x04\xd8\x35\xcf\x6e\x67\x55\x12\x2d\x35\xce\xe1\x91\xed\x80\xd5\x6c\xdf\x01\xdc\xcc\xad\x1e\xb8\x07\x10\xb1\x39\x2a\xfd\x61\x89\xe1\x1e\x21\xb8\xa0\x6a\x71\x13\xcf\x85\x64\x7a\x91\x94\x81\x29\x64\x42\x63\xa6\xa8\x61\xb4\xf9\xda\x4e\x73\x2d\xc1\x58\x23\xb8\xee\x04\xe7\x3e\x1f\xb6\x8d\x4c\x3a\x51\x2c\xe3\x16\x08\x93\x8a\x6c\xce\xa9\x4e\x25\xee\x69\x04\xda\xa1\xa1\x59\x25\x18\x31\xfa\xd1\xe7\xe3\xa6\xce\xf4\x68\xe1\xdd\x56\xc1\xa7\x84\xaa\xf7\x95\x8f\x0b\x74\x40\xae\xb9\x88\x99\x9d\xfa\x0a\xb5\x21\x1f\xb3\xb7\xda\xe7\xd0\x1a\xe5\x42\xac\x58\x16\xf4\xf6\x28\x4c\x35\x85\x1d\xbd\x2d\xd5\xa1\x8c\x6b\xaf\xd9\x9a\x1e\xad\x98\xb5\x78\xb0\x39\xa2\x64\x78\xe7\x9b\xcf\xd6\x2e\x4e\x4d\xa3\x42\x89\x3c\x44\x3b\xb1\x43\xbf\x3c\x4c\xc6\x22\xa4\xf1\x59\x5e\xfc\xdb\x3a\x8a\x2f\x8b\x1f\x30\xc6\x50\x0b\x79\x68\x15\x7d\x85\x8a\x56\x3d\x89\xdd\x79\x2d\x0f\xb5\x4b\x41\x69\xd7\xe3\x60\xed\x10\x58\x3d\x26\x76\x1f\x57\x1b\xce\x8e\xad\x7a\x36\xb2\xe8\xea\x94\x70\x02\x34\xd4\x29\x8d\xe3\x6c\x5c\x72\x0a\x6c\xa2\x3d\x0d\x41\x2d\x31\x64\x34\x06\x89\x06\x3e\xb4\x4c\xfe\xb8\xcd\xf5\xd5\x7a\xe4\x7a\x46\x0b\x8e\xd5\x1e\x19\x78\x4e\x3c\x8d\x2b\x43\x7c\x4b\x83\xab\x66\xbe\x3d\xe2\xb8\x74\x2b\x2b\xe4\x9e\x83\xb8\x27\xa0\x76\xbe\xb6\xc8\xe3\x11\x4e\x2c\xbe\x4d\xfa\x92\xca\x45\x7e\xfc\x4e\x95\x86\x84\xea\x70\x51\x49\x04\xb5\x31\xcf\x6d\xce\xe4\xb1\xed\x7c\x95\xad\xea\xa0\xf0\x63\xcc\x2b\xb4\x72\x45\xfb\x85\xa2\xd5\x11\x2b\x4f\x22\xce\x09\x3b\x0f\x9a\x36\x04\xc8\x05\x10\x73\x8c\x93\x9c\xc6\xdf\x7d\xec\xdc\x71\xe8\x6c\x01\x13\x21\xfb\x73\x2c\x36\x66\xce\x16\x68\xab\xfd\x5f\x58\x8c\x2a\x53\x1a\x93\x7d\x31\x6f\x9b\x98\xbd\x66\xc5\x10\x21\xfb\x2d\xa1\xf3\xa3\x0e\x8a\x76\xc9\x0c\x95\xa2\x4f\xbe\xc8\x09\xd2\xde\x9b\xcc\x99\xd2\x32\x2b\x62\xa8\xce\x66\xcb\xad\x4b\x69\xca\x1d\x15\xab\xa9\x15\x00\x89\x69\xe6\xf3\xf0\x38\x5d\x80\xe4\xe2\x10\x28\x2f\x02\x66\x6d\x43\xec\x8d\x11\xbe\x3e\x42\x98\x29\x36\xa1\x9c\xcd\x50\xe9\xf5\xf1\x75\x8d\xe9\x81\x33\xb2\xb3\x8a\x2b\xd8\x2e\x35\x9c\x04\x0a\xb4\xd8\xc2\x71\x3d\x40\x37\xd9\x39\x08\xcf\x4a\x53\x39\x47\x8d\x11\x84\x82\xeb\x62\x28\x6a\x25\xaf\xd8\xe7\x4e\x5d\xcc\x7b\x60\x1c\xa6\x99\x46\xe5\x79\x4c\x8d\xb1\xd7\xe9\xf2\x34\x99\x1a\x87\xf6\x00\x5a\x13\xf5\x88\x1c\x98\xb1\x18\xcb\xfe\x78\x6c\xc4\x34\x48\x58\x46\x8f\x67\xd5\x66\x17\xff\xbe\x6a\x0e\xd0\x0b\xaa\x81\x29\xab\xbb\x31\x3f\xe3\xf6\xdd\x4f\xe6\xa5\xfa\x09\x22\x26\xed\x10\x9e\xb5\xfa\xc3\xdb\xed\xf6\x80\xdc\x7a\x23\x83\xdd\xae\xe7\x59\x77\x70\xd6\x03\xd3\xe6\x3b\x3c\x31\xbd\xc8\x4d\x13\xa6\x52\x22\xd7\xd0\xf4\x85\xa9\xcb\x4a\xbe\xac\xde\xe5\x93\xd0\x31\x1f\x86\xaa\x13\x7f\x93\x11\x7f\xcc\x44\xdb\xfb\x88\x75\xc6\xdb\x0f\x22\x6d\x03\x45\xa5\xe5\xbe\x45\x93\x2f\xaf\xc1\x8e\xc8\xd5\xd4\xdf\x8e\x1f\xd9\xd5\x8d\x30\x85\x27\xd2\x8e\x9b\xf0\x1e\xc0\x1c\x39\x4a\x16\x7e\xc7\x5b\xec\x5c\x02\x77\x91\x9d\x2f\x7e\x24\xf5\xff\x41\x52\x97\x8e\x71\xfb\xdf\x37\xa7\x6b\x81\xfa\x16\x29\x5d\x34\xa4\x9d\x6f\xa4\xf6\xbe\x82\xda\x8c\xd1\x8d\x4f\x91\xaa\xf2\x72\x29\xc5\x8a\x45\xa5\x37\x03\x20\xb5\xbb\x84\xfa\xb5\x56\x31\xc2\xab\x1a\xfd\x1a\xc6\xb6\xb8\xdf\xfd\x56\xeb\x88\xa0\xdc\xd4\x79\xef\x18\xdb\xf8\x0a\xd2\x75\xd6\xdc\xf8\x52\x4c\xe0\xc4\x8f\x21\x71\x76\x01\x4f\x08\x82\xc7\x59\xfe\xeb\x08\x3b\xad\x0b\xee\xef\x9f\xbd\x0f\xb6\x64\xd1\xab\xe5\x4a\xee\xbe\x17\xba\x87\x58\xfb\x6c\xe8\xf1\x1b\x62\xe8\x65\x18\x6e\x12\x2e\x83\xe0\x50\xcd\x76\xf7\x7d\xf5\xee\x8e\xec\x18\x2c\xb5\x19\x73\x27\xa4\xb5\x16\x66\x6b\x49\xb3\x49\xe1\xcb\xb7\x5e\xaf\xb7\x56\x58\xaa\x55\x23\x00\x92\xa0\xfb\x7d\x55\x35\xb3\x49\xaf\x9e\xb7\xe5\xef\xb8\x1a\x05\xf2\x24\xd6\x0a\x5a\xb7\x83\x48\xf5\x53\x4d\x7d\x30\xa8\x38\xa4\xe6\x8c\xee\xcf\x1f\xa4\xf7\xbf\x00\x00\x00\xff\xff\xbc\x3c\x49\xda\x2b\x27\x00\x00")
+var _LanguageIndependentComponentDescriptorV2SchemaYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x1a\x5d\x6f\xdb\x38\xf2\xdd\xbf\x62\xb0\x29\xc0\xa4\xa9\xec\x24\x77\x5d\xa0\x7e\x09\xb2\x2d\xee\x6e\x71\x87\xf6\xd0\xf6\xee\xe1\x52\xdf\x82\x96\x46\x16\xb3\x12\xe9\x23\x29\x27\xde\x6e\xff\xfb\x81\xa4\x48\x49\xb6\x24\xdb\xf9\x58\xb4\x40\x51\xa0\xb1\xc8\xf9\xe6\xcc\x70\x86\xe4\x33\x96\x4c\x81\x64\x5a\x2f\xd5\x74\x32\x59\x50\x99\x20\x47\x39\x8e\x73\x51\x26\x13\x15\x67\x58\x50\x35\x89\x45\xb1\x14\x1c\xb9\x8e\x12\x54\xb1\x64\x4b\x2d\x64\xb4\xba\x20\xa3\x67\x0e\xa2\x41\xe1\x46\x09\x1e\xb9\xd1\xb1\x90\x8b\x49\x22\x69\xaa\x27\x17\x67\x17\x67\xd1\xf9\x45\x45\x90\x8c\x3c\x19\x26\xf8\x14\xc8\x5f\x2b\xae\xf0\xda\xf3\x81\x37\x81\x0f\xac\x2e\xa0\x46\x4b\x19\x67\x9a\x09\xae\xa6\x23\x80\x02\x35\x35\x7f\x01\xf4\x7a\x89\x53\x20\x62\x7e\x83\xb1\x26\x76\xa8\xcd\x22\x68\x10\xc6\x85\xb4\xf8\x09\xd5\xd4\x21\x48\xfc\x5f\xc9\x24\x26\x8e\x22\x40\x04\xc4\xf1\xfd\x37\x4a\xc5\x04\x77\x50\x4b\x29\x96\x28\x35\x43\xe5\xe1\x5a\x40\x7e\x30\x88\xa4\xb4\x64\x7c\x41\x46\x23\x80\x9c\xce\x31\xef\x95\xb7\x83\x3d\xa7\x05\x92\xfa\x73\x45\xf3\x12\x2d\xa5\xa0\xcd\x5b\x5a\xe0\x74\xd4\xc1\xce\x0c\x15\xf4\xee\x1f\xc8\x17\x3a\x9b\xc2\xc5\xcb\x97\x16\x6a\x49\xb5\x46\x69\x0c\xf2\xdf\x6b\x1a\xfd\x76\x16\xbd\x1a\x7f\x8a\x66\xa7\xd7\xe3\x99\xf9\x74\xff\x9d\x4e\xae\x23\x37\x37\xf9\x65\x3c\x7b\xfe\xcc\x72\x64\x09\x72\xcd\xf4\xfa\x4a\x6b\xc9\xe6\xa5\xc6\xbf\xe3\xda\x31\x2e\x18\x0f\x5c\x7a\x78\xcc\x8e\xaf\xa3\x5f\x4e\xab\xdf\xcf\xfd\xe0\xc9\xa5\x23\x2d\x31\xa7\x77\x98\x7c\xc0\x62\x85\xd2\xd1\x3c\x02\x4d\x7f\x45\x0e\xa9\x14\x05\x28\x3b\x61\x9c\x09\x28\x4f\x80\x26\x37\xa5\xd2\x98\x80\x16\x40\xf3\x5c\xdc\x02\xe5\x20\xec\x3a\xd3\x1c\x72\xa4\x09\xe3\x0b\x20\x2b\xf2\x02\x0a\x7a\x23\x64\x24\x78\xbe\x7e\x61\x51\xed\xf7\xb8\x60\xbc\x1a\xf5\xbc\x32\xa6\xa0\x40\xca\x15\xe8\x0c\x21\x15\x86\xaa\x21\xe2\xd6\x4e\x01\x95\x68\x58\xc1\x8a\xe6\x2c\x69\xcb\x5b\x79\xc1\x11\x9c\x8f\x2f\xc6\x7f\x6a\xfe\x8e\x52\x21\x4e\xe7\x54\x56\x63\xab\x26\xc0\xaa\x0b\xe2\x7c\x7c\xe1\x7f\x55\x7f\x57\xf5\x8f\x30\xb7\x3a\x6f\xa1\x35\x8d\xbd\x9a\x5d\x1e\x9f\xfd\x7e\x7d\x1e\xbd\x9a\x7d\x4a\x9e\x9f\x1c\x5f\x4e\x3f\x8d\x9b\x03\x27\x97\xdd\x43\xd1\xf1\xf1\xe5\xb4\x1e\xfc\xfd\x53\x62\xd7\xe8\x2a\xfa\x4f\x34\xbb\x3e\x8b\x5e\xf9\xdf\x9e\xe4\x9e\xc0\x27\x9e\xe3\xe9\x71\x73\xe2\xd4\x0c\x8d\x5b\x23\x16\xf2\x19\xe9\xf2\xe3\x2e\xd7\xeb\x0d\xa1\x2a\x36\xd7\x26\x2a\xd4\x14\x3e\xc3\x33\x89\xe9\x14\xc8\xd1\xa4\x91\x38\x26\x5d\xae\x4c\xe0\x8b\x73\xc5\xa5\x50\x4c\x0b\xb9\x7e\x2d\xb8\xc6\x3b\x7d\x48\xb4\x1a\xa8\xbe\x1c\x61\xe6\xfc\xef\x2e\x1d\x45\xcc\xde\x77\xf3\xa6\x79\xfe\x2e\xf5\xa8\x51\xb7\x46\x5b\x62\xd7\x49\x63\x53\x4e\x33\x46\xe6\x54\xe1\xbf\x64\xee\xa1\xba\x04\x36\xff\x2a\xb0\xe6\xd0\x96\xec\x1b\x13\x43\xa0\x23\x00\x1a\xc7\xa8\x2a\x1e\x1d\x46\x6d\xa7\x6c\xc3\xde\xd2\x80\x54\xc8\x0a\x15\x15\x1c\x9b\x2f\xbc\xd3\xc8\x4d\x52\x56\x27\x3b\xd6\x63\x04\xb0\x60\x3a\x2b\xe7\x57\xc3\xbc\x7b\x09\x84\x4f\x63\xe5\x86\xd5\xec\x48\x7a\xaf\x05\xf7\xc3\xc8\xcb\x62\x0a\xd7\xc4\x09\x48\x66\x15\x7c\xc5\x68\x07\xba\x71\x84\x61\x88\x58\x14\x05\xd3\xbd\x40\x23\x00\x2e\x38\x3e\xc4\x2e\x0f\xd4\xfb\xad\xe0\x48\x66\xc6\xff\x95\x28\x65\x8c\x6f\x82\x4f\x1f\x20\x8e\xd9\x24\xc3\xc7\xca\x6d\xd5\xe1\xdb\x50\x08\x1f\xce\x85\x7a\x04\xe7\xb4\xd8\x2d\xf8\xfe\xf9\xa4\x42\xc1\x3b\x2d\xe9\xcf\x15\xc0\xf4\x40\x3a\x9e\x48\xa5\xd4\x0e\xf4\xd6\xb6\x44\xf6\x5f\x0e\x5b\x95\xa8\x2d\x20\x2a\x25\x0d\x6a\x00\x30\x8d\x45\x03\xa8\x47\x06\x4b\xcb\x23\x35\x83\xdd\xfc\xa3\x7c\x5d\x67\xb2\x81\x6c\xe6\xf0\xc8\x6e\xc0\x66\x5c\xef\x01\x6e\x6a\x5c\x0f\x3c\x02\x48\xd8\x02\x95\xfe\xb0\xc4\xf8\x00\x67\xcb\xa8\xca\xae\xf2\x85\x90\x4c\x67\x45\x18\xe5\x42\x16\x34\x67\x8a\x9a\x74\xbc\x3d\x6d\xeb\xb6\x1e\xb7\x6b\x11\xdc\x5c\x04\x17\xa9\xd5\x60\x37\x93\x41\x14\xcb\x78\x10\x42\xb1\xdf\x1a\xfe\xd1\xce\xbf\x1f\x33\xb4\xf3\x20\x52\x5b\x17\x39\x83\x61\x02\xf3\x5c\xcc\x81\x71\x98\xaf\x35\x36\x0c\xef\xc8\x33\xae\x71\x81\x32\x8c\x16\x8c\xb3\xc2\xa4\xb9\x33\x1b\xe7\x6c\xc1\xa9\x2e\x25\x1e\x68\x77\xea\xf5\xed\x30\xaa\x31\x71\x81\x09\xa3\x1f\xd7\xcb\x3e\x33\x07\xfc\xe9\x86\xb8\x07\xda\x2b\xf0\x19\x32\x9a\x05\xb2\x71\xe4\x4d\x17\xd4\x86\xaa\x86\x1f\x60\x11\x1b\xc9\x53\x16\x53\x3d\xc8\x64\x89\x05\x20\x8f\x45\x82\x49\x13\x05\xe2\x8c\x32\xde\xe4\x6b\x0a\xd9\x5f\x71\x3d\xc8\x93\x0b\xfd\x13\xa6\x42\x0e\x72\xd4\xac\x40\x57\x91\xdf\x66\x2c\xce\x40\xf0\x0d\xdd\x98\x32\xea\xb1\x64\x90\x15\x98\xdd\xbc\xa0\x7a\x0a\x09\xd5\x18\x19\xa2\xd5\x0c\x17\xfa\x2a\xd5\x28\x77\xca\x50\x72\xcd\xf2\x4a\x88\x47\x92\xa0\xe9\x9a\xf7\xdd\x7b\x5c\x7c\x84\xcf\x40\xef\x80\x0d\xa7\x25\xa7\xa3\x57\x83\x74\x26\xb5\x3a\x8b\x79\x9d\x37\xf4\xe8\xc5\x0c\x70\x0e\xd9\x58\x40\xc6\xef\x31\xed\x55\xbf\xbd\x1a\x14\x24\xa6\x28\x91\xc7\x68\x3b\x31\x38\x0e\x4d\x69\x94\x8b\x98\xe6\x27\xd5\xa6\xde\x57\x29\xf8\xed\xee\x03\xe6\x18\x6b\x21\x77\x88\xdb\xbb\x3b\x3e\xc1\xfe\xd5\xec\xb0\xdf\x7b\x2d\xef\x6b\x97\x40\xa9\xcf\x89\x36\xdb\xfc\x80\xf0\x76\xa3\xfd\x1f\x3e\x86\x68\xa1\x4d\x47\x83\x7a\x76\xb2\x18\xaa\x80\xe0\x08\x68\xac\x4b\x9a\xe7\xeb\x69\xcd\x29\x32\x40\x70\x3b\x01\xb5\xc4\x98\xd1\x1c\x24\x1a\x0f\x8e\x8d\x29\xd4\xb0\x04\x5f\x73\xd1\xf4\x64\x15\xd1\x66\x44\x0b\x8e\xcd\x8a\x28\xf2\x9c\x78\x99\x07\x9c\xde\x72\xa6\x19\xf9\xb6\x75\x75\xe1\x56\x6f\x4e\x3b\x5d\xb5\xdd\x60\x79\x02\x6a\x5f\x3f\xf5\xfe\x08\x47\x26\xa1\x82\x0d\xfa\x9a\xca\x8b\xea\x58\xa5\x54\x1a\x0a\xaa\xe3\xac\x76\x1b\xa2\xfc\xea\x74\xf5\x14\x55\x67\x95\xdb\x3a\xa7\x31\xd4\x2c\x0b\xf7\xcb\xa6\x1b\x85\xee\xbe\x1e\xf4\x6d\x95\xef\x2e\x69\xab\x2d\xa8\x7b\x79\xab\x23\xe6\xb1\xfc\x22\xec\x90\xa0\xee\xe7\xac\x0b\x90\x17\x40\x4c\x7b\x2e\x39\xcd\xc9\xec\xa9\x43\x6a\x47\x93\xb1\x67\x8b\xd1\x03\x26\x62\xf6\x53\x2e\xe6\x57\xfb\x41\x5b\xed\xff\xc2\x72\x54\x6b\xa5\xb1\x38\x14\xf3\x5d\x17\xb3\x27\xcb\x18\x95\xb5\x92\xc4\xce\xd1\xfc\x8d\x9d\x6b\x18\xb2\x9d\x2a\x9c\x1c\xca\xd7\x95\x3e\xca\x41\x67\x54\xdb\xe3\xd1\x98\xe6\x71\x99\x53\x73\x2e\x7b\xcb\x74\x06\x42\x67\x28\x6d\x77\x53\xd7\xde\x8a\x3c\xca\xfa\x6f\xab\xd1\x73\x02\xdf\x56\xc1\x01\xf9\x68\xf4\x9a\x38\xff\xb1\xf2\x38\x65\x6e\xa9\x82\x52\x19\x35\x32\xe4\x6d\x65\xcd\x14\x4d\x12\x4c\x48\x5f\x40\xb8\x93\xbc\x9f\x0b\xba\x78\xd0\xb1\x8a\xfd\x64\x86\x4a\xa8\x3e\xfa\xf2\xde\x41\xe7\x2d\xf6\x94\x71\xc1\x94\x96\xeb\x10\x99\x6d\x36\xbd\xa4\x9c\x66\xb5\x83\xee\xa9\x58\x4b\xad\x08\x48\x4e\xd7\x28\x1f\x43\x17\x20\x95\x38\x04\x66\x5d\x07\x62\xed\xb5\xbf\x32\xc2\xb7\x0b\x33\xb3\xb2\x05\xe5\x2c\x45\xa5\xc9\x30\xd3\x7b\x36\x7d\x6e\xb9\xdd\x36\x58\xb9\x99\x95\x40\x81\x16\x3b\x38\x6e\x86\xfd\x36\x3b\x07\xe1\x59\x69\x2a\x17\x68\x62\x2f\x36\x47\xc7\x5c\xef\x20\xbf\x57\xd7\xef\x1b\x7c\xcf\xc3\x74\xfd\x5b\x74\x79\x59\xcc\xcd\x82\x9a\xdb\xad\xbe\xf4\xf7\x80\x18\x48\x59\x8e\x75\xd5\xf1\x50\x8f\xe9\x90\xb0\xf6\x1e\xcf\xaa\xcf\x2e\x7e\xbe\x69\x0e\x97\x31\x98\xb2\xba\x1b\xf3\x33\x97\x31\x7e\x30\x93\xea\x07\x48\x98\xb4\xad\xcd\x9a\xf4\xc9\xe8\xed\xf6\xee\x1e\xb1\xf5\x07\x19\xec\xdd\x66\x9c\x0d\x3b\x67\xdb\x31\x6d\xbc\xdb\x1d\xa1\x32\x4d\x5c\x4a\x69\x6e\x61\x43\x31\x18\xd0\x85\x24\x7d\x82\x35\xd2\xea\xfb\x2a\x19\x1f\x62\xa3\x9e\xba\xb5\xd7\x88\xdf\x2b\xcd\xce\x4a\x33\x38\x06\xf1\x8b\xf1\xc7\x97\x77\x9d\x18\x5e\x9c\xef\xa5\xd3\xb7\x5d\x3a\xd5\x07\xf4\x0f\xc8\x80\xa5\xcc\xfb\x22\xf7\x20\x1f\x37\xc2\x04\xff\x2e\x07\x6e\xe3\xcc\x05\xa3\x79\x32\xc3\xe2\x87\xc8\xfe\x40\x69\x2b\x09\xc8\xac\x21\xce\xf7\x54\xf9\x15\xa4\xca\x7a\x61\xbe\x86\x4c\x59\x49\xf3\x3d\x51\x7e\xdb\x89\x32\x14\x4f\xbd\xa1\xdd\x16\xeb\x1e\x87\xd0\x95\x0a\x8d\x91\xad\x47\x26\xc1\xd4\x11\x90\xa5\x14\x2b\x96\xd4\x31\x62\xde\xce\x35\x4f\x13\xdb\x07\xdb\xa1\xdd\x6c\xce\x4a\x6c\x61\xec\xca\x26\x9d\xcb\xd6\x79\xae\x5d\xa9\x72\x9f\x50\xdf\xd6\x79\xba\xb9\x2c\xbb\xdc\x6b\xeb\xd6\xbb\x37\x74\xba\xde\x00\x11\x38\xf2\x25\xb3\x79\xc4\x76\x8b\x60\x5e\xb3\x55\xef\xde\x6c\x67\x29\xb8\xbf\x81\xf2\x6b\xb0\x25\x62\x3b\x37\x3d\x59\x06\xaa\x96\xef\x71\x28\x6f\x3e\x08\xf1\xf8\x1d\x3e\xf4\x38\x0c\xb7\x09\x7b\x0a\xc1\x31\x9f\x70\xed\x3d\x8f\x8f\x8d\xed\x75\x97\xb3\xb4\xfa\xa1\xbd\x90\x36\x0a\x03\xdb\x58\x75\x9b\x14\x3e\x7f\x19\x8d\x46\x1b\x89\xa5\x99\x35\x22\x20\xe6\xf5\x2c\x19\xb5\x23\x9b\x8c\xda\x71\x5b\xbf\xd0\xed\x14\xc8\x93\x08\xf8\x03\xb0\x0d\x1e\x8d\x4b\xce\xca\xde\xdb\x0b\xd2\x5a\x8c\xe1\x0b\x50\x32\xaa\x83\xe7\x75\x7d\x95\xfe\x4f\xc6\xa7\x1d\xc9\xd4\xa4\xeb\x0c\xef\xc2\xe5\xfb\x87\xbf\x5d\x45\x17\x2f\x7f\x84\x94\xf1\x05\xca\xa5\x64\x5c\x6f\x5e\xbf\x37\xef\xe7\xab\x29\xcf\xaf\xf3\xd1\xe3\xe6\xc3\x4e\xfb\x56\x32\x9d\x7d\xfe\xf1\xcf\x5f\xec\x2b\x49\x2d\x4b\xa5\xdf\x88\x82\xf6\x4b\x98\x60\x4a\xcb\x5c\x3b\x50\x48\x2c\x6c\x53\x2c\x67\x3c\x3f\x12\x6c\xbb\xd5\x8d\x6f\x49\x56\xbf\xbc\xeb\x62\x5d\x6f\xf6\xb6\x6a\x48\xcc\x15\x3b\x0d\xc7\x24\x54\x6b\x1a\x67\xee\xe9\xee\x5e\x6c\xc5\xfc\x06\x63\x4d\x46\xff\x1f\x00\x76\x09\xaf\x18\xa5\x2e\x00\x00")
 
 func LanguageIndependentComponentDescriptorV2SchemaYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -93,10 +93,10 @@ func LanguageIndependentComponentDescriptorV2SchemaYaml() (*asset, error) {
 
 	info := bindataFileInfo{
 		name:        "../../../../language-independent/component-descriptor-v2-schema.yaml",
-		size:        10026,
+		size:        11941,
 		md5checksum: "",
 		mode:        os.FileMode(420),
-		modTime:     time.Unix(1681220532, 0),
+		modTime:     time.Unix(1791978756, 0),
 	}
 
 	a := &asset{bytes: bytes, info: info}
@@ -0,0 +1,212 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// labelSchemas contains the json schemas of well-known labels.
+// The title of each schema is the name of the label it describes.
+//
+//go:embed schemas/*.json
+var labelSchemas embed.FS
+
+// LabelSchemaRegistry contains json schemas for the values of labels, identified by the label name.
+// Multiple versions of a schema can be registered for a label.
+type LabelSchemaRegistry struct {
+	mux     sync.RWMutex
+	schemas map[string]map[string]*gojsonschema.Schema
+}
+
+// NewLabelSchemaRegistry creates a new empty label schema registry.
+func NewLabelSchemaRegistry() *LabelSchemaRegistry {
+	return &LabelSchemaRegistry{
+		schemas: map[string]map[string]*gojsonschema.Schema{},
+	}
+}
+
+// DefaultLabelSchemaRegistry contains the schemas of all well-known labels.
+var DefaultLabelSchemaRegistry = NewLabelSchemaRegistry()
+
+func init() {
+	if err := registerEmbeddedLabelSchemas(DefaultLabelSchemaRegistry); err != nil {
+		panic(err)
+	}
+}
+
+// Register registers the unversioned json schema for the values of the label with the given name.
+// The unversioned schema is only the latest schema if no versioned schema is registered for the label.
+func (r *LabelSchemaRegistry) Register(labelName string, schema []byte) error {
+	return r.RegisterLabelSchemaVersion(labelName, "", schema)
+}
+
+// RegisterLabelSchemaVersion registers a version of the json schema for the values of the label with the given name.
+// An already registered schema with the same version is replaced.
+func (r *LabelSchemaRegistry) RegisterLabelSchemaVersion(labelName, schemaVersion string, schema []byte) error {
+	if len(labelName) == 0 {
+		return errors.New("a label name must not be empty")
+	}
+	s, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema))
+	if err != nil {
+		return fmt.Errorf("unable to load schema version %q for label %q: %w", schemaVersion, labelName, err)
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if _, ok := r.schemas[labelName]; !ok {
+		r.schemas[labelName] = map[string]*gojsonschema.Schema{}
+	}
+	r.schemas[labelName][schemaVersion] = s
+	return nil
+}
+
+// Get returns the latest schema for the label with the given name.
+func (r *LabelSchemaRegistry) Get(labelName string) (*gojsonschema.Schema, bool) {
+	return r.GetVersion(labelName, r.LatestVersion(labelName))
+}
+
+// GetVersion returns the schema with the given version for the label with the given name.
+func (r *LabelSchemaRegistry) GetVersion(labelName, schemaVersion string) (*gojsonschema.Schema, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	s, ok := r.schemas[labelName][schemaVersion]
+	return s, ok
+}
+
+// SchemaVersions returns all registered schema versions of the label with the given name in ascending order.
+// Versions are compared as semantic versions if possible, otherwise lexically.
+// The unversioned schema is represented by an empty version and always sorted first.
+func (r *LabelSchemaRegistry) SchemaVersions(labelName string) []string {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	versions := make([]string, 0, len(r.schemas[labelName]))
+	for version := range r.schemas[labelName] {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareSchemaVersions(versions[i], versions[j]) < 0
+	})
+	return versions
+}
+
+// LatestVersion returns the latest registered schema version of the label with the given name.
+// An empty string is returned if only an unversioned schema or no schema is registered.
+func (r *LabelSchemaRegistry) LatestVersion(labelName string) string {
+	versions := r.SchemaVersions(labelName)
+	if len(versions) == 0 {
+		return ""
+	}
+	return versions[len(versions)-1]
+}
+
+// compareSchemaVersions compares two schema versions as semantic versions if possible, otherwise lexically.
+func compareSchemaVersions(a, b string) int {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) - len(b)
+	}
+	semverA, errA := semver.NewVersion(a)
+	semverB, errB := semver.NewVersion(b)
+	if errA == nil && errB == nil {
+		if c := semverA.Compare(semverB); c != 0 {
+			return c
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// ValidateLabelValue validates the value of the label against the schema that is registered for the label's name.
+// Labels without a registered schema are always valid.
+// The schema version is selected as described in ValidateLabel.
+func ValidateLabelValue(label Label, registry *LabelSchemaRegistry) error {
+	return ValidateLabel(label, registry)
+}
+
+// ValidateLabel validates the value of the label against a version of the schema that is registered for the label's name.
+// By convention, a label value that is a json object may select the schema version with a "schema.version" field,
+// e.g. {"schema": {"version": "v2"}, ...}.
+// Without such a field the value is validated against the latest schema version.
+// Labels without a registered schema are always valid.
+func ValidateLabel(label Label, registry *LabelSchemaRegistry) error {
+	if len(registry.SchemaVersions(label.Name)) == 0 {
+		return nil
+	}
+	schemaVersion, ok := labelSchemaVersion(label.Value)
+	if !ok {
+		schemaVersion = registry.LatestVersion(label.Name)
+	}
+	schema, ok := registry.GetVersion(label.Name, schemaVersion)
+	if !ok {
+		return fmt.Errorf("no schema version %q registered for label %q", schemaVersion, label.Name)
+	}
+	res, err := schema.Validate(gojsonschema.NewBytesLoader(label.Value))
+	if err != nil {
+		return fmt.Errorf("unable to validate value of label %q: %w", label.Name, err)
+	}
+	if !res.Valid() {
+		errs := make([]string, len(res.Errors()))
+		for i, resErr := range res.Errors() {
+			errs[i] = resErr.String()
+		}
+		return fmt.Errorf("invalid value of label %q: %s", label.Name, strings.Join(errs, ";"))
+	}
+	return nil
+}
+
+// labelSchemaVersion returns the schema version that is defined by the "schema.version" field of a label value.
+func labelSchemaVersion(value json.RawMessage) (string, bool) {
+	obj := struct {
+		Schema *struct {
+			Version *string `json:"version"`
+		} `json:"schema"`
+	}{}
+	if err := json.Unmarshal(value, &obj); err != nil || obj.Schema == nil || obj.Schema.Version == nil {
+		return "", false
+	}
+	return *obj.Schema.Version, true
+}
+
+// registerEmbeddedLabelSchemas registers all embedded label schemas in the given registry.
+func registerEmbeddedLabelSchemas(registry *LabelSchemaRegistry) error {
+	files, err := labelSchemas.ReadDir("schemas")
+	if err != nil {
+		return fmt.Errorf("unable to read embedded label schemas: %w", err)
+	}
+	for _, file := range files {
+		data, err := labelSchemas.ReadFile(path.Join("schemas", file.Name()))
+		if err != nil {
+			return fmt.Errorf("unable to read embedded label schema %q: %w", file.Name(), err)
+		}
+		meta := struct {
+			Title string `json:"title"`
+		}{}
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return fmt.Errorf("unable to decode embedded label schema %q: %w", file.Name(), err)
+		}
+		if err := registry.Register(meta.Title, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+var _ = Describe("label schemas", func() {
+
+	It("should validate known-good label values against the embedded schemas", func() {
+		Expect(v2.ValidateLabelValue(v2.Label{
+			Name:  "cloud.gardener/os-update-strategy",
+			Value: json.RawMessage(`"in-place"`),
+		}, v2.DefaultLabelSchemaRegistry)).To(Succeed())
+		Expect(v2.ValidateLabelValue(v2.Label{
+			Name:  "gardener.cloud/regional-mirrors",
+			Value: json.RawMessage(`[{"region": "eu", "repositoryContext": {"type": "ociRegistry", "baseUrl": "eu.example.com"}}]`),
+		}, v2.DefaultLabelSchemaRegistry)).To(Succeed())
+	})
+
+	It("should reject known-bad label values", func() {
+		Expect(v2.ValidateLabelValue(v2.Label{
+			Name:  "cloud.gardener/os-update-strategy",
+			Value: json.RawMessage(`"rolling"`),
+		}, v2.DefaultLabelSchemaRegistry)).ToNot(Succeed())
+		Expect(v2.ValidateLabelValue(v2.Label{
+			Name:  "gardener.cloud/regional-mirrors",
+			Value: json.RawMessage(`[{"region": "eu"}]`),
+		}, v2.DefaultLabelSchemaRegistry)).ToNot(Succeed())
+	})
+
+	It("should accept labels without a registered schema", func() {
+		Expect(v2.ValidateLabelValue(v2.Label{
+			Name:  "example.com/unknown",
+			Value: json.RawMessage(`{"any": "value"}`),
+		}, v2.DefaultLabelSchemaRegistry)).To(Succeed())
+	})
+
+	It("should validate against custom registered schemas", func() {
+		registry := v2.NewLabelSchemaRegistry()
+		Expect(registry.Register("example.com/count", []byte(`{"type": "integer"}`))).To(Succeed())
+		Expect(v2.ValidateLabelValue(v2.Label{Name: "example.com/count", Value: json.RawMessage(`3`)}, registry)).To(Succeed())
+		Expect(v2.ValidateLabelValue(v2.Label{Name: "example.com/count", Value: json.RawMessage(`"3"`)}, registry)).ToNot(Succeed())
+	})
+
+	Context("schema versions", func() {
+		var registry *v2.LabelSchemaRegistry
+
+		BeforeEach(func() {
+			registry = v2.NewLabelSchemaRegistry()
+			Expect(registry.RegisterLabelSchemaVersion("example.com/config", "v1.10.0", []byte(`{
+				"type": "object",
+				"required": ["endpoints"],
+				"properties": {"endpoints": {"type": "array", "items": {"type": "string"}}}
+			}`))).To(Succeed())
+			Expect(registry.RegisterLabelSchemaVersion("example.com/config", "v1.2.0", []byte(`{
+				"type": "object",
+				"required": ["endpoint"],
+				"properties": {"endpoint": {"type": "string"}}
+			}`))).To(Succeed())
+		})
+
+		It("should list all registered versions in semantic version order", func() {
+			Expect(registry.SchemaVersions("example.com/config")).To(Equal([]string{"v1.2.0", "v1.10.0"}))
+			Expect(registry.LatestVersion("example.com/config")).To(Equal("v1.10.0"))
+			Expect(registry.SchemaVersions("example.com/unknown")).To(BeEmpty())
+			Expect(registry.LatestVersion("example.com/unknown")).To(BeEmpty())
+		})
+
+		It("should validate against the version selected by the label value", func() {
+			Expect(v2.ValidateLabel(v2.Label{
+				Name:  "example.com/config",
+				Value: json.RawMessage(`{"schema": {"version": "v1.2.0"}, "endpoint": "a.example.com"}`),
+			}, registry)).To(Succeed())
+			Expect(v2.ValidateLabel(v2.Label{
+				Name:  "example.com/config",
+				Value: json.RawMessage(`{"schema": {"version": "v1.10.0"}, "endpoint": "a.example.com"}`),
+			}, registry)).ToNot(Succeed())
+			Expect(v2.ValidateLabel(v2.Label{
+				Name:  "example.com/config",
+				Value: json.RawMessage(`{"schema": {"version": "v1.10.0"}, "endpoints": ["a.example.com"]}`),
+			}, registry)).To(Succeed())
+		})
+
+		It("should validate against the latest version if the label value selects no version", func() {
+			Expect(v2.ValidateLabel(v2.Label{
+				Name:  "example.com/config",
+				Value: json.RawMessage(`{"endpoints": ["a.example.com"]}`),
+			}, registry)).To(Succeed())
+			Expect(v2.ValidateLabel(v2.Label{
+				Name:  "example.com/config",
+				Value: json.RawMessage(`{"endpoint": "a.example.com"}`),
+			}, registry)).ToNot(Succeed())
+		})
+
+		It("should reject label values that select an unknown version", func() {
+			Expect(v2.ValidateLabel(v2.Label{
+				Name:  "example.com/config",
+				Value: json.RawMessage(`{"schema": {"version": "v3.0.0"}, "endpoint": "a.example.com"}`),
+			}, registry)).ToNot(Succeed())
+		})
+
+		It("should prefer versioned schemas over the unversioned schema", func() {
+			Expect(registry.Register("example.com/config", []byte(`{"type": "string"}`))).To(Succeed())
+			Expect(registry.SchemaVersions("example.com/config")).To(Equal([]string{"", "v1.2.0", "v1.10.0"}))
+			Expect(registry.LatestVersion("example.com/config")).To(Equal("v1.10.0"))
+		})
+	})
+})
@@ -0,0 +1,118 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ProviderDNSRecordPrefix is the prefix of the dns name of the TXT records that authorize a provider domain.
+const ProviderDNSRecordPrefix = "_gardener-provider"
+
+// TXTResolver looks up the TXT records of a dns name.
+// It is implemented by *net.Resolver.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+var _ TXTResolver = &net.Resolver{}
+
+// ProviderAuthorityError is returned if the dns records of a provider domain do not authorize a component.
+type ProviderAuthorityError struct {
+	// Provider is the provider domain.
+	Provider string
+	// ComponentName is the name of the component that is not authorized.
+	ComponentName string
+}
+
+func (e ProviderAuthorityError) Error() string {
+	return fmt.Sprintf("the TXT records of %s.%s do not authorize component %q", ProviderDNSRecordPrefix, e.Provider, e.ComponentName)
+}
+
+// ProviderDNSToken returns the token that has to be published as TXT record of the provider domain
+// to authorize the component with the given name.
+// The token is the hex encoded sha256 digest of the component name with the prefix "sha256:".
+func ProviderDNSToken(componentName string) string {
+	sum := sha256.Sum256([]byte(componentName))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// VerifyProviderDNS verifies with the default resolver that the provider domain authorizes the component.
+func VerifyProviderDNS(ctx context.Context, provider, componentName string) error {
+	return VerifyProviderDNSWithResolver(ctx, net.DefaultResolver, provider, componentName)
+}
+
+// VerifyProviderDNSWithResolver looks up the TXT records of "_gardener-provider.<provider>" and
+// verifies that one of them contains the token of the component (see ProviderDNSToken).
+// A ProviderAuthorityError is returned if no record contains the token.
+func VerifyProviderDNSWithResolver(ctx context.Context, resolver TXTResolver, provider, componentName string) error {
+	if len(provider) == 0 {
+		return errors.New("a provider domain must not be empty")
+	}
+	name := ProviderDNSRecordPrefix + "." + provider
+	records, err := resolver.LookupTXT(ctx, name)
+	if err != nil {
+		return fmt.Errorf("unable to lookup TXT records of %s: %w", name, err)
+	}
+	token := ProviderDNSToken(componentName)
+	for _, record := range records {
+		if strings.TrimSpace(record) == token {
+			return nil
+		}
+	}
+	return ProviderAuthorityError{
+		Provider:      provider,
+		ComponentName: componentName,
+	}
+}
+
+// ValidateProviderAuthority verifies with the default resolver that the provider of the component descriptor authorizes the component.
+func ValidateProviderAuthority(ctx context.Context, cd *ComponentDescriptor) error {
+	return ValidateProviderAuthorityWithResolver(ctx, net.DefaultResolver, cd)
+}
+
+// ValidateProviderAuthorityWithResolver extracts the domain of the component descriptor's provider and
+// verifies that it authorizes the component.
+// The provider is expected to be a domain, optionally with a scheme and path like "https://example.com/team".
+func ValidateProviderAuthorityWithResolver(ctx context.Context, resolver TXTResolver, cd *ComponentDescriptor) error {
+	domain, err := ProviderDomain(cd.Provider)
+	if err != nil {
+		return err
+	}
+	return VerifyProviderDNSWithResolver(ctx, resolver, domain, cd.GetName())
+}
+
+// ProviderDomain returns the domain of the provider.
+// An error is returned if the provider does not define a domain, e.g. for the "internal" and "external" providers.
+func ProviderDomain(provider ProviderType) (string, error) {
+	domain := strings.TrimSpace(string(provider))
+	if i := strings.Index(domain, "://"); i != -1 {
+		domain = domain[i+3:]
+	}
+	if i := strings.Index(domain, "/"); i != -1 {
+		domain = domain[:i]
+	}
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if !strings.Contains(domain, ".") {
+		return "", fmt.Errorf("provider %q does not define a domain", provider)
+	}
+	return domain, nil
+}
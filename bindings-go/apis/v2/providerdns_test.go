@@ -0,0 +1,76 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2_test
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// stubTXTResolver returns static TXT records by dns name.
+type stubTXTResolver map[string][]string
+
+func (r stubTXTResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, ok := r[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+var _ = Describe("provider authority", func() {
+
+	newComponentDescriptor := func(name, provider string) *v2.ComponentDescriptor {
+		cd := &v2.ComponentDescriptor{}
+		cd.Name = name
+		cd.Provider = v2.ProviderType(provider)
+		return cd
+	}
+
+	resolver := stubTXTResolver{
+		"_gardener-provider.example.com": {"some-other-record", v2.ProviderDNSToken("example.com/comp")},
+	}
+
+	It("should accept components that are authorized by the provider domain", func() {
+		Expect(v2.VerifyProviderDNSWithResolver(context.TODO(), resolver, "example.com", "example.com/comp")).To(Succeed())
+		Expect(v2.ValidateProviderAuthorityWithResolver(context.TODO(), resolver, newComponentDescriptor("example.com/comp", "https://Example.com/team"))).To(Succeed())
+	})
+
+	It("should reject components that are not authorized by the provider domain", func() {
+		err := v2.ValidateProviderAuthorityWithResolver(context.TODO(), resolver, newComponentDescriptor("example.com/other", "example.com"))
+		Expect(err).To(HaveOccurred())
+		authorityErr := v2.ProviderAuthorityError{}
+		Expect(errors.As(err, &authorityErr)).To(BeTrue())
+		Expect(authorityErr).To(Equal(v2.ProviderAuthorityError{Provider: "example.com", ComponentName: "example.com/other"}))
+	})
+
+	It("should fail if the TXT records cannot be looked up", func() {
+		err := v2.ValidateProviderAuthorityWithResolver(context.TODO(), resolver, newComponentDescriptor("example.org/comp", "example.org"))
+		Expect(err).To(HaveOccurred())
+		dnsErr := &net.DNSError{}
+		Expect(errors.As(err, &dnsErr)).To(BeTrue())
+	})
+
+	It("should fail for providers without domain", func() {
+		Expect(v2.ValidateProviderAuthorityWithResolver(context.TODO(), resolver, newComponentDescriptor("example.com/comp", "internal"))).ToNot(Succeed())
+	})
+
+})
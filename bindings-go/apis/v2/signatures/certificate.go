@@ -0,0 +1,190 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// ProviderCertificatePinMismatchError describes a certificate that does not match the pinned provider certificate.
+type ProviderCertificatePinMismatchError struct {
+	// Pinned is the pinned fingerprint.
+	Pinned string
+	// Actual is the fingerprint of the presented certificate.
+	Actual string
+}
+
+func (e ProviderCertificatePinMismatchError) Error() string {
+	return fmt.Sprintf("certificate fingerprint %s does not match the pinned provider certificate %s", e.Actual, e.Pinned)
+}
+
+// CertificateFingerprint returns the hex encoded SHA-256 fingerprint of the certificate.
+func CertificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// PinProviderCertificate pins the given certificate as signing certificate of the component descriptor's provider.
+func PinProviderCertificate(cd *cdv2.ComponentDescriptor, cert *x509.Certificate) error {
+	if cert == nil {
+		return errors.New("a certificate must be defined")
+	}
+	cd.ProviderCertificatePin = CertificateFingerprint(cert)
+	return nil
+}
+
+// VerifyProviderCertificatePin checks that the given certificate matches the pinned provider certificate.
+// An error is returned if the component descriptor has no pinned certificate.
+// Note that the pin is not part of the normalised component descriptor and therefore not protected by signatures,
+// a trusted pin should be configured at the CertificateVerifier instead.
+func VerifyProviderCertificatePin(cd *cdv2.ComponentDescriptor, cert *x509.Certificate) error {
+	if len(cd.ProviderCertificatePin) == 0 {
+		return errors.New("the component descriptor has no pinned provider certificate")
+	}
+	return verifyCertificatePin(cd.ProviderCertificatePin, cert)
+}
+
+// verifyCertificatePin checks that the fingerprint of the certificate matches the pinned fingerprint.
+func verifyCertificatePin(pinned string, cert *x509.Certificate) error {
+	if cert == nil {
+		return errors.New("a certificate must be defined")
+	}
+	if fingerprint := CertificateFingerprint(cert); fingerprint != pinned {
+		return ProviderCertificatePinMismatchError{
+			Pinned: pinned,
+			Actual: fingerprint,
+		}
+	}
+	return nil
+}
+
+// CertificateVerifier is a signatures.Verifier compatible struct that verifies signatures
+// with the certificate that is embedded into the signature.
+// The signing certificate has to be valid for the configured roots and has to match the configured pin.
+// At least one of both has to be configured, otherwise every verification fails.
+type CertificateVerifier struct {
+	roots *x509.CertPool
+	pin   string
+}
+
+var _ Verifier = &CertificateVerifier{}
+
+// NewCertificateVerifier creates a new certificate verifier.
+// If roots are given, the embedded certificate chain has to be valid for one of the roots.
+func NewCertificateVerifier(roots *x509.CertPool) *CertificateVerifier {
+	return &CertificateVerifier{
+		roots: roots,
+	}
+}
+
+// WithPinnedCertificate configures the hex encoded SHA-256 fingerprint (see CertificateFingerprint)
+// the signing certificate has to match.
+func (v *CertificateVerifier) WithPinnedCertificate(fingerprint string) *CertificateVerifier {
+	v.pin = fingerprint
+	return v
+}
+
+// Verify checks the signature, returns an error on verification failure
+func (v CertificateVerifier) Verify(componentDescriptor cdv2.ComponentDescriptor, signature cdv2.Signature) error {
+	if v.roots == nil && len(v.pin) == 0 {
+		return errors.New("neither certificate roots nor a pinned certificate are configured")
+	}
+	certs, err := parseCertificateChain(signature.Signature.Certificate)
+	if err != nil {
+		return err
+	}
+	leaf := certs[0]
+	if v.roots != nil {
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := leaf.Verify(x509.VerifyOptions{
+			Roots:         v.roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err != nil {
+			return fmt.Errorf("unable to verify signing certificate: %w", err)
+		}
+	}
+	if len(v.pin) != 0 {
+		if err := verifyCertificatePin(v.pin, leaf); err != nil {
+			return err
+		}
+	}
+
+	switch key := leaf.PublicKey.(type) {
+	case *rsa.PublicKey:
+		verifier, err := CreateRSAVerifier(key)
+		if err != nil {
+			return err
+		}
+		return verifier.Verify(componentDescriptor, signature)
+	case *ecdsa.PublicKey:
+		if signature.Signature.MediaType != cdv2.MediaTypeECDSASignature {
+			return fmt.Errorf("invalid signature mediaType %s", signature.Signature.MediaType)
+		}
+		signatureBytes, err := hex.DecodeString(signature.Signature.Value)
+		if err != nil {
+			return fmt.Errorf("unable to hex decode signature %s: %w", signature.Signature.Value, err)
+		}
+		decodedHash, err := hex.DecodeString(signature.Digest.Value)
+		if err != nil {
+			return fmt.Errorf("unable to hex decode hash %s: %w", signature.Digest.Value, err)
+		}
+		if !ecdsa.VerifyASN1(key, decodedHash, signatureBytes) {
+			return errors.New("unable to verify signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported certificate key type %T", key)
+	}
+}
+
+// parseCertificateChain parses the pem encoded certificate chain of a signature.
+// The first certificate is expected to be the signing certificate.
+func parseCertificateChain(data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("signature does not contain a certificate")
+	}
+	return certs, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+)
+
+// certificateSigner signs with an ecdsa key and embeds the self-signed certificate of the key.
+type certificateSigner struct {
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+}
+
+func newCertificateSigner(commonName string) *certificateSigner {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	Expect(err).ToNot(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).ToNot(HaveOccurred())
+	return &certificateSigner{key: key, cert: cert}
+}
+
+func (s *certificateSigner) Sign(_ cdv2.ComponentDescriptor, digest cdv2.DigestSpec) (*cdv2.SignatureSpec, error) {
+	decodedHash, err := hex.DecodeString(digest.Value)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := s.key.Sign(rand.Reader, decodedHash, crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	return &cdv2.SignatureSpec{
+		Algorithm:   cdv2.ECDSAASN1,
+		Value:       hex.EncodeToString(signature),
+		MediaType:   cdv2.MediaTypeECDSASignature,
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.cert.Raw})),
+	}, nil
+}
+
+var _ = Describe("provider certificate pinning", func() {
+	var (
+		cd     *cdv2.ComponentDescriptor
+		hasher signatures.Hasher
+	)
+
+	BeforeEach(func() {
+		cd = &cdv2.ComponentDescriptor{
+			Metadata: cdv2.Metadata{
+				Version: "v2",
+			},
+		}
+		cd.Name = "example.com/a"
+		cd.Version = "v0.0.1"
+		hasher = signatures.Hasher{
+			HashFunction:  sha256.New(),
+			AlgorithmName: signatures.SHA256,
+		}
+	})
+
+	It("should pin the fingerprint of the certificate", func() {
+		signer := newCertificateSigner("provider")
+		Expect(signatures.PinProviderCertificate(cd, signer.cert)).To(Succeed())
+		sum := sha256.Sum256(signer.cert.Raw)
+		Expect(cd.ProviderCertificatePin).To(Equal(hex.EncodeToString(sum[:])))
+		Expect(signatures.VerifyProviderCertificatePin(cd, signer.cert)).To(Succeed())
+	})
+
+	It("should fail the pin check for a different certificate", func() {
+		Expect(signatures.PinProviderCertificate(cd, newCertificateSigner("provider").cert)).To(Succeed())
+		other := newCertificateSigner("malicious").cert
+
+		err := signatures.VerifyProviderCertificatePin(cd, other)
+		Expect(err).To(HaveOccurred())
+		mismatchErr := signatures.ProviderCertificatePinMismatchError{}
+		Expect(errors.As(err, &mismatchErr)).To(BeTrue())
+		Expect(mismatchErr.Pinned).To(Equal(cd.ProviderCertificatePin))
+		Expect(mismatchErr.Actual).To(Equal(signatures.CertificateFingerprint(other)))
+	})
+
+	It("should fail the pin check without a pinned certificate", func() {
+		Expect(signatures.VerifyProviderCertificatePin(cd, newCertificateSigner("provider").cert)).ToNot(Succeed())
+	})
+
+	It("should verify a signature of the pinned certificate", func() {
+		signer := newCertificateSigner("provider")
+		Expect(signatures.PinProviderCertificate(cd, signer.cert)).To(Succeed())
+		Expect(signatures.SignComponentDescriptor(cd, signer, hasher, "provider")).To(Succeed())
+
+		roots := x509.NewCertPool()
+		roots.AddCert(signer.cert)
+		Expect(signatures.VerifySignedComponentDescriptor(cd, signatures.NewCertificateVerifier(roots), "provider")).To(Succeed())
+
+		verifier := signatures.NewCertificateVerifier(nil).WithPinnedCertificate(signatures.CertificateFingerprint(signer.cert))
+		Expect(signatures.VerifySignedComponentDescriptor(cd, verifier, "provider")).To(Succeed())
+	})
+
+	It("should reject a signature of a certificate that is not pinned", func() {
+		provider := newCertificateSigner("provider")
+		signer := newCertificateSigner("malicious")
+		Expect(signatures.SignComponentDescriptor(cd, signer, hasher, "provider")).To(Succeed())
+		// the pin of the component descriptor is not trusted
+		Expect(signatures.PinProviderCertificate(cd, signer.cert)).To(Succeed())
+
+		verifier := signatures.NewCertificateVerifier(nil).WithPinnedCertificate(signatures.CertificateFingerprint(provider.cert))
+		err := signatures.VerifySignedComponentDescriptor(cd, verifier, "provider")
+		Expect(err).To(HaveOccurred())
+		Expect(errors.As(err, &signatures.ProviderCertificatePinMismatchError{})).To(BeTrue())
+	})
+
+	It("should reject every signature without roots and pinned certificate", func() {
+		signer := newCertificateSigner("provider")
+		Expect(signatures.SignComponentDescriptor(cd, signer, hasher, "provider")).To(Succeed())
+		Expect(signatures.PinProviderCertificate(cd, signer.cert)).To(Succeed())
+
+		Expect(signatures.VerifySignedComponentDescriptor(cd, signatures.NewCertificateVerifier(nil), "provider")).ToNot(Succeed())
+	})
+
+	It("should reject a certificate that is not signed by the roots", func() {
+		signer := newCertificateSigner("provider")
+		Expect(signatures.SignComponentDescriptor(cd, signer, hasher, "provider")).To(Succeed())
+
+		roots := x509.NewCertPool()
+		roots.AddCert(newCertificateSigner("root").cert)
+		Expect(signatures.VerifySignedComponentDescriptor(cd, signatures.NewCertificateVerifier(roots), "provider")).ToNot(Succeed())
+	})
+})
@@ -14,12 +14,18 @@
 
 package signatures
 
-import "crypto"
+import (
+	"crypto"
+	// register the sha512 hash functions
+	_ "crypto/sha512"
+)
 
 const (
 	SHA256 = "sha256"
+	SHA512 = "sha512"
 )
 
 var HashFunctions = map[string]crypto.Hash{
 	SHA256: crypto.SHA256,
+	SHA512: crypto.SHA512,
 }
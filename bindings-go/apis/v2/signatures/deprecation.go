@@ -0,0 +1,66 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import "sync"
+
+var (
+	deprecationMux sync.RWMutex
+
+	// DeprecatedHashAlgorithms contains the names of hash algorithms that are deprecated.
+	// The DeprecationHandler is called whenever a deprecated algorithm is used for hashing or verification.
+	// Use SetDeprecatedHashAlgorithms to change the algorithms concurrency-safe.
+	DeprecatedHashAlgorithms []string
+
+	// DeprecationHandler is called with the name of a deprecated hash algorithm whenever it is used.
+	// It is a no-op by default.
+	// Use SetDeprecationHandler to change the handler concurrency-safe.
+	DeprecationHandler = func(algorithm string) {}
+)
+
+// SetDeprecationHandler sets the handler that is called if a deprecated hash algorithm is used.
+// A nil handler disables the handling.
+func SetDeprecationHandler(h func(algorithm string)) {
+	deprecationMux.Lock()
+	defer deprecationMux.Unlock()
+	if h == nil {
+		h = func(algorithm string) {}
+	}
+	DeprecationHandler = h
+}
+
+// SetDeprecatedHashAlgorithms sets the names of the deprecated hash algorithms.
+func SetDeprecatedHashAlgorithms(algos []string) {
+	deprecationMux.Lock()
+	defer deprecationMux.Unlock()
+	DeprecatedHashAlgorithms = append([]string{}, algos...)
+}
+
+// warnIfDeprecated calls the deprecation handler if the hash algorithm is deprecated.
+func warnIfDeprecated(algorithm string) {
+	deprecationMux.RLock()
+	handler := DeprecationHandler
+	deprecated := false
+	for _, algo := range DeprecatedHashAlgorithms {
+		if algo == algorithm {
+			deprecated = true
+			break
+		}
+	}
+	deprecationMux.RUnlock()
+	if deprecated && handler != nil {
+		handler(algorithm)
+	}
+}
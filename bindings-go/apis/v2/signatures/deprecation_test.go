@@ -0,0 +1,89 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+)
+
+var _ = Describe("hash algorithm deprecation", func() {
+
+	var (
+		cd    *cdv2.ComponentDescriptor
+		calls []string
+	)
+
+	BeforeEach(func() {
+		cd = &cdv2.ComponentDescriptor{
+			Metadata: cdv2.Metadata{
+				Version: "v2",
+			},
+		}
+		cd.Name = "example.com/a"
+		cd.Version = "v0.0.1"
+
+		calls = nil
+		signatures.SetDeprecationHandler(func(algorithm string) {
+			calls = append(calls, algorithm)
+		})
+	})
+
+	AfterEach(func() {
+		signatures.SetDeprecationHandler(nil)
+		signatures.SetDeprecatedHashAlgorithms(nil)
+	})
+
+	It("should not call the handler for algorithms that are not deprecated", func() {
+		hasher, err := signatures.HasherForName(signatures.SHA256)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signatures.SignComponentDescriptor(cd, TestSigner{}, *hasher, "sig")).To(Succeed())
+		Expect(signatures.VerifySignedComponentDescriptor(cd, TestVerifier{}, "sig")).To(Succeed())
+		Expect(calls).To(BeEmpty())
+	})
+
+	It("should call the handler when a deprecated algorithm is used for hashing", func() {
+		signatures.SetDeprecatedHashAlgorithms([]string{signatures.SHA256})
+		hasher, err := signatures.HasherForName(signatures.SHA256)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal([]string{signatures.SHA256}))
+
+		_, err = signatures.HashForComponentDescriptor(*cd, *hasher)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal([]string{signatures.SHA256, signatures.SHA256}))
+
+		sha512, err := signatures.HasherForName(signatures.SHA512)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = signatures.HashForComponentDescriptor(*cd, *sha512)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(HaveLen(2))
+	})
+
+	It("should call the handler exactly once per verification of a deprecated signature", func() {
+		hasher, err := signatures.HasherForName(signatures.SHA256)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signatures.SignComponentDescriptor(cd, TestSigner{}, *hasher, "sig")).To(Succeed())
+
+		signatures.SetDeprecatedHashAlgorithms([]string{signatures.SHA256})
+		Expect(signatures.VerifySignedComponentDescriptor(cd, TestVerifier{}, "sig")).To(Succeed())
+		Expect(calls).To(Equal([]string{signatures.SHA256}))
+		Expect(signatures.VerifySignedComponentDescriptor(cd, TestVerifier{}, "sig")).To(Succeed())
+		Expect(calls).To(Equal([]string{signatures.SHA256, signatures.SHA256}))
+	})
+
+})
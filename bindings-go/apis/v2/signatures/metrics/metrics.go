@@ -0,0 +1,124 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+)
+
+const (
+	// StatusSuccess is the status label value of successful operations.
+	StatusSuccess = "success"
+	// StatusFailure is the status label value of failed operations.
+	StatusFailure = "failure"
+
+	// UnknownAlgorithm is the algorithm label value of operations whose signature algorithm is not known,
+	// e.g. because the signer failed before a signature was created.
+	UnknownAlgorithm = "unknown"
+)
+
+// SigningMetrics contains the prometheus metrics of signing and verifying component descriptors.
+type SigningMetrics struct {
+	// SigningTotal counts the signing operations by signature algorithm and status.
+	SigningTotal *prometheus.CounterVec
+	// SigningDuration observes the duration of signing operations by signature algorithm.
+	SigningDuration *prometheus.HistogramVec
+	// VerificationTotal counts the verification operations by signature algorithm and status.
+	VerificationTotal *prometheus.CounterVec
+	// VerificationDuration observes the duration of verification operations by signature algorithm.
+	VerificationDuration *prometheus.HistogramVec
+}
+
+// NewPrometheusSigningMetrics creates the signing metrics and registers them at the given registerer.
+func NewPrometheusSigningMetrics(reg prometheus.Registerer) (*SigningMetrics, error) {
+	m := &SigningMetrics{
+		SigningTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "signing_total",
+			Help: "Total number of component descriptor signing operations.",
+		}, []string{"algorithm", "status"}),
+		SigningDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "signing_duration_seconds",
+			Help:    "Duration of component descriptor signing operations in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"algorithm"}),
+		VerificationTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "verification_total",
+			Help: "Total number of component descriptor signature verifications.",
+		}, []string{"algorithm", "status"}),
+		VerificationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "verification_duration_seconds",
+			Help:    "Duration of component descriptor signature verifications in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"algorithm"}),
+	}
+	for _, c := range []prometheus.Collector{m.SigningTotal, m.SigningDuration, m.VerificationTotal, m.VerificationDuration} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// MeasuredSignComponentDescriptor signs the component descriptor with signatures.SignComponentDescriptor
+// and records the duration and the result of the signing in the metrics.
+// The algorithm label is the algorithm of the created signature.
+func MeasuredSignComponentDescriptor(cd *cdv2.ComponentDescriptor, signer signatures.Signer, hasher signatures.Hasher, signatureName string, metrics *SigningMetrics, opts ...signatures.SignOption) error {
+	start := time.Now()
+	err := signatures.SignComponentDescriptor(cd, signer, hasher, signatureName, opts...)
+	if metrics == nil {
+		return err
+	}
+
+	algorithm := UnknownAlgorithm
+	if err == nil {
+		if signature, sigErr := signatures.GetSignatureByName(cd, signatureName); sigErr == nil && len(signature.Signature.Algorithm) != 0 {
+			algorithm = signature.Signature.Algorithm
+		}
+	}
+	metrics.SigningDuration.WithLabelValues(algorithm).Observe(time.Since(start).Seconds())
+	metrics.SigningTotal.WithLabelValues(algorithm, status(err)).Inc()
+	return err
+}
+
+// MeasuredVerifySignedComponentDescriptor verifies the signature of the component descriptor with signatures.VerifySignedComponentDescriptor
+// and records the duration and the result of the verification in the metrics.
+// The algorithm label is the algorithm of the verified signature.
+func MeasuredVerifySignedComponentDescriptor(cd *cdv2.ComponentDescriptor, verifier signatures.Verifier, signatureName string, metrics *SigningMetrics) error {
+	algorithm := UnknownAlgorithm
+	if signature, err := signatures.GetSignatureByName(cd, signatureName); err == nil && len(signature.Signature.Algorithm) != 0 {
+		algorithm = signature.Signature.Algorithm
+	}
+
+	start := time.Now()
+	err := signatures.VerifySignedComponentDescriptor(cd, verifier, signatureName)
+	if metrics == nil {
+		return err
+	}
+	metrics.VerificationDuration.WithLabelValues(algorithm).Observe(time.Since(start).Seconds())
+	metrics.VerificationTotal.WithLabelValues(algorithm, status(err)).Inc()
+	return err
+}
+
+func status(err error) string {
+	if err != nil {
+		return StatusFailure
+	}
+	return StatusSuccess
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures/metrics"
+)
+
+// testSigner signs with a static signature of the "test" algorithm.
+type testSigner struct {
+	err error
+}
+
+func (s testSigner) Sign(_ cdv2.ComponentDescriptor, _ cdv2.DigestSpec) (*cdv2.SignatureSpec, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &cdv2.SignatureSpec{Algorithm: "test", Value: "signature", MediaType: "test"}, nil
+}
+
+// testVerifier accepts or rejects all signatures.
+type testVerifier struct {
+	err error
+}
+
+func (v testVerifier) Verify(_ cdv2.ComponentDescriptor, _ cdv2.Signature) error {
+	return v.err
+}
+
+var _ = Describe("signing metrics", func() {
+
+	var (
+		reg *prometheus.Registry
+		m   *metrics.SigningMetrics
+		cd  *cdv2.ComponentDescriptor
+	)
+
+	BeforeEach(func() {
+		reg = prometheus.NewRegistry()
+		var err error
+		m, err = metrics.NewPrometheusSigningMetrics(reg)
+		Expect(err).ToNot(HaveOccurred())
+
+		cd = &cdv2.ComponentDescriptor{}
+		cd.Metadata.Version = "v2"
+		cd.Name = "example.com/component"
+		cd.Version = "v0.0.1"
+	})
+
+	It("should fail to register the metrics twice", func() {
+		_, err := metrics.NewPrometheusSigningMetrics(reg)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should record successful and failed signing operations", func() {
+		hasher, err := signatures.HasherForName(signatures.SHA256)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(metrics.MeasuredSignComponentDescriptor(cd, testSigner{}, *hasher, "sig", m)).To(Succeed())
+		Expect(cd.Signatures).To(HaveLen(1))
+		err = metrics.MeasuredSignComponentDescriptor(cd, testSigner{err: errors.New("failed")}, *hasher, "other", m)
+		Expect(err).To(HaveOccurred())
+
+		Expect(testutil.ToFloat64(m.SigningTotal.WithLabelValues("test", metrics.StatusSuccess))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(m.SigningTotal.WithLabelValues(metrics.UnknownAlgorithm, metrics.StatusFailure))).To(Equal(1.0))
+		Expect(testutil.CollectAndCount(m.SigningDuration)).To(Equal(2))
+	})
+
+	It("should record successful and failed verifications", func() {
+		hasher, err := signatures.HasherForName(signatures.SHA256)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signatures.SignComponentDescriptor(cd, testSigner{}, *hasher, "sig")).To(Succeed())
+
+		Expect(metrics.MeasuredVerifySignedComponentDescriptor(cd, testVerifier{}, "sig", m)).To(Succeed())
+		Expect(metrics.MeasuredVerifySignedComponentDescriptor(cd, testVerifier{err: errors.New("invalid")}, "sig", m)).ToNot(Succeed())
+		Expect(metrics.MeasuredVerifySignedComponentDescriptor(cd, testVerifier{}, "unknown", m)).ToNot(Succeed())
+
+		Expect(testutil.ToFloat64(m.VerificationTotal.WithLabelValues("test", metrics.StatusSuccess))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(m.VerificationTotal.WithLabelValues("test", metrics.StatusFailure))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(m.VerificationTotal.WithLabelValues(metrics.UnknownAlgorithm, metrics.StatusFailure))).To(Equal(1.0))
+		Expect(testutil.CollectAndCount(m.VerificationDuration)).To(Equal(2))
+	})
+
+	It("should sign without metrics", func() {
+		hasher, err := signatures.HasherForName(signatures.SHA256)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metrics.MeasuredSignComponentDescriptor(cd, testSigner{}, *hasher, "sig", nil)).To(Succeed())
+		Expect(metrics.MeasuredVerifySignedComponentDescriptor(cd, testVerifier{}, "sig", nil)).To(Succeed())
+	})
+
+})
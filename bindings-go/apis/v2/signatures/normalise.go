@@ -55,18 +55,49 @@ func AddDigestsToComponentDescriptor(ctx context.Context, cd *cdv2.ComponentDesc
 		if err != nil {
 			return fmt.Errorf("unable to resolve resource %s:%s: %w", res.Name, res.Version, err)
 		}
-		if res.Digest != nil && !reflect.DeepEqual(res.Digest, digest) {
+		if res.Digest != nil && !digestsMatch(res.Digest, digest) {
 			return fmt.Errorf("calculated digest mismatches existing digest for resource %s:%s", res.Name, res.Version)
 		}
+		// keep an already known size of the blob
+		if res.Digest != nil && digest.Size == 0 {
+			withSize := *digest
+			withSize.Size = res.Digest.Size
+			digest = &withSize
+		}
 		cd.Resources[i].Digest = digest
 	}
 	return nil
 }
 
+// digestsMatch checks whether both digests are equal.
+// The size is ignored as it is not part of the normalised component descriptor.
+func digestsMatch(a, b *cdv2.DigestSpec) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.HashAlgorithm == b.HashAlgorithm && a.NormalisationAlgorithm == b.NormalisationAlgorithm && a.Value == b.Value
+}
+
 // HashForComponentDescriptor return the hash for the component-descriptor, if it is normaliseable
 // (= componentReferences and resources contain digest field)
 func HashForComponentDescriptor(cd cdv2.ComponentDescriptor, hash Hasher) (*cdv2.DigestSpec, error) {
-	normalisedComponentDescriptor, err := normaliseComponentDescriptor(cd)
+	return HashForComponentDescriptorWithNormalisation(cd, hash, cdv2.JsonNormalisationV1)
+}
+
+// HashForComponentDescriptorWithNormalisation return the hash for the component-descriptor
+// that is normalised with the given normalisation algorithm.
+// The DeprecationHandler is called if the hash algorithm is deprecated.
+func HashForComponentDescriptorWithNormalisation(cd cdv2.ComponentDescriptor, hash Hasher, algorithm cdv2.NormalisationAlgorithm) (*cdv2.DigestSpec, error) {
+	warnIfDeprecated(hash.AlgorithmName)
+	return hashForComponentDescriptor(cd, hash, algorithm)
+}
+
+func hashForComponentDescriptor(cd cdv2.ComponentDescriptor, hash Hasher, algorithm cdv2.NormalisationAlgorithm) (*cdv2.DigestSpec, error) {
+	normalise, ok := Normalisations[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("normalisation algorithm %s not found/implemented", algorithm)
+	}
+	normalisedComponentDescriptor, err := normalise(cd)
 	if err != nil {
 		return nil, fmt.Errorf("unable to normalise component descriptor: %w", err)
 	}
@@ -76,7 +107,7 @@ func HashForComponentDescriptor(cd cdv2.ComponentDescriptor, hash Hasher) (*cdv2
 	}
 	return &cdv2.DigestSpec{
 		HashAlgorithm:          hash.AlgorithmName,
-		NormalisationAlgorithm: string(cdv2.JsonNormalisationV1),
+		NormalisationAlgorithm: string(algorithm),
 		Value:                  hex.EncodeToString(hash.HashFunction.Sum(nil)),
 	}, nil
 }
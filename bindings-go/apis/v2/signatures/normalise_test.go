@@ -137,6 +137,14 @@ var _ = Describe("Normalise/Hash component-descriptor", func() {
 			Expect(err).To(BeNil())
 			Expect(hash.Value).To(Equal(correctBaseCdHash))
 		})
+		It("should succeed with resource digest size changes", func() {
+			baseCd.Resources[0].Digest.Size = 1024
+			hasher, err := signatures.HasherForName(signatures.SHA256)
+			Expect(err).To(BeNil())
+			hash, err := signatures.HashForComponentDescriptor(baseCd, *hasher)
+			Expect(err).To(BeNil())
+			Expect(hash.Value).To(Equal(correctBaseCdHash))
+		})
 
 	})
 	Describe("should correctly handle empty access and digest", func() {
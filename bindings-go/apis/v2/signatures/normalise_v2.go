@@ -0,0 +1,209 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// NormalisationUpgradesLabel is the name of the component label that records the v1 digests of signatures
+// that have been upgraded to the v2 normalisation.
+const NormalisationUpgradesLabel = "gardener.cloud/normalisation-upgrades"
+
+// NormalisationFunc normalises a component descriptor into a deterministic representation that can be hashed.
+type NormalisationFunc func(cd cdv2.ComponentDescriptor) ([]byte, error)
+
+// Normalisations contains all known normalisation algorithms.
+var Normalisations = map[cdv2.NormalisationAlgorithm]NormalisationFunc{
+	cdv2.JsonNormalisationV1: normaliseComponentDescriptor,
+	cdv2.JsonNormalisationV2: normaliseComponentDescriptorV2,
+}
+
+// normaliseComponentDescriptorV2 normalises the same attributes as the v1 normalisation
+// and additionally the access of all resources.
+// All other attributes like signatures, repository contexts, labels and digest sizes are not part of the normalisation
+// as these might change without changing the component itself.
+// All object keys are sorted recursively and all null and empty list attributes are removed,
+// so that semantically equal component descriptors result in the same representation.
+func normaliseComponentDescriptorV2(cd cdv2.ComponentDescriptor) ([]byte, error) {
+	if err := isNormaliseable(cd); err != nil {
+		return nil, fmt.Errorf("component descriptor %s:%s is not normaliseable: %w", cd.Name, cd.Version, err)
+	}
+
+	componentReferences := make([]interface{}, 0, len(cd.ComponentReferences))
+	for _, ref := range cd.ComponentReferences {
+		componentReferences = append(componentReferences, map[string]interface{}{
+			"componentName": ref.ComponentName,
+			"name":          ref.Name,
+			"version":       ref.Version,
+			"extraIdentity": ref.ExtraIdentity,
+			"digest":        normalisedDigestV2(ref.Digest),
+		})
+	}
+
+	resources := make([]interface{}, 0, len(cd.Resources))
+	for _, res := range cd.Resources {
+		resource := map[string]interface{}{
+			"name":          res.Name,
+			"version":       res.Version,
+			"type":          res.Type,
+			"relation":      res.Relation,
+			"extraIdentity": res.ExtraIdentity,
+		}
+		// like in the v1 normalisation resources without an access have no digest
+		if res.Access != nil && res.Access.Type != "None" {
+			resource["access"] = res.Access
+			resource["digest"] = normalisedDigestV2(res.Digest)
+		}
+		resources = append(resources, resource)
+	}
+
+	normalised := map[string]interface{}{
+		"meta": map[string]interface{}{
+			"schemaVersion": cd.Metadata.Version,
+		},
+		"component": map[string]interface{}{
+			"name":                cd.Name,
+			"version":             cd.Version,
+			"provider":            cd.Provider,
+			"componentReferences": componentReferences,
+			"resources":           resources,
+		},
+	}
+
+	data, err := json.Marshal(normalised)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode component descriptor: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewBuffer(data))
+	decoder.UseNumber()
+	var obj interface{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("unable to decode component descriptor: %w", err)
+	}
+
+	byteBuffer := bytes.NewBuffer([]byte{})
+	encoder := json.NewEncoder(byteBuffer)
+	encoder.SetEscapeHTML(false)
+	// maps are encoded with sorted keys
+	if err := encoder.Encode(removeEmptyValues(obj)); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(byteBuffer.Bytes(), []byte("\n")), nil
+}
+
+// normalisedDigestV2 returns the normalised attributes of a digest.
+func normalisedDigestV2(digest *cdv2.DigestSpec) map[string]interface{} {
+	return map[string]interface{}{
+		"hashAlgorithm":          digest.HashAlgorithm,
+		"normalisationAlgorithm": digest.NormalisationAlgorithm,
+		"value":                  digest.Value,
+	}
+}
+
+// removeEmptyValues recursively removes all object attributes that are null or empty lists.
+func removeEmptyValues(in interface{}) interface{} {
+	switch castIn := in.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(castIn))
+		for key, val := range castIn {
+			val = removeEmptyValues(val)
+			if val == nil {
+				continue
+			}
+			if list, ok := val.([]interface{}); ok && len(list) == 0 {
+				continue
+			}
+			out[key] = val
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(castIn))
+		for i, val := range castIn {
+			out[i] = removeEmptyValues(val)
+		}
+		return out
+	default:
+		return in
+	}
+}
+
+// UpgradeNormalisationAlgorithm re-signs the signature with the given name using the v2 normalisation.
+// The v1 digest of the signature is recorded in the NormalisationUpgradesLabel of the component descriptor.
+func UpgradeNormalisationAlgorithm(cd *cdv2.ComponentDescriptor, signer Signer, hasher Hasher, signatureName string) error {
+	idx := -1
+	for i, signature := range cd.Signatures {
+		if signature.Name == signatureName {
+			idx = i
+		}
+	}
+	if idx == -1 {
+		return fmt.Errorf("signature with name %s not found in component descriptor", signatureName)
+	}
+	oldDigest := cd.Signatures[idx].Digest
+	if oldDigest.NormalisationAlgorithm != string(cdv2.JsonNormalisationV1) {
+		return fmt.Errorf("signature %s is not normalised with %s", signatureName, cdv2.JsonNormalisationV1)
+	}
+
+	hashedDigest, err := HashForComponentDescriptorWithNormalisation(*cd, hasher, cdv2.JsonNormalisationV2)
+	if err != nil {
+		return fmt.Errorf("unable to get hash for component descriptor: %w", err)
+	}
+	// the validity period of the old signature is kept and bound to the new digest
+	oldSpec := cd.Signatures[idx].Signature
+	signedDigest, err := SignedDigest(cdv2.Signature{
+		Digest:    *hashedDigest,
+		Signature: cdv2.SignatureSpec{NotBefore: oldSpec.NotBefore, NotAfter: oldSpec.NotAfter},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get signed digest: %w", err)
+	}
+	signature, err := signer.Sign(*cd, *signedDigest)
+	if err != nil {
+		return fmt.Errorf("unable to sign hash of normalised component descriptor: %w", err)
+	}
+	signature.NotBefore = oldSpec.NotBefore
+	signature.NotAfter = oldSpec.NotAfter
+
+	upgrades := map[string]cdv2.DigestSpec{}
+	labelIdx := -1
+	for i, label := range cd.Labels {
+		if label.Name != NormalisationUpgradesLabel {
+			continue
+		}
+		if err := json.Unmarshal(label.Value, &upgrades); err != nil {
+			return fmt.Errorf("unable to decode normalisation upgrades: %w", err)
+		}
+		labelIdx = i
+	}
+	upgrades[signatureName] = oldDigest
+	data, err := json.Marshal(upgrades)
+	if err != nil {
+		return fmt.Errorf("unable to encode normalisation upgrades: %w", err)
+	}
+	if labelIdx == -1 {
+		cd.Labels = append(cd.Labels, cdv2.Label{Name: NormalisationUpgradesLabel})
+		labelIdx = len(cd.Labels) - 1
+	}
+	cd.Labels[labelIdx].Value = data
+
+	cd.Signatures[idx].Digest = *hashedDigest
+	cd.Signatures[idx].Signature = *signature
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures_test
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+	"github.com/gardener/component-spec/bindings-go/codec"
+)
+
+const normalisationV2CD1 = `
+meta:
+  schemaVersion: v2
+component:
+  name: example.com/component
+  version: v0.0.1
+  provider: internal
+  repositoryContexts:
+  - type: ociRegistry
+    baseUrl: example.com/a
+  sources: []
+  componentReferences: []
+  resources:
+  - name: image
+    version: v0.0.1
+    type: ociImage
+    relation: external
+    labels:
+    - name: example.com/label
+      value:
+        a: 1
+        b: [ "x", "y" ]
+    access:
+      type: ociRegistry
+      imageReference: example.com/image:v0.0.1
+      mediaType: null
+    digest:
+      hashAlgorithm: sha256
+      normalisationAlgorithm: ociArtifactDigest/v1
+      value: "00000000000000"
+`
+
+const normalisationV2CD2 = `
+component:
+  resources:
+  - access:
+      imageReference: example.com/image:v0.0.1
+      type: ociRegistry
+    digest:
+      value: "00000000000000"
+      normalisationAlgorithm: ociArtifactDigest/v1
+      hashAlgorithm: sha256
+    relation: external
+    labels:
+    - value:
+        b: [ "x", "y" ]
+        a: 1
+      name: example.com/label
+    type: ociImage
+    version: v0.0.1
+    name: image
+  componentReferences: []
+  sources: []
+  repositoryContexts:
+  - baseUrl: example.com/b
+    type: ociRegistry
+  provider: internal
+  version: v0.0.1
+  name: example.com/component
+  labels:
+  - name: example.com/component-label
+    value: true
+meta:
+  schemaVersion: v2
+`
+
+var _ = Describe("Normalisation v2", func() {
+
+	var hasher signatures.Hasher
+
+	decode := func(data string) *cdv2.ComponentDescriptor {
+		cd := &cdv2.ComponentDescriptor{}
+		Expect(codec.Decode([]byte(data), cd)).To(Succeed())
+		return cd
+	}
+
+	BeforeEach(func() {
+		hasher = signatures.Hasher{
+			HashFunction:  sha256.New(),
+			AlgorithmName: signatures.SHA256,
+		}
+	})
+
+	It("should calculate the same hash for differently ordered component descriptors", func() {
+		digest1, err := signatures.HashForComponentDescriptorWithNormalisation(*decode(normalisationV2CD1), hasher, cdv2.JsonNormalisationV2)
+		Expect(err).ToNot(HaveOccurred())
+		digest2, err := signatures.HashForComponentDescriptorWithNormalisation(*decode(normalisationV2CD2), hasher, cdv2.JsonNormalisationV2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest1.NormalisationAlgorithm).To(Equal(string(cdv2.JsonNormalisationV2)))
+		Expect(digest1.Value).To(Equal(digest2.Value))
+	})
+
+	It("should calculate a different hash if the access of a resource changes", func() {
+		cd := decode(normalisationV2CD1)
+		digest1, err := signatures.HashForComponentDescriptorWithNormalisation(*cd, hasher, cdv2.JsonNormalisationV2)
+		Expect(err).ToNot(HaveOccurred())
+		cd.Resources[0].Access.Object["imageReference"] = "example.com/image:v0.0.2"
+		digest2, err := signatures.HashForComponentDescriptorWithNormalisation(*cd, hasher, cdv2.JsonNormalisationV2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest1.Value).ToNot(Equal(digest2.Value))
+	})
+
+	It("should calculate the same hash if attributes change that are not normalised", func() {
+		cd := decode(normalisationV2CD1)
+		digest1, err := signatures.HashForComponentDescriptorWithNormalisation(*cd, hasher, cdv2.JsonNormalisationV2)
+		Expect(err).ToNot(HaveOccurred())
+		cd.Resources[0].Digest.Size = 42
+		cd.Resources[0].Labels = nil
+		cd.Resources[0].AdditionalDigests = []cdv2.DigestSpec{{HashAlgorithm: signatures.SHA512, Value: "00"}}
+		cd.TrustDomain = "example.com"
+		cd.ProviderCertificatePin = "00"
+		cd.Extensions = map[string]json.RawMessage{"example.com/ext": json.RawMessage(`{}`)}
+		digest2, err := signatures.HashForComponentDescriptorWithNormalisation(*cd, hasher, cdv2.JsonNormalisationV2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest1.Value).To(Equal(digest2.Value))
+	})
+
+	It("should upgrade a v1 signature to v2 and record the v1 digest", func() {
+		cd := decode(normalisationV2CD1)
+		Expect(signatures.SignComponentDescriptor(cd, TestSigner{}, hasher, "sig")).To(Succeed())
+		v1Digest := cd.Signatures[0].Digest
+
+		Expect(signatures.UpgradeNormalisationAlgorithm(cd, TestSigner{}, hasher, "sig")).To(Succeed())
+		Expect(cd.Signatures).To(HaveLen(1))
+		Expect(cd.Signatures[0].Digest.NormalisationAlgorithm).To(Equal(string(cdv2.JsonNormalisationV2)))
+		Expect(signatures.VerifySignedComponentDescriptor(cd, TestVerifier{}, "sig")).To(Succeed())
+
+		upgrades := map[string]cdv2.DigestSpec{}
+		Expect(cd.Labels).To(HaveLen(1))
+		Expect(cd.Labels[0].Name).To(Equal(signatures.NormalisationUpgradesLabel))
+		Expect(json.Unmarshal(cd.Labels[0].Value, &upgrades)).To(Succeed())
+		Expect(upgrades).To(HaveKeyWithValue("sig", v1Digest))
+	})
+
+})
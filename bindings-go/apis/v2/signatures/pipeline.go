@@ -0,0 +1,78 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import (
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// PreSignHook is called before a component descriptor is signed.
+// Signing is aborted if the hook returns an error.
+type PreSignHook func(cd *cdv2.ComponentDescriptor) error
+
+// PostSignHook is called with the created signature after a component descriptor has been signed.
+type PostSignHook func(cd *cdv2.ComponentDescriptor, signature cdv2.Signature) error
+
+// SigningPipeline signs component descriptors and runs custom hooks before and after signing.
+type SigningPipeline struct {
+	preSignHooks  []PreSignHook
+	postSignHooks []PostSignHook
+}
+
+// NewSigningPipeline creates a new signing pipeline without any hooks.
+func NewSigningPipeline() *SigningPipeline {
+	return &SigningPipeline{}
+}
+
+// WithPreSignHook adds a hook that is called before signing.
+// Hooks are called in the order they have been added.
+func (p *SigningPipeline) WithPreSignHook(hook func(cd *cdv2.ComponentDescriptor) error) *SigningPipeline {
+	p.preSignHooks = append(p.preSignHooks, hook)
+	return p
+}
+
+// WithPostSignHook adds a hook that is called after signing.
+// Hooks are called in the order they have been added.
+func (p *SigningPipeline) WithPostSignHook(hook func(cd *cdv2.ComponentDescriptor, signature cdv2.Signature) error) *SigningPipeline {
+	p.postSignHooks = append(p.postSignHooks, hook)
+	return p
+}
+
+// Sign runs all pre-sign hooks, signs the component descriptor and then runs all post-sign hooks.
+// The component descriptor is not signed if one of the pre-sign hooks fails.
+func (p *SigningPipeline) Sign(cd *cdv2.ComponentDescriptor, signer Signer, hasher Hasher, signatureName string) error {
+	for i, hook := range p.preSignHooks {
+		if err := hook(cd); err != nil {
+			return fmt.Errorf("pre-sign hook %d failed: %w", i, err)
+		}
+	}
+
+	if err := SignComponentDescriptor(cd, signer, hasher, signatureName); err != nil {
+		return err
+	}
+	signature, err := GetSignatureByName(cd, signatureName)
+	if err != nil {
+		return err
+	}
+
+	for i, hook := range p.postSignHooks {
+		if err := hook(cd, *signature); err != nil {
+			return fmt.Errorf("post-sign hook %d failed: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures_test
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+)
+
+var _ = Describe("signing pipeline", func() {
+
+	var (
+		cd     *cdv2.ComponentDescriptor
+		hasher signatures.Hasher
+	)
+
+	BeforeEach(func() {
+		cd = &cdv2.ComponentDescriptor{
+			Metadata: cdv2.Metadata{
+				Version: "v2",
+			},
+			ComponentSpec: cdv2.ComponentSpec{
+				ObjectMeta: cdv2.ObjectMeta{
+					Name:    "example.com/component",
+					Version: "v0.0.1",
+				},
+			},
+		}
+		hasher = signatures.Hasher{
+			HashFunction:  sha256.New(),
+			AlgorithmName: signatures.SHA256,
+		}
+	})
+
+	It("should call the hooks in order", func() {
+		calls := []string{}
+		pipeline := signatures.NewSigningPipeline().
+			WithPreSignHook(func(cd *cdv2.ComponentDescriptor) error {
+				Expect(cd.Signatures).To(BeEmpty())
+				calls = append(calls, "pre1")
+				return nil
+			}).
+			WithPreSignHook(func(cd *cdv2.ComponentDescriptor) error {
+				calls = append(calls, "pre2")
+				return nil
+			}).
+			WithPostSignHook(func(cd *cdv2.ComponentDescriptor, signature cdv2.Signature) error {
+				Expect(signature.Name).To(Equal("test"))
+				Expect(cd.Signatures).To(HaveLen(1))
+				calls = append(calls, "post")
+				return nil
+			})
+
+		Expect(pipeline.Sign(cd, TestSigner{}, hasher, "test")).To(Succeed())
+		Expect(calls).To(Equal([]string{"pre1", "pre2", "post"}))
+	})
+
+	It("should not sign if a pre-sign hook fails", func() {
+		postCalled := false
+		hookErr := errors.New("audit log not available")
+		pipeline := signatures.NewSigningPipeline().
+			WithPreSignHook(func(cd *cdv2.ComponentDescriptor) error {
+				return hookErr
+			}).
+			WithPostSignHook(func(cd *cdv2.ComponentDescriptor, signature cdv2.Signature) error {
+				postCalled = true
+				return nil
+			})
+
+		err := pipeline.Sign(cd, TestSigner{}, hasher, "test")
+		Expect(errors.Is(err, hookErr)).To(BeTrue())
+		Expect(cd.Signatures).To(BeEmpty())
+		Expect(postCalled).To(BeFalse())
+	})
+})
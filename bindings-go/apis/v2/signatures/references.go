@@ -0,0 +1,205 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// DigestMismatchError describes a component reference whose digest does not match the referenced component descriptor.
+type DigestMismatchError struct {
+	// Reference is the component reference with the mismatching digest.
+	Reference cdv2.ComponentReference
+	// Expected is the digest value of the component reference.
+	Expected string
+	// Actual is the digest value of the resolved component descriptor.
+	// It is empty if the digest could not be calculated.
+	Actual string
+	// Err is the reason why the digest could not be calculated.
+	Err error
+}
+
+func (e DigestMismatchError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("unable to verify digest of component reference %q (%s:%s): %s", e.Reference.Name, e.Reference.ComponentName, e.Reference.Version, e.Err.Error())
+	}
+	return fmt.Sprintf("digest of component reference %q (%s:%s) mismatches: expected %q but got %q", e.Reference.Name, e.Reference.ComponentName, e.Reference.Version, e.Expected, e.Actual)
+}
+
+func (e DigestMismatchError) Unwrap() error {
+	return e.Err
+}
+
+// VerifyComponentReferenceDigests resolves all component references of the component descriptor that define a digest
+// and verifies that the digest of the resolved component descriptor matches the digest of the reference.
+// The referenced component descriptors are normalised with the normalisation algorithm of the reference digest.
+// References without digest or with an excluded digest are not verified.
+func VerifyComponentReferenceDigests(ctx context.Context, cd *cdv2.ComponentDescriptor, resolver ctf.ComponentResolver, hasher Hasher) []DigestMismatchError {
+	_, mismatches := verifyComponentReferenceDigests(ctx, cd, resolver, hasher)
+	return mismatches
+}
+
+// VerifyRecursive verifies the signature of the component descriptor
+// and then recursively verifies the digests of all transitively referenced component descriptors.
+func VerifyRecursive(ctx context.Context, cd *cdv2.ComponentDescriptor, resolver ctf.ComponentResolver, verifier Verifier, signatureName string, hasher Hasher) error {
+	if err := VerifySignedComponentDescriptor(cd, verifier, signatureName); err != nil {
+		return err
+	}
+
+	visited := map[string]bool{}
+	queue := []*cdv2.ComponentDescriptor{cd}
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+		key := current.Name + ":" + current.Version
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+
+		resolved, mismatches := verifyComponentReferenceDigests(ctx, current, resolver, hasher)
+		if len(mismatches) != 0 {
+			msgs := make([]string, len(mismatches))
+			for i, mismatch := range mismatches {
+				msgs[i] = mismatch.Error()
+			}
+			return fmt.Errorf("invalid component references of %s:%s: %s", current.Name, current.Version, strings.Join(msgs, "; "))
+		}
+		queue = append(queue, resolved...)
+	}
+	return nil
+}
+
+// verifyComponentReferenceDigests verifies the digests of all component references and
+// returns the resolved component descriptors of the successfully verified references.
+func verifyComponentReferenceDigests(ctx context.Context, cd *cdv2.ComponentDescriptor, resolver ctf.ComponentResolver, hasher Hasher) ([]*cdv2.ComponentDescriptor, []DigestMismatchError) {
+	resolved := make([]*cdv2.ComponentDescriptor, 0)
+	mismatches := make([]DigestMismatchError, 0)
+	repoCtx := cd.GetEffectiveRepositoryContext()
+	for _, ref := range cd.ComponentReferences {
+		if ref.Digest == nil || reflect.DeepEqual(ref.Digest, cdv2.NewExcludeFromSignatureDigest()) {
+			continue
+		}
+		if repoCtx == nil {
+			mismatches = append(mismatches, DigestMismatchError{
+				Reference: ref,
+				Expected:  ref.Digest.Value,
+				Err:       fmt.Errorf("component descriptor %s:%s has no repository context", cd.Name, cd.Version),
+			})
+			continue
+		}
+		if ref.Digest.HashAlgorithm != hasher.AlgorithmName {
+			mismatches = append(mismatches, DigestMismatchError{
+				Reference: ref,
+				Expected:  ref.Digest.Value,
+				Err:       fmt.Errorf("digest uses hash algorithm %s but %s is expected", ref.Digest.HashAlgorithm, hasher.AlgorithmName),
+			})
+			continue
+		}
+		refCd, err := resolver.Resolve(ctx, repoCtx, ref.ComponentName, ref.Version)
+		if err != nil {
+			mismatches = append(mismatches, DigestMismatchError{
+				Reference: ref,
+				Expected:  ref.Digest.Value,
+				Err:       fmt.Errorf("unable to resolve component descriptor: %w", err),
+			})
+			continue
+		}
+
+		normalisationAlgorithm := cdv2.NormalisationAlgorithm(ref.Digest.NormalisationAlgorithm)
+		if len(normalisationAlgorithm) == 0 {
+			normalisationAlgorithm = cdv2.JsonNormalisationV1
+		}
+		digest, err := HashForComponentDescriptorWithNormalisation(*refCd, hasher, normalisationAlgorithm)
+		if err != nil {
+			mismatches = append(mismatches, DigestMismatchError{
+				Reference: ref,
+				Expected:  ref.Digest.Value,
+				Err:       fmt.Errorf("unable to hash component descriptor: %w", err),
+			})
+			continue
+		}
+		if digest.Value != ref.Digest.Value {
+			mismatches = append(mismatches, DigestMismatchError{
+				Reference: ref,
+				Expected:  ref.Digest.Value,
+				Actual:    digest.Value,
+			})
+			continue
+		}
+		resolved = append(resolved, refCd)
+	}
+	return resolved, mismatches
+}
+
+// DigestInconsistency describes a component reference whose stored digest does not match
+// the digest computed for the referenced component descriptor.
+type DigestInconsistency struct {
+	// Reference is the inconsistent component reference.
+	Reference cdv2.ComponentReference
+	// Stored is the digest of the component reference.
+	// It is empty if the reference has no digest.
+	Stored cdv2.DigestSpec
+	// Computed is the digest of the resolved component descriptor.
+	Computed cdv2.DigestSpec
+}
+
+// CheckReferenceDigestConsistency resolves all component references of the component descriptor
+// and returns the references whose digest is missing or does not match the digest of the resolved component descriptor.
+// References with an excluded digest are not checked.
+// An error is returned if a referenced component descriptor cannot be resolved or hashed.
+func CheckReferenceDigestConsistency(ctx context.Context, cd *cdv2.ComponentDescriptor, resolver ctf.ComponentResolver, hasher Hasher) ([]DigestInconsistency, error) {
+	inconsistencies := make([]DigestInconsistency, 0)
+	repoCtx := cd.GetEffectiveRepositoryContext()
+	for _, ref := range cd.ComponentReferences {
+		if ref.Digest != nil && reflect.DeepEqual(ref.Digest, cdv2.NewExcludeFromSignatureDigest()) {
+			continue
+		}
+		if repoCtx == nil {
+			return nil, fmt.Errorf("component descriptor %s:%s has no repository context", cd.Name, cd.Version)
+		}
+		refCd, err := resolver.Resolve(ctx, repoCtx, ref.ComponentName, ref.Version)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve component descriptor of reference %q (%s:%s): %w", ref.Name, ref.ComponentName, ref.Version, err)
+		}
+
+		stored := cdv2.DigestSpec{}
+		normalisationAlgorithm := cdv2.JsonNormalisationV1
+		if ref.Digest != nil {
+			stored = *ref.Digest
+			if len(stored.NormalisationAlgorithm) != 0 {
+				normalisationAlgorithm = cdv2.NormalisationAlgorithm(stored.NormalisationAlgorithm)
+			}
+		}
+		computed, err := HashForComponentDescriptorWithNormalisation(*refCd, hasher, normalisationAlgorithm)
+		if err != nil {
+			return nil, fmt.Errorf("unable to hash component descriptor of reference %q (%s:%s): %w", ref.Name, ref.ComponentName, ref.Version, err)
+		}
+		if stored.HashAlgorithm != computed.HashAlgorithm || stored.Value != computed.Value {
+			inconsistencies = append(inconsistencies, DigestInconsistency{
+				Reference: ref,
+				Stored:    stored,
+				Computed:  *computed,
+			})
+		}
+	}
+	return inconsistencies, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures_test
+
+import (
+	"context"
+	"crypto/sha256"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("component reference digests", func() {
+
+	var (
+		hasher  signatures.Hasher
+		repoCtx *cdv2.UnstructuredTypedObject
+	)
+
+	newComponentDescriptor := func(name string, refs ...cdv2.ComponentReference) cdv2.ComponentDescriptor {
+		return cdv2.ComponentDescriptor{
+			Metadata: cdv2.Metadata{
+				Version: cdv2.SchemaVersion,
+			},
+			ComponentSpec: cdv2.ComponentSpec{
+				ObjectMeta: cdv2.ObjectMeta{
+					Name:    name,
+					Version: "v0.0.1",
+				},
+				RepositoryContexts:  []*cdv2.UnstructuredTypedObject{repoCtx},
+				ComponentReferences: refs,
+			},
+		}
+	}
+
+	newReference := func(cd cdv2.ComponentDescriptor) cdv2.ComponentReference {
+		digest, err := signatures.HashForComponentDescriptor(cd, hasher)
+		Expect(err).ToNot(HaveOccurred())
+		return cdv2.ComponentReference{
+			Name:          cd.Name,
+			ComponentName: cd.Name,
+			Version:       cd.Version,
+			Digest:        digest,
+		}
+	}
+
+	newResolver := func(cds ...cdv2.ComponentDescriptor) ctf.ComponentResolver {
+		resolver, err := ctf.NewListResolver(&cdv2.ComponentDescriptorList{Components: cds})
+		Expect(err).ToNot(HaveOccurred())
+		return resolver
+	}
+
+	BeforeEach(func() {
+		hasher = signatures.Hasher{
+			HashFunction:  sha256.New(),
+			AlgorithmName: signatures.SHA256,
+		}
+		unstructured, err := cdv2.NewUnstructured(cdv2.NewOCIRegistryRepository("example.com", ""))
+		Expect(err).ToNot(HaveOccurred())
+		repoCtx = &unstructured
+	})
+
+	It("should report a reference whose digest does not match the resolved component descriptor", func() {
+		leaf := newComponentDescriptor("example.com/leaf")
+		ref := newReference(leaf)
+		// the resolved component descriptor differs from the one the digest was calculated for
+		modifiedLeaf := newComponentDescriptor("example.com/leaf", newReference(newComponentDescriptor("example.com/other")))
+		root := newComponentDescriptor("example.com/root", ref)
+
+		mismatches := signatures.VerifyComponentReferenceDigests(context.TODO(), &root, newResolver(modifiedLeaf), hasher)
+		Expect(mismatches).To(HaveLen(1))
+		Expect(mismatches[0].Reference.Name).To(Equal("example.com/leaf"))
+		Expect(mismatches[0].Expected).To(Equal(ref.Digest.Value))
+		Expect(mismatches[0].Actual).ToNot(BeEmpty())
+		Expect(mismatches[0].Actual).ToNot(Equal(ref.Digest.Value))
+		Expect(mismatches[0].Err).ToNot(HaveOccurred())
+	})
+
+	It("should not report matching references", func() {
+		leaf := newComponentDescriptor("example.com/leaf")
+		root := newComponentDescriptor("example.com/root", newReference(leaf))
+		Expect(signatures.VerifyComponentReferenceDigests(context.TODO(), &root, newResolver(leaf), hasher)).To(BeEmpty())
+	})
+
+	It("should report references that cannot be resolved", func() {
+		leaf := newComponentDescriptor("example.com/leaf")
+		root := newComponentDescriptor("example.com/root", newReference(leaf))
+		mismatches := signatures.VerifyComponentReferenceDigests(context.TODO(), &root, newResolver(), hasher)
+		Expect(mismatches).To(HaveLen(1))
+		Expect(mismatches[0].Err).To(HaveOccurred())
+	})
+
+	It("should recursively verify the digests of all references", func() {
+		leaf := newComponentDescriptor("example.com/leaf")
+		mid := newComponentDescriptor("example.com/mid", newReference(leaf))
+		root := newComponentDescriptor("example.com/root", newReference(mid))
+		Expect(signatures.SignComponentDescriptor(&root, TestSigner{}, hasher, "test")).To(Succeed())
+
+		Expect(signatures.VerifyRecursive(context.TODO(), &root, newResolver(mid, leaf), TestVerifier{}, "test", hasher)).To(Succeed())
+
+		modifiedLeaf := newComponentDescriptor("example.com/leaf", newReference(newComponentDescriptor("example.com/other")))
+		Expect(signatures.VerifyRecursive(context.TODO(), &root, newResolver(mid, modifiedLeaf), TestVerifier{}, "test", hasher)).ToNot(Succeed())
+	})
+	It("should only report the reference with an inconsistent digest", func() {
+		leaf1 := newComponentDescriptor("example.com/leaf-1")
+		leaf2 := newComponentDescriptor("example.com/leaf-2")
+		ref1 := newReference(leaf1)
+		ref2 := newReference(leaf2)
+		ref2.Digest.Value = "0000000000000000000000000000000000000000000000000000000000000000"
+		root := newComponentDescriptor("example.com/root", ref1, ref2)
+
+		inconsistencies, err := signatures.CheckReferenceDigestConsistency(context.TODO(), &root, newResolver(leaf1, leaf2), hasher)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inconsistencies).To(HaveLen(1))
+		Expect(inconsistencies[0].Reference.Name).To(Equal("example.com/leaf-2"))
+		Expect(inconsistencies[0].Stored).To(Equal(*ref2.Digest))
+		Expect(inconsistencies[0].Computed.Value).To(Equal(newReference(leaf2).Digest.Value))
+	})
+
+	It("should report references without digest", func() {
+		leaf := newComponentDescriptor("example.com/leaf")
+		ref := newReference(leaf)
+		ref.Digest = nil
+		root := newComponentDescriptor("example.com/root", ref)
+
+		inconsistencies, err := signatures.CheckReferenceDigestConsistency(context.TODO(), &root, newResolver(leaf), hasher)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inconsistencies).To(HaveLen(1))
+		Expect(inconsistencies[0].Stored).To(Equal(cdv2.DigestSpec{}))
+		Expect(inconsistencies[0].Computed.Value).ToNot(BeEmpty())
+	})
+
+	It("should return an error if a reference cannot be resolved", func() {
+		leaf := newComponentDescriptor("example.com/leaf")
+		root := newComponentDescriptor("example.com/root", newReference(leaf))
+		_, err := signatures.CheckReferenceDigestConsistency(context.TODO(), &root, newResolver(), hasher)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,79 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// HashForResource returns the hash for the identity and access of a single resource.
+// The hash is calculated over the canonical json of the resource's name, version, type, extra identity and access
+// with recursively sorted keys.
+// Labels and the digest of the resource are not part of the hash, so that the hash can be stored in both.
+func HashForResource(res cdv2.Resource, hasher Hasher) (*cdv2.DigestSpec, error) {
+	normalisedResource, err := normaliseResource(res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to normalise resource %s:%s: %w", res.Name, res.Version, err)
+	}
+	hasher.HashFunction.Reset()
+	if _, err := hasher.HashFunction.Write(normalisedResource); err != nil {
+		return nil, fmt.Errorf("unable to hash normalised resource %s:%s: %w", res.Name, res.Version, err)
+	}
+	return &cdv2.DigestSpec{
+		HashAlgorithm:          hasher.AlgorithmName,
+		NormalisationAlgorithm: string(cdv2.ResourceIdentityV1),
+		Value:                  hex.EncodeToString(hasher.HashFunction.Sum(nil)),
+	}, nil
+}
+
+func normaliseResource(res cdv2.Resource) ([]byte, error) {
+	var access interface{}
+	if res.Access != nil {
+		data, err := json.Marshal(res.Access)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode access: %w", err)
+		}
+		decoder := json.NewDecoder(bytes.NewBuffer(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&access); err != nil {
+			return nil, fmt.Errorf("unable to decode access: %w", err)
+		}
+	}
+	extraIdentity := map[string]string{}
+	for key, val := range res.ExtraIdentity {
+		extraIdentity[key] = val
+	}
+	obj := map[string]interface{}{
+		"name":          res.Name,
+		"version":       res.Version,
+		"type":          res.Type,
+		"extraIdentity": extraIdentity,
+		"access":        access,
+	}
+
+	byteBuffer := bytes.NewBuffer([]byte{})
+	encoder := json.NewEncoder(byteBuffer)
+	encoder.SetEscapeHTML(false)
+	// maps are encoded with sorted keys
+	if err := encoder.Encode(obj); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(byteBuffer.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+)
+
+var _ = Describe("resource hashing", func() {
+
+	newResource := func(imageRef string) cdv2.Resource {
+		access, err := cdv2.NewUnstructured(cdv2.NewOCIRegistryAccess(imageRef))
+		Expect(err).ToNot(HaveOccurred())
+		return cdv2.Resource{
+			IdentityObjectMeta: cdv2.IdentityObjectMeta{
+				Name:          "image",
+				Version:       "v0.0.1",
+				Type:          "ociImage",
+				ExtraIdentity: cdv2.Identity{"platform": "linux"},
+			},
+			Relation: cdv2.ExternalRelation,
+			Access:   &access,
+		}
+	}
+
+	It("should ignore labels and digest of the resource", func() {
+		hasher, err := signatures.HasherForName(signatures.SHA256)
+		Expect(err).ToNot(HaveOccurred())
+
+		res1 := newResource("example.com/image:v0.0.1")
+		res2 := newResource("example.com/image:v0.0.1")
+		res2.Labels = cdv2.Labels{{Name: "example.com/label", Value: json.RawMessage(`"val"`)}}
+		res2.Digest = &cdv2.DigestSpec{
+			HashAlgorithm:          signatures.SHA256,
+			NormalisationAlgorithm: string(cdv2.OciArtifactDigestV1),
+			Value:                  "00000000000000",
+		}
+
+		digest1, err := signatures.HashForResource(res1, *hasher)
+		Expect(err).ToNot(HaveOccurred())
+		digest2, err := signatures.HashForResource(res2, *hasher)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest1).To(Equal(digest2))
+		Expect(digest1.HashAlgorithm).To(Equal(signatures.SHA256))
+		Expect(digest1.NormalisationAlgorithm).To(Equal(string(cdv2.ResourceIdentityV1)))
+	})
+
+	It("should calculate different hashes for different accesses", func() {
+		hasher, err := signatures.HasherForName(signatures.SHA256)
+		Expect(err).ToNot(HaveOccurred())
+
+		digest1, err := signatures.HashForResource(newResource("example.com/image:v0.0.1"), *hasher)
+		Expect(err).ToNot(HaveOccurred())
+		digest2, err := signatures.HashForResource(newResource("example.com/image:v0.0.2"), *hasher)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest1.Value).ToNot(Equal(digest2.Value))
+	})
+})
@@ -16,7 +16,10 @@ package signatures_test
 
 import (
 	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -156,6 +159,50 @@ var _ = Describe("Sign/Verify component-descriptor", func() {
 		})
 	})
 
+	Describe("signature validity period", func() {
+		It("should set the end of the validity period", func() {
+			err := signatures.SignComponentDescriptor(&baseCd, TestSigner{}, testSHA256Hasher, signatureName, signatures.ValidFor(time.Hour))
+			Expect(err).To(BeNil())
+			Expect(baseCd.Signatures[0].Signature.NotAfter).ToNot(BeNil())
+			Expect(*baseCd.Signatures[0].Signature.NotAfter).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			err = signatures.VerifySignedComponentDescriptor(&baseCd, TestVerifier{}, signatureName)
+			Expect(err).To(BeNil())
+		})
+		It("should reject an expired signature", func() {
+			err := signatures.SignComponentDescriptor(&baseCd, TestSigner{}, testSHA256Hasher, signatureName, signatures.ValidFor(time.Hour))
+			Expect(err).To(BeNil())
+			notAfter := *baseCd.Signatures[0].Signature.NotAfter
+			result, err := signatures.NewDefaultVerificationPipeline(TestVerifier{}).
+				AddStep(signatures.ExpiryCheckStep{Now: func() time.Time { return notAfter.Add(time.Minute) }}).
+				Run(&baseCd, signatureName)
+			Expect(err).To(BeNil())
+			var expiredErr signatures.SignatureExpiredError
+			Expect(errors.As(result.FirstError(), &expiredErr)).To(BeTrue())
+			Expect(expiredErr.NotAfter).To(Equal(notAfter))
+		})
+		It("should reject a signature that is not yet valid", func() {
+			err := signatures.SignComponentDescriptor(&baseCd, TestSigner{}, testSHA256Hasher, signatureName)
+			Expect(err).To(BeNil())
+			signature := baseCd.Signatures[0]
+			notBefore := time.Now().Add(time.Hour)
+			signature.Signature.NotBefore = &notBefore
+			err = signatures.ExpiryCheckStep{}.Verify(&baseCd, signature)
+			var notYetValidErr signatures.SignatureNotYetValidError
+			Expect(errors.As(err, &notYetValidErr)).To(BeTrue())
+		})
+		It("should reject a signature whose validity period has been changed", func() {
+			err := signatures.SignComponentDescriptor(&baseCd, TestSigner{}, testSHA256Hasher, signatureName, signatures.ValidFor(time.Hour))
+			Expect(err).To(BeNil())
+
+			notAfter := baseCd.Signatures[0].Signature.NotAfter.Add(time.Hour)
+			baseCd.Signatures[0].Signature.NotAfter = &notAfter
+			Expect(signatures.VerifySignedComponentDescriptor(&baseCd, TestVerifier{}, signatureName)).ToNot(Succeed())
+
+			baseCd.Signatures[0].Signature.NotAfter = nil
+			Expect(signatures.VerifySignedComponentDescriptor(&baseCd, TestVerifier{}, signatureName)).ToNot(Succeed())
+		})
+	})
+
 	Describe("verify normalised component-descriptor digest with signed digest ", func() {
 		It("should reject an invalid hash", func() {
 			err := signatures.SignComponentDescriptor(&baseCd, TestSigner{}, testSHA256Hasher, signatureName)
@@ -174,4 +221,44 @@ var _ = Describe("Sign/Verify component-descriptor", func() {
 			Expect(err).ToNot(BeNil())
 		})
 	})
+
+	Describe("upgrade signature algorithm", func() {
+		It("should add an upgraded signature and keep the existing ones valid", func() {
+			testSHA512Hasher := signatures.Hasher{
+				HashFunction:  sha512.New(),
+				AlgorithmName: signatures.SHA512,
+			}
+			testSHA512_256Hasher := signatures.Hasher{
+				HashFunction:  sha512.New512_256(),
+				AlgorithmName: "sha512_256",
+			}
+			Expect(signatures.SignComponentDescriptor(&baseCd, TestSigner{}, testSHA256Hasher, signatureName)).To(Succeed())
+
+			Expect(signatures.UpgradeSignatureAlgorithm(&baseCd, signatureName, testSHA512Hasher, TestSigner{})).To(Succeed())
+			Expect(signatures.UpgradeSignatureAlgorithm(&baseCd, signatureName+"-sha512", testSHA512_256Hasher, TestSigner{})).To(Succeed())
+			Expect(baseCd.Signatures).To(HaveLen(3))
+			Expect(baseCd.Signatures[1].Name).To(Equal(signatureName + "-sha512"))
+			Expect(baseCd.Signatures[1].Digest.HashAlgorithm).To(Equal(signatures.SHA512))
+			Expect(baseCd.Signatures[2].Name).To(Equal(signatureName + "-sha512-sha512_256"))
+
+			Expect(signatures.VerifySignedComponentDescriptor(&baseCd, TestVerifier{}, signatureName)).To(Succeed())
+			Expect(signatures.VerifySignedComponentDescriptor(&baseCd, TestVerifier{}, signatureName+"-sha512")).To(Succeed())
+
+			chain, err := signatures.GetSignatureUpgradeChain(&baseCd, signatureName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(chain).To(Equal([]string{signatureName, signatureName + "-sha512", signatureName + "-sha512-sha512_256"}))
+
+			chain, err = signatures.GetSignatureUpgradeChain(&baseCd, signatureName+"-sha512")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(chain).To(Equal([]string{signatureName + "-sha512", signatureName + "-sha512-sha512_256"}))
+		})
+
+		It("should reject the upgrade of a missing signature", func() {
+			testSHA512Hasher := signatures.Hasher{
+				HashFunction:  sha512.New(),
+				AlgorithmName: signatures.SHA512,
+			}
+			Expect(signatures.UpgradeSignatureAlgorithm(&baseCd, signatureName, testSHA512Hasher, TestSigner{})).ToNot(Succeed())
+		})
+	})
 })
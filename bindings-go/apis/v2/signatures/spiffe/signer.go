@@ -0,0 +1,111 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffe
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+)
+
+// SVIDSource provides the X.509 SVID of the current workload.
+type SVIDSource interface {
+	GetSVID(ctx context.Context) (*x509svid.SVID, error)
+}
+
+// SPIFFESigner is a signatures.Signer compatible struct that signs with the private key of a workload's X.509 SVID.
+// RSA keys are signed with RSASSA-PKCS1-V1_5, ECDSA keys with ASN.1 encoded ECDSA signatures.
+type SPIFFESigner struct {
+	svidSource SVIDSource
+}
+
+var _ signatures.Signer = &SPIFFESigner{}
+
+// NewSPIFFESigner creates a new signer that fetches the SVID from the given source.
+// As SVIDs are short-lived, the SVID is fetched from the source for every signature.
+func NewSPIFFESigner(svidSource SVIDSource) (*SPIFFESigner, error) {
+	if svidSource == nil {
+		return nil, errors.New("a svid source must be defined")
+	}
+	return &SPIFFESigner{
+		svidSource: svidSource,
+	}, nil
+}
+
+// Sign returns the signature for the data for the component descriptor.
+// The certificate chain of the SVID is embedded into the signature.
+func (s *SPIFFESigner) Sign(_ cdv2.ComponentDescriptor, digest cdv2.DigestSpec) (*cdv2.SignatureSpec, error) {
+	hashfunc, ok := signatures.HashFunctions[digest.HashAlgorithm]
+	if !ok {
+		return nil, fmt.Errorf("unknown hash algorithm %s", digest.HashAlgorithm)
+	}
+	decodedHash, err := hex.DecodeString(digest.Value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to hex decode hash: %w", err)
+	}
+
+	svid, err := s.svidSource.GetSVID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("unable to get svid: %w", err)
+	}
+	if svid.PrivateKey == nil {
+		return nil, fmt.Errorf("svid %q has no private key", svid.ID.String())
+	}
+	if len(svid.Certificates) == 0 {
+		return nil, fmt.Errorf("svid %q has no certificates", svid.ID.String())
+	}
+
+	var algorithm, mediaType string
+	switch svid.PrivateKey.Public().(type) {
+	case *rsa.PublicKey:
+		algorithm, mediaType = cdv2.RSAPKCS1v15, cdv2.MediaTypeRSASignature
+	case *ecdsa.PublicKey:
+		algorithm, mediaType = cdv2.ECDSAASN1, cdv2.MediaTypeECDSASignature
+	default:
+		return nil, fmt.Errorf("unsupported svid key type %T", svid.PrivateKey.Public())
+	}
+
+	// crypto.Signer implementations sign rsa keys with PKCS #1 v1.5 and ecdsa keys with ASN.1 encoded signatures
+	// if the options are a plain crypto.Hash.
+	signature, err := svid.PrivateKey.Sign(rand.Reader, decodedHash, hashfunc)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign hash: %w", err)
+	}
+
+	certs := bytes.NewBuffer([]byte{})
+	for _, cert := range svid.Certificates {
+		if err := pem.Encode(certs, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+			return nil, fmt.Errorf("unable to encode svid certificate: %w", err)
+		}
+	}
+
+	return &cdv2.SignatureSpec{
+		Algorithm:   algorithm,
+		Value:       hex.EncodeToString(signature),
+		MediaType:   mediaType,
+		Certificate: certs.String(),
+	}, nil
+}
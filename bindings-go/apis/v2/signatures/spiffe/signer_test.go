@@ -0,0 +1,136 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffe_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures/spiffe"
+)
+
+type staticSVIDSource struct {
+	svid *x509svid.SVID
+}
+
+func (s staticSVIDSource) GetSVID(_ context.Context) (*x509svid.SVID, error) {
+	return s.svid, nil
+}
+
+func newSVID(key crypto.Signer) *x509svid.SVID {
+	id := spiffeid.RequireFromString("spiffe://example.org/workload")
+	uri, err := url.Parse(id.String())
+	Expect(err).ToNot(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{uri},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	Expect(err).ToNot(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).ToNot(HaveOccurred())
+	return &x509svid.SVID{
+		ID:           id,
+		Certificates: []*x509.Certificate{cert},
+		PrivateKey:   key,
+	}
+}
+
+var _ = Describe("SPIFFE signer", func() {
+
+	var (
+		hash   []byte
+		digest cdv2.DigestSpec
+	)
+
+	BeforeEach(func() {
+		sum := sha256.Sum256([]byte("component descriptor"))
+		hash = sum[:]
+		digest = cdv2.DigestSpec{
+			HashAlgorithm:          "sha256",
+			NormalisationAlgorithm: string(cdv2.JsonNormalisationV2),
+			Value:                  hex.EncodeToString(hash),
+		}
+	})
+
+	It("should sign with an ecdsa svid and embed the certificate", func() {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		svid := newSVID(key)
+
+		signer, err := spiffe.NewSPIFFESigner(staticSVIDSource{svid: svid})
+		Expect(err).ToNot(HaveOccurred())
+		signature, err := signer.Sign(cdv2.ComponentDescriptor{}, digest)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signature.Algorithm).To(Equal(cdv2.ECDSAASN1))
+		Expect(signature.MediaType).To(Equal(cdv2.MediaTypeECDSASignature))
+
+		value, err := hex.DecodeString(signature.Value)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ecdsa.VerifyASN1(&key.PublicKey, hash, value)).To(BeTrue())
+
+		block, _ := pem.Decode([]byte(signature.Certificate))
+		Expect(block).ToNot(BeNil())
+		Expect(block.Bytes).To(Equal(svid.Certificates[0].Raw))
+	})
+
+	It("should sign with a rsa svid", func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+
+		signer, err := spiffe.NewSPIFFESigner(staticSVIDSource{svid: newSVID(key)})
+		Expect(err).ToNot(HaveOccurred())
+		signature, err := signer.Sign(cdv2.ComponentDescriptor{}, digest)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signature.Algorithm).To(Equal(cdv2.RSAPKCS1v15))
+		Expect(signature.MediaType).To(Equal(cdv2.MediaTypeRSASignature))
+
+		value, err := hex.DecodeString(signature.Value)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash, value)).To(Succeed())
+	})
+
+	It("should fail for unknown hash algorithms", func() {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		signer, err := spiffe.NewSPIFFESigner(staticSVIDSource{svid: newSVID(key)})
+		Expect(err).ToNot(HaveOccurred())
+
+		digest.HashAlgorithm = "md5"
+		_, err = signer.Sign(cdv2.ComponentDescriptor{}, digest)
+		Expect(err).To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,77 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import (
+	"errors"
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// ThresholdSigningSession collects signatures of multiple independent signers for a component descriptor.
+// A component descriptor is complete when it contains the signatures of at least Threshold signers of the session.
+// The signer at index i creates the signature with the name at index i of the SignatureNames.
+type ThresholdSigningSession struct {
+	Threshold      int
+	Signers        []Signer
+	Hasher         Hasher
+	SignatureNames []string
+}
+
+// AddSignature signs the component descriptor with the first signer of the session whose signature is not yet present.
+func (s *ThresholdSigningSession) AddSignature(cd *cdv2.ComponentDescriptor) error {
+	if len(s.Signers) != len(s.SignatureNames) {
+		return fmt.Errorf("the session defines %d signers but %d signature names", len(s.Signers), len(s.SignatureNames))
+	}
+	for i, name := range s.SignatureNames {
+		if hasSignature(cd, name) {
+			continue
+		}
+		if err := SignComponentDescriptor(cd, s.Signers[i], s.Hasher, name); err != nil {
+			return fmt.Errorf("unable to add signature %q: %w", name, err)
+		}
+		return nil
+	}
+	return errors.New("all signers of the session have already signed the component descriptor")
+}
+
+// IsComplete returns true if the component descriptor contains at least Threshold signatures of the session.
+func (s *ThresholdSigningSession) IsComplete(cd *cdv2.ComponentDescriptor) bool {
+	return s.MissingSigCount(cd) == 0
+}
+
+// MissingSigCount returns how many signatures of the session are still needed to reach the threshold.
+func (s *ThresholdSigningSession) MissingSigCount(cd *cdv2.ComponentDescriptor) int {
+	present := 0
+	for _, name := range s.SignatureNames {
+		if hasSignature(cd, name) {
+			present++
+		}
+	}
+	if missing := s.Threshold - present; missing > 0 {
+		return missing
+	}
+	return 0
+}
+
+func hasSignature(cd *cdv2.ComponentDescriptor, name string) bool {
+	for _, signature := range cd.Signatures {
+		if signature.Name == name {
+			return true
+		}
+	}
+	return false
+}
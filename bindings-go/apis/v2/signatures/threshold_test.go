@@ -0,0 +1,92 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures_test
+
+import (
+	"crypto/sha256"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+)
+
+var _ = Describe("threshold signing", func() {
+
+	var (
+		cd      *cdv2.ComponentDescriptor
+		session *signatures.ThresholdSigningSession
+	)
+
+	BeforeEach(func() {
+		cd = &cdv2.ComponentDescriptor{
+			Metadata: cdv2.Metadata{
+				Version: "v2",
+			},
+			ComponentSpec: cdv2.ComponentSpec{
+				ObjectMeta: cdv2.ObjectMeta{
+					Name:    "example.com/component",
+					Version: "v0.0.1",
+				},
+			},
+		}
+		session = &signatures.ThresholdSigningSession{
+			Threshold: 2,
+			Signers:   []signatures.Signer{TestSigner{}, TestSigner{}, TestSigner{}},
+			Hasher: signatures.Hasher{
+				HashFunction:  sha256.New(),
+				AlgorithmName: signatures.SHA256,
+			},
+			SignatureNames: []string{"release-manager", "security", "qa"},
+		}
+	})
+
+	It("should be complete after 2 of 3 signers have signed", func() {
+		Expect(session.IsComplete(cd)).To(BeFalse())
+		Expect(session.MissingSigCount(cd)).To(Equal(2))
+
+		Expect(session.AddSignature(cd)).To(Succeed())
+		Expect(session.IsComplete(cd)).To(BeFalse())
+		Expect(session.MissingSigCount(cd)).To(Equal(1))
+
+		Expect(session.AddSignature(cd)).To(Succeed())
+		Expect(session.IsComplete(cd)).To(BeTrue())
+		Expect(session.MissingSigCount(cd)).To(Equal(0))
+		Expect(cd.Signatures).To(HaveLen(2))
+		Expect(cd.Signatures[0].Name).To(Equal("release-manager"))
+		Expect(cd.Signatures[1].Name).To(Equal("security"))
+	})
+
+	It("should skip signers that have already signed", func() {
+		Expect(signatures.SignComponentDescriptor(cd, TestSigner{}, session.Hasher, "release-manager")).To(Succeed())
+		Expect(session.AddSignature(cd)).To(Succeed())
+		Expect(cd.Signatures[1].Name).To(Equal("security"))
+		Expect(session.IsComplete(cd)).To(BeTrue())
+	})
+
+	It("should ignore signatures that are not part of the session", func() {
+		Expect(signatures.SignComponentDescriptor(cd, TestSigner{}, session.Hasher, "other")).To(Succeed())
+		Expect(session.MissingSigCount(cd)).To(Equal(2))
+	})
+
+	It("should fail if all signers have signed", func() {
+		for i := 0; i < 3; i++ {
+			Expect(session.AddSignature(cd)).To(Succeed())
+		}
+		Expect(session.AddSignature(cd)).ToNot(Succeed())
+	})
+
+})
@@ -0,0 +1,77 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// TrustDomainSignatureName returns the name of a signature in the given trust domain.
+func TrustDomainSignatureName(trustDomain, signatureName string) string {
+	return trustDomain + "/" + signatureName
+}
+
+// SignComponentDescriptorForTrustDomain signs the component descriptor like SignComponentDescriptor
+// but prefixes the signature name with the trust domain.
+// The trust domain of the component descriptor is used if no trust domain is given.
+func SignComponentDescriptorForTrustDomain(cd *cdv2.ComponentDescriptor, signer Signer, hasher Hasher, signatureName, trustDomain string, opts ...SignOption) error {
+	trustDomain, err := effectiveTrustDomain(cd, trustDomain)
+	if err != nil {
+		return err
+	}
+	return SignComponentDescriptor(cd, signer, hasher, TrustDomainSignatureName(trustDomain, signatureName), opts...)
+}
+
+// VerifySignedComponentDescriptorInTrustDomain verifies the signatures of the given trust domain.
+// The verifiers are indexed by the signature name without the trust domain prefix
+// and every verifier only verifies the signature named "<trustDomain>/<signatureName>".
+// Signatures of other trust domains are ignored so that a verifier of one domain can never accept a signature of another domain.
+// The trust domain of the component descriptor is used if no trust domain is given.
+func VerifySignedComponentDescriptorInTrustDomain(cd *cdv2.ComponentDescriptor, verifiers map[string]Verifier, trustDomain string) error {
+	trustDomain, err := effectiveTrustDomain(cd, trustDomain)
+	if err != nil {
+		return err
+	}
+	if len(verifiers) == 0 {
+		return fmt.Errorf("no verifiers defined for trust domain %q", trustDomain)
+	}
+
+	names := make([]string, 0, len(verifiers))
+	for name := range verifiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := VerifySignedComponentDescriptor(cd, verifiers[name], TrustDomainSignatureName(trustDomain, name)); err != nil {
+			return fmt.Errorf("unable to verify signature %q in trust domain %q: %w", name, trustDomain, err)
+		}
+	}
+	return nil
+}
+
+// effectiveTrustDomain returns the given trust domain or the trust domain of the component descriptor.
+func effectiveTrustDomain(cd *cdv2.ComponentDescriptor, trustDomain string) (string, error) {
+	if len(trustDomain) != 0 {
+		return trustDomain, nil
+	}
+	if len(cd.TrustDomain) != 0 {
+		return cd.TrustDomain, nil
+	}
+	return "", errors.New("no trust domain defined")
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures_test
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+)
+
+var _ = Describe("trust domains", func() {
+
+	var (
+		cd               *cdv2.ComponentDescriptor
+		hasher           signatures.Hasher
+		signerA, signerB *certificateSigner
+		verifierA        signatures.Verifier
+		verifierB        signatures.Verifier
+	)
+
+	newVerifier := func(signer *certificateSigner) signatures.Verifier {
+		roots := x509.NewCertPool()
+		roots.AddCert(signer.cert)
+		return signatures.NewCertificateVerifier(roots)
+	}
+
+	BeforeEach(func() {
+		cd = &cdv2.ComponentDescriptor{
+			Metadata: cdv2.Metadata{
+				Version: "v2",
+			},
+		}
+		cd.Name = "example.com/a"
+		cd.Version = "v0.0.1"
+		hasher = signatures.Hasher{
+			HashFunction:  sha256.New(),
+			AlgorithmName: signatures.SHA256,
+		}
+		signerA = newCertificateSigner("cluster-a")
+		signerB = newCertificateSigner("cluster-b")
+		verifierA = newVerifier(signerA)
+		verifierB = newVerifier(signerB)
+	})
+
+	It("should prefix the signature name with the trust domain", func() {
+		Expect(signatures.SignComponentDescriptorForTrustDomain(cd, signerA, hasher, "release", "cluster-a")).To(Succeed())
+		Expect(cd.Signatures).To(HaveLen(1))
+		Expect(cd.Signatures[0].Name).To(Equal("cluster-a/release"))
+	})
+
+	It("should verify the signatures of the trust domain", func() {
+		Expect(signatures.SignComponentDescriptorForTrustDomain(cd, signerA, hasher, "release", "cluster-a")).To(Succeed())
+		Expect(signatures.SignComponentDescriptorForTrustDomain(cd, signerB, hasher, "release", "cluster-b")).To(Succeed())
+
+		Expect(signatures.VerifySignedComponentDescriptorInTrustDomain(cd, map[string]signatures.Verifier{"release": verifierA}, "cluster-a")).To(Succeed())
+		Expect(signatures.VerifySignedComponentDescriptorInTrustDomain(cd, map[string]signatures.Verifier{"release": verifierB}, "cluster-b")).To(Succeed())
+	})
+
+	It("should not verify a signature of another trust domain", func() {
+		Expect(signatures.SignComponentDescriptorForTrustDomain(cd, signerB, hasher, "release", "cluster-b")).To(Succeed())
+
+		// the cluster-a verifier only considers signatures of the cluster-a trust domain
+		Expect(signatures.VerifySignedComponentDescriptorInTrustDomain(cd, map[string]signatures.Verifier{"release": verifierA}, "cluster-a")).ToNot(Succeed())
+		// the cluster-a verifier does not accept the cluster-b signature
+		Expect(signatures.VerifySignedComponentDescriptorInTrustDomain(cd, map[string]signatures.Verifier{"release": verifierA}, "cluster-b")).ToNot(Succeed())
+	})
+
+	It("should use the trust domain of the component descriptor by default", func() {
+		cd.TrustDomain = "cluster-a"
+		Expect(signatures.SignComponentDescriptorForTrustDomain(cd, signerA, hasher, "release", "")).To(Succeed())
+		Expect(cd.Signatures[0].Name).To(Equal("cluster-a/release"))
+		Expect(signatures.VerifySignedComponentDescriptorInTrustDomain(cd, map[string]signatures.Verifier{"release": verifierA}, "")).To(Succeed())
+	})
+
+	It("should fail without a trust domain", func() {
+		Expect(signatures.SignComponentDescriptorForTrustDomain(cd, signerA, hasher, "release", "")).ToNot(Succeed())
+		Expect(signatures.VerifySignedComponentDescriptorInTrustDomain(cd, map[string]signatures.Verifier{"release": verifierA}, "")).ToNot(Succeed())
+	})
+
+})
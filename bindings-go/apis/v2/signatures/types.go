@@ -43,7 +43,13 @@ type Hasher struct {
 }
 
 // HasherForName creates a Hasher instance for the algorithmName.
+// The DeprecationHandler is called if the algorithm is deprecated.
 func HasherForName(algorithmName string) (*Hasher, error) {
+	warnIfDeprecated(algorithmName)
+	return hasherForName(algorithmName)
+}
+
+func hasherForName(algorithmName string) (*Hasher, error) {
 	hashfunc, ok := HashFunctions[algorithmName]
 	if !ok {
 		return nil, fmt.Errorf("hash algorithm %s not found/implemented", algorithmName)
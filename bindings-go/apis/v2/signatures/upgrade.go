@@ -0,0 +1,124 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// SignatureUpgradesLabel is the name of the component label that records the signature algorithm upgrades.
+const SignatureUpgradesLabel = "gardener.cloud/signature-upgrades"
+
+// SignatureUpgrade describes the upgrade of a signature to a new hash algorithm.
+type SignatureUpgrade struct {
+	// From is the name of the upgraded signature.
+	From string `json:"from"`
+	// To is the name of the new signature.
+	To string `json:"to"`
+	// HashAlgorithm is the hash algorithm of the new signature.
+	HashAlgorithm string `json:"hashAlgorithm"`
+}
+
+// UpgradeSignatureAlgorithm re-signs the component descriptor with a new hash algorithm.
+// The existing signature is kept so that it can still be verified, the new signature is named "<signatureName>-<algorithm>".
+// The upgrade is recorded in the SignatureUpgradesLabel of the component descriptor.
+func UpgradeSignatureAlgorithm(cd *cdv2.ComponentDescriptor, signatureName string, newHasher Hasher, signer Signer) error {
+	if _, err := GetSignatureByName(cd, signatureName); err != nil {
+		return err
+	}
+	newSignatureName := fmt.Sprintf("%s-%s", signatureName, newHasher.AlgorithmName)
+	if _, err := GetSignatureByName(cd, newSignatureName); err == nil {
+		return fmt.Errorf("signature with name %s already exists in component descriptor", newSignatureName)
+	}
+
+	upgrades, err := getSignatureUpgrades(cd)
+	if err != nil {
+		return err
+	}
+	if err := SignComponentDescriptor(cd, signer, newHasher, newSignatureName); err != nil {
+		return err
+	}
+
+	upgrades = append(upgrades, SignatureUpgrade{
+		From:          signatureName,
+		To:            newSignatureName,
+		HashAlgorithm: newHasher.AlgorithmName,
+	})
+	data, err := json.Marshal(upgrades)
+	if err != nil {
+		return fmt.Errorf("unable to encode signature upgrades: %w", err)
+	}
+	for i, label := range cd.Labels {
+		if label.Name == SignatureUpgradesLabel {
+			cd.Labels[i].Value = data
+			return nil
+		}
+	}
+	cd.Labels = append(cd.Labels, cdv2.Label{
+		Name:  SignatureUpgradesLabel,
+		Value: data,
+	})
+	return nil
+}
+
+// GetSignatureUpgradeChain returns the names of all signatures that resulted from upgrades of the given signature.
+// The chain starts with the given signature and ends with the most recent upgrade.
+func GetSignatureUpgradeChain(cd *cdv2.ComponentDescriptor, signatureName string) ([]string, error) {
+	if _, err := GetSignatureByName(cd, signatureName); err != nil {
+		return nil, err
+	}
+	upgrades, err := getSignatureUpgrades(cd)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := []string{signatureName}
+	visited := map[string]bool{signatureName: true}
+	current := signatureName
+	for {
+		next := ""
+		for _, upgrade := range upgrades {
+			if upgrade.From == current {
+				next = upgrade.To
+			}
+		}
+		if len(next) == 0 {
+			return chain, nil
+		}
+		if visited[next] {
+			return nil, fmt.Errorf("signature upgrade chain of %s contains a cycle", signatureName)
+		}
+		visited[next] = true
+		chain = append(chain, next)
+		current = next
+	}
+}
+
+// getSignatureUpgrades returns the signature upgrades that are recorded in the SignatureUpgradesLabel.
+func getSignatureUpgrades(cd *cdv2.ComponentDescriptor) ([]SignatureUpgrade, error) {
+	upgrades := make([]SignatureUpgrade, 0)
+	for _, label := range cd.Labels {
+		if label.Name != SignatureUpgradesLabel {
+			continue
+		}
+		if err := json.Unmarshal(label.Value, &upgrades); err != nil {
+			return nil, fmt.Errorf("unable to decode signature upgrades: %w", err)
+		}
+	}
+	return upgrades, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// SignOptions defines the options for signing a component descriptor.
+type SignOptions struct {
+	// ValidFor limits the validity of the signature to the given duration from the time of signing.
+	// The validity is not limited if the duration is not greater than 0.
+	ValidFor time.Duration
+}
+
+// ApplyOptions applies the given options on these options,
+// and then returns itself (for convenient chaining).
+func (o *SignOptions) ApplyOptions(opts []SignOption) *SignOptions {
+	for _, opt := range opts {
+		if opt != nil {
+			opt.ApplyOption(o)
+		}
+	}
+	return o
+}
+
+// SignOption is the interface to specify different sign options
+type SignOption interface {
+	ApplyOption(options *SignOptions)
+}
+
+// ValidFor limits the validity of a signature to the given duration.
+type ValidFor time.Duration
+
+// ApplyOption applies the configured validity duration.
+func (v ValidFor) ApplyOption(options *SignOptions) {
+	options.ValidFor = time.Duration(v)
+}
+
+// SignatureExpiredError describes a signature whose validity period has ended.
+type SignatureExpiredError struct {
+	// NotAfter is the time until which the signature was valid.
+	NotAfter time.Time
+}
+
+func (e SignatureExpiredError) Error() string {
+	return fmt.Sprintf("signature expired at %s", e.NotAfter.Format(time.RFC3339))
+}
+
+// SignatureNotYetValidError describes a signature whose validity period has not started yet.
+type SignatureNotYetValidError struct {
+	// NotBefore is the time from which on the signature is valid.
+	NotBefore time.Time
+}
+
+func (e SignatureNotYetValidError) Error() string {
+	return fmt.Sprintf("signature is not valid before %s", e.NotBefore.Format(time.RFC3339))
+}
+
+// verifyValidityPeriod checks that the given time is within the validity period of the signature.
+func verifyValidityPeriod(signature cdv2.SignatureSpec, now time.Time) error {
+	if signature.NotBefore != nil && now.Before(*signature.NotBefore) {
+		return SignatureNotYetValidError{NotBefore: *signature.NotBefore}
+	}
+	if signature.NotAfter != nil && now.After(*signature.NotAfter) {
+		return SignatureExpiredError{NotAfter: *signature.NotAfter}
+	}
+	return nil
+}
+
+// SignedDigest returns the digest that is actually signed for the given signature.
+// Signatures without a validity period sign the digest of the normalised component descriptor.
+// Signatures with a validity period sign a digest of that digest and the validity period,
+// so that the period cannot be changed or removed without invalidating the signature.
+func SignedDigest(signature cdv2.Signature) (*cdv2.DigestSpec, error) {
+	spec := signature.Signature
+	if spec.NotBefore == nil && spec.NotAfter == nil {
+		return &signature.Digest, nil
+	}
+	hashfunc, ok := HashFunctions[signature.Digest.HashAlgorithm]
+	if !ok {
+		return nil, fmt.Errorf("unknown hash algorithm %s", signature.Digest.HashAlgorithm)
+	}
+	decodedHash, err := hex.DecodeString(signature.Digest.Value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to hex decode hash %s: %w", signature.Digest.Value, err)
+	}
+	h := hashfunc.New()
+	h.Write(decodedHash)
+	fmt.Fprintf(h, "\nnotBefore=%s\nnotAfter=%s", formatValidityTime(spec.NotBefore), formatValidityTime(spec.NotAfter))
+	return &cdv2.DigestSpec{
+		HashAlgorithm:          signature.Digest.HashAlgorithm,
+		NormalisationAlgorithm: signature.Digest.NormalisationAlgorithm,
+		Value:                  hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
+// formatValidityTime formats a bound of the validity period independent of its time zone.
+func formatValidityTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,230 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// VerificationStep is a single check of a signature that is run by a VerificationPipeline.
+type VerificationStep interface {
+	// Name returns the name of the step that is used in the pipeline result.
+	Name() string
+	// Verify checks the signature of the component descriptor.
+	Verify(cd *cdv2.ComponentDescriptor, sig cdv2.Signature) error
+}
+
+// StepResult is the result of a single verification step.
+type StepResult struct {
+	// Name is the name of the step.
+	Name string
+	// Err is the error of the step, it is nil if the step succeeded.
+	Err error
+}
+
+// PipelineResult is the result of a verification pipeline run.
+type PipelineResult struct {
+	// SignatureName is the name of the verified signature.
+	SignatureName string
+	// Steps contains the results of all steps in the order they have been run.
+	Steps []StepResult
+}
+
+// OK returns true if at least one verification step has been run and all verification steps succeeded.
+func (r *PipelineResult) OK() bool {
+	return len(r.Steps) != 0 && r.FirstError() == nil
+}
+
+// FirstError returns the error of the first failed step or nil if all steps succeeded.
+func (r *PipelineResult) FirstError() error {
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			return step.Err
+		}
+	}
+	return nil
+}
+
+// VerificationPipeline verifies a signature of a component descriptor with a configurable list of steps.
+type VerificationPipeline struct {
+	steps []VerificationStep
+}
+
+// NewVerificationPipeline creates a new verification pipeline without any steps.
+// At least one step has to be added before the pipeline can be run.
+func NewVerificationPipeline() *VerificationPipeline {
+	return &VerificationPipeline{}
+}
+
+// NewDefaultVerificationPipeline creates the verification pipeline that is used by VerifySignedComponentDescriptor.
+// It verifies the signature with the verifier, checks the validity period of the signature
+// and verifies that the digest of the signature matches the normalised component descriptor.
+func NewDefaultVerificationPipeline(verifier Verifier) *VerificationPipeline {
+	return NewVerificationPipeline().
+		AddStep(CryptoVerificationStep{Verifier: verifier}).
+		AddStep(ExpiryCheckStep{}).
+		AddStep(DigestConsistencyStep{})
+}
+
+// AddStep adds a step to the pipeline.
+// Steps are run in the order they have been added.
+func (p *VerificationPipeline) AddStep(step VerificationStep) *VerificationPipeline {
+	p.steps = append(p.steps, step)
+	return p
+}
+
+// Run runs all steps of the pipeline on the signature with the given name and returns the results of all steps.
+// All steps are run even if a previous step failed.
+// An error is returned if the pipeline has no steps or if the component descriptor has no signature with the given name.
+func (p *VerificationPipeline) Run(cd *cdv2.ComponentDescriptor, signatureName string) (*PipelineResult, error) {
+	if len(p.steps) == 0 {
+		return nil, errors.New("the verification pipeline has no steps")
+	}
+	signature, err := GetSignatureByName(cd, signatureName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get signature from component descriptor: %w", err)
+	}
+
+	// the hash algorithm of the signature is checked once for the whole verification
+	warnIfDeprecated(signature.Digest.HashAlgorithm)
+
+	result := &PipelineResult{
+		SignatureName: signatureName,
+		Steps:         make([]StepResult, 0, len(p.steps)),
+	}
+	for _, step := range p.steps {
+		result.Steps = append(result.Steps, StepResult{
+			Name: step.Name(),
+			Err:  step.Verify(cd, *signature),
+		})
+	}
+	return result, nil
+}
+
+// CryptoVerificationStep verifies the signature with a verifier.
+type CryptoVerificationStep struct {
+	Verifier Verifier
+}
+
+// Name returns the name of the step.
+func (s CryptoVerificationStep) Name() string {
+	return "crypto"
+}
+
+// Verify verifies the author of the signature.
+// The verifier is called with the signed digest of the signature (see SignedDigest).
+func (s CryptoVerificationStep) Verify(cd *cdv2.ComponentDescriptor, sig cdv2.Signature) error {
+	signedDigest, err := SignedDigest(sig)
+	if err != nil {
+		return fmt.Errorf("unable to get signed digest: %w", err)
+	}
+	sig.Digest = *signedDigest
+	if err := s.Verifier.Verify(*cd, sig); err != nil {
+		return fmt.Errorf("unable to verify signature: %w", err)
+	}
+	return nil
+}
+
+// ExpiryCheckStep checks that the current time is within the validity period of the signature.
+type ExpiryCheckStep struct {
+	// Now returns the current time, it defaults to time.Now.
+	Now func() time.Time
+}
+
+// Name returns the name of the step.
+func (s ExpiryCheckStep) Name() string {
+	return "expiry"
+}
+
+// Verify returns a SignatureExpiredError or SignatureNotYetValidError if the signature is not valid at the current time.
+func (s ExpiryCheckStep) Verify(_ *cdv2.ComponentDescriptor, sig cdv2.Signature) error {
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	return verifyValidityPeriod(sig.Signature, now())
+}
+
+// DigestConsistencyStep verifies that the digest of the signature matches the normalised component descriptor.
+// The component descriptor is hashed and normalised with the algorithms of the signature digest.
+type DigestConsistencyStep struct{}
+
+// Name returns the name of the step.
+func (s DigestConsistencyStep) Name() string {
+	return "digest"
+}
+
+// Verify hashes the normalised component descriptor and compares it with the digest of the signature.
+func (s DigestConsistencyStep) Verify(cd *cdv2.ComponentDescriptor, sig cdv2.Signature) error {
+	hasher, err := hasherForName(sig.Digest.HashAlgorithm)
+	if err != nil {
+		return fmt.Errorf("unable to create hasher for %s: %w", sig.Digest.HashAlgorithm, err)
+	}
+
+	normalisationAlgorithm := cdv2.NormalisationAlgorithm(sig.Digest.NormalisationAlgorithm)
+	if len(normalisationAlgorithm) == 0 {
+		normalisationAlgorithm = cdv2.JsonNormalisationV1
+	}
+	calculatedDigest, err := hashForComponentDescriptor(*cd, *hasher, normalisationAlgorithm)
+	if err != nil {
+		return fmt.Errorf("unable to hash component descriptor %s:%s: %w", cd.Name, cd.Version, err)
+	}
+
+	if !reflect.DeepEqual(*calculatedDigest, sig.Digest) {
+		return fmt.Errorf("normalised component descriptor does not match hash from signature")
+	}
+	return nil
+}
+
+// SignatureRevokedError is returned if a signature has been revoked.
+type SignatureRevokedError struct {
+	// Name is the name of the revoked signature.
+	Name string
+}
+
+func (e SignatureRevokedError) Error() string {
+	return fmt.Sprintf("signature %s has been revoked", e.Name)
+}
+
+// RevocationCheckStep checks that a signature has not been revoked.
+type RevocationCheckStep struct {
+	// IsRevoked returns whether the signature of the component descriptor has been revoked.
+	IsRevoked func(cd *cdv2.ComponentDescriptor, sig cdv2.Signature) (bool, error)
+}
+
+// Name returns the name of the step.
+func (s RevocationCheckStep) Name() string {
+	return "revocation"
+}
+
+// Verify returns a SignatureRevokedError if the signature has been revoked.
+func (s RevocationCheckStep) Verify(cd *cdv2.ComponentDescriptor, sig cdv2.Signature) error {
+	if s.IsRevoked == nil {
+		return fmt.Errorf("no revocation check defined")
+	}
+	revoked, err := s.IsRevoked(cd, sig)
+	if err != nil {
+		return fmt.Errorf("unable to check revocation of signature %s: %w", sig.Name, err)
+	}
+	if revoked {
+		return SignatureRevokedError{Name: sig.Name}
+	}
+	return nil
+}
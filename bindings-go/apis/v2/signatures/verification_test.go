@@ -0,0 +1,140 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+)
+
+// namedStep is a verification step that records its calls.
+type namedStep struct {
+	name  string
+	err   error
+	calls *[]string
+}
+
+func (s namedStep) Name() string {
+	return s.name
+}
+
+func (s namedStep) Verify(_ *cdv2.ComponentDescriptor, _ cdv2.Signature) error {
+	*s.calls = append(*s.calls, s.name)
+	return s.err
+}
+
+var _ = Describe("VerificationPipeline", func() {
+
+	var cd *cdv2.ComponentDescriptor
+
+	BeforeEach(func() {
+		cd = &cdv2.ComponentDescriptor{
+			Metadata: cdv2.Metadata{
+				Version: "v2",
+			},
+		}
+		cd.Name = "example.com/a"
+		cd.Version = "v0.0.1"
+		hasher, err := signatures.HasherForName(signatures.SHA256)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signatures.SignComponentDescriptor(cd, TestSigner{}, *hasher, "sig", signatures.ValidFor(time.Hour))).To(Succeed())
+	})
+
+	It("should run all steps in order and report their results", func() {
+		calls := []string{}
+		failure := errors.New("failed")
+		result, err := signatures.NewVerificationPipeline().
+			AddStep(namedStep{name: "first", calls: &calls}).
+			AddStep(namedStep{name: "second", err: failure, calls: &calls}).
+			AddStep(namedStep{name: "third", calls: &calls}).
+			Run(cd, "sig")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal([]string{"first", "second", "third"}))
+		Expect(result.SignatureName).To(Equal("sig"))
+		Expect(result.Steps).To(Equal([]signatures.StepResult{
+			{Name: "first"},
+			{Name: "second", Err: failure},
+			{Name: "third"},
+		}))
+		Expect(result.OK()).To(BeFalse())
+		Expect(result.FirstError()).To(Equal(failure))
+	})
+
+	It("should fail if the signature does not exist", func() {
+		_, err := signatures.NewDefaultVerificationPipeline(TestVerifier{}).Run(cd, "unknown")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail if the pipeline has no steps", func() {
+		_, err := signatures.NewVerificationPipeline().Run(cd, "sig")
+		Expect(err).To(HaveOccurred())
+		Expect((&signatures.PipelineResult{}).OK()).To(BeFalse())
+	})
+
+	It("should succeed with the default steps for a valid signature", func() {
+		result, err := signatures.NewDefaultVerificationPipeline(TestVerifier{}).Run(cd, "sig")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.OK()).To(BeTrue())
+		Expect(result.Steps).To(Equal([]signatures.StepResult{
+			{Name: "crypto"},
+			{Name: "expiry"},
+			{Name: "digest"},
+		}))
+	})
+
+	It("should report a modified component descriptor in the digest step", func() {
+		cd.Version = "v0.0.2"
+		result, err := signatures.NewDefaultVerificationPipeline(TestVerifier{}).Run(cd, "sig")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Steps[0].Err).ToNot(HaveOccurred())
+		Expect(result.Steps[2].Err).To(HaveOccurred())
+		Expect(signatures.VerifySignedComponentDescriptor(cd, TestVerifier{}, "sig")).ToNot(Succeed())
+	})
+
+	It("should report expired signatures", func() {
+		step := signatures.ExpiryCheckStep{Now: func() time.Time { return time.Now().Add(2 * time.Hour) }}
+		result, err := signatures.NewVerificationPipeline().AddStep(step).Run(cd, "sig")
+		Expect(err).ToNot(HaveOccurred())
+		expiredErr := signatures.SignatureExpiredError{}
+		Expect(errors.As(result.FirstError(), &expiredErr)).To(BeTrue())
+	})
+
+	It("should report revoked signatures", func() {
+		revoked := map[string]bool{}
+		step := signatures.RevocationCheckStep{
+			IsRevoked: func(_ *cdv2.ComponentDescriptor, sig cdv2.Signature) (bool, error) {
+				return revoked[sig.Signature.Value], nil
+			},
+		}
+		pipeline := signatures.NewDefaultVerificationPipeline(TestVerifier{}).AddStep(step)
+
+		result, err := pipeline.Run(cd, "sig")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.OK()).To(BeTrue())
+
+		revoked[cd.Signatures[0].Signature.Value] = true
+		result, err = pipeline.Run(cd, "sig")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Steps[3].Name).To(Equal("revocation"))
+		Expect(result.FirstError()).To(Equal(signatures.SignatureRevokedError{Name: "sig"}))
+	})
+
+})
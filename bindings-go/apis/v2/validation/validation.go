@@ -152,12 +152,27 @@ func ValidateResource(fldPath *field.Path, res v2.Resource) field.ErrorList {
 	}
 	if res.Access == nil {
 		allErrs = append(allErrs, field.Required(fldPath.Child("access"), "must specify a access"))
+	} else {
+		allErrs = append(allErrs, ValidateAccess(fldPath.Child("access"), res.Access)...)
 	}
 	allErrs = append(allErrs, ValidateIdentity(fldPath.Child("extraIdentity"), res.ExtraIdentity)...)
 
 	return allErrs
 }
 
+// ValidateAccess validates a access against the schema that is registered for its type.
+func ValidateAccess(fldPath *field.Path, access *v2.UnstructuredTypedObject) field.ErrorList {
+	allErrs := field.ErrorList{}
+	raw, err := access.GetRaw()
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, access.GetType(), err.Error()))
+	}
+	if err := v2.ValidateAccessSpec(raw, v2.DefaultAccessSchemaRegistry); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, access.GetType(), err.Error()))
+	}
+	return allErrs
+}
+
 func validateProvider(fldPath *field.Path, provider v2.ProviderType) *field.Error {
 	if len(provider) == 0 {
 		return field.Required(fldPath, "provider must be set to a non-empty string")
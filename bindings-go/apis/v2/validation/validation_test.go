@@ -271,6 +271,24 @@ var _ = Describe("Validation", func() {
 			}))))
 		})
 
+		It("should forbid if a resource's access does not match the schema of its type", func() {
+			comp.Resources = []v2.Resource{
+				{
+					IdentityObjectMeta: v2.IdentityObjectMeta{
+						Name:    "locRes",
+						Version: "1.2.3",
+					},
+					Relation: v2.LocalRelation,
+					Access:   v2.NewEmptyUnstructured(v2.LocalFilesystemBlobType),
+				},
+			}
+			errList := validate(nil, comp)
+			Expect(errList).To(ContainElement(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("component.resources[0].access"),
+			}))))
+		})
+
 		It("should forbid if a resource name contains invalid characters", func() {
 			comp.Resources = []v2.Resource{
 				{
@@ -12,6 +12,7 @@ package v2
 
 import (
 	json "encoding/json"
+	time "time"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -22,7 +23,24 @@ func (in *ComponentDescriptor) DeepCopyInto(out *ComponentDescriptor) {
 	if in.Signatures != nil {
 		in, out := &in.Signatures, &out.Signatures
 		*out = make([]Signature, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make(map[string]json.RawMessage, len(*in))
+		for key, val := range *in {
+			var outVal []byte
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(json.RawMessage, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
 	}
 	return
 }
@@ -295,6 +313,11 @@ func (in *Resource) DeepCopyInto(out *Resource) {
 		*out = new(DigestSpec)
 		**out = **in
 	}
+	if in.AdditionalDigests != nil {
+		in, out := &in.AdditionalDigests, &out.AdditionalDigests
+		*out = make([]DigestSpec, len(*in))
+		copy(*out, *in)
+	}
 	if in.SourceRef != nil {
 		in, out := &in.SourceRef, &out.SourceRef
 		*out = make([]SourceRef, len(*in))
@@ -323,7 +346,7 @@ func (in *Resource) DeepCopy() *Resource {
 func (in *Signature) DeepCopyInto(out *Signature) {
 	*out = *in
 	out.Digest = in.Digest
-	out.Signature = in.Signature
+	in.Signature.DeepCopyInto(&out.Signature)
 	return
 }
 
@@ -340,6 +363,16 @@ func (in *Signature) DeepCopy() *Signature {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SignatureSpec) DeepCopyInto(out *SignatureSpec) {
 	*out = *in
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = new(time.Time)
+		**out = **in
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = new(time.Time)
+		**out = **in
+	}
 	return
 }
 
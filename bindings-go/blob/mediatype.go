@@ -0,0 +1,69 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// DefaultMediaType is the media type of blobs whose content type is unknown.
+	DefaultMediaType = "application/octet-stream"
+	// JSONMediaType is the media type that is detected for json content.
+	JSONMediaType = "application/json"
+
+	// sniffLen is the number of bytes that are used to detect the media type of a blob.
+	sniffLen = 512
+)
+
+// NeedsMediaTypeDetection returns whether the given media type is too unspecific to describe the content of a blob.
+func NeedsMediaTypeDetection(mediaType string) bool {
+	return len(mediaType) == 0 || mediaType == DefaultMediaType
+}
+
+// DetectMediaType detects the media type of the content of the given reader.
+// The given hint is returned without reading any content if it is a specific media type.
+// Otherwise, the first 512 bytes of the reader are sniffed with http.DetectContentType.
+// The returned reader has to be used instead of the given reader as it also returns the sniffed bytes.
+func DetectMediaType(r io.Reader, hint string) (string, io.Reader, error) {
+	if !NeedsMediaTypeDetection(hint) {
+		return hint, r, nil
+	}
+	buf := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", nil, fmt.Errorf("unable to read content for media type detection: %w", err)
+	}
+	buf = buf[:n]
+	return detectContentType(buf), io.MultiReader(bytes.NewReader(buf), r), nil
+}
+
+// detectContentType detects the media type of the given content.
+// Json content is not detected by http.DetectContentType, so text that looks like a json object or array
+// is reported as json.
+func detectContentType(data []byte) string {
+	mediaType := http.DetectContentType(data)
+	if strings.HasPrefix(mediaType, "text/plain") {
+		trimmed := bytes.TrimLeft(data, " \t\r\n")
+		if len(trimmed) != 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+			return JSONMediaType
+		}
+	}
+	return mediaType
+}
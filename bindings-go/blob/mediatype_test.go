@@ -0,0 +1,75 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/component-spec/bindings-go/blob"
+)
+
+const ociManifest = `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "config": {
+    "mediaType": "application/vnd.oci.image.config.v1+json",
+    "digest": "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
+    "size": 2
+  },
+  "layers": []
+}`
+
+var _ = Describe("media type detection", func() {
+
+	It("should detect json content and return the complete content", func() {
+		mediaType, r, err := blob.DetectMediaType(strings.NewReader(ociManifest), "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mediaType).To(Equal(blob.JSONMediaType))
+		data, err := io.ReadAll(r)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(ociManifest))
+	})
+
+	It("should detect the media type of unspecific hints", func() {
+		mediaType, _, err := blob.DetectMediaType(strings.NewReader(ociManifest), blob.DefaultMediaType)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mediaType).To(Equal(blob.JSONMediaType))
+	})
+
+	It("should return the content after the sniffed bytes", func() {
+		content := bytes.Repeat([]byte("\x00\x01"), 1024)
+		mediaType, r, err := blob.DetectMediaType(bytes.NewReader(content), "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mediaType).To(Equal(blob.DefaultMediaType))
+		data, err := io.ReadAll(r)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(Equal(content))
+	})
+
+	It("should keep specific hints without reading the content", func() {
+		in := strings.NewReader(ociManifest)
+		mediaType, r, err := blob.DetectMediaType(in, "application/vnd.oci.image.manifest.v1+json")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mediaType).To(Equal("application/vnd.oci.image.manifest.v1+json"))
+		Expect(r).To(BeIdenticalTo(in))
+		Expect(in.Len()).To(Equal(len(ociManifest)))
+	})
+
+})
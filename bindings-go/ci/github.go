@@ -0,0 +1,64 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ci
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/codec"
+)
+
+// WriteGitHubActionsOutputs writes the metadata of the component descriptor as GitHub Actions step outputs.
+// The outputs are written in the GITHUB_OUTPUT format ("key=value", one per line)
+// and contain the name, version, provider, resource_count and the base64 encoded yaml component_descriptor.
+// An error is returned and no output is written if a value contains line breaks, as they would define additional outputs.
+func WriteGitHubActionsOutputs(cd *cdv2.ComponentDescriptor, w io.Writer) error {
+	data, err := codec.Encode(cd)
+	if err != nil {
+		return fmt.Errorf("unable to encode component descriptor: %w", err)
+	}
+	data, err = yaml.JSONToYAML(data)
+	if err != nil {
+		return fmt.Errorf("unable to convert component descriptor to yaml: %w", err)
+	}
+
+	outputs := []struct {
+		key   string
+		value string
+	}{
+		{key: "name", value: cd.GetName()},
+		{key: "version", value: cd.GetVersion()},
+		{key: "provider", value: string(cd.Provider)},
+		{key: "resource_count", value: fmt.Sprint(len(cd.Resources))},
+		{key: "component_descriptor", value: base64.StdEncoding.EncodeToString(data)},
+	}
+	for _, output := range outputs {
+		if strings.ContainsAny(output.value, "\r\n") {
+			return fmt.Errorf("the value of output %q must not contain line breaks", output.key)
+		}
+	}
+	for _, output := range outputs {
+		if _, err := fmt.Fprintf(w, "%s=%s\n", output.key, output.value); err != nil {
+			return fmt.Errorf("unable to write output %q: %w", output.key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ci_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ci"
+	"github.com/gardener/component-spec/bindings-go/codec"
+)
+
+var _ = Describe("GitHub Actions", func() {
+
+	It("should write the component descriptor metadata as step outputs", func() {
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Name = "example.com/comp"
+		cd.Version = "1.0.0"
+		cd.Provider = "internal"
+		access, err := cdv2.NewUnstructured(cdv2.NewOCIRegistryAccess("example.com/image:1.0.0"))
+		Expect(err).ToNot(HaveOccurred())
+		cd.Resources = []cdv2.Resource{
+			{
+				IdentityObjectMeta: cdv2.IdentityObjectMeta{Name: "res1", Version: "1.0.0", Type: cdv2.OCIImageType},
+				Relation:           cdv2.ExternalRelation,
+				Access:             &access,
+			},
+			{
+				IdentityObjectMeta: cdv2.IdentityObjectMeta{Name: "res2", Version: "1.0.0", Type: cdv2.OCIImageType},
+				Relation:           cdv2.ExternalRelation,
+				Access:             &access,
+			},
+		}
+		Expect(cdv2.DefaultComponent(cd)).To(Succeed())
+
+		var buf bytes.Buffer
+		Expect(ci.WriteGitHubActionsOutputs(cd, &buf)).To(Succeed())
+
+		outputs := map[string]string{}
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			split := strings.SplitN(scanner.Text(), "=", 2)
+			Expect(split).To(HaveLen(2))
+			outputs[split[0]] = split[1]
+		}
+		Expect(scanner.Err()).ToNot(HaveOccurred())
+
+		Expect(outputs).To(HaveKeyWithValue("name", "example.com/comp"))
+		Expect(outputs).To(HaveKeyWithValue("version", "1.0.0"))
+		Expect(outputs).To(HaveKeyWithValue("provider", "internal"))
+		Expect(outputs).To(HaveKeyWithValue("resource_count", "2"))
+		Expect(outputs).To(HaveKey("component_descriptor"))
+
+		data, err := base64.StdEncoding.DecodeString(outputs["component_descriptor"])
+		Expect(err).ToNot(HaveOccurred())
+		decoded := &cdv2.ComponentDescriptor{}
+		Expect(codec.Decode(data, decoded)).To(Succeed())
+		Expect(decoded.Name).To(Equal("example.com/comp"))
+		Expect(decoded.Resources).To(HaveLen(2))
+	})
+
+	It("should reject values with line breaks", func() {
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Name = "example.com/comp"
+		cd.Version = "1.0.0"
+		cd.Provider = "internal\ncomponent_descriptor=b3ZlcnJpZGRlbg=="
+		Expect(cdv2.DefaultComponent(cd)).To(Succeed())
+
+		var buf bytes.Buffer
+		Expect(ci.WriteGitHubActionsOutputs(cd, &buf)).ToNot(Succeed())
+		Expect(buf.Len()).To(BeZero())
+
+		cd.Provider = "internal\r"
+		Expect(ci.WriteGitHubActionsOutputs(cd, &buf)).ToNot(Succeed())
+		Expect(buf.Len()).To(BeZero())
+	})
+
+})
@@ -0,0 +1,110 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+	"github.com/gardener/component-spec/bindings-go/codec"
+)
+
+// benchDescriptorPath is a realistic component descriptor with 20 resources, 5 sources and 3 component references.
+const benchDescriptorPath = "./testdata/bench-descriptor.yaml"
+
+func readBenchDescriptor(b *testing.B) ([]byte, *v2.ComponentDescriptor) {
+	data, err := ioutil.ReadFile(benchDescriptorPath)
+	if err != nil {
+		b.Fatal(err)
+	}
+	cd := &v2.ComponentDescriptor{}
+	if err := codec.Decode(data, cd); err != nil {
+		b.Fatal(err)
+	}
+	return data, cd
+}
+
+func BenchmarkEncodeYAML(b *testing.B) {
+	_, cd := readBenchDescriptor(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data, err := codec.Encode(cd)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := yaml.JSONToYAML(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeYAML(b *testing.B) {
+	data, _ := readBenchDescriptor(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cd := &v2.ComponentDescriptor{}
+		if err := codec.Decode(data, cd); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeJSON(b *testing.B) {
+	_, cd := readBenchDescriptor(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := codec.Encode(cd); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeJSON(b *testing.B) {
+	_, cd := readBenchDescriptor(b)
+	data, err := codec.Encode(cd)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cd := &v2.ComponentDescriptor{}
+		if err := codec.Decode(data, cd); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHashForComponentDescriptor(b *testing.B) {
+	_, cd := readBenchDescriptor(b)
+	hasher, err := signatures.HasherForName(signatures.SHA256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := signatures.HashForComponentDescriptor(*cd, *hasher); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/yaml"
 
 	"github.com/gardener/component-spec/bindings-go/apis"
@@ -71,6 +72,11 @@ func Decode(data []byte, obj interface{}, opts ...DecodeOption) error {
 		if err := v2.DefaultComponent(comp); err != nil {
 			return err
 		}
+		if o.StrictDecode {
+			if err := decodeAccesses(field.NewPath("component"), comp); err != nil {
+				return err
+			}
+		}
 
 		if o.DisableValidation {
 			return nil
@@ -92,6 +98,13 @@ func Decode(data []byte, obj interface{}, opts ...DecodeOption) error {
 		if err := v2.DefaultList(list); err != nil {
 			return err
 		}
+		if o.StrictDecode {
+			for i := range list.Components {
+				if err := decodeAccesses(field.NewPath("components").Index(i).Child("component"), &list.Components[i]); err != nil {
+					return err
+				}
+			}
+		}
 		if o.DisableValidation {
 			return nil
 		}
@@ -136,6 +149,7 @@ func Encode(obj interface{}) ([]byte, error) {
 type DecodeOptions struct {
 	DisableValidation bool
 	StrictMode        bool
+	StrictDecode      bool
 }
 
 // ApplyOptions applies the given list options on these options,
@@ -169,3 +183,17 @@ type DisableValidation bool
 func (v DisableValidation) ApplyOption(options *DecodeOptions) {
 	options.DisableValidation = bool(v)
 }
+
+// StrictDecode enables or disables the decoding of all access specifications of known access types.
+type StrictDecode bool
+
+// ApplyOption applies the configured strict decode option.
+func (s StrictDecode) ApplyOption(options *DecodeOptions) {
+	options.StrictDecode = bool(s)
+}
+
+// WithStrictDecode returns a decode option that decodes every access specification of a known access type
+// into its typed object so that malformed access specifications are rejected when the component descriptor is decoded.
+func WithStrictDecode() DecodeOption {
+	return StrictDecode(true)
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"unicode"
+
+	"sigs.k8s.io/yaml"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// DecodeReader decodes a json or yaml encoded component descriptor from the reader.
+// The format is detected by the first non-whitespace character: a "{" is decoded as json, anything else as yaml.
+func DecodeReader(r io.Reader, cd *v2.ComponentDescriptor, opts ...DecodeOption) error {
+	br := bufio.NewReader(r)
+	isJSON, err := peekJSON(br)
+	if err != nil {
+		return fmt.Errorf("unable to detect format of component descriptor: %w", err)
+	}
+	data, err := io.ReadAll(br)
+	if err != nil {
+		return fmt.Errorf("unable to read component descriptor: %w", err)
+	}
+	return decodeFormat(data, isJSON, cd, opts)
+}
+
+// DecodeBytes decodes a json or yaml encoded component descriptor.
+// The format is detected by the first non-whitespace character: a "{" is decoded as json, anything else as yaml.
+func DecodeBytes(data []byte, cd *v2.ComponentDescriptor, opts ...DecodeOption) error {
+	trimmed := bytes.TrimLeftFunc(data, unicode.IsSpace)
+	return decodeFormat(data, len(trimmed) != 0 && trimmed[0] == '{', cd, opts)
+}
+
+// peekJSON returns whether the first non-whitespace character of the reader starts a json object.
+// The read data is not consumed.
+func peekJSON(br *bufio.Reader) (bool, error) {
+	for n := 1; n <= br.Size(); n++ {
+		data, err := br.Peek(n)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return false, nil
+			}
+			return false, err
+		}
+		if c := data[n-1]; !unicode.IsSpace(rune(c)) {
+			return c == '{', nil
+		}
+	}
+	return false, nil
+}
+
+// decodeFormat decodes the json or yaml encoded component descriptor.
+// Yaml is converted to json before it is decoded, leading whitespace of json is removed
+// as it may contain tabs which are not valid yaml indentation.
+func decodeFormat(data []byte, isJSON bool, cd *v2.ComponentDescriptor, opts []DecodeOption) error {
+	if isJSON {
+		data = bytes.TrimLeftFunc(data, unicode.IsSpace)
+	} else {
+		var err error
+		data, err = yaml.YAMLToJSON(data)
+		if err != nil {
+			return fmt.Errorf("unable to decode yaml component descriptor: %w", err)
+		}
+	}
+	return Decode(data, cd, opts...)
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec_test
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/codec"
+)
+
+var _ = Describe("format detection", func() {
+
+	var (
+		jsonData []byte
+		yamlData []byte
+	)
+
+	BeforeEach(func() {
+		data, err := ioutil.ReadFile("../../language-independent/test-resources/component_descriptor_v2.yaml")
+		Expect(err).ToNot(HaveOccurred())
+		var comp v2.ComponentDescriptor
+		Expect(codec.Decode(data, &comp)).To(Succeed())
+
+		jsonData, err = codec.Encode(&comp)
+		Expect(err).ToNot(HaveOccurred())
+		yamlData, err = yaml.JSONToYAML(jsonData)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should decode json and yaml encoded component descriptors from a reader", func() {
+		var fromJSON, fromYAML v2.ComponentDescriptor
+		Expect(codec.DecodeReader(bytes.NewReader(jsonData), &fromJSON)).To(Succeed())
+		Expect(codec.DecodeReader(bytes.NewReader(yamlData), &fromYAML)).To(Succeed())
+		Expect(fromJSON.Name).To(Equal("github.com/gardener/gardener"))
+		Expect(fromYAML).To(Equal(fromJSON))
+	})
+
+	It("should decode json and yaml encoded component descriptors from bytes", func() {
+		var fromJSON, fromYAML v2.ComponentDescriptor
+		Expect(codec.DecodeBytes(append([]byte("\n  "), jsonData...), &fromJSON)).To(Succeed())
+		Expect(codec.DecodeBytes(yamlData, &fromYAML)).To(Succeed())
+		Expect(fromYAML).To(Equal(fromJSON))
+	})
+
+	It("should detect json after leading whitespace in a reader", func() {
+		var comp v2.ComponentDescriptor
+		Expect(codec.DecodeReader(bytes.NewReader(append([]byte(" \t\n"), jsonData...)), &comp)).To(Succeed())
+		Expect(comp.Name).To(Equal("github.com/gardener/gardener"))
+	})
+
+	It("should fail for empty input", func() {
+		var comp v2.ComponentDescriptor
+		Expect(codec.DecodeReader(bytes.NewReader(nil), &comp)).ToNot(Succeed())
+	})
+
+})
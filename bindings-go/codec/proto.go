@@ -0,0 +1,359 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/codec/cdv2pb"
+)
+
+// EncodeProto encodes the component descriptor as protobuf and writes it to the given writer.
+func EncodeProto(cd *v2.ComponentDescriptor, w io.Writer) error {
+	pb, err := DescriptorToProto(cd)
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(pb)
+	if err != nil {
+		return fmt.Errorf("unable to marshal component descriptor: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("unable to write component descriptor: %w", err)
+	}
+	return nil
+}
+
+// DecodeProto reads a protobuf encoded component descriptor from the given reader.
+// The decoded component descriptor is defaulted but not validated.
+func DecodeProto(r io.Reader) (*v2.ComponentDescriptor, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read component descriptor: %w", err)
+	}
+	pb := &cdv2pb.ComponentDescriptor{}
+	if err := proto.Unmarshal(data, pb); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal component descriptor: %w", err)
+	}
+	cd, err := ProtoToDescriptor(pb)
+	if err != nil {
+		return nil, err
+	}
+	if err := v2.DefaultComponent(cd); err != nil {
+		return nil, err
+	}
+	return cd, nil
+}
+
+// DescriptorToProto converts a component descriptor into its protobuf representation.
+func DescriptorToProto(cd *v2.ComponentDescriptor) (*cdv2pb.ComponentDescriptor, error) {
+	var err error
+	pb := &cdv2pb.ComponentDescriptor{
+		Meta: &cdv2pb.Metadata{
+			SchemaVersion: cd.Metadata.Version,
+		},
+		Component: &cdv2pb.ComponentSpec{
+			Name:         cd.Name,
+			Version:      cd.Version,
+			Labels:       labelsToProto(cd.Labels),
+			Provider:     string(cd.Provider),
+			CreationTime: cd.CreationTime,
+		},
+	}
+	for i, repoCtx := range cd.RepositoryContexts {
+		obj, err := typedObjectToProto(repoCtx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert repository context %d: %w", i, err)
+		}
+		pb.Component.RepositoryContexts = append(pb.Component.RepositoryContexts, obj)
+	}
+	for _, src := range cd.Sources {
+		pbSrc := &cdv2pb.Source{
+			Name:          src.Name,
+			Version:       src.Version,
+			Type:          src.Type,
+			ExtraIdentity: src.ExtraIdentity,
+			Labels:        labelsToProto(src.Labels),
+		}
+		if pbSrc.Access, err = typedObjectToProto(src.Access); err != nil {
+			return nil, fmt.Errorf("unable to convert access of source %q: %w", src.Name, err)
+		}
+		pb.Component.Sources = append(pb.Component.Sources, pbSrc)
+	}
+	for _, ref := range cd.ComponentReferences {
+		pb.Component.ComponentReferences = append(pb.Component.ComponentReferences, &cdv2pb.ComponentReference{
+			Name:          ref.Name,
+			ComponentName: ref.ComponentName,
+			Version:       ref.Version,
+			ExtraIdentity: ref.ExtraIdentity,
+			Digest:        digestToProto(ref.Digest),
+			Labels:        labelsToProto(ref.Labels),
+		})
+	}
+	for _, res := range cd.Resources {
+		pbRes := &cdv2pb.Resource{
+			Name:          res.Name,
+			Version:       res.Version,
+			Type:          res.Type,
+			ExtraIdentity: res.ExtraIdentity,
+			Labels:        labelsToProto(res.Labels),
+			Digest:        digestToProto(res.Digest),
+			Relation:      string(res.Relation),
+			SchemaVersion: res.SchemaVersion,
+		}
+		for i := range res.AdditionalDigests {
+			pbRes.AdditionalDigests = append(pbRes.AdditionalDigests, digestToProto(&res.AdditionalDigests[i]))
+		}
+		for _, ref := range res.SourceRef {
+			pbRes.SrcRefs = append(pbRes.SrcRefs, &cdv2pb.SourceRef{
+				IdentitySelector: ref.IdentitySelector,
+				Labels:           labelsToProto(ref.Labels),
+			})
+		}
+		if pbRes.Access, err = typedObjectToProto(res.Access); err != nil {
+			return nil, fmt.Errorf("unable to convert access of resource %q: %w", res.Name, err)
+		}
+		pb.Component.Resources = append(pb.Component.Resources, pbRes)
+	}
+	for _, signature := range cd.Signatures {
+		pb.Signatures = append(pb.Signatures, &cdv2pb.Signature{
+			Name:   signature.Name,
+			Digest: digestToProto(&signature.Digest),
+			Signature: &cdv2pb.SignatureSpec{
+				Algorithm:   signature.Signature.Algorithm,
+				Value:       signature.Signature.Value,
+				MediaType:   signature.Signature.MediaType,
+				Certificate: signature.Signature.Certificate,
+				NotBefore:   timeToProto(signature.Signature.NotBefore),
+				NotAfter:    timeToProto(signature.Signature.NotAfter),
+			},
+		})
+	}
+	pb.ProviderCertificatePin = cd.ProviderCertificatePin
+	pb.TrustDomain = cd.TrustDomain
+	if len(cd.Extensions) != 0 {
+		pb.Extensions = make(map[string][]byte, len(cd.Extensions))
+		for key, value := range cd.Extensions {
+			pb.Extensions[key] = value
+		}
+	}
+	return pb, nil
+}
+
+// ProtoToDescriptor converts the protobuf representation of a component descriptor into a component descriptor.
+func ProtoToDescriptor(pb *cdv2pb.ComponentDescriptor) (*v2.ComponentDescriptor, error) {
+	var err error
+	cd := &v2.ComponentDescriptor{}
+	cd.Metadata.Version = pb.GetMeta().GetSchemaVersion()
+
+	comp := pb.GetComponent()
+	cd.Name = comp.GetName()
+	cd.Version = comp.GetVersion()
+	cd.Labels = labelsFromProto(comp.GetLabels())
+	cd.Provider = v2.ProviderType(comp.GetProvider())
+	cd.CreationTime = comp.GetCreationTime()
+	for i, repoCtx := range comp.GetRepositoryContexts() {
+		obj, err := typedObjectFromProto(repoCtx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert repository context %d: %w", i, err)
+		}
+		cd.RepositoryContexts = append(cd.RepositoryContexts, obj)
+	}
+	for _, pbSrc := range comp.GetSources() {
+		src := v2.Source{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:          pbSrc.GetName(),
+				Version:       pbSrc.GetVersion(),
+				Type:          pbSrc.GetType(),
+				ExtraIdentity: identityFromProto(pbSrc.GetExtraIdentity()),
+				Labels:        labelsFromProto(pbSrc.GetLabels()),
+			},
+		}
+		if src.Access, err = typedObjectFromProto(pbSrc.GetAccess()); err != nil {
+			return nil, fmt.Errorf("unable to convert access of source %q: %w", src.Name, err)
+		}
+		cd.Sources = append(cd.Sources, src)
+	}
+	for _, pbRef := range comp.GetComponentReferences() {
+		cd.ComponentReferences = append(cd.ComponentReferences, v2.ComponentReference{
+			Name:          pbRef.GetName(),
+			ComponentName: pbRef.GetComponentName(),
+			Version:       pbRef.GetVersion(),
+			ExtraIdentity: identityFromProto(pbRef.GetExtraIdentity()),
+			Digest:        digestFromProto(pbRef.GetDigest()),
+			Labels:        labelsFromProto(pbRef.GetLabels()),
+		})
+	}
+	for _, pbRes := range comp.GetResources() {
+		res := v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:          pbRes.GetName(),
+				Version:       pbRes.GetVersion(),
+				Type:          pbRes.GetType(),
+				ExtraIdentity: identityFromProto(pbRes.GetExtraIdentity()),
+				Labels:        labelsFromProto(pbRes.GetLabels()),
+			},
+			Digest:        digestFromProto(pbRes.GetDigest()),
+			Relation:      v2.ResourceRelation(pbRes.GetRelation()),
+			SchemaVersion: pbRes.GetSchemaVersion(),
+		}
+		for _, pbDigest := range pbRes.GetAdditionalDigests() {
+			if digest := digestFromProto(pbDigest); digest != nil {
+				res.AdditionalDigests = append(res.AdditionalDigests, *digest)
+			}
+		}
+		for _, pbRef := range pbRes.GetSrcRefs() {
+			var selector map[string]string
+			if len(pbRef.GetIdentitySelector()) != 0 {
+				selector = pbRef.GetIdentitySelector()
+			}
+			res.SourceRef = append(res.SourceRef, v2.SourceRef{
+				IdentitySelector: selector,
+				Labels:           labelsFromProto(pbRef.GetLabels()),
+			})
+		}
+		if res.Access, err = typedObjectFromProto(pbRes.GetAccess()); err != nil {
+			return nil, fmt.Errorf("unable to convert access of resource %q: %w", res.Name, err)
+		}
+		cd.Resources = append(cd.Resources, res)
+	}
+	for _, pbSig := range pb.GetSignatures() {
+		signature := v2.Signature{
+			Name: pbSig.GetName(),
+			Signature: v2.SignatureSpec{
+				Algorithm:   pbSig.GetSignature().GetAlgorithm(),
+				Value:       pbSig.GetSignature().GetValue(),
+				MediaType:   pbSig.GetSignature().GetMediaType(),
+				Certificate: pbSig.GetSignature().GetCertificate(),
+				NotBefore:   timeFromProto(pbSig.GetSignature().GetNotBefore()),
+				NotAfter:    timeFromProto(pbSig.GetSignature().GetNotAfter()),
+			},
+		}
+		if digest := digestFromProto(pbSig.GetDigest()); digest != nil {
+			signature.Digest = *digest
+		}
+		cd.Signatures = append(cd.Signatures, signature)
+	}
+	cd.ProviderCertificatePin = pb.GetProviderCertificatePin()
+	cd.TrustDomain = pb.GetTrustDomain()
+	if len(pb.GetExtensions()) != 0 {
+		cd.Extensions = make(map[string]json.RawMessage, len(pb.GetExtensions()))
+		for key, value := range pb.GetExtensions() {
+			cd.Extensions[key] = value
+		}
+	}
+	return cd, nil
+}
+
+func labelsToProto(labels v2.Labels) []*cdv2pb.Label {
+	var pbLabels []*cdv2pb.Label
+	for _, label := range labels {
+		pbLabels = append(pbLabels, &cdv2pb.Label{
+			Name:  label.Name,
+			Value: label.Value,
+		})
+	}
+	return pbLabels
+}
+
+func labelsFromProto(pbLabels []*cdv2pb.Label) v2.Labels {
+	var labels v2.Labels
+	for _, pbLabel := range pbLabels {
+		labels = append(labels, v2.Label{
+			Name:  pbLabel.GetName(),
+			Value: pbLabel.GetValue(),
+		})
+	}
+	return labels
+}
+
+func identityFromProto(id map[string]string) v2.Identity {
+	if len(id) == 0 {
+		return nil
+	}
+	return id
+}
+
+func digestToProto(digest *v2.DigestSpec) *cdv2pb.DigestSpec {
+	if digest == nil {
+		return nil
+	}
+	return &cdv2pb.DigestSpec{
+		HashAlgorithm:          digest.HashAlgorithm,
+		NormalisationAlgorithm: digest.NormalisationAlgorithm,
+		Value:                  digest.Value,
+		Size:                   digest.Size,
+	}
+}
+
+func digestFromProto(pbDigest *cdv2pb.DigestSpec) *v2.DigestSpec {
+	if pbDigest == nil {
+		return nil
+	}
+	return &v2.DigestSpec{
+		HashAlgorithm:          pbDigest.GetHashAlgorithm(),
+		NormalisationAlgorithm: pbDigest.GetNormalisationAlgorithm(),
+		Value:                  pbDigest.GetValue(),
+		Size:                   pbDigest.GetSize(),
+	}
+}
+
+func timeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func timeFromProto(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func typedObjectToProto(obj *v2.UnstructuredTypedObject) (*cdv2pb.TypedObject, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	raw, err := obj.GetRaw()
+	if err != nil {
+		return nil, err
+	}
+	return &cdv2pb.TypedObject{
+		Type: obj.GetType(),
+		Raw:  raw,
+	}, nil
+}
+
+func typedObjectFromProto(pbObj *cdv2pb.TypedObject) (*v2.UnstructuredTypedObject, error) {
+	if pbObj == nil {
+		return nil, nil
+	}
+	obj := &v2.UnstructuredTypedObject{}
+	if err := json.Unmarshal(pbObj.GetRaw(), obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
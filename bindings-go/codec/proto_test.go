@@ -0,0 +1,146 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/codec"
+)
+
+var _ = Describe("protobuf codec", func() {
+
+	newAccess := func(obj v2.TypedObjectAccessor) *v2.UnstructuredTypedObject {
+		u, err := v2.NewUnstructured(obj)
+		Expect(err).ToNot(HaveOccurred())
+		return &u
+	}
+
+	It("should encode and decode a component descriptor without loss", func() {
+		notAfter := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		repoCtx := newAccess(v2.NewOCIRegistryRepository("example.com/components", ""))
+		cd := &v2.ComponentDescriptor{
+			Metadata: v2.Metadata{Version: v2.SchemaVersion},
+			ComponentSpec: v2.ComponentSpec{
+				ObjectMeta: v2.ObjectMeta{
+					Name:    "github.com/gardener/gardener",
+					Version: "v1.7.2",
+					Labels: v2.Labels{
+						{Name: "label", Value: json.RawMessage(`{"a":"b"}`)},
+					},
+				},
+				RepositoryContexts: []*v2.UnstructuredTypedObject{repoCtx},
+				Provider:           "internal",
+				CreationTime:       "2022-01-01T00:00:00Z",
+				Sources: []v2.Source{
+					{
+						IdentityObjectMeta: v2.IdentityObjectMeta{
+							Name:          "src",
+							Version:       "v1.7.2",
+							Type:          "git",
+							ExtraIdentity: v2.Identity{"key": "value"},
+						},
+						Access: newAccess(v2.NewGitHubAccess("https://github.com/gardener/gardener", "master", "abc")),
+					},
+				},
+				ComponentReferences: []v2.ComponentReference{
+					{
+						Name:          "ref",
+						ComponentName: "github.com/gardener/etcd",
+						Version:       "v0.1.0",
+						Digest: &v2.DigestSpec{
+							HashAlgorithm:          "sha256",
+							NormalisationAlgorithm: "jsonNormalisation/v1",
+							Value:                  "00",
+						},
+					},
+				},
+				Resources: []v2.Resource{
+					{
+						IdentityObjectMeta: v2.IdentityObjectMeta{
+							Name:    "image",
+							Version: "v1.7.2",
+							Type:    v2.OCIImageType,
+						},
+						Digest: &v2.DigestSpec{
+							HashAlgorithm:          "sha256",
+							NormalisationAlgorithm: "ociArtifactDigest/v1",
+							Value:                  "01",
+							Size:                   1024,
+						},
+						AdditionalDigests: []v2.DigestSpec{
+							{
+								HashAlgorithm:          "sha512",
+								NormalisationAlgorithm: "genericBlobDigest/v1",
+								Value:                  "02",
+							},
+						},
+						Relation: v2.LocalRelation,
+						SourceRef: []v2.SourceRef{
+							{IdentitySelector: map[string]string{"name": "src"}},
+						},
+						Access:        newAccess(v2.NewOCIRegistryAccess("example.com/image:v1.7.2")),
+						SchemaVersion: "v1",
+					},
+				},
+			},
+			Signatures: []v2.Signature{
+				{
+					Name: "sig",
+					Digest: v2.DigestSpec{
+						HashAlgorithm:          "sha256",
+						NormalisationAlgorithm: "jsonNormalisation/v1",
+						Value:                  "02",
+					},
+					Signature: v2.SignatureSpec{
+						Algorithm:   "RSASSA-PKCS1-V1_5",
+						Value:       "03",
+						MediaType:   "application/vnd.ocm.signature.rsa",
+						Certificate: "cert",
+						NotAfter:    &notAfter,
+					},
+				},
+			},
+			ProviderCertificatePin: "04",
+			TrustDomain:            "cluster-a",
+			Extensions: map[string]json.RawMessage{
+				"example.com/ext": json.RawMessage(`{"key":"value"}`),
+			},
+		}
+
+		var buf bytes.Buffer
+		Expect(codec.EncodeProto(cd, &buf)).To(Succeed())
+
+		decoded, err := codec.DecodeProto(&buf)
+		Expect(err).ToNot(HaveOccurred())
+
+		expected, err := json.Marshal(cd)
+		Expect(err).ToNot(HaveOccurred())
+		actual, err := json.Marshal(decoded)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actual).To(MatchJSON(expected))
+	})
+
+	It("should fail to decode invalid data", func() {
+		_, err := codec.DecodeProto(bytes.NewReader([]byte("invalid")))
+		Expect(err).To(HaveOccurred())
+	})
+})
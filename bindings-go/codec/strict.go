@@ -0,0 +1,72 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// ValidationError is returned by a strict decode if access specifications are malformed.
+// The field paths of the errors point to the malformed access specifications.
+type ValidationError struct {
+	Errors field.ErrorList
+}
+
+func (e *ValidationError) Error() string {
+	return e.Errors.ToAggregate().Error()
+}
+
+// decodeAccesses decodes the access specifications of all resources and sources of the component descriptor
+// into the typed objects of their access type.
+// Access specifications of unknown types are ignored.
+func decodeAccesses(fldPath *field.Path, cd *v2.ComponentDescriptor) error {
+	allErrs := field.ErrorList{}
+	for i, res := range cd.Resources {
+		if err := decodeAccess(res.Access); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("resources").Index(i).Child("access"), res.Access.GetType(), err.Error()))
+		}
+	}
+	for i, src := range cd.Sources {
+		if err := decodeAccess(src.Access); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sources").Index(i).Child("access"), src.Access.GetType(), err.Error()))
+		}
+	}
+	if len(allErrs) != 0 {
+		return &ValidationError{Errors: allErrs}
+	}
+	return nil
+}
+
+func decodeAccess(access *v2.UnstructuredTypedObject) error {
+	if access == nil {
+		return nil
+	}
+	newObj, ok := v2.KnownAccessTypeObjects[access.GetType()]
+	if !ok {
+		return nil
+	}
+	raw, err := access.GetRaw()
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(newObj())
+}
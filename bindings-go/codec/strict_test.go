@@ -0,0 +1,89 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/codec"
+)
+
+var _ = Describe("strict decode", func() {
+
+	componentWithAccess := func(access string) []byte {
+		return []byte(`
+meta:
+  schemaVersion: 'v2'
+component:
+  name: 'github.com/gardener/gardener'
+  version: 'v1.7.2'
+  repositoryContexts:
+    - type: 'ociRegistry'
+      baseUrl: 'eu.gcr.io/gardener-project/components/dev'
+  provider: 'internal'
+  sources: []
+  componentReferences: []
+  resources:
+    - name: 'blob'
+      version: 'v1.7.2'
+      type: 'blob'
+      relation: 'local'
+      access:
+` + access)
+	}
+
+	It("should decode valid access specifications", func() {
+		data := componentWithAccess(`
+        type: 'ociBlob'
+        ref: 'eu.gcr.io/gardener-project/blob'
+        digest: 'sha256:abc'
+        size: 5
+`)
+		var comp v2.ComponentDescriptor
+		Expect(codec.Decode(data, &comp, codec.WithStrictDecode())).To(Succeed())
+	})
+
+	It("should reject malformed access specifications", func() {
+		data := componentWithAccess(`
+        type: 'ociBlob'
+        ref: 'eu.gcr.io/gardener-project/blob'
+        digest: 'sha256:abc'
+        size: 'five'
+`)
+		var comp v2.ComponentDescriptor
+		Expect(codec.Decode(data, &comp)).To(Succeed(), "the access should only be decoded in strict decode mode")
+
+		err := codec.Decode(data, &comp, codec.WithStrictDecode())
+		Expect(err).To(HaveOccurred())
+		validationErr := &codec.ValidationError{}
+		Expect(errors.As(err, &validationErr)).To(BeTrue())
+		Expect(validationErr.Errors).To(HaveLen(1))
+		Expect(validationErr.Errors[0].Field).To(Equal("component.resources[0].access"))
+	})
+
+	It("should ignore access specifications of unknown types", func() {
+		data := componentWithAccess(`
+        type: 'custom'
+        size: 'five'
+`)
+		var comp v2.ComponentDescriptor
+		Expect(codec.Decode(data, &comp, codec.WithStrictDecode())).To(Succeed())
+	})
+
+})
@@ -0,0 +1,191 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// ArtifactoryAPIKeyHeader is the header that contains the api key for authenticated Artifactory requests.
+const ArtifactoryAPIKeyHeader = "X-JFrog-Art-Api"
+
+// ArtifactoryBlobResolver implements the BlobResolver interface for "artifactory" access types.
+// The files are fetched via http from a generic Artifactory repository.
+// The api key is not sent to other hosts if a download is redirected, e.g. to a cloud storage.
+type ArtifactoryBlobResolver struct {
+	client *http.Client
+}
+
+var _ TypedBlobResolver = &ArtifactoryBlobResolver{}
+
+// NewArtifactoryBlobResolver creates a new Artifactory blob resolver that uses the given http client.
+// The default http client is used if no client is given.
+func NewArtifactoryBlobResolver(client *http.Client) *ArtifactoryBlobResolver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	redirectClient := *client
+	redirectClient.CheckRedirect = dropAPIKeyOnRedirect(client.CheckRedirect)
+	return &ArtifactoryBlobResolver{
+		client: &redirectClient,
+	}
+}
+
+// dropAPIKeyOnRedirect removes the api key from redirected requests that leave the scheme or host of the original request.
+// The redirect is then checked with the given redirect policy or the default policy of the http client.
+func dropAPIKeyOnRedirect(checkRedirect func(req *http.Request, via []*http.Request) error) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if req.URL.Scheme != via[0].URL.Scheme || !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+			req.Header.Del(ArtifactoryAPIKeyHeader)
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+}
+
+// ArtifactoryFileURL returns the download url of the file in the Artifactory repository.
+// The url has the form "<url>/artifactory/<repository>/<path>".
+func ArtifactoryFileURL(access *v2.ArtifactoryAccess) string {
+	return artifactoryURL(access, "artifactory")
+}
+
+// ArtifactoryFileInfoURL returns the url of the file info api for the file in the Artifactory repository.
+// The url has the form "<url>/artifactory/api/storage/<repository>/<path>".
+func ArtifactoryFileInfoURL(access *v2.ArtifactoryAccess) string {
+	return artifactoryURL(access, "artifactory/api/storage")
+}
+
+func artifactoryURL(access *v2.ArtifactoryAccess, prefix string) string {
+	return strings.Join([]string{
+		strings.TrimSuffix(access.URL, "/"),
+		prefix,
+		strings.Trim(access.Repository, "/"),
+		strings.TrimPrefix(access.Path, "/"),
+	}, "/")
+}
+
+// artifactoryFileInfo is the response of the Artifactory file info api.
+type artifactoryFileInfo struct {
+	Size      string `json:"size"`
+	MimeType  string `json:"mimeType"`
+	Checksums struct {
+		SHA256 string `json:"sha256"`
+	} `json:"checksums"`
+}
+
+func (a *ArtifactoryBlobResolver) CanResolve(res v2.Resource) bool {
+	return res.Access != nil && res.Access.GetType() == v2.ArtifactoryAccessType
+}
+
+// Info returns the blob info of the file from the Artifactory file info api without downloading the file.
+func (a *ArtifactoryBlobResolver) Info(ctx context.Context, res v2.Resource) (*BlobInfo, error) {
+	access, err := a.decodeAccess(res)
+	if err != nil {
+		return nil, err
+	}
+	url := ArtifactoryFileInfoURL(access)
+	resp, err := a.get(ctx, url, access)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	fileInfo := &artifactoryFileInfo{}
+	if err := json.NewDecoder(resp.Body).Decode(fileInfo); err != nil {
+		return nil, fmt.Errorf("unable to decode file info from %q: %w", url, err)
+	}
+	size, err := strconv.ParseInt(fileInfo.Size, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse size %q of file info from %q: %w", fileInfo.Size, url, err)
+	}
+	if len(fileInfo.Checksums.SHA256) == 0 {
+		return nil, fmt.Errorf("file info from %q contains no sha256 checksum", url)
+	}
+	return &BlobInfo{
+		MediaType: mediaTypeOrDefault(fileInfo.MimeType, res),
+		Digest:    digest.NewDigestFromEncoded(digest.SHA256, fileInfo.Checksums.SHA256).String(),
+		Size:      size,
+	}, nil
+}
+
+// Resolve fetches the file of the resource and writes it to the given writer.
+func (a *ArtifactoryBlobResolver) Resolve(ctx context.Context, res v2.Resource, writer io.Writer) (*BlobInfo, error) {
+	access, err := a.decodeAccess(res)
+	if err != nil {
+		return nil, err
+	}
+	url := ArtifactoryFileURL(access)
+	resp, err := a.get(ctx, url, access)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(writer, digester.Hash()), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read artifactory file from %q: %w", url, err)
+	}
+	metrics.BlobsResolved.Add(1)
+	return &BlobInfo{
+		MediaType: mediaTypeOrDefault(resp.Header.Get("Content-Type"), res),
+		Digest:    digester.Digest().String(),
+		Size:      size,
+	}, nil
+}
+
+func (a *ArtifactoryBlobResolver) decodeAccess(res v2.Resource) (*v2.ArtifactoryAccess, error) {
+	if !a.CanResolve(res) {
+		return nil, UnsupportedResolveType
+	}
+	access := &v2.ArtifactoryAccess{}
+	if err := res.Access.DecodeInto(access); err != nil {
+		return nil, fmt.Errorf("unable to decode access to type '%s': %w", res.Access.GetType(), err)
+	}
+	return access, nil
+}
+
+// get performs an authenticated get request and returns the response if the request was successful.
+func (a *ArtifactoryBlobResolver) get(ctx context.Context, url string, access *v2.ArtifactoryAccess) (*http.Response, error) {
+	return doHTTPRequest(ctx, a.client, http.MethodGet, url, "artifactory file", func(req *http.Request) {
+		if len(access.APIKey) != 0 {
+			req.Header.Set(ArtifactoryAPIKeyHeader, access.APIKey)
+		}
+	})
+}
+
+// mediaTypeOrDefault returns the given media type or the type of the resource if no media type is given.
+func mediaTypeOrDefault(mediaType string, res v2.Resource) string {
+	if len(mediaType) == 0 {
+		return res.GetType()
+	}
+	return mediaType
+}
@@ -0,0 +1,177 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("Artifactory", func() {
+
+	const apiKey = "secret-key"
+
+	var (
+		server *httptest.Server
+		data   = []byte("artifactory file content")
+	)
+
+	newArtifactoryResource := func(access *v2.ArtifactoryAccess) v2.Resource {
+		unstructured, err := v2.NewUnstructured(access)
+		Expect(err).ToNot(HaveOccurred())
+		return v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:    "tool",
+				Version: "1.2.3",
+				Type:    "tgz",
+			},
+			Relation: v2.ExternalRelation,
+			Access:   &unstructured,
+		}
+	}
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(ctf.ArtifactoryAPIKeyHeader) != apiKey {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch r.URL.Path {
+			case "/artifactory/generic-local/tools/tool-1.2.3.tgz":
+				w.Header().Set("Content-Type", "application/gzip")
+				_, _ = w.Write(data)
+			case "/artifactory/api/storage/generic-local/tools/tool-1.2.3.tgz":
+				w.Header().Set("Content-Type", "application/vnd.org.jfrog.artifactory.storage.FileInfo+json")
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"repo":     "generic-local",
+					"path":     "/tools/tool-1.2.3.tgz",
+					"size":     strconv.Itoa(len(data)),
+					"mimeType": "application/gzip",
+					"checksums": map[string]string{
+						"sha1":   "0000",
+						"md5":    "0000",
+						"sha256": digest.FromBytes(data).Encoded(),
+					},
+				})
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newAccess := func(path string) *v2.ArtifactoryAccess {
+		access := v2.NewArtifactoryAccess(server.URL, "generic-local", path)
+		access.APIKey = apiKey
+		return access
+	}
+
+	It("should construct the artifactory urls", func() {
+		access := v2.NewArtifactoryAccess("https://example.jfrog.io/", "generic-local", "/tools/tool.tgz")
+		Expect(ctf.ArtifactoryFileURL(access)).To(Equal("https://example.jfrog.io/artifactory/generic-local/tools/tool.tgz"))
+		Expect(ctf.ArtifactoryFileInfoURL(access)).To(Equal("https://example.jfrog.io/artifactory/api/storage/generic-local/tools/tool.tgz"))
+	})
+
+	It("should resolve an artifactory file", func() {
+		resolver := ctf.NewArtifactoryBlobResolver(server.Client())
+		res := newArtifactoryResource(newAccess("tools/tool-1.2.3.tgz"))
+		Expect(resolver.CanResolve(res)).To(BeTrue())
+
+		var result bytes.Buffer
+		info, err := resolver.Resolve(context.TODO(), res, &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(data))
+		Expect(info.MediaType).To(Equal("application/gzip"))
+		Expect(info.Digest).To(Equal(digest.FromBytes(data).String()))
+		Expect(info.Size).To(Equal(int64(len(data))))
+	})
+
+	It("should not send the api key to the host of a redirected download", func() {
+		var leakedKey string
+		storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			leakedKey = r.Header.Get(ctf.ArtifactoryAPIKeyHeader)
+			_, _ = w.Write(data)
+		}))
+		defer storage.Close()
+		redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(ctf.ArtifactoryAPIKeyHeader) != apiKey {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			http.Redirect(w, r, storage.URL+"/bucket/tool-1.2.3.tgz", http.StatusFound)
+		}))
+		defer redirecting.Close()
+
+		access := v2.NewArtifactoryAccess(redirecting.URL, "generic-local", "tools/tool-1.2.3.tgz")
+		access.APIKey = apiKey
+		var result bytes.Buffer
+		_, err := ctf.NewArtifactoryBlobResolver(redirecting.Client()).Resolve(context.TODO(), newArtifactoryResource(access), &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(data))
+		Expect(leakedKey).To(BeEmpty())
+	})
+
+	It("should return the blob info from the file info api", func() {
+		resolver := ctf.NewArtifactoryBlobResolver(server.Client())
+		info, err := resolver.Info(context.TODO(), newArtifactoryResource(newAccess("tools/tool-1.2.3.tgz")))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info).To(Equal(&ctf.BlobInfo{
+			MediaType: "application/gzip",
+			Digest:    digest.FromBytes(data).String(),
+			Size:      int64(len(data)),
+		}))
+	})
+
+	It("should return a not found error if the file does not exist", func() {
+		resolver := ctf.NewArtifactoryBlobResolver(server.Client())
+		_, err := resolver.Info(context.TODO(), newArtifactoryResource(newAccess("tools/unknown.tgz")))
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+	It("should fail without a valid api key", func() {
+		resolver := ctf.NewArtifactoryBlobResolver(server.Client())
+		access := newAccess("tools/tool-1.2.3.tgz")
+		access.APIKey = ""
+		_, err := resolver.Resolve(context.TODO(), newArtifactoryResource(access), &bytes.Buffer{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not resolve other access types", func() {
+		resolver := ctf.NewArtifactoryBlobResolver(server.Client())
+		res := newArtifactoryResource(newAccess("tools/tool-1.2.3.tgz"))
+		web, err := v2.NewUnstructured(v2.NewWebAccess(server.URL))
+		Expect(err).ToNot(HaveOccurred())
+		res.Access = &web
+		Expect(resolver.CanResolve(res)).To(BeFalse())
+		_, err = resolver.Info(context.TODO(), res)
+		Expect(errors.Is(err, ctf.UnsupportedResolveType)).To(BeTrue())
+	})
+
+})
@@ -0,0 +1,165 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// DefaultServiceURL returns the url of the blob service of the given storage account in the Azure public cloud.
+func DefaultServiceURL(accountName string) string {
+	return fmt.Sprintf("https://%s.blob.core.windows.net/", accountName)
+}
+
+// AzureBlobResolver implements the BlobResolver interface for "azureBlob" access types.
+// The blobs are downloaded with the Azure Blob Storage SDK.
+type AzureBlobResolver struct {
+	serviceURL    func(accountName string) string
+	clientOptions *azblob.ClientOptions
+}
+
+var _ ctf.TypedBlobResolver = &AzureBlobResolver{}
+
+// NewAzureBlobResolver creates a new blob resolver for the Azure Blob Storage.
+func NewAzureBlobResolver() *AzureBlobResolver {
+	return &AzureBlobResolver{
+		serviceURL: DefaultServiceURL,
+	}
+}
+
+// WithServiceURL configures the function that returns the blob service url of a storage account,
+// e.g. for sovereign clouds or the Azurite emulator.
+func (r *AzureBlobResolver) WithServiceURL(serviceURL func(accountName string) string) *AzureBlobResolver {
+	r.serviceURL = serviceURL
+	return r
+}
+
+// WithClientOptions configures the options of the Azure Blob Storage clients.
+func (r *AzureBlobResolver) WithClientOptions(opts *azblob.ClientOptions) *AzureBlobResolver {
+	r.clientOptions = opts
+	return r
+}
+
+func (r *AzureBlobResolver) CanResolve(res v2.Resource) bool {
+	return res.Access != nil && res.Access.GetType() == v2.AzureBlobAccessType
+}
+
+// Info returns the size and content type of the blob without downloading it.
+// The digest is not returned as the blob storage only stores the md5 hash of a blob,
+// whereas Resolve returns the sha256 digest of the blob content.
+func (r *AzureBlobResolver) Info(ctx context.Context, res v2.Resource) (*ctf.BlobInfo, error) {
+	access, client, err := r.client(res)
+	if err != nil {
+		return nil, err
+	}
+	props, err := client.ServiceClient().
+		NewContainerClient(access.ContainerName).
+		NewBlobClient(access.BlobName).
+		GetProperties(ctx, nil)
+	if err != nil {
+		return nil, wrapError(access, err)
+	}
+
+	info := &ctf.BlobInfo{
+		MediaType: res.GetType(),
+	}
+	if props.ContentType != nil && len(*props.ContentType) != 0 {
+		info.MediaType = *props.ContentType
+	}
+	if props.ContentLength != nil {
+		info.Size = *props.ContentLength
+	}
+	return info, nil
+}
+
+// Resolve downloads the blob and streams it to the given writer.
+func (r *AzureBlobResolver) Resolve(ctx context.Context, res v2.Resource, writer io.Writer) (*ctf.BlobInfo, error) {
+	access, client, err := r.client(res)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.DownloadStream(ctx, access.ContainerName, access.BlobName, nil)
+	if err != nil {
+		return nil, wrapError(access, err)
+	}
+	defer resp.Body.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(writer, digester.Hash()), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download blob %q: %w", blobName(access), err)
+	}
+
+	mediaType := res.GetType()
+	if resp.ContentType != nil && len(*resp.ContentType) != 0 {
+		mediaType = *resp.ContentType
+	}
+	return &ctf.BlobInfo{
+		MediaType: mediaType,
+		Digest:    digester.Digest().String(),
+		Size:      size,
+	}, nil
+}
+
+// client decodes the access of the resource and creates a client for its storage account.
+func (r *AzureBlobResolver) client(res v2.Resource) (*v2.AzureBlobAccess, *azblob.Client, error) {
+	if !r.CanResolve(res) {
+		return nil, nil, ctf.UnsupportedResolveType
+	}
+	access := &v2.AzureBlobAccess{}
+	if err := res.Access.DecodeInto(access); err != nil {
+		return nil, nil, fmt.Errorf("unable to decode access to type '%s': %w", res.Access.GetType(), err)
+	}
+	if len(access.AccountName) == 0 || len(access.ContainerName) == 0 || len(access.BlobName) == 0 {
+		return nil, nil, errors.New("the account, container and blob name of an azure blob access have to be defined")
+	}
+
+	serviceURL := r.serviceURL(access.AccountName)
+	if len(access.SASToken) != 0 {
+		serviceURL = strings.TrimSuffix(serviceURL, "?") + "?" + strings.TrimPrefix(access.SASToken, "?")
+	}
+	client, err := azblob.NewClientWithNoCredential(serviceURL, r.clientOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create client for storage account %q: %w", access.AccountName, err)
+	}
+	return access, client, nil
+}
+
+// wrapError wraps errors of missing blobs with a ctf.NotFoundError.
+func wrapError(access *v2.AzureBlobAccess, err error) error {
+	respErr := &azcore.ResponseError{}
+	if bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound, bloberror.ResourceNotFound) ||
+		(errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound) {
+		return fmt.Errorf("blob %q: %w", blobName(access), ctf.NotFoundError)
+	}
+	return fmt.Errorf("unable to access blob %q: %w", blobName(access), err)
+}
+
+func blobName(access *v2.AzureBlobAccess) string {
+	return fmt.Sprintf("%s/%s/%s", access.AccountName, access.ContainerName, access.BlobName)
+}
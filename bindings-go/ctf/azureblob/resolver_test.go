@@ -0,0 +1,154 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblob_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/ctf/azureblob"
+)
+
+var _ = Describe("AzureBlobResolver", func() {
+
+	var (
+		server   *httptest.Server
+		resolver *azureblob.AzureBlobResolver
+		data     = []byte("some blob data")
+		mux      sync.Mutex
+		methods  []string
+	)
+
+	newResource := func(blobName string) cdv2.Resource {
+		access, err := cdv2.NewUnstructured(cdv2.NewAzureBlobAccess("account", "container", blobName, "?sv=2020&sig=secret"))
+		Expect(err).ToNot(HaveOccurred())
+		return cdv2.Resource{
+			IdentityObjectMeta: cdv2.IdentityObjectMeta{
+				Name:    "blob",
+				Version: "1.0.0",
+				Type:    "plain-text",
+			},
+			Relation: cdv2.ExternalRelation,
+			Access:   &access,
+		}
+	}
+
+	BeforeEach(func() {
+		methods = nil
+		// the handler mimics the responses of the blob service for a single blob
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mux.Lock()
+			methods = append(methods, r.Method)
+			mux.Unlock()
+			if r.URL.Query().Get("sig") != "secret" {
+				w.Header().Set("x-ms-error-code", "AuthenticationFailed")
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			if r.URL.Path != "/account/container/blob.txt" {
+				w.Header().Set("x-ms-error-code", "BlobNotFound")
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			sum := md5.Sum(data)
+			w.Header().Set("Content-Type", "text/plain")
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+			w.Header().Set("ETag", "\"0x8D9\"")
+			w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+			w.Header().Set("x-ms-blob-type", "BlockBlob")
+			switch r.Method {
+			case http.MethodHead:
+				w.WriteHeader(http.StatusOK)
+			case http.MethodGet:
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(data)
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		}))
+		resolver = azureblob.NewAzureBlobResolver().
+			WithServiceURL(func(accountName string) string {
+				return server.URL + "/" + accountName + "/"
+			}).
+			WithClientOptions(&azblob.ClientOptions{
+				ClientOptions: azcore.ClientOptions{
+					Retry: policy.RetryOptions{MaxRetries: -1},
+				},
+			})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should download the blob", func() {
+		res := newResource("blob.txt")
+		Expect(resolver.CanResolve(res)).To(BeTrue())
+
+		var buf bytes.Buffer
+		info, err := resolver.Resolve(context.TODO(), res, &buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.Bytes()).To(Equal(data))
+		Expect(info).To(Equal(&ctf.BlobInfo{
+			MediaType: "text/plain",
+			Digest:    digest.FromBytes(data).String(),
+			Size:      int64(len(data)),
+		}))
+	})
+
+	It("should return the properties of the blob without downloading it", func() {
+		info, err := resolver.Info(context.TODO(), newResource("blob.txt"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info).To(Equal(&ctf.BlobInfo{
+			MediaType: "text/plain",
+			Size:      int64(len(data)),
+		}))
+		Expect(methods).To(Equal([]string{http.MethodHead}))
+	})
+
+	It("should return a not found error for missing blobs", func() {
+		_, err := resolver.Info(context.TODO(), newResource("missing.txt"))
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+		_, err = resolver.Resolve(context.TODO(), newResource("missing.txt"), &bytes.Buffer{})
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+	It("should not resolve other access types", func() {
+		access, err := cdv2.NewUnstructured(cdv2.NewWebAccess("https://example.com/blob.txt"))
+		Expect(err).ToNot(HaveOccurred())
+		res := cdv2.Resource{Access: &access}
+		Expect(resolver.CanResolve(res)).To(BeFalse())
+		_, err = resolver.Info(context.TODO(), res)
+		Expect(err).To(MatchError(ctf.UnsupportedResolveType))
+		Expect(methods).To(BeEmpty())
+	})
+})
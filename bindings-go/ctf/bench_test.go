@@ -0,0 +1,80 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"archive/tar"
+	"fmt"
+	"testing"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// benchCTFComponents is the number of components of the ctf that is used by the walk benchmark.
+const benchCTFComponents = 50
+
+// newBenchCTF creates an in-memory ctf with the given number of components.
+func newBenchCTF(b *testing.B, components int) *ctf.CTF {
+	fs := memoryfs.New()
+	file, err := fs.Create("/ctf.tar")
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := tar.NewWriter(file).Close(); err != nil {
+		b.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		b.Fatal(err)
+	}
+	c, err := ctf.NewCTF(fs, "/ctf.tar")
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < components; i++ {
+		cd := &v2.ComponentDescriptor{}
+		cd.Name = fmt.Sprintf("example.com/comp-%02d", i)
+		cd.Version = "1.0.0"
+		cd.Provider = "internal"
+		if err := v2.DefaultComponent(cd); err != nil {
+			b.Fatal(err)
+		}
+		if err := c.AddComponentArchive(ctf.NewComponentArchive(cd, memoryfs.New()), ctf.ArchiveFormatTar); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return c
+}
+
+func BenchmarkCTFWalk(b *testing.B) {
+	c := newBenchCTF(b, benchCTFComponents)
+	defer c.Close()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		count := 0
+		if err := c.Walk(func(ca *ctf.ComponentArchive) error {
+			count++
+			return nil
+		}); err != nil {
+			b.Fatal(err)
+		}
+		if count != benchCTFComponents {
+			b.Fatalf("expected %d components but walked %d", benchCTFComponents, count)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"context"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// SizeBudget defines the maximum sizes of the blobs of a component descriptor's resources.
+// A budget is not enforced if it is not greater than 0.
+type SizeBudget struct {
+	// MaxResourceBytes is the maximum size of a single resource's blob in bytes.
+	MaxResourceBytes int64
+	// MaxTotalBytes is the maximum size of the blobs of all resources in bytes.
+	MaxTotalBytes int64
+}
+
+// BudgetViolation describes a resource whose blob exceeds the size budget.
+type BudgetViolation struct {
+	// ResourceName is the name of the resource that exceeds the budget.
+	// It is empty if the total size of all resources exceeds the budget.
+	ResourceName string
+	// ActualBytes is the size of the resource's blob or the total size of all blobs.
+	ActualBytes int64
+	// BudgetBytes is the budget that has been exceeded.
+	BudgetBytes int64
+	// Err describes why the size of the resource could not be determined.
+	Err error
+}
+
+// CheckSizeBudget checks the sizes of the blobs of all resources of the component descriptor against the given budget.
+// The sizes are determined using the blob info of the resolver, so no blob has to be downloaded.
+// All found violations are returned.
+func CheckSizeBudget(cd *v2.ComponentDescriptor, resolver BlobResolver, ctx context.Context, budget SizeBudget) []BudgetViolation {
+	var (
+		violations []BudgetViolation
+		total      int64
+	)
+	for _, res := range cd.Resources {
+		info, err := resolver.Info(ctx, res)
+		if err != nil {
+			violations = append(violations, BudgetViolation{
+				ResourceName: res.Name,
+				BudgetBytes:  budget.MaxResourceBytes,
+				Err:          err,
+			})
+			continue
+		}
+		total += info.Size
+		if budget.MaxResourceBytes > 0 && info.Size > budget.MaxResourceBytes {
+			violations = append(violations, BudgetViolation{
+				ResourceName: res.Name,
+				ActualBytes:  info.Size,
+				BudgetBytes:  budget.MaxResourceBytes,
+			})
+		}
+	}
+	if budget.MaxTotalBytes > 0 && total > budget.MaxTotalBytes {
+		violations = append(violations, BudgetViolation{
+			ActualBytes: total,
+			BudgetBytes: budget.MaxTotalBytes,
+		})
+	}
+	return violations
+}
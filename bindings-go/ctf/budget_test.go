@@ -0,0 +1,100 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// sizeBlobResolver returns blob infos with the configured size per resource.
+type sizeBlobResolver map[string]int64
+
+func (r sizeBlobResolver) Info(_ context.Context, res v2.Resource) (*ctf.BlobInfo, error) {
+	size, ok := r[res.Name]
+	if !ok {
+		return nil, ctf.NotFoundError
+	}
+	return &ctf.BlobInfo{Size: size}, nil
+}
+
+func (r sizeBlobResolver) Resolve(_ context.Context, _ v2.Resource, _ io.Writer) (*ctf.BlobInfo, error) {
+	Fail("blobs must not be downloaded")
+	return nil, nil
+}
+
+var _ = Describe("SizeBudget", func() {
+
+	const GiB = int64(1024 * 1024 * 1024)
+
+	newComponentDescriptor := func(names ...string) *v2.ComponentDescriptor {
+		cd := &v2.ComponentDescriptor{}
+		for _, name := range names {
+			cd.Resources = append(cd.Resources, v2.Resource{
+				IdentityObjectMeta: v2.IdentityObjectMeta{Name: name},
+			})
+		}
+		return cd
+	}
+
+	It("should report no violations if all resources are within the budget", func() {
+		resolver := sizeBlobResolver{"a": 1 * GiB, "b": 2 * GiB}
+		violations := ctf.CheckSizeBudget(newComponentDescriptor("a", "b"), resolver, context.TODO(), ctf.SizeBudget{
+			MaxResourceBytes: 10 * GiB,
+			MaxTotalBytes:    3 * GiB,
+		})
+		Expect(violations).To(BeEmpty())
+	})
+
+	It("should report resources that exceed the resource budget", func() {
+		resolver := sizeBlobResolver{"a": 1 * GiB, "b": 11 * GiB}
+		violations := ctf.CheckSizeBudget(newComponentDescriptor("a", "b"), resolver, context.TODO(), ctf.SizeBudget{
+			MaxResourceBytes: 10 * GiB,
+		})
+		Expect(violations).To(ConsistOf(ctf.BudgetViolation{
+			ResourceName: "b",
+			ActualBytes:  11 * GiB,
+			BudgetBytes:  10 * GiB,
+		}))
+	})
+
+	It("should report if the total size exceeds the total budget", func() {
+		resolver := sizeBlobResolver{"a": 6 * GiB, "b": 6 * GiB}
+		violations := ctf.CheckSizeBudget(newComponentDescriptor("a", "b"), resolver, context.TODO(), ctf.SizeBudget{
+			MaxResourceBytes: 10 * GiB,
+			MaxTotalBytes:    10 * GiB,
+		})
+		Expect(violations).To(ConsistOf(ctf.BudgetViolation{
+			ActualBytes: 12 * GiB,
+			BudgetBytes: 10 * GiB,
+		}))
+	})
+
+	It("should report resources whose size cannot be determined", func() {
+		violations := ctf.CheckSizeBudget(newComponentDescriptor("unknown"), sizeBlobResolver{}, context.TODO(), ctf.SizeBudget{
+			MaxResourceBytes: 10 * GiB,
+		})
+		Expect(violations).To(HaveLen(1))
+		Expect(violations[0].ResourceName).To(Equal("unknown"))
+		Expect(errors.Is(violations[0].Err, ctf.NotFoundError)).To(BeTrue())
+	})
+})
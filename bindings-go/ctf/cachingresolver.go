@@ -0,0 +1,239 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"sigs.k8s.io/yaml"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/codec"
+)
+
+const (
+	cacheDescriptorSuffix = ".yaml"
+	cacheMetaSuffix       = ".meta.json"
+)
+
+// cacheEntryMeta describes the metadata sidecar of a cached component descriptor.
+type cacheEntryMeta struct {
+	// FetchedAt is the time when the component descriptor was fetched from the inner resolver.
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// CachingComponentResolver is a component resolver that caches the resolved component descriptors in a filesystem.
+// A component descriptor is stored as "<repository>/<name>/<version>.yaml" with a "<repository>/<name>/<version>.meta.json" sidecar
+// that contains the time the component descriptor was fetched.
+// The repository is the hex encoded sha256 hash of the json encoded repository context,
+// so that components with the same name and version in different repositories are cached separately.
+// Names and versions that would escape their cache directory are not cached.
+// Cached component descriptors are evicted once they are older than the configured ttl.
+type CachingComponentResolver struct {
+	inner ComponentResolver
+	fs    vfs.FileSystem
+	ttl   time.Duration
+}
+
+var _ ComponentResolver = &CachingComponentResolver{}
+
+// NewCachingComponentResolver creates a new resolver that caches the component descriptors of the inner resolver in the given filesystem.
+func NewCachingComponentResolver(inner ComponentResolver, cacheFS vfs.FileSystem, ttl time.Duration) *CachingComponentResolver {
+	return &CachingComponentResolver{
+		inner: inner,
+		fs:    cacheFS,
+		ttl:   ttl,
+	}
+}
+
+// Resolve returns the cached component descriptor or resolves it using the inner resolver if it is not cached or expired.
+func (c *CachingComponentResolver) Resolve(ctx context.Context, repoCtx v2.Repository, name, version string) (*v2.ComponentDescriptor, error) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("name", name, "version", version)
+	cd, err := c.get(repoCtx, name, version)
+	if err == nil {
+		return cd, nil
+	}
+	log.V(5).Info(err.Error())
+
+	cd, err = c.inner.Resolve(ctx, repoCtx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.store(repoCtx, cd); err != nil {
+		log.Error(err, "unable to store component descriptor")
+	}
+	return cd, nil
+}
+
+// ResolveWithBlobResolver resolves the component descriptor and its blob resolver using the inner resolver.
+// The blob resolver cannot be cached, therefore the result is only used to update the cache.
+func (c *CachingComponentResolver) ResolveWithBlobResolver(ctx context.Context, repoCtx v2.Repository, name, version string) (*v2.ComponentDescriptor, BlobResolver, error) {
+	cd, blobResolver, err := c.inner.ResolveWithBlobResolver(ctx, repoCtx, name, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.store(repoCtx, cd); err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "unable to store component descriptor", "name", name, "version", version)
+	}
+	return cd, blobResolver, nil
+}
+
+// PurgeExpired removes all expired component descriptors from the cache.
+// It returns the number of removed component descriptors.
+func (c *CachingComponentResolver) PurgeExpired() (int, error) {
+	expired := make([]string, 0)
+	err := vfs.Walk(c.fs, "/", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, cacheMetaSuffix) {
+			return nil
+		}
+		meta, err := c.readMeta(path)
+		if err != nil || c.isExpired(meta) {
+			expired = append(expired, strings.TrimSuffix(path, cacheMetaSuffix))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to read cache: %w", err)
+	}
+	for _, entry := range expired {
+		if err := c.evict(entry); err != nil {
+			return 0, err
+		}
+	}
+	return len(expired), nil
+}
+
+// get reads the component descriptor from the cache.
+// Expired component descriptors are evicted.
+func (c *CachingComponentResolver) get(repoCtx v2.Repository, name, version string) (*v2.ComponentDescriptor, error) {
+	entry, err := cacheEntryPath(repoCtx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := c.readMeta(entry + cacheMetaSuffix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("component descriptor %s:%s is not cached: %w", name, version, NotFoundError)
+		}
+		return nil, err
+	}
+	if c.isExpired(meta) {
+		if err := c.evict(entry); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("cached component descriptor %s:%s is expired: %w", name, version, NotFoundError)
+	}
+
+	data, err := vfs.ReadFile(c.fs, entry+cacheDescriptorSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cached component descriptor %s:%s: %w", name, version, err)
+	}
+	cd := &v2.ComponentDescriptor{}
+	if err := codec.Decode(data, cd); err != nil {
+		return nil, fmt.Errorf("unable to decode cached component descriptor %s:%s: %w", name, version, err)
+	}
+	return cd, nil
+}
+
+// store writes the component descriptor that has been resolved in the repository context and its metadata sidecar to the cache.
+func (c *CachingComponentResolver) store(repoCtx v2.Repository, cd *v2.ComponentDescriptor) error {
+	entry, err := cacheEntryPath(repoCtx, cd.Name, cd.Version)
+	if err != nil {
+		return err
+	}
+	data, err := codec.Encode(cd.DeepCopy())
+	if err != nil {
+		return fmt.Errorf("unable to encode component descriptor: %w", err)
+	}
+	data, err = yaml.JSONToYAML(data)
+	if err != nil {
+		return fmt.Errorf("unable to convert component descriptor to yaml: %w", err)
+	}
+	meta, err := json.Marshal(cacheEntryMeta{FetchedAt: time.Now()})
+	if err != nil {
+		return fmt.Errorf("unable to encode cache metadata: %w", err)
+	}
+
+	if err := c.fs.MkdirAll(vfs.Dir(c.fs, entry), os.ModePerm); err != nil {
+		return fmt.Errorf("unable to create cache directory: %w", err)
+	}
+	if err := vfs.WriteFile(c.fs, entry+cacheDescriptorSuffix, data, os.ModePerm); err != nil {
+		return fmt.Errorf("unable to write component descriptor to cache: %w", err)
+	}
+	// the sidecar is written last so that only complete entries are read from the cache.
+	if err := vfs.WriteFile(c.fs, entry+cacheMetaSuffix, meta, os.ModePerm); err != nil {
+		return fmt.Errorf("unable to write cache metadata: %w", err)
+	}
+	return nil
+}
+
+func (c *CachingComponentResolver) readMeta(path string) (*cacheEntryMeta, error) {
+	data, err := vfs.ReadFile(c.fs, path)
+	if err != nil {
+		return nil, err
+	}
+	meta := &cacheEntryMeta{}
+	if err := json.Unmarshal(data, meta); err != nil {
+		return nil, fmt.Errorf("unable to decode cache metadata %q: %w", path, err)
+	}
+	return meta, nil
+}
+
+func (c *CachingComponentResolver) isExpired(meta *cacheEntryMeta) bool {
+	return time.Since(meta.FetchedAt) > c.ttl
+}
+
+// evict removes the cache entry with the given path prefix.
+func (c *CachingComponentResolver) evict(entry string) error {
+	for _, path := range []string{entry + cacheMetaSuffix, entry + cacheDescriptorSuffix} {
+		if err := c.fs.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to remove %q from cache: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// cacheEntryPath returns the path of a cached component descriptor without file suffix.
+// An error is returned if the name or version contain path elements that would escape the cache directory of the component.
+func cacheEntryPath(repoCtx v2.Repository, name, version string) (string, error) {
+	for _, elem := range append(strings.Split(name, "/"), version) {
+		if len(elem) == 0 || elem == "." || elem == ".." || strings.ContainsAny(elem, `/\`) {
+			return "", fmt.Errorf("component descriptor %s:%s cannot be cached: invalid path element %q", name, version, elem)
+		}
+	}
+	repoData, err := json.Marshal(repoCtx)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode repository context: %w", err)
+	}
+	repoHash := sha256.Sum256(repoData)
+	entry := "/" + hex.EncodeToString(repoHash[:]) + "/" + name + "/" + version
+	if path.Clean(entry) != entry {
+		return "", fmt.Errorf("component descriptor %s:%s cannot be cached: invalid path %q", name, version, entry)
+	}
+	return entry, nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// countingResolver counts the calls to the inner resolver.
+type countingResolver struct {
+	ctf.ComponentResolver
+	calls int
+}
+
+func (r *countingResolver) Resolve(ctx context.Context, repoCtx v2.Repository, name, version string) (*v2.ComponentDescriptor, error) {
+	r.calls++
+	return r.ComponentResolver.Resolve(ctx, repoCtx, name, version)
+}
+
+var _ = Describe("CachingComponentResolver", func() {
+
+	var (
+		fs      vfs.FileSystem
+		inner   *countingResolver
+		repoCtx *v2.UnstructuredTypedObject
+	)
+
+	// entryPaths returns the paths of all cached component descriptors with the given name and version without file suffix.
+	entryPaths := func(name, version string) []string {
+		paths := []string{}
+		Expect(vfs.Walk(fs, "/", func(path string, info os.FileInfo, err error) error {
+			if err == nil && strings.HasSuffix(path, "/"+name+"/"+version+".yaml") {
+				paths = append(paths, strings.TrimSuffix(path, ".yaml"))
+			}
+			return err
+		})).To(Succeed())
+		return paths
+	}
+
+	// entryPath returns the path of the single cached component descriptor with the given name and version.
+	entryPath := func(name, version string) string {
+		paths := entryPaths(name, version)
+		Expect(paths).To(HaveLen(1))
+		return paths[0]
+	}
+
+	// expire sets the fetch time of the cached component descriptor into the past.
+	expire := func(name, version string) {
+		data, err := json.Marshal(map[string]interface{}{"fetchedAt": time.Now().Add(-2 * time.Hour)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vfs.WriteFile(fs, entryPath(name, version)+".meta.json", data, os.ModePerm)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		fs = memoryfs.New()
+		unstructured, err := v2.NewUnstructured(v2.NewOCIRegistryRepository("example.com/components", ""))
+		Expect(err).ToNot(HaveOccurred())
+		repoCtx = &unstructured
+		list := &v2.ComponentDescriptorList{}
+		for _, name := range []string{"example.com/a", "example.com/b"} {
+			cd := newComponentArchive(name, "1.0.0").ComponentDescriptor
+			Expect(v2.InjectRepositoryContext(cd, repoCtx)).To(Succeed())
+			list.Components = append(list.Components, *cd)
+		}
+		lr, err := ctf.NewListResolver(list)
+		Expect(err).ToNot(HaveOccurred())
+		inner = &countingResolver{ComponentResolver: lr}
+	})
+
+	It("should cache resolved component descriptors", func() {
+		resolver := ctf.NewCachingComponentResolver(inner, fs, time.Hour)
+		cd, err := resolver.Resolve(context.TODO(), repoCtx, "example.com/a", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cd.Name).To(Equal("example.com/a"))
+		Expect(vfs.FileExists(fs, entryPath("example.com/a", "1.0.0")+".meta.json")).To(BeTrue())
+
+		cd, err = resolver.Resolve(context.TODO(), repoCtx, "example.com/a", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cd.Name).To(Equal("example.com/a"))
+		Expect(inner.calls).To(Equal(1))
+	})
+
+	It("should resolve expired component descriptors again", func() {
+		resolver := ctf.NewCachingComponentResolver(inner, fs, time.Hour)
+		_, err := resolver.Resolve(context.TODO(), repoCtx, "example.com/a", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		expire("example.com/a", "1.0.0")
+
+		_, err = resolver.Resolve(context.TODO(), repoCtx, "example.com/a", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inner.calls).To(Equal(2))
+	})
+
+	It("should fall back to the inner resolver if the cache entry is corrupted", func() {
+		resolver := ctf.NewCachingComponentResolver(inner, fs, time.Hour)
+		_, err := resolver.Resolve(context.TODO(), repoCtx, "example.com/a", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vfs.WriteFile(fs, entryPath("example.com/a", "1.0.0")+".yaml", []byte("invalid"), os.ModePerm)).To(Succeed())
+
+		cd, err := resolver.Resolve(context.TODO(), repoCtx, "example.com/a", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cd.Name).To(Equal("example.com/a"))
+		Expect(inner.calls).To(Equal(2))
+	})
+
+	It("should return errors of the inner resolver", func() {
+		resolver := ctf.NewCachingComponentResolver(inner, fs, time.Hour)
+		_, err := resolver.Resolve(context.TODO(), repoCtx, "example.com/c", "1.0.0")
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+	It("should purge expired component descriptors", func() {
+		resolver := ctf.NewCachingComponentResolver(inner, fs, time.Hour)
+		_, err := resolver.Resolve(context.TODO(), repoCtx, "example.com/a", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = resolver.Resolve(context.TODO(), repoCtx, "example.com/b", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		expire("example.com/b", "1.0.0")
+		expiredEntry := entryPath("example.com/b", "1.0.0")
+
+		purged, err := resolver.PurgeExpired()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(purged).To(Equal(1))
+		Expect(entryPaths("example.com/a", "1.0.0")).To(HaveLen(1))
+		Expect(entryPaths("example.com/b", "1.0.0")).To(BeEmpty())
+		Expect(vfs.FileExists(fs, expiredEntry+".meta.json")).To(BeFalse())
+	})
+
+	It("should cache component descriptors of different repositories separately", func() {
+		resolver := ctf.NewCachingComponentResolver(inner, fs, time.Hour)
+		_, err := resolver.Resolve(context.TODO(), repoCtx, "example.com/a", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+
+		other, err := v2.NewUnstructured(v2.NewOCIRegistryRepository("other.example.com/components", ""))
+		Expect(err).ToNot(HaveOccurred())
+		// the component does not exist in the other repository, so the cached component descriptor must not be returned.
+		_, err = resolver.Resolve(context.TODO(), &other, "example.com/a", "1.0.0")
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+		Expect(inner.calls).To(Equal(2))
+	})
+
+	It("should not cache component descriptors whose path escapes the cache directory", func() {
+		resolver := ctf.NewCachingComponentResolver(inner, fs, time.Hour)
+		for _, name := range []string{"../../example.com/a", "example.com//a", "example.com/./a"} {
+			_, err := resolver.Resolve(context.TODO(), repoCtx, name, "1.0.0")
+			Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue(), name)
+		}
+		_, err := resolver.Resolve(context.TODO(), repoCtx, "example.com/a", "../1.0.0")
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+		files, err := vfs.ReadDir(fs, "/")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(BeEmpty())
+	})
+})
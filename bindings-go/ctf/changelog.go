@@ -0,0 +1,127 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// CreatedAtLabel is the name of the label that contains the RFC3339 timestamp of the creation of a component version.
+const CreatedAtLabel = "gardener.cloud/created-at"
+
+// ChangelogLabel is the name of the label that contains the list of changes of a component version.
+const ChangelogLabel = "gardener.cloud/changelog"
+
+// Changelog describes the changes of all versions of a component that are contained in a ctf.
+type Changelog struct {
+	// ComponentName is the name of the described component.
+	ComponentName string
+	// Entries contains one entry per component version, ordered by descending version.
+	Entries []ChangelogEntry
+}
+
+// ChangelogEntry describes the changes of one component version.
+type ChangelogEntry struct {
+	Version string
+	// Timestamp is the creation time of the version.
+	// It is zero if the component descriptor has no created-at label.
+	Timestamp time.Time
+	Changes   []string
+}
+
+// GenerateChangelog generates the changelog of the component with the given name from all its versions in the ctf.
+// The timestamps and changes are read from the CreatedAtLabel and ChangelogLabel of the component descriptors.
+// Semver versions are ordered before all other versions, which are ordered lexically.
+// A NotFoundError is returned if the ctf contains no version of the component.
+func GenerateChangelog(ctf *CTF, componentName string) (*Changelog, error) {
+	changelog := &Changelog{ComponentName: componentName}
+	err := ctf.Walk(func(ca *ComponentArchive) error {
+		cd := ca.ComponentDescriptor
+		if cd.GetName() != componentName {
+			return nil
+		}
+		entry := ChangelogEntry{Version: cd.GetVersion()}
+		if data, ok := cd.Labels.Get(CreatedAtLabel); ok {
+			var createdAt string
+			if err := json.Unmarshal(data, &createdAt); err != nil {
+				return fmt.Errorf("unable to decode label %q of version %q: %w", CreatedAtLabel, entry.Version, err)
+			}
+			t, err := time.Parse(time.RFC3339, createdAt)
+			if err != nil {
+				return fmt.Errorf("unable to parse label %q of version %q: %w", CreatedAtLabel, entry.Version, err)
+			}
+			entry.Timestamp = t
+		}
+		if data, ok := cd.Labels.Get(ChangelogLabel); ok {
+			if err := json.Unmarshal(data, &entry.Changes); err != nil {
+				return fmt.Errorf("unable to decode label %q of version %q: %w", ChangelogLabel, entry.Version, err)
+			}
+		}
+		changelog.Entries = append(changelog.Entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(changelog.Entries) == 0 {
+		return nil, fmt.Errorf("no version of %q found: %w", componentName, NotFoundError)
+	}
+	sort.SliceStable(changelog.Entries, func(i, j int) bool {
+		return versionGreater(changelog.Entries[i].Version, changelog.Entries[j].Version)
+	})
+	return changelog, nil
+}
+
+// versionGreater returns true if version a is ordered before version b in a descending order.
+func versionGreater(a, b string) bool {
+	va, errA := semver.NewVersion(a)
+	vb, errB := semver.NewVersion(b)
+	switch {
+	case errA == nil && errB == nil:
+		return va.GreaterThan(vb)
+	case errA == nil:
+		return true
+	case errB == nil:
+		return false
+	default:
+		return a > b
+	}
+}
+
+// String returns the changelog as markdown document with one section per version.
+func (c Changelog) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Changelog of %s\n", c.ComponentName)
+	for _, entry := range c.Entries {
+		sb.WriteString("\n## " + entry.Version)
+		if !entry.Timestamp.IsZero() {
+			sb.WriteString(" (" + entry.Timestamp.UTC().Format("2006-01-02") + ")")
+		}
+		sb.WriteString("\n")
+		if len(entry.Changes) != 0 {
+			sb.WriteString("\n")
+		}
+		for _, change := range entry.Changes {
+			sb.WriteString("- " + change + "\n")
+		}
+	}
+	return sb.String()
+}
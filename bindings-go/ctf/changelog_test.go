@@ -0,0 +1,95 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("changelog", func() {
+
+	var c *ctf.CTF
+
+	addVersion := func(version, createdAt string, changes ...string) {
+		ca := newComponentArchive("example.com/comp", version)
+		data, err := json.Marshal(createdAt)
+		Expect(err).ToNot(HaveOccurred())
+		ca.ComponentDescriptor.Labels = append(ca.ComponentDescriptor.Labels, v2.Label{Name: ctf.CreatedAtLabel, Value: data})
+		if len(changes) != 0 {
+			data, err := json.Marshal(changes)
+			Expect(err).ToNot(HaveOccurred())
+			ca.ComponentDescriptor.Labels = append(ca.ComponentDescriptor.Labels, v2.Label{Name: ctf.ChangelogLabel, Value: data})
+		}
+		Expect(c.AddComponentArchiveWithName("comp-"+version, ca, ctf.ArchiveFormatTar)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		c = newEmptyCTF(memoryfs.New(), "/ctf.tar")
+	})
+
+	AfterEach(func() {
+		Expect(c.Close()).To(Succeed())
+	})
+
+	It("should generate a changelog ordered by descending version", func() {
+		addVersion("1.2.0", "2022-02-01T10:00:00Z", "add feature b")
+		addVersion("1.10.0", "2022-03-01T10:00:00Z", "add feature c", "fix bug in feature b")
+		addVersion("1.0.0", "2022-01-01T10:00:00Z")
+		Expect(c.AddComponentArchive(newComponentArchive("example.com/other", "2.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+
+		changelog, err := ctf.GenerateChangelog(c, "example.com/comp")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changelog.Entries).To(Equal([]ctf.ChangelogEntry{
+			{Version: "1.10.0", Timestamp: time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC), Changes: []string{"add feature c", "fix bug in feature b"}},
+			{Version: "1.2.0", Timestamp: time.Date(2022, 2, 1, 10, 0, 0, 0, time.UTC), Changes: []string{"add feature b"}},
+			{Version: "1.0.0", Timestamp: time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)},
+		}))
+		Expect(changelog.String()).To(Equal(`# Changelog of example.com/comp
+
+## 1.10.0 (2022-03-01)
+
+- add feature c
+- fix bug in feature b
+
+## 1.2.0 (2022-02-01)
+
+- add feature b
+
+## 1.0.0 (2022-01-01)
+`))
+	})
+
+	It("should fail for invalid timestamps", func() {
+		addVersion("1.0.0", "yesterday")
+		_, err := ctf.GenerateChangelog(c, "example.com/comp")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return a not found error for unknown components", func() {
+		addVersion("1.0.0", "2022-01-01T10:00:00Z")
+		_, err := ctf.GenerateChangelog(c, "example.com/unknown")
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+})
@@ -0,0 +1,153 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+)
+
+// ChecksumManifestFileName is the name of the checksum manifest file in the ctf root.
+const ChecksumManifestFileName = "sha256sums.txt"
+
+var checksumManifestPath = filepath.Join("/", ChecksumManifestFileName)
+
+// ChecksumMismatch describes a file of a ctf whose checksum does not match the checksum manifest.
+type ChecksumMismatch struct {
+	// Path is the path of the file in the ctf.
+	Path string
+	// Expected is the checksum defined in the manifest.
+	Expected string
+	// Actual is the calculated checksum of the file.
+	// It is empty if the file does not exist.
+	Actual string
+}
+
+// WriteCTFChecksumManifest writes the sha256 checksums of all files in the ctf in the format of sha256sum.
+// The checksum manifest of the ctf itself is excluded.
+func WriteCTFChecksumManifest(ctf *CTF, w io.Writer) error {
+	ctf.mux.RLock()
+	defer ctf.mux.RUnlock()
+	return ctf.writeChecksumManifest(w)
+}
+
+// writeChecksumManifest writes the checksum manifest of the ctf.
+// The caller has to hold the lock of the ctf.
+func (ctf *CTF) writeChecksumManifest(w io.Writer) error {
+	if err := ctf.checkExpired(); err != nil {
+		return err
+	}
+	paths := make([]string, 0)
+	err := vfs.Walk(ctf.tempFs, "/", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || path == checksumManifestPath {
+			return nil
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to walk ctf: %w", err)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		checksum, err := ctf.fileChecksum(path)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s  %s\n", checksum, strings.TrimPrefix(path, "/")); err != nil {
+			return fmt.Errorf("unable to write checksum of %q: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// AddCTFChecksumManifest adds the checksum manifest of all current files to the ctf.
+// The manifest is written as last entry of the ctf archive.
+func AddCTFChecksumManifest(ctf *CTF) error {
+	ctf.mux.Lock()
+	defer ctf.mux.Unlock()
+	var buf bytes.Buffer
+	if err := ctf.writeChecksumManifest(&buf); err != nil {
+		return err
+	}
+	ctf.dirty = true
+	return vfs.WriteFile(ctf.tempFs, checksumManifestPath, buf.Bytes(), os.ModePerm)
+}
+
+// VerifyCTFChecksumManifest verifies the files of the ctf against the given checksum manifest.
+// All files whose checksum does not match or that do not exist are returned.
+func VerifyCTFChecksumManifest(ctf *CTF, r io.Reader) ([]ChecksumMismatch, error) {
+	ctf.mux.RLock()
+	defer ctf.mux.RUnlock()
+	if err := ctf.checkExpired(); err != nil {
+		return nil, err
+	}
+	mismatches := make([]ChecksumMismatch, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		split := strings.SplitN(line, "  ", 2)
+		if len(split) != 2 {
+			return nil, fmt.Errorf("invalid checksum manifest line %q", line)
+		}
+		expected, path := split[0], filepath.Join("/", split[1])
+
+		actual, err := ctf.fileChecksum(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if actual != expected {
+			mismatches = append(mismatches, ChecksumMismatch{
+				Path:     split[1],
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read checksum manifest: %w", err)
+	}
+	return mismatches, nil
+}
+
+// fileChecksum calculates the hex encoded sha256 checksum of a file of the ctf.
+func (ctf *CTF) fileChecksum(path string) (string, error) {
+	file, err := ctf.tempFs.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	dig, err := digest.SHA256.FromReader(file)
+	if err != nil {
+		return "", fmt.Errorf("unable to calculate checksum of %q: %w", path, err)
+	}
+	return dig.Encoded(), nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("Checksum manifest", func() {
+
+	var (
+		fs vfs.FileSystem
+		c  *ctf.CTF
+	)
+
+	BeforeEach(func() {
+		fs = memoryfs.New()
+		c = newEmptyCTF(fs, "/ctf.tar")
+		Expect(c.AddComponentArchiveWithName("comp-1", newComponentArchive("example.com/comp-1", "1.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+		Expect(c.AddComponentArchiveWithName("comp-2", newComponentArchive("example.com/comp-2", "1.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(c.Close()).To(Succeed())
+	})
+
+	It("should verify an unmodified ctf", func() {
+		var manifest bytes.Buffer
+		Expect(ctf.WriteCTFChecksumManifest(c, &manifest)).To(Succeed())
+		Expect(manifest.String()).To(MatchRegexp(`^[0-9a-f]{64}  comp-1\n[0-9a-f]{64}  comp-2\n$`))
+
+		mismatches, err := ctf.VerifyCTFChecksumManifest(c, &manifest)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mismatches).To(BeEmpty())
+	})
+
+	It("should report a corrupted file", func() {
+		var manifest bytes.Buffer
+		Expect(ctf.WriteCTFChecksumManifest(c, &manifest)).To(Succeed())
+		Expect(c.AddComponentArchiveWithName("comp-2", newComponentArchive("example.com/comp-2", "2.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+
+		mismatches, err := ctf.VerifyCTFChecksumManifest(c, &manifest)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mismatches).To(HaveLen(1))
+		Expect(mismatches[0].Path).To(Equal("comp-2"))
+		Expect(mismatches[0].Actual).ToNot(Equal(mismatches[0].Expected))
+	})
+
+	It("should write the checksum manifest as last entry of the ctf", func() {
+		// the archive would be walked after the manifest because of its name
+		Expect(c.AddComponentArchiveWithName("zz-comp-3", newComponentArchive("example.com/comp-3", "1.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+		Expect(ctf.AddCTFChecksumManifest(c)).To(Succeed())
+		Expect(c.Write()).To(Succeed())
+
+		file, err := fs.Open("/ctf.tar")
+		Expect(err).ToNot(HaveOccurred())
+		defer file.Close()
+		var (
+			last    string
+			content bytes.Buffer
+		)
+		tr := tar.NewReader(file)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).ToNot(HaveOccurred())
+			last = header.Name
+			content.Reset()
+			_, err = io.Copy(&content, tr)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(last).To(Equal(ctf.ChecksumManifestFileName))
+
+		reopened, err := ctf.NewCTF(fs, "/ctf.tar")
+		Expect(err).ToNot(HaveOccurred())
+		defer reopened.Close()
+		Expect(componentNames(reopened)).To(ConsistOf("example.com/comp-1", "example.com/comp-2", "example.com/comp-3"))
+		mismatches, err := ctf.VerifyCTFChecksumManifest(reopened, &content)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mismatches).To(BeEmpty())
+	})
+
+})
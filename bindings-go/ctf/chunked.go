@@ -0,0 +1,218 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+)
+
+// ChunkedBlobManifest describes a blob that is stored in multiple chunks.
+// The manifest is stored as "blobs/<digest>.manifest" next to the chunks "blobs/<digest>-part-<n>".
+type ChunkedBlobManifest struct {
+	// MediaType is the media type of the complete blob.
+	MediaType string `json:"mediaType,omitempty"`
+	// Digest is the digest of the complete blob.
+	Digest string `json:"digest"`
+	// Size is the size of the complete blob.
+	Size int64 `json:"size"`
+	// Chunks are the chunks of the blob in the order they have to be concatenated.
+	Chunks []BlobChunk `json:"chunks"`
+}
+
+// BlobChunk describes a single chunk of a chunked blob.
+type BlobChunk struct {
+	// Name is the filename of the chunk in the blobs directory.
+	Name string `json:"name"`
+	// Size is the size of the chunk.
+	Size int64 `json:"size"`
+}
+
+// ChunkedBlobManifestName returns the filename of the manifest of a chunked blob.
+func ChunkedBlobManifestName(digest string) string {
+	return digest + ".manifest"
+}
+
+// ChunkedBlobPartName returns the filename of the n-th chunk of a chunked blob.
+func ChunkedBlobPartName(digest string, n int) string {
+	return fmt.Sprintf("%s-part-%d", digest, n)
+}
+
+// AddBlobChunked reads the blob from the reader and stores it in chunks of at most chunkSize bytes in the blobs directory of destFS.
+// A manifest that lists all chunks is written as "blobs/<digest>.manifest".
+// The component archive's filesystem is used if no destFS is given.
+func (ca *ComponentArchive) AddBlobChunked(mediaType string, r io.Reader, chunkSize int64, destFS vfs.FileSystem) (BlobInfo, error) {
+	if chunkSize <= 0 {
+		return BlobInfo{}, errors.New("the chunk size has to be greater than 0")
+	}
+	if destFS == nil {
+		destFS = ca.fs
+	}
+	if err := destFS.MkdirAll(BlobsDirectoryName, os.ModePerm); err != nil {
+		return BlobInfo{}, fmt.Errorf("unable to create blob directory: %w", err)
+	}
+
+	var (
+		digester  = digest.Canonical.Digester()
+		tempFiles = make([]string, 0)
+		chunks    = make([]BlobChunk, 0)
+		size      int64
+	)
+	cleanup := func() {
+		for _, name := range tempFiles {
+			_ = destFS.Remove(name)
+		}
+	}
+	for {
+		file, err := vfs.TempFile(destFS, BlobsDirectoryName, "chunk-")
+		if err != nil {
+			cleanup()
+			return BlobInfo{}, fmt.Errorf("unable to create chunk: %w", err)
+		}
+		tempFiles = append(tempFiles, file.Name())
+		n, err := io.CopyN(io.MultiWriter(file, digester.Hash()), r, chunkSize)
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			cleanup()
+			return BlobInfo{}, fmt.Errorf("unable to write chunk %d: %w", len(chunks), err)
+		}
+		if n == 0 {
+			// the last chunk is empty and therefore not needed.
+			_ = destFS.Remove(file.Name())
+			tempFiles = tempFiles[:len(tempFiles)-1]
+			break
+		}
+		chunks = append(chunks, BlobChunk{Size: n})
+		size += n
+		if err != nil {
+			break
+		}
+	}
+
+	dgst := digester.Digest().String()
+	for i := range chunks {
+		chunks[i].Name = ChunkedBlobPartName(dgst, i)
+		chunkPath := BlobPath(chunks[i].Name)
+		if err := destFS.Remove(chunkPath); err != nil && !os.IsNotExist(err) {
+			cleanup()
+			return BlobInfo{}, fmt.Errorf("unable to remove existing chunk %q: %w", chunkPath, err)
+		}
+		if err := destFS.Rename(tempFiles[i], chunkPath); err != nil {
+			cleanup()
+			return BlobInfo{}, fmt.Errorf("unable to rename chunk %d: %w", i, err)
+		}
+	}
+
+	manifest := ChunkedBlobManifest{
+		MediaType: mediaType,
+		Digest:    dgst,
+		Size:      size,
+		Chunks:    chunks,
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return BlobInfo{}, fmt.Errorf("unable to encode chunk manifest: %w", err)
+	}
+	if err := vfs.WriteFile(destFS, BlobPath(ChunkedBlobManifestName(dgst)), data, os.ModePerm); err != nil {
+		return BlobInfo{}, fmt.Errorf("unable to write chunk manifest: %w", err)
+	}
+	return BlobInfo{
+		MediaType: mediaType,
+		Digest:    dgst,
+		Size:      size,
+	}, nil
+}
+
+// ReadBlobChunked returns a reader that reconstructs a chunked blob of the component archive.
+// The manifest has to describe the blob with the given digest and its chunks have to use the chunk names of that digest.
+// The digest of the blob is verified when the reader reaches the end of the blob.
+func ReadBlobChunked(ca *ComponentArchive, dgst string) (io.ReadCloser, error) {
+	expected, err := digest.Parse(dgst)
+	if err != nil {
+		return nil, fmt.Errorf("invalid digest %q: %w", dgst, err)
+	}
+	data, err := vfs.ReadFile(ca.fs, BlobPath(ChunkedBlobManifestName(dgst)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("chunked blob %q: %w", dgst, NotFoundError)
+		}
+		return nil, fmt.Errorf("unable to read chunk manifest of %q: %w", dgst, err)
+	}
+	manifest := &ChunkedBlobManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("unable to decode chunk manifest of %q: %w", dgst, err)
+	}
+	if manifest.Digest != dgst {
+		return nil, fmt.Errorf("chunk manifest of %q describes the blob %q", dgst, manifest.Digest)
+	}
+	for i, chunk := range manifest.Chunks {
+		if chunk.Name != ChunkedBlobPartName(dgst, i) {
+			return nil, fmt.Errorf("chunk %d of %q has the unexpected name %q", i, dgst, chunk.Name)
+		}
+	}
+
+	files := make([]io.Closer, 0, len(manifest.Chunks))
+	readers := make([]io.Reader, 0, len(manifest.Chunks))
+	for _, chunk := range manifest.Chunks {
+		file, err := ca.fs.Open(BlobPath(chunk.Name))
+		if err != nil {
+			for _, f := range files {
+				_ = f.Close()
+			}
+			return nil, fmt.Errorf("unable to open chunk %q: %w", chunk.Name, err)
+		}
+		files = append(files, file)
+		readers = append(readers, io.LimitReader(file, chunk.Size))
+	}
+	return &chunkedBlobReader{
+		reader:   io.MultiReader(readers...),
+		files:    files,
+		verifier: expected.Verifier(),
+	}, nil
+}
+
+// chunkedBlobReader reads all chunks of a blob and verifies the digest of the complete blob.
+type chunkedBlobReader struct {
+	reader   io.Reader
+	files    []io.Closer
+	verifier digest.Verifier
+}
+
+func (c *chunkedBlobReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	_, _ = c.verifier.Write(p[:n])
+	if errors.Is(err, io.EOF) && !c.verifier.Verified() {
+		return n, errors.New("the digest of the reconstructed blob does not match the digest of the chunk manifest")
+	}
+	return n, err
+}
+
+func (c *chunkedBlobReader) Close() error {
+	var err error
+	for _, file := range c.files {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
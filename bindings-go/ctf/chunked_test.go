@@ -0,0 +1,129 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("Chunked blobs", func() {
+
+	var (
+		fs vfs.FileSystem
+		ca *ctf.ComponentArchive
+	)
+
+	BeforeEach(func() {
+		fs = memoryfs.New()
+		ca = ctf.NewComponentArchive(newComponentArchive("example.com/a", "1.0.0").ComponentDescriptor, fs)
+	})
+
+	It("should store a blob in chunks and reconstruct it", func() {
+		data := make([]byte, 2500)
+		_, err := rand.Read(data)
+		Expect(err).ToNot(HaveOccurred())
+
+		info, err := ca.AddBlobChunked("application/octet-stream", bytes.NewReader(data), 1000, fs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Digest).To(Equal(digest.FromBytes(data).String()))
+		Expect(info.Size).To(Equal(int64(2500)))
+
+		for i, size := range []int64{1000, 1000, 500} {
+			fi, err := fs.Stat(ctf.BlobPath(ctf.ChunkedBlobPartName(info.Digest, i)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fi.Size()).To(Equal(size))
+		}
+		Expect(vfs.FileExists(fs, ctf.BlobPath(ctf.ChunkedBlobPartName(info.Digest, 3)))).To(BeFalse())
+		Expect(vfs.FileExists(fs, ctf.BlobPath(ctf.ChunkedBlobManifestName(info.Digest)))).To(BeTrue())
+
+		reader, err := ctf.ReadBlobChunked(ca, info.Digest)
+		Expect(err).ToNot(HaveOccurred())
+		defer reader.Close()
+		result, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(data))
+	})
+
+	It("should store a blob that is exactly the chunk size in a single chunk", func() {
+		data := []byte("0123456789")
+		info, err := ca.AddBlobChunked("text/plain", bytes.NewReader(data), 10, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vfs.FileExists(fs, ctf.BlobPath(ctf.ChunkedBlobPartName(info.Digest, 0)))).To(BeTrue())
+		Expect(vfs.FileExists(fs, ctf.BlobPath(ctf.ChunkedBlobPartName(info.Digest, 1)))).To(BeFalse())
+
+		reader, err := ctf.ReadBlobChunked(ca, info.Digest)
+		Expect(err).ToNot(HaveOccurred())
+		defer reader.Close()
+		result, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(data))
+	})
+
+	It("should detect corrupted chunks", func() {
+		info, err := ca.AddBlobChunked("text/plain", bytes.NewReader([]byte("0123456789")), 4, fs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vfs.WriteFile(fs, ctf.BlobPath(ctf.ChunkedBlobPartName(info.Digest, 1)), []byte("xxxx"), 0644)).To(Succeed())
+
+		reader, err := ctf.ReadBlobChunked(ca, info.Digest)
+		Expect(err).ToNot(HaveOccurred())
+		defer reader.Close()
+		_, err = io.ReadAll(reader)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject a manifest that describes another blob", func() {
+		info, err := ca.AddBlobChunked("text/plain", bytes.NewReader([]byte("0123456789")), 4, fs)
+		Expect(err).ToNot(HaveOccurred())
+		requested := digest.FromString("requested").String()
+		manifest, err := vfs.ReadFile(fs, ctf.BlobPath(ctf.ChunkedBlobManifestName(info.Digest)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vfs.WriteFile(fs, ctf.BlobPath(ctf.ChunkedBlobManifestName(requested)), manifest, 0644)).To(Succeed())
+
+		_, err = ctf.ReadBlobChunked(ca, requested)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject chunks with unexpected names", func() {
+		info, err := ca.AddBlobChunked("text/plain", bytes.NewReader([]byte("0123456789")), 4, fs)
+		Expect(err).ToNot(HaveOccurred())
+		manifest, err := json.Marshal(ctf.ChunkedBlobManifest{
+			Digest: info.Digest,
+			Size:   info.Size,
+			Chunks: []ctf.BlobChunk{{Name: "../" + ctf.ComponentDescriptorFileName, Size: info.Size}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vfs.WriteFile(fs, ctf.BlobPath(ctf.ChunkedBlobManifestName(info.Digest)), manifest, 0644)).To(Succeed())
+
+		_, err = ctf.ReadBlobChunked(ca, info.Digest)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return a not found error for unknown blobs", func() {
+		_, err := ctf.ReadBlobChunked(ca, digest.FromString("unknown").String())
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+})
@@ -16,6 +16,7 @@ package ctf
 
 import (
 	"archive/tar"
+	"bufio"
 	"bytes"
 	"compress/gzip"
 	"context"
@@ -23,7 +24,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/mandelsoft/vfs/pkg/memoryfs"
@@ -33,6 +36,7 @@ import (
 	"github.com/opencontainers/go-digest"
 
 	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/blob"
 	"github.com/gardener/component-spec/bindings-go/codec"
 )
 
@@ -83,6 +87,44 @@ func ComponentArchiveFromCTF(path string) (*ComponentArchive, error) {
 	return NewComponentArchiveFromTarReader(file)
 }
 
+// OpenComponentArchive opens the component archive at the given path and detects its format.
+// The archive is read as directory, as gzipped tar if it starts with the gzip magic bytes, or otherwise as plain tar.
+func OpenComponentArchive(path string) (*ComponentArchive, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get file info for %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return ComponentArchiveFromPath(path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open archive from %s: %w", path, err)
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	magic, err := reader.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("unable to read archive from %s: %w", path, err)
+	}
+	if bytes.Equal(magic, gzipMagic) {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open gzip reader for %s: %w", path, err)
+		}
+		return NewComponentArchiveFromTarReader(gzipReader)
+	}
+	ca, err := NewComponentArchiveFromTarReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%s is neither a directory, a gzipped tar nor a tar component archive: %w", path, err)
+	}
+	return ca, nil
+}
+
+// gzipMagic are the first bytes of gzip compressed data.
+var gzipMagic = []byte{0x1f, 0x8b}
+
 // NewComponentArchiveFromTarReader creates a new manifest builder from a input reader.
 // todo: make the fs configurable to also use a temporary filesystem
 func NewComponentArchiveFromTarReader(in io.Reader) (*ComponentArchive, error) {
@@ -106,6 +148,7 @@ func NewComponentArchiveFromFilesystem(fs vfs.FileSystem, decodeOpts ...codec.De
 	if err := codec.Decode(data, cd, decodeOpts...); err != nil {
 		return nil, fmt.Errorf("unable to parse component descriptor read from %s: %w", ComponentDescriptorFileName, err)
 	}
+	metrics.ComponentArchivesOpened.Add(1)
 
 	return &ComponentArchive{
 		ComponentDescriptor: cd,
@@ -289,6 +332,12 @@ func (ca *ComponentArchive) WriteTarGzip(writer io.Writer) error {
 
 // WriteTar tars the current components descriptor and its artifacts.
 func (ca *ComponentArchive) WriteTar(writer io.Writer) error {
+	return ca.writeTar(writer, time.Now())
+}
+
+// writeTar tars the current components descriptor and its artifacts with the given modification time for all entries.
+// Writing the same archive with the same modification time results in the same tar.
+func (ca *ComponentArchive) writeTar(writer io.Writer, modTime time.Time) error {
 	tw := tar.NewWriter(writer)
 
 	// write component descriptor
@@ -300,7 +349,7 @@ func (ca *ComponentArchive) WriteTar(writer io.Writer) error {
 		Name:    ComponentDescriptorFileName,
 		Size:    int64(len(cdBytes)),
 		Mode:    0644,
-		ModTime: time.Now(),
+		ModTime: modTime,
 	}
 
 	if err := tw.WriteHeader(cdHeader); err != nil {
@@ -315,7 +364,7 @@ func (ca *ComponentArchive) WriteTar(writer io.Writer) error {
 		Typeflag: tar.TypeDir,
 		Name:     BlobsDirectoryName,
 		Mode:     0644,
-		ModTime:  time.Now(),
+		ModTime:  modTime,
 	})
 	if err != nil {
 		return fmt.Errorf("unable to write blob directory: %w", err)
@@ -334,7 +383,7 @@ func (ca *ComponentArchive) WriteTar(writer io.Writer) error {
 			Name:    blobpath,
 			Size:    blobInfo.Size(),
 			Mode:    0644,
-			ModTime: time.Now(),
+			ModTime: modTime,
 		}
 		if err := tw.WriteHeader(header); err != nil {
 			return fmt.Errorf("unable to write blob header: %w", err)
@@ -410,6 +459,8 @@ func (ca *ComponentArchive) WriteToFilesystem(fs vfs.FileSystem, path string) er
 // "LocalFilesystemBlob" access types.
 type ComponentArchiveBlobResolver struct {
 	fs vfs.FileSystem
+	// autoDetectMediaType detects the media type of resolved blobs with an unspecific media type.
+	autoDetectMediaType bool
 }
 
 // NewComponentArchiveBlobResolver creates new ComponentArchive blob that can resolve local filesystem references.
@@ -450,6 +501,7 @@ func (ca *ComponentArchiveBlobResolver) Resolve(ctx context.Context, res v2.Reso
 	if err := file.Close(); err != nil {
 		return nil, err
 	}
+	metrics.BlobsResolved.Add(1)
 	return info, nil
 }
 
@@ -486,6 +538,15 @@ func (ca *ComponentArchiveBlobResolver) resolve(_ context.Context, res v2.Resour
 	if _, err := file.Seek(0, io.SeekStart); err != nil {
 		return nil, nil, fmt.Errorf("unable to reset file reader: %w", err)
 	}
+	if ca.autoDetectMediaType && blob.NeedsMediaTypeDetection(localFSAccess.MediaType) {
+		mediaType, _, err = blob.DetectMediaType(file, "")
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to detect media type of %s: %w", localFSAccess.Filename, err)
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, nil, fmt.Errorf("unable to reset file reader: %w", err)
+		}
+	}
 	return &BlobInfo{
 		MediaType: mediaType,
 		Digest:    dig.String(),
@@ -500,6 +561,17 @@ func BlobPath(name string) string {
 
 // ExtractTarToFs writes a tar stream to a filesystem.
 func ExtractTarToFs(fs vfs.FileSystem, in io.Reader) error {
+	return ExtractTarToFsWithProgress(fs, in, nil)
+}
+
+// ExtractTarToFsWithProgress writes a tar stream to a filesystem and reports the progress for every tar entry.
+// The progress function is called with a bytesWritten of -1 before an entry is written
+// and with the actual number of written bytes after the entry has been written.
+// A nil progress function disables the reporting.
+func ExtractTarToFsWithProgress(fs vfs.FileSystem, in io.Reader, progress func(entry string, bytesWritten int64)) error {
+	if progress == nil {
+		progress = func(string, int64) {}
+	}
 	tr := tar.NewReader(in)
 	for {
 		header, err := tr.Next()
@@ -510,6 +582,8 @@ func ExtractTarToFs(fs vfs.FileSystem, in io.Reader) error {
 			return err
 		}
 
+		progress(header.Name, -1)
+		var n int64
 		switch header.Typeflag {
 		case tar.TypeDir:
 			if err := fs.MkdirAll(header.Name, os.FileMode(header.Mode)); err != nil {
@@ -520,12 +594,55 @@ func ExtractTarToFs(fs vfs.FileSystem, in io.Reader) error {
 			if err != nil {
 				return fmt.Errorf("unable to open file %s: %w", header.Name, err)
 			}
-			if _, err := io.Copy(file, tr); err != nil {
+			n, err = io.Copy(file, tr)
+			if err != nil {
 				return fmt.Errorf("unable to copy tar file to filesystem: %w", err)
 			}
 			if err := file.Close(); err != nil {
 				return fmt.Errorf("unable to close file %s: %w", header.Name, err)
 			}
+		case tar.TypeSymlink:
+			if err := validateDeduplicationRecord(header); err != nil {
+				return err
+			}
+			if err := fs.Symlink(header.Linkname, header.Name); err != nil {
+				return fmt.Errorf("unable to create symlink %s: %w", header.Name, err)
+			}
+		}
+		progress(header.Name, n)
+	}
+}
+
+// validateDeduplicationRecord checks that a symlink of a tar is a de-duplication record,
+// i.e. a blob that links to a shared blob of the ctf (see SharedBlobPath).
+// All other symlinks are rejected as they might point outside of the extracted filesystem.
+func validateDeduplicationRecord(header *tar.Header) error {
+	name := strings.TrimPrefix(header.Name, "/")
+	if path.Clean(name) != name || path.Dir(name) != BlobsDirectoryName {
+		return fmt.Errorf("symlink %q is not a blob", header.Name)
+	}
+	dig := path.Base(header.Linkname)
+	if dig == "." || dig == ".." || dig == "/" || header.Linkname != SharedBlobPath(dig) {
+		return fmt.Errorf("symlink %q links to %q which is not a shared blob", header.Name, header.Linkname)
+	}
+	return nil
+}
+
+// EstimateTarSize returns the total uncompressed size of all regular files of a tar stream.
+// Only the tar headers are read, the file contents are skipped.
+func EstimateTarSize(r io.ReadSeeker) (int64, error) {
+	tr := tar.NewReader(r)
+	var size int64
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return size, nil
+			}
+			return 0, fmt.Errorf("unable to read tar header: %w", err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			size += header.Size
 		}
 	}
 }
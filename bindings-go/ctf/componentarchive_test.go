@@ -0,0 +1,85 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("open component archive", func() {
+
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "component-archive-")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	// writeArchive writes the component archive to a file without extension using the given write function.
+	writeArchive := func(write func(ca *ctf.ComponentArchive, file *os.File) error) string {
+		path := filepath.Join(dir, "archive")
+		file, err := os.Create(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(write(newComponentArchive("example.com/comp", "1.0.0"), file)).To(Succeed())
+		Expect(file.Close()).To(Succeed())
+		return path
+	}
+
+	It("should open a tar archive", func() {
+		path := writeArchive(func(ca *ctf.ComponentArchive, file *os.File) error {
+			return ca.WriteTar(file)
+		})
+		ca, err := ctf.OpenComponentArchive(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ca.ComponentDescriptor.GetName()).To(Equal("example.com/comp"))
+	})
+
+	It("should open a gzipped tar archive", func() {
+		path := writeArchive(func(ca *ctf.ComponentArchive, file *os.File) error {
+			return ca.WriteTarGzip(file)
+		})
+		ca, err := ctf.OpenComponentArchive(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ca.ComponentDescriptor.GetName()).To(Equal("example.com/comp"))
+	})
+
+	It("should open a directory", func() {
+		path := filepath.Join(dir, "archive")
+		Expect(newComponentArchive("example.com/comp", "1.0.0").WriteToFilesystem(osfs.New(), path)).To(Succeed())
+		ca, err := ctf.OpenComponentArchive(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ca.ComponentDescriptor.GetName()).To(Equal("example.com/comp"))
+	})
+
+	It("should reject files that are no archives", func() {
+		path := filepath.Join(dir, "archive")
+		Expect(os.WriteFile(path, []byte("no archive"), os.ModePerm)).To(Succeed())
+		_, err := ctf.OpenComponentArchive(path)
+		Expect(err).To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,56 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// FindComponentByConstraint returns the component archive of the component with the given name
+// whose version is the highest version that satisfies the given semver constraint, e.g. ">=1.1.0 <2.0.0".
+// Archives whose version is no semver version are ignored.
+// A NotFoundError is returned if no version of the component satisfies the constraint.
+func (ctf *CTF) FindComponentByConstraint(name, constraint string) (*ComponentArchive, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse version constraint %q: %w", constraint, err)
+	}
+	var (
+		highest *semver.Version
+		found   *ComponentArchive
+	)
+	err = ctf.Walk(func(ca *ComponentArchive) error {
+		if ca.ComponentDescriptor.GetName() != name {
+			return nil
+		}
+		v, err := semver.NewVersion(ca.ComponentDescriptor.GetVersion())
+		if err != nil {
+			return nil
+		}
+		if c.Check(v) && (highest == nil || v.GreaterThan(highest)) {
+			highest, found = v, ca
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no version of %q satisfies %q: %w", name, constraint, NotFoundError)
+	}
+	return found, nil
+}
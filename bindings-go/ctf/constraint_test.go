@@ -0,0 +1,67 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"errors"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("find component by constraint", func() {
+
+	var c *ctf.CTF
+
+	BeforeEach(func() {
+		c = newEmptyCTF(memoryfs.New(), "/ctf.tar")
+		for _, version := range []string{"1.0.0", "1.2.0", "2.0.0"} {
+			Expect(c.AddComponentArchiveWithName("comp-"+version, newComponentArchive("example.com/comp", version), ctf.ArchiveFormatTar)).To(Succeed())
+		}
+		Expect(c.AddComponentArchive(newComponentArchive("example.com/other", "1.5.0"), ctf.ArchiveFormatTar)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(c.Close()).To(Succeed())
+	})
+
+	It("should return the highest version that satisfies the constraint", func() {
+		ca, err := c.FindComponentByConstraint("example.com/comp", ">=1.1.0 <2.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ca.ComponentDescriptor.GetName()).To(Equal("example.com/comp"))
+		Expect(ca.ComponentDescriptor.GetVersion()).To(Equal("1.2.0"))
+
+		ca, err = c.FindComponentByConstraint("example.com/comp", ">=1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ca.ComponentDescriptor.GetVersion()).To(Equal("2.0.0"))
+	})
+
+	It("should return a not found error if no version satisfies the constraint", func() {
+		_, err := c.FindComponentByConstraint("example.com/comp", ">=3.0.0")
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+		_, err = c.FindComponentByConstraint("example.com/unknown", ">=1.0.0")
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+	It("should fail for invalid constraints", func() {
+		_, err := c.FindComponentByConstraint("example.com/comp", "invalid")
+		Expect(err).To(HaveOccurred())
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeFalse())
+	})
+
+})
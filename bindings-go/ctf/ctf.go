@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/mandelsoft/vfs/pkg/projectionfs"
 	"github.com/mandelsoft/vfs/pkg/vfs"
@@ -95,11 +97,24 @@ type CTF struct {
 	ctfPath string
 	tempDir string
 	tempFs vfs.FileSystem
+	opts CTFOptions
+	expiry ctfExpiry
+
+	// mux guards the extracted content of the ctf in the temporary filesystem.
+	mux sync.RWMutex
+	// dirty is true if the extracted content contains changes that have not been written.
+	dirty bool
 }
 
 // NewCTF reads a CTF archive from a file.
 // The use should call "Close" to remove all temporary files
 func NewCTF(fs vfs.FileSystem, ctfPath string) (*CTF, error) {
+	return OpenCTF(fs, ctfPath)
+}
+
+// OpenCTF opens a CTF archive from a file.
+// The user should call "Close" to remove all temporary files
+func OpenCTF(fs vfs.FileSystem, ctfPath string, opts ...CTFOption) (*CTF, error) {
 	tempDir, err := vfs.TempDir(fs, "", "ctf-")
 	if err != nil {
 		return nil, err
@@ -115,36 +130,74 @@ func NewCTF(fs vfs.FileSystem, ctfPath string) (*CTF, error) {
 		tempDir: tempDir,
 		tempFs:  tempFs,
 	}
+	ctf.opts.ApplyOptions(opts)
 	if err := ctf.extract(); err != nil {
 		return nil, fmt.Errorf("unable to read ctf: %w", err)
 	}
+	ctf.startTimeout()
+	metrics.CTFOpens.Add(1)
 	return ctf, nil
 }
 
 type WalkFunc = func(ca *ComponentArchive) error
 
 // Walk traverses through all component archives that are included in the ctf.
+// The walk function is called without holding a lock of the ctf, so it may access the ctf itself.
+// Component archives that are removed during the walk, e.g. by a reload of a watched ctf, are skipped.
 func (ctf *CTF) Walk(walkFunc WalkFunc) error {
-	err := vfs.Walk(ctf.tempFs, "/", func(path string, info os.FileInfo, err error) error {
+	ctf.mux.RLock()
+	archives, err := ctf.listArchiveFiles()
+	ctf.mux.RUnlock()
+	if err != nil {
+		return err
+	}
+	metrics.CTFWalks.Add(1)
+	for _, path := range archives {
+		ca, err := ctf.readArchive(path)
 		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
 			return err
 		}
-		if info.IsDir() {
-			return nil
+		if err := walkFunc(ca); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		file, err := ctf.tempFs.Open(path)
-		if err != nil {
-			return fmt.Errorf("unable to read component archive file %q: %w", path, err)
-		}
-		ca, err := NewComponentArchiveFromTarReader(file)
-		if err != nil {
+// readArchive reads the component archive at the given path while holding the read lock of the ctf.
+func (ctf *CTF) readArchive(path string) (*ComponentArchive, error) {
+	ctf.mux.RLock()
+	defer ctf.mux.RUnlock()
+	if err := ctf.checkExpired(); err != nil {
+		return nil, err
+	}
+	return ctf.readComponentArchive(path)
+}
+
+// GetAllComponentDescriptors returns the component descriptors of all component archives that are included in the ctf.
+// The blobs of the component archives are not returned.
+func (ctf *CTF) GetAllComponentDescriptors() ([]*v2.ComponentDescriptor, error) {
+	return ctf.GetAllComponentDescriptorsContext(context.Background())
+}
+
+// GetAllComponentDescriptorsContext returns the component descriptors of all component archives that are included in the ctf.
+// The traversal is aborted if the given context is canceled.
+func (ctf *CTF) GetAllComponentDescriptorsContext(ctx context.Context) ([]*v2.ComponentDescriptor, error) {
+	cds := make([]*v2.ComponentDescriptor, 0)
+	err := ctf.Walk(func(ca *ComponentArchive) error {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
-
-		return walkFunc(ca)
+		cds = append(cds, ca.ComponentDescriptor)
+		return nil
 	})
-	return err
+	if err != nil {
+		return nil, fmt.Errorf("unable to read component descriptors: %w", err)
+	}
+	return cds, nil
 }
 
 // AddComponentArchive adds or updates a component archive in the ctf archive.
@@ -158,11 +211,21 @@ func (ctf *CTF) AddComponentArchive(ca *ComponentArchive, format ArchiveFormat)
 
 // AddComponentArchiveWithName adds or updates a component archive in the ctf archive.
 // The archive is added to the ctf with the given name
+// An ErrCTFSizeLimitExceeded error is returned before the archive is written if it would exceed the configured maximum size.
 func (ctf *CTF) AddComponentArchiveWithName(filename string, ca *ComponentArchive, format ArchiveFormat) error {
+	ctf.mux.Lock()
+	defer ctf.mux.Unlock()
+	if err := ctf.resetTimeout(); err != nil {
+		return err
+	}
+	if err := ctf.checkSizeLimit(filename, ca); err != nil {
+		return err
+	}
 	file, err := ctf.tempFs.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
 	if err != nil {
 		return err
 	}
+	ctf.dirty = true
 	switch format {
 	case ArchiveFormatTar:
 		if err := ca.WriteTar(file); err != nil {
@@ -182,6 +245,7 @@ func (ctf *CTF) AddComponentArchiveWithName(filename string, ca *ComponentArchiv
 }
 
 // extract untars the given ctf archive to the tmp directory.
+// The caller has to hold the lock of the ctf.
 func (ctf *CTF) extract() error {
 	file, err := ctf.fs.Open(ctf.ctfPath)
 	if err != nil {
@@ -192,53 +256,94 @@ func (ctf *CTF) extract() error {
 }
 
 // Write writes the current changes back to the original ctf.
+// A checksum manifest of the ctf is written as last entry so that it can be read after all other files.
 func (ctf *CTF) Write() error {
+	ctf.mux.Lock()
+	defer ctf.mux.Unlock()
+	if err := ctf.resetTimeout(); err != nil {
+		return err
+	}
 	file, err := ctf.fs.OpenFile(ctf.ctfPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
-	tw := tar.NewWriter(file)
+	tw := tar.NewWriter(&countingWriter{Writer: file, counter: &metrics.BytesWritten})
 	defer tw.Close()
+	metrics.CTFWrites.Add(1)
 
 	err = vfs.Walk(ctf.tempFs, "/", func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if info.IsDir() {
+		if path == "/" || path == checksumManifestPath {
 			return nil
 		}
-		header, err := tar.FileInfoHeader(info, "")
-		if err != nil {
-			return err
-		}
-		if err := tw.WriteHeader(header); err != nil {
-			return fmt.Errorf("unable to write header for %q: %w", path, err)
-		}
+		return ctf.writeTarEntry(tw, path, info)
+	})
+	if err != nil {
+		return err
+	}
 
-		blob, err := ctf.tempFs.Open(path)
-		if err != nil {
-			return fmt.Errorf("unable to open blob %q: %w", path, err)
-		}
-		defer blob.Close()
-		if _, err := io.Copy(tw, blob); err != nil {
-			return fmt.Errorf("unable to write blob %q: %w", path, err)
+	info, err := ctf.tempFs.Stat(checksumManifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			ctf.dirty = false
+			return nil
 		}
+		return err
+	}
+	if err := ctf.writeTarEntry(tw, checksumManifestPath, info); err != nil {
+		return err
+	}
+	ctf.dirty = false
+	return nil
+}
+
+// writeTarEntry writes the file or directory of the temporary ctf filesystem to the tar.
+func (ctf *CTF) writeTarEntry(tw *tar.Writer, path string, info os.FileInfo) error {
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	// keep the directory structure, e.g. for shared blobs
+	header.Name = strings.TrimPrefix(path, "/")
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("unable to write header for %q: %w", path, err)
+	}
+	if info.IsDir() {
 		return nil
-	})
-	return err
+	}
+
+	blob, err := ctf.tempFs.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open blob %q: %w", path, err)
+	}
+	defer blob.Close()
+	if _, err := io.Copy(tw, blob); err != nil {
+		return fmt.Errorf("unable to write blob %q: %w", path, err)
+	}
+	return nil
 }
 
 // Close closes the CTF that deletes all temporary files
+// The temporary files of an expired ctf have already been deleted.
 func (ctf *CTF) Close() error {
+	ctf.mux.Lock()
+	defer ctf.mux.Unlock()
+	if expired := ctf.stopTimeout(); expired {
+		return nil
+	}
 	return ctf.fs.RemoveAll(ctf.tempDir)
 }
 
 // AggregatedBlobResolver combines multiple blob resolver.
 // Is automatically picks the right resolver based on the resolvers type information.
-// If multiple resolvers match, the first matching resolver is used.
+// If multiple resolvers match, the first matching resolver is used
+// unless a type override is set for the access type of the resource.
 type AggregatedBlobResolver struct {
-	resolver []TypedBlobResolver
+	resolver  []TypedBlobResolver
+	overrides map[string]TypedBlobResolver
 }
 
 var _ BlobResolver = &AggregatedBlobResolver{}
@@ -270,6 +375,28 @@ func (a *AggregatedBlobResolver) Add(resolvers ...BlobResolver) error {
 	return nil
 }
 
+// SetTypeOverride forces the given resolver to be used for all resources with the given access type
+// regardless of the CanResolve result of the registered resolvers.
+// An already existing override for the access type is replaced.
+func (a *AggregatedBlobResolver) SetTypeOverride(accessType string, resolver TypedBlobResolver) error {
+	if len(accessType) == 0 {
+		return errors.New("an access type must be defined for a type override")
+	}
+	if resolver == nil {
+		return fmt.Errorf("no resolver is defined for the type override of %q", accessType)
+	}
+	if a.overrides == nil {
+		a.overrides = map[string]TypedBlobResolver{}
+	}
+	a.overrides[accessType] = resolver
+	return nil
+}
+
+// ClearTypeOverride removes the type override for the given access type.
+func (a *AggregatedBlobResolver) ClearTypeOverride(accessType string) {
+	delete(a.overrides, accessType)
+}
+
 func (a *AggregatedBlobResolver) Info(ctx context.Context, res v2.Resource) (*BlobInfo, error) {
 	resolver, err := a.getResolver(res)
 	if err != nil {
@@ -291,6 +418,9 @@ func (a *AggregatedBlobResolver) getResolver(res v2.Resource) (BlobResolver, err
 		return nil, fmt.Errorf("no access is defined")
 	}
 
+	if resolver, ok := a.overrides[res.Access.GetType()]; ok {
+		return resolver, nil
+	}
 	for _, resolver := range a.resolver {
 		if resolver.CanResolve(res) {
 			return resolver, nil
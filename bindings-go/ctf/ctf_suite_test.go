@@ -15,6 +15,7 @@
 package ctf_test
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
 	"io"
@@ -98,6 +99,65 @@ var _ = Describe("ComponentArchive", func() {
 		Expect(blobData).To(Equal([]byte("{\"some\": \"data\"}")))
 	})
 
+	It("should only extract symlinks that are de-duplication records", func() {
+		extractSymlink := func(name, target string) error {
+			var data bytes.Buffer
+			tw := tar.NewWriter(&data)
+			Expect(tw.WriteHeader(&tar.Header{Typeflag: tar.TypeSymlink, Name: name, Linkname: target})).To(Succeed())
+			Expect(tw.Close()).To(Succeed())
+			fs := memoryfs.New()
+			Expect(fs.MkdirAll(ctf.BlobsDirectoryName, os.ModePerm)).To(Succeed())
+			return ctf.ExtractTarToFs(fs, &data)
+		}
+		Expect(extractSymlink("blobs/sha256:00", ctf.SharedBlobPath("sha256:00"))).To(Succeed())
+
+		Expect(extractSymlink("blobs/sha256:00", "/etc/passwd")).ToNot(Succeed())
+		Expect(extractSymlink("blobs/sha256:00", "../../etc/passwd")).ToNot(Succeed())
+		Expect(extractSymlink("blobs/sha256:00", "/blobs/../etc/passwd")).ToNot(Succeed())
+		Expect(extractSymlink("blobs/sha256:00", "/blobs/")).ToNot(Succeed())
+		Expect(extractSymlink("component-descriptor.yaml", ctf.SharedBlobPath("sha256:00"))).ToNot(Succeed())
+		Expect(extractSymlink("blobs/../../sha256:00", ctf.SharedBlobPath("sha256:00"))).ToNot(Succeed())
+	})
+
+	It("should report the extraction progress for every tar entry", func() {
+		ca, err := ctf.ComponentArchiveFromPath("./testdata/component-01")
+		Expect(err).ToNot(HaveOccurred())
+
+		var data bytes.Buffer
+		Expect(ca.WriteTar(&data)).To(Succeed())
+		entries := 0
+		tr := tar.NewReader(bytes.NewReader(data.Bytes()))
+		for {
+			_, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).ToNot(HaveOccurred())
+			entries++
+		}
+
+		var (
+			started  int
+			finished int
+			written  int64
+		)
+		fs := memoryfs.New()
+		Expect(ctf.ExtractTarToFsWithProgress(fs, bytes.NewReader(data.Bytes()), func(entry string, n int64) {
+			if n == -1 {
+				started++
+				return
+			}
+			finished++
+			written += n
+		})).To(Succeed())
+		Expect(started).To(Equal(entries))
+		Expect(finished).To(Equal(entries))
+
+		size, err := ctf.EstimateTarSize(bytes.NewReader(data.Bytes()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(size).To(Equal(written))
+	})
+
 	It("should add a resource to the component archive from a data reader", func() {
 		ctx := context.Background()
 		defer ctx.Done()
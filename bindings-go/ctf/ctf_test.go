@@ -0,0 +1,153 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("GetAllComponentDescriptors", func() {
+
+	var c *ctf.CTF
+
+	BeforeEach(func() {
+		c = newEmptyCTF(memoryfs.New(), "/ctf.tar")
+		ca, err := ctf.ComponentArchiveFromPath("./testdata/component-01")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(c.AddComponentArchive(ca, ctf.ArchiveFormatTar)).To(Succeed())
+		Expect(c.AddComponentArchive(newComponentArchive("example.com/comp-1", "1.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(c.Close()).To(Succeed())
+	})
+
+	It("should return all component descriptors of the ctf", func() {
+		cds, err := c.GetAllComponentDescriptors()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cds).To(HaveLen(2))
+
+		versions := map[string]string{}
+		for _, cd := range cds {
+			versions[cd.Name] = cd.Version
+		}
+		Expect(versions).To(Equal(map[string]string{
+			"github.com/gardener/gardener": "v1.7.2",
+			"example.com/comp-1":           "1.0.0",
+		}))
+		for _, cd := range cds {
+			if cd.Name == "github.com/gardener/gardener" {
+				Expect(cd.Resources).To(HaveLen(1))
+				Expect(cd.Resources[0].Name).To(Equal("artifact"))
+			}
+		}
+	})
+
+	It("should abort if the context is canceled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := c.GetAllComponentDescriptorsContext(ctx)
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+	})
+})
+
+// namedTypedResolver is a typed blob resolver that records its calls.
+type namedTypedResolver struct {
+	name       string
+	accessType string
+	calls      int
+}
+
+func (r *namedTypedResolver) CanResolve(res v2.Resource) bool {
+	return res.Access != nil && res.Access.GetType() == r.accessType
+}
+
+func (r *namedTypedResolver) Info(_ context.Context, _ v2.Resource) (*ctf.BlobInfo, error) {
+	r.calls++
+	return &ctf.BlobInfo{MediaType: r.name}, nil
+}
+
+func (r *namedTypedResolver) Resolve(ctx context.Context, res v2.Resource, _ io.Writer) (*ctf.BlobInfo, error) {
+	return r.Info(ctx, res)
+}
+
+var _ = Describe("AggregatedBlobResolver", func() {
+
+	var (
+		slow, fast *namedTypedResolver
+		agg        *ctf.AggregatedBlobResolver
+		res        v2.Resource
+	)
+
+	BeforeEach(func() {
+		slow = &namedTypedResolver{name: "slow", accessType: v2.OCIRegistryType}
+		fast = &namedTypedResolver{name: "fast", accessType: v2.OCIRegistryType}
+		var err error
+		agg, err = ctf.NewAggregatedBlobResolver(slow, fast)
+		Expect(err).ToNot(HaveOccurred())
+
+		access, err := v2.NewUnstructured(v2.NewOCIRegistryAccess("example.com/image:1.0.0"))
+		Expect(err).ToNot(HaveOccurred())
+		res = v2.Resource{Access: &access}
+	})
+
+	It("should use the first matching resolver", func() {
+		info, err := agg.Info(context.TODO(), res)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.MediaType).To(Equal("slow"))
+	})
+
+	It("should use the override resolver for the access type", func() {
+		Expect(agg.SetTypeOverride(v2.OCIRegistryType, fast)).To(Succeed())
+
+		info, err := agg.Resolve(context.TODO(), res, io.Discard)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.MediaType).To(Equal("fast"))
+		Expect(fast.calls).To(Equal(1))
+		Expect(slow.calls).To(Equal(0))
+	})
+
+	It("should use the override resolver even if it cannot resolve the resource", func() {
+		other := &namedTypedResolver{name: "other", accessType: v2.WebType}
+		Expect(agg.SetTypeOverride(v2.OCIRegistryType, other)).To(Succeed())
+
+		info, err := agg.Info(context.TODO(), res)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.MediaType).To(Equal("other"))
+	})
+
+	It("should use the matching resolvers again after the override is cleared", func() {
+		Expect(agg.SetTypeOverride(v2.OCIRegistryType, fast)).To(Succeed())
+		agg.ClearTypeOverride(v2.OCIRegistryType)
+
+		info, err := agg.Info(context.TODO(), res)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.MediaType).To(Equal("slow"))
+	})
+
+	It("should reject invalid overrides", func() {
+		Expect(agg.SetTypeOverride("", fast)).ToNot(Succeed())
+		Expect(agg.SetTypeOverride(v2.OCIRegistryType, nil)).ToNot(Succeed())
+	})
+})
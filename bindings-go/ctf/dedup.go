@@ -0,0 +1,341 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+)
+
+// SharedBlobsDirectoryName is the name of the directory in the ctf root that contains blobs
+// that are shared by multiple component archives.
+const SharedBlobsDirectoryName = "blobs"
+
+// SharedBlobPath returns the path of a shared blob in the ctf for a given digest.
+func SharedBlobPath(dig string) string {
+	return filepath.Join("/", SharedBlobsDirectoryName, dig)
+}
+
+// DeduplicateCTFBlobs moves all blobs that are stored by multiple component archives into the shared blob directory of the ctf.
+// The blobs in the component archives are replaced by de-duplication records (symlinks that point to the shared blob),
+// that are transparently resolved when a component archive is read from the ctf.
+// The number of bytes that are saved by the de-duplication is returned.
+func DeduplicateCTFBlobs(ctf *CTF) (saved int64, err error) {
+	ctf.mux.Lock()
+	defer ctf.mux.Unlock()
+	archives, err := ctf.listArchiveFiles()
+	if err != nil {
+		return 0, err
+	}
+
+	type blobOccurrence struct {
+		archive string
+		size    int64
+	}
+	occurrences := map[string][]blobOccurrence{}
+	for _, archive := range archives {
+		err := ctf.forEachArchiveBlob(archive, func(header *tar.Header, content io.Reader) error {
+			dig, err := digest.FromReader(content)
+			if err != nil {
+				return fmt.Errorf("unable to compute digest of blob %q in %q: %w", header.Name, archive, err)
+			}
+			occurrences[dig.String()] = append(occurrences[dig.String()], blobOccurrence{
+				archive: archive,
+				size:    header.Size,
+			})
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// collect all blobs that are either stored multiple times or are already part of the shared blobs.
+	shared := map[string]struct{}{}
+	affectedArchives := map[string]struct{}{}
+	for dig, occ := range occurrences {
+		exists, err := vfs.Exists(ctf.tempFs, SharedBlobPath(dig))
+		if err != nil {
+			return 0, err
+		}
+		if len(occ) < 2 && !exists {
+			continue
+		}
+		shared[dig] = struct{}{}
+		for _, o := range occ {
+			affectedArchives[o.archive] = struct{}{}
+			saved += o.size
+		}
+		if !exists {
+			// one copy is kept in the shared blob directory
+			saved -= occ[0].size
+		}
+	}
+
+	for _, archive := range archives {
+		if _, ok := affectedArchives[archive]; !ok {
+			continue
+		}
+		ctf.dirty = true
+		if err := ctf.deduplicateArchive(archive, shared); err != nil {
+			return 0, err
+		}
+	}
+	return saved, nil
+}
+
+// listArchiveFiles returns the paths of all component archives in the ctf.
+// The caller has to hold the lock of the ctf.
+func (ctf *CTF) listArchiveFiles() ([]string, error) {
+	if err := ctf.checkExpired(); err != nil {
+		return nil, err
+	}
+	archives := make([]string, 0)
+	err := vfs.Walk(ctf.tempFs, "/", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || isSharedBlobPath(path) || path == checksumManifestPath {
+			return nil
+		}
+		archives = append(archives, path)
+		return nil
+	})
+	return archives, err
+}
+
+// forEachArchiveBlob calls the given function for every regular blob file of the component archive.
+func (ctf *CTF) forEachArchiveBlob(archive string, f func(header *tar.Header, content io.Reader) error) error {
+	file, err := ctf.tempFs.Open(archive)
+	if err != nil {
+		return fmt.Errorf("unable to open component archive %q: %w", archive, err)
+	}
+	defer file.Close()
+
+	tr := tar.NewReader(file)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("unable to read component archive %q: %w", archive, err)
+		}
+		if header.Typeflag != tar.TypeReg || !isBlobPath(header.Name) {
+			continue
+		}
+		if err := f(header, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// deduplicateArchive rewrites the component archive and replaces all given shared blobs with de-duplication records.
+func (ctf *CTF) deduplicateArchive(archive string, shared map[string]struct{}) error {
+	tmpPath := archive + ".dedup"
+	in, err := ctf.tempFs.Open(archive)
+	if err != nil {
+		return fmt.Errorf("unable to open component archive %q: %w", archive, err)
+	}
+	defer in.Close()
+	out, err := ctf.tempFs.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("unable to open file %q: %w", tmpPath, err)
+	}
+	defer out.Close()
+
+	tr := tar.NewReader(in)
+	tw := tar.NewWriter(out)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("unable to read component archive %q: %w", archive, err)
+		}
+		if header.Typeflag != tar.TypeReg || !isBlobPath(header.Name) {
+			if err := copyTarEntry(tw, header, tr); err != nil {
+				return fmt.Errorf("unable to copy %q of component archive %q: %w", header.Name, archive, err)
+			}
+			continue
+		}
+
+		// the content has to be buffered as the digest is only known after the blob has been read completely.
+		blobFs := memoryfs.New()
+		blob, err := blobFs.Create("blob")
+		if err != nil {
+			return err
+		}
+		dig, err := digest.FromReader(io.TeeReader(tr, blob))
+		if err != nil {
+			return fmt.Errorf("unable to compute digest of blob %q in %q: %w", header.Name, archive, err)
+		}
+		if _, err := blob.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+
+		if _, ok := shared[dig.String()]; !ok {
+			if err := copyTarEntry(tw, header, blob); err != nil {
+				return fmt.Errorf("unable to copy %q of component archive %q: %w", header.Name, archive, err)
+			}
+			continue
+		}
+		if err := ctf.storeSharedBlob(dig.String(), blob); err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeSymlink,
+			Name:     header.Name,
+			Linkname: SharedBlobPath(dig.String()),
+			Mode:     header.Mode,
+			ModTime:  header.ModTime,
+		}); err != nil {
+			return fmt.Errorf("unable to write de-duplication record for %q: %w", header.Name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("unable to close tar writer: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("unable to close file %q: %w", tmpPath, err)
+	}
+	if err := in.Close(); err != nil {
+		return fmt.Errorf("unable to close component archive %q: %w", archive, err)
+	}
+	if err := ctf.tempFs.Remove(archive); err != nil {
+		return fmt.Errorf("unable to remove component archive %q: %w", archive, err)
+	}
+	return ctf.tempFs.Rename(tmpPath, archive)
+}
+
+// storeSharedBlob writes the blob with the given digest to the shared blob directory if it does not exist yet.
+func (ctf *CTF) storeSharedBlob(dig string, content io.Reader) error {
+	blobpath := SharedBlobPath(dig)
+	exists, err := vfs.Exists(ctf.tempFs, blobpath)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	if err := ctf.tempFs.MkdirAll(filepath.Dir(blobpath), os.ModePerm); err != nil {
+		return fmt.Errorf("unable to create shared blob directory: %w", err)
+	}
+	file, err := ctf.tempFs.OpenFile(blobpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("unable to open shared blob %q: %w", blobpath, err)
+	}
+	if _, err := io.Copy(file, content); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("unable to write shared blob %q: %w", blobpath, err)
+	}
+	return file.Close()
+}
+
+// readComponentArchive reads the component archive at the given path of the ctf.
+// De-duplication records are replaced by the referenced shared blobs.
+func (ctf *CTF) readComponentArchive(path string) (*ComponentArchive, error) {
+	file, err := ctf.tempFs.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read component archive file %q: %w", path, err)
+	}
+	defer file.Close()
+
+	fs := memoryfs.New()
+	if err := ExtractTarToFs(fs, file); err != nil {
+		return nil, fmt.Errorf("unable to extract tar: %w", err)
+	}
+	if err := ctf.resolveSharedBlobs(fs); err != nil {
+		return nil, fmt.Errorf("unable to resolve shared blobs of %q: %w", path, err)
+	}
+	ca, err := NewComponentArchiveFromFilesystem(fs)
+	if err != nil {
+		return nil, err
+	}
+	if resolver, ok := ca.BlobResolver.(*ComponentArchiveBlobResolver); ok {
+		resolver.autoDetectMediaType = ctf.opts.AutoDetectMediaType
+	}
+	return ca, nil
+}
+
+// resolveSharedBlobs replaces all de-duplication records in the blob directory of the component archive filesystem
+// with the content of the shared blob.
+func (ctf *CTF) resolveSharedBlobs(fs vfs.FileSystem) error {
+	dir, err := fs.Open(BlobsDirectoryName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	names, err := dir.Readdirnames(-1)
+	_ = dir.Close()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		blobpath := BlobPath(name)
+		info, err := fs.Lstat(blobpath)
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			continue
+		}
+		target, err := fs.Readlink(blobpath)
+		if err != nil {
+			return err
+		}
+		if !isSharedBlobPath(target) {
+			return fmt.Errorf("blob %q links to %q which is not a shared blob", name, target)
+		}
+		data, err := vfs.ReadFile(ctf.tempFs, target)
+		if err != nil {
+			return fmt.Errorf("unable to read shared blob %q: %w", target, err)
+		}
+		if err := fs.Remove(blobpath); err != nil {
+			return err
+		}
+		if err := vfs.WriteFile(fs, blobpath, data, os.ModePerm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyTarEntry(tw *tar.Writer, header *tar.Header, content io.Reader) error {
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.Copy(tw, content)
+	return err
+}
+
+func isSharedBlobPath(path string) bool {
+	return strings.HasPrefix(path, filepath.Join("/", SharedBlobsDirectoryName)+"/")
+}
+
+func isBlobPath(name string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(name, "/"), BlobsDirectoryName+"/")
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("Deduplication", func() {
+
+	var (
+		fs         vfs.FileSystem
+		sharedData = []byte("shared blob data")
+		uniqueData = []byte("unique blob data")
+	)
+
+	addResource := func(ca *ctf.ComponentArchive, name string, data []byte) {
+		res := &v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:    name,
+				Version: "1.0.0",
+				Type:    "txt",
+			},
+			Relation: v2.ExternalRelation,
+		}
+		Expect(ca.AddResource(res, ctf.BlobInfo{
+			MediaType: "txt",
+			Digest:    digest.FromBytes(data).String(),
+			Size:      int64(len(data)),
+		}, bytes.NewBuffer(data))).To(Succeed())
+	}
+
+	expectResolvableBlobs := func(c *ctf.CTF) {
+		resolved := 0
+		Expect(c.Walk(func(ca *ctf.ComponentArchive) error {
+			for _, res := range ca.ComponentDescriptor.Resources {
+				var data bytes.Buffer
+				_, err := ca.Resolve(context.TODO(), res, &data)
+				Expect(err).ToNot(HaveOccurred())
+				if res.Name == "shared" {
+					Expect(data.Bytes()).To(Equal(sharedData))
+				} else {
+					Expect(data.Bytes()).To(Equal(uniqueData))
+				}
+				resolved++
+			}
+			return nil
+		})).To(Succeed())
+		Expect(resolved).To(Equal(3))
+	}
+
+	BeforeEach(func() {
+		fs = memoryfs.New()
+	})
+
+	It("should store blobs that are used by multiple component archives only once", func() {
+		c := newEmptyCTF(fs, "/ctf.tar")
+		defer c.Close()
+
+		ca1 := newComponentArchive("example.com/comp-1", "1.0.0")
+		addResource(ca1, "shared", sharedData)
+		addResource(ca1, "unique", uniqueData)
+		ca2 := newComponentArchive("example.com/comp-2", "1.0.0")
+		addResource(ca2, "shared", sharedData)
+		Expect(c.AddComponentArchive(ca1, ctf.ArchiveFormatTar)).To(Succeed())
+		Expect(c.AddComponentArchive(ca2, ctf.ArchiveFormatTar)).To(Succeed())
+
+		saved, err := ctf.DeduplicateCTFBlobs(c)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(saved).To(Equal(int64(len(sharedData))))
+		expectResolvableBlobs(c)
+
+		// a second run should not save anything
+		saved, err = ctf.DeduplicateCTFBlobs(c)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(saved).To(Equal(int64(0)))
+	})
+
+	It("should resolve shared blobs of a written and reopened ctf", func() {
+		c := newEmptyCTF(fs, "/ctf.tar")
+
+		ca1 := newComponentArchive("example.com/comp-1", "1.0.0")
+		addResource(ca1, "shared", sharedData)
+		addResource(ca1, "unique", uniqueData)
+		ca2 := newComponentArchive("example.com/comp-2", "1.0.0")
+		addResource(ca2, "shared", sharedData)
+		Expect(c.AddComponentArchive(ca1, ctf.ArchiveFormatTar)).To(Succeed())
+		Expect(c.AddComponentArchive(ca2, ctf.ArchiveFormatTar)).To(Succeed())
+
+		_, err := ctf.DeduplicateCTFBlobs(c)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(c.Write()).To(Succeed())
+		Expect(c.Close()).To(Succeed())
+
+		c, err = ctf.NewCTF(fs, "/ctf.tar")
+		Expect(err).ToNot(HaveOccurred())
+		defer c.Close()
+		expectResolvableBlobs(c)
+	})
+
+})
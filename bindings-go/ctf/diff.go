@@ -0,0 +1,116 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ComponentVersion identifies a component by its name and version.
+type ComponentVersion struct {
+	Name    string
+	Version string
+}
+
+// ComponentVersionPair describes a component whose version changed.
+type ComponentVersionPair struct {
+	Old ComponentVersion
+	New ComponentVersion
+}
+
+// CTFDiff describes the component versions that changed between two ctfs.
+type CTFDiff struct {
+	// Added contains the component versions that are only part of the new ctf.
+	Added []ComponentVersion
+	// Removed contains the component versions that are only part of the old ctf.
+	Removed []ComponentVersion
+	// Updated contains the components that are part of both ctfs but in different versions.
+	Updated []ComponentVersionPair
+}
+
+// DiffCTF compares the component versions of two ctfs.
+// A component that is contained in different versions is reported as updated.
+// If a component is contained in multiple versions, the versions that are not part of both ctfs are
+// paired in sorted order and the remaining versions are reported as added or removed.
+func DiffCTF(oldCTF, newCTF *CTF) (*CTFDiff, error) {
+	oldVersions, err := componentVersionsByName(oldCTF)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read old ctf: %w", err)
+	}
+	newVersions, err := componentVersionsByName(newCTF)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read new ctf: %w", err)
+	}
+
+	names := make([]string, 0, len(oldVersions)+len(newVersions))
+	for name := range oldVersions {
+		names = append(names, name)
+	}
+	for name := range newVersions {
+		if _, ok := oldVersions[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	diff := &CTFDiff{}
+	for _, name := range names {
+		removed := versionsNotIn(oldVersions[name], newVersions[name])
+		added := versionsNotIn(newVersions[name], oldVersions[name])
+		for len(removed) != 0 && len(added) != 0 {
+			diff.Updated = append(diff.Updated, ComponentVersionPair{
+				Old: ComponentVersion{Name: name, Version: removed[0]},
+				New: ComponentVersion{Name: name, Version: added[0]},
+			})
+			removed, added = removed[1:], added[1:]
+		}
+		for _, version := range removed {
+			diff.Removed = append(diff.Removed, ComponentVersion{Name: name, Version: version})
+		}
+		for _, version := range added {
+			diff.Added = append(diff.Added, ComponentVersion{Name: name, Version: version})
+		}
+	}
+	return diff, nil
+}
+
+// componentVersionsByName returns the versions of all components of the ctf grouped by the component name.
+func componentVersionsByName(ctf *CTF) (map[string]map[string]struct{}, error) {
+	cds, err := ctf.GetAllComponentDescriptors()
+	if err != nil {
+		return nil, err
+	}
+	versions := map[string]map[string]struct{}{}
+	for _, cd := range cds {
+		if _, ok := versions[cd.GetName()]; !ok {
+			versions[cd.GetName()] = map[string]struct{}{}
+		}
+		versions[cd.GetName()][cd.GetVersion()] = struct{}{}
+	}
+	return versions, nil
+}
+
+// versionsNotIn returns the sorted versions of a that are not contained in b.
+func versionsNotIn(a, b map[string]struct{}) []string {
+	versions := make([]string, 0)
+	for version := range a {
+		if _, ok := b[version]; !ok {
+			versions = append(versions, version)
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("ctf diff", func() {
+
+	newCTFWithComponents := func(components ...ctf.ComponentVersion) *ctf.CTF {
+		c := newEmptyCTF(memoryfs.New(), "/ctf.tar")
+		for _, comp := range components {
+			Expect(c.AddComponentArchive(newComponentArchive(comp.Name, comp.Version), ctf.ArchiveFormatTar)).To(Succeed())
+		}
+		return c
+	}
+
+	It("should report added, removed and updated components", func() {
+		oldCTF := newCTFWithComponents(
+			ctf.ComponentVersion{Name: "example.com/unchanged", Version: "1.0.0"},
+			ctf.ComponentVersion{Name: "example.com/updated", Version: "1.0.0"},
+			ctf.ComponentVersion{Name: "example.com/removed", Version: "1.0.0"},
+		)
+		defer func() {
+			Expect(oldCTF.Close()).To(Succeed())
+		}()
+		newCTF := newCTFWithComponents(
+			ctf.ComponentVersion{Name: "example.com/unchanged", Version: "1.0.0"},
+			ctf.ComponentVersion{Name: "example.com/updated", Version: "1.1.0"},
+			ctf.ComponentVersion{Name: "example.com/added", Version: "1.0.0"},
+		)
+		defer func() {
+			Expect(newCTF.Close()).To(Succeed())
+		}()
+
+		diff, err := ctf.DiffCTF(oldCTF, newCTF)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff.Added).To(ConsistOf(ctf.ComponentVersion{Name: "example.com/added", Version: "1.0.0"}))
+		Expect(diff.Removed).To(ConsistOf(ctf.ComponentVersion{Name: "example.com/removed", Version: "1.0.0"}))
+		Expect(diff.Updated).To(ConsistOf(ctf.ComponentVersionPair{
+			Old: ctf.ComponentVersion{Name: "example.com/updated", Version: "1.0.0"},
+			New: ctf.ComponentVersion{Name: "example.com/updated", Version: "1.1.0"},
+		}))
+	})
+
+	It("should report no changes for equal ctfs", func() {
+		oldCTF := newCTFWithComponents(ctf.ComponentVersion{Name: "example.com/comp", Version: "1.0.0"})
+		defer func() {
+			Expect(oldCTF.Close()).To(Succeed())
+		}()
+		newCTF := newCTFWithComponents(ctf.ComponentVersion{Name: "example.com/comp", Version: "1.0.0"})
+		defer func() {
+			Expect(newCTF.Close()).To(Succeed())
+		}()
+
+		diff, err := ctf.DiffCTF(oldCTF, newCTF)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff.Added).To(BeEmpty())
+		Expect(diff.Removed).To(BeEmpty())
+		Expect(diff.Updated).To(BeEmpty())
+	})
+})
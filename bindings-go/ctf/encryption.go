@@ -0,0 +1,213 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"filippo.io/age"
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// AgeEncryptedLabel is the name of the label that marks resources whose blob is encrypted with age.
+const AgeEncryptedLabel = "age-encrypted"
+
+// EncryptComponentArchive returns a copy of the component archive where the local blobs of all resources
+// are encrypted for the given age recipients.
+// The encrypted resources are marked with the AgeEncryptedLabel.
+// The component descriptor itself is not encrypted so that the archive can still be routed.
+func EncryptComponentArchive(ca *ComponentArchive, recipients []age.Recipient) (*ComponentArchive, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("at least one recipient is required to encrypt a component archive")
+	}
+	return transformResourceBlobs(ca, true, func(w io.Writer, r io.Reader) error {
+		enc, err := age.Encrypt(w, recipients...)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(enc, r); err != nil {
+			return err
+		}
+		return enc.Close()
+	})
+}
+
+// DecryptComponentArchive returns a copy of the component archive where the local blobs of all resources
+// that are marked with the AgeEncryptedLabel are decrypted with the given age identity.
+func DecryptComponentArchive(ca *ComponentArchive, identity age.Identity) (*ComponentArchive, error) {
+	if identity == nil {
+		return nil, errors.New("an identity is required to decrypt a component archive")
+	}
+	return transformResourceBlobs(ca, false, func(w io.Writer, r io.Reader) error {
+		dec, err := age.Decrypt(r, identity)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, dec)
+		return err
+	})
+}
+
+// transformResourceBlobs copies the component archive into a new in-memory archive
+// and applies the transformation to the local blobs of all resources that are (encrypt=false) or are not (encrypt=true) labeled as encrypted.
+// A transformed blob is stored under the digest of its transformed content and the access of the resources is updated accordingly,
+// so that the digest of the plaintext is only kept in the signed digest of the resource.
+// The label of the transformed resources is updated accordingly.
+func transformResourceBlobs(ca *ComponentArchive, encrypt bool, transform func(w io.Writer, r io.Reader) error) (*ComponentArchive, error) {
+	cd := ca.ComponentDescriptor.DeepCopy()
+	blobs := map[string][]int{}
+	for i, res := range cd.Resources {
+		if res.Access == nil || res.Access.GetType() != v2.LocalFilesystemBlobType {
+			continue
+		}
+		if isEncrypted(res) == encrypt {
+			continue
+		}
+		localFSAccess := &v2.LocalFilesystemBlobAccess{}
+		if err := res.Access.DecodeInto(localFSAccess); err != nil {
+			return nil, fmt.Errorf("unable to decode access of resource %q: %w", res.Name, err)
+		}
+		blobs[localFSAccess.Filename] = append(blobs[localFSAccess.Filename], i)
+		if encrypt {
+			cd.Resources[i].Labels = append(cd.Resources[i].Labels, v2.Label{
+				Name:  AgeEncryptedLabel,
+				Value: json.RawMessage("true"),
+			})
+		} else {
+			cd.Resources[i].Labels = removeLabel(cd.Resources[i].Labels, AgeEncryptedLabel)
+		}
+	}
+
+	fs := memoryfs.New()
+	if err := fs.Mkdir(BlobsDirectoryName, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("unable to create blob directory: %w", err)
+	}
+	blobInfos, err := vfs.ReadDir(ca.fs, BlobsDirectoryName)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("unable to read blobs: %w", err)
+	}
+	for _, blobInfo := range blobInfos {
+		if blobInfo.IsDir() {
+			continue
+		}
+		name := blobInfo.Name()
+		resources, ok := blobs[name]
+		if !ok {
+			if err := copyBlob(ca.fs, fs, name); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		transformed, err := transformBlob(ca.fs, fs, name, transform)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range resources {
+			if err := setLocalBlobFilename(&cd.Resources[i], transformed); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return NewComponentArchive(cd, fs), nil
+}
+
+// copyBlob copies the blob with the given name from one filesystem to the other.
+func copyBlob(from, to vfs.FileSystem, name string) error {
+	blobpath := BlobPath(name)
+	in, err := from.Open(blobpath)
+	if err != nil {
+		return fmt.Errorf("unable to open blob %q: %w", blobpath, err)
+	}
+	defer in.Close()
+	out, err := to.Create(blobpath)
+	if err != nil {
+		return fmt.Errorf("unable to create blob %q: %w", blobpath, err)
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("unable to copy blob %q: %w", blobpath, err)
+	}
+	return nil
+}
+
+// transformBlob transforms the blob with the given name from one filesystem into the other
+// and returns the new name of the blob which is the digest of the transformed content.
+func transformBlob(from, to vfs.FileSystem, name string, transform func(w io.Writer, r io.Reader) error) (string, error) {
+	blobpath := BlobPath(name)
+	in, err := from.Open(blobpath)
+	if err != nil {
+		return "", fmt.Errorf("unable to open blob %q: %w", blobpath, err)
+	}
+	defer in.Close()
+	tmpPath := BlobPath(".transform")
+	out, err := to.Create(tmpPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to create blob %q: %w", tmpPath, err)
+	}
+	digester := digest.Canonical.Digester()
+	err = transform(io.MultiWriter(out, digester.Hash()), in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = to.Remove(tmpPath)
+		return "", fmt.Errorf("unable to transform blob %q: %w", blobpath, err)
+	}
+	transformed := digester.Digest().String()
+	if err := to.Rename(tmpPath, BlobPath(transformed)); err != nil {
+		return "", fmt.Errorf("unable to store blob %q: %w", transformed, err)
+	}
+	return transformed, nil
+}
+
+// setLocalBlobFilename sets the filename of the local filesystem blob access of the resource.
+func setLocalBlobFilename(res *v2.Resource, filename string) error {
+	localFSAccess := &v2.LocalFilesystemBlobAccess{}
+	if err := res.Access.DecodeInto(localFSAccess); err != nil {
+		return fmt.Errorf("unable to decode access of resource %q: %w", res.Name, err)
+	}
+	access, err := v2.NewUnstructured(v2.NewLocalFilesystemBlobAccess(filename, localFSAccess.MediaType))
+	if err != nil {
+		return fmt.Errorf("unable to encode access of resource %q: %w", res.Name, err)
+	}
+	res.Access = &access
+	return nil
+}
+
+// isEncrypted returns whether the blob of the resource is labeled as encrypted.
+// The digest of an encrypted resource is the digest of its plaintext and not of the stored blob.
+func isEncrypted(res v2.Resource) bool {
+	_, encrypted := res.Labels.Get(AgeEncryptedLabel)
+	return encrypted
+}
+
+// removeLabel returns the labels without the label with the given name.
+func removeLabel(labels v2.Labels, name string) v2.Labels {
+	result := make(v2.Labels, 0, len(labels))
+	for _, label := range labels {
+		if label.Name != name {
+			result = append(result, label)
+		}
+	}
+	return result
+}
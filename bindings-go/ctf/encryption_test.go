@@ -0,0 +1,136 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"filippo.io/age"
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("component archive encryption", func() {
+
+	var (
+		ca       *ctf.ComponentArchive
+		original []byte
+		identity *age.X25519Identity
+	)
+
+	BeforeEach(func() {
+		var err error
+		ca, err = ctf.ComponentArchiveFromPath("./testdata/component-01")
+		Expect(err).ToNot(HaveOccurred())
+		original, err = os.ReadFile("./testdata/component-01/blobs/myblob")
+		Expect(err).ToNot(HaveOccurred())
+		identity, err = age.GenerateX25519Identity()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should encrypt the resource blobs and restore them on decryption", func() {
+		encrypted, err := ctf.EncryptComponentArchive(ca, []age.Recipient{identity.Recipient()})
+		Expect(err).ToNot(HaveOccurred())
+		value, ok := encrypted.ComponentDescriptor.Resources[0].Labels.Get(ctf.AgeEncryptedLabel)
+		Expect(ok).To(BeTrue())
+		Expect(string(value)).To(Equal("true"))
+		_, ok = ca.ComponentDescriptor.Resources[0].Labels.Get(ctf.AgeEncryptedLabel)
+		Expect(ok).To(BeFalse(), "the original archive should not be modified")
+
+		fs := memoryfs.New()
+		Expect(encrypted.WriteToFilesystem(fs, "/encrypted")).To(Succeed())
+		access := &v2.LocalFilesystemBlobAccess{}
+		Expect(encrypted.ComponentDescriptor.Resources[0].Access.DecodeInto(access)).To(Succeed())
+		ciphertext, err := vfs.ReadFile(fs, "/encrypted/blobs/"+access.Filename)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ciphertext).ToNot(Equal(original))
+		Expect(bytes.HasPrefix(ciphertext, []byte("age-encryption.org/v1"))).To(BeTrue())
+		Expect(access.Filename).To(Equal(digest.FromBytes(ciphertext).String()))
+		_, err = vfs.ReadFile(fs, "/encrypted/blobs/myblob")
+		Expect(os.IsNotExist(err)).To(BeTrue(), "the plaintext blob should not be stored")
+
+		decrypted, err := ctf.DecryptComponentArchive(encrypted, identity)
+		Expect(err).ToNot(HaveOccurred())
+		_, ok = decrypted.ComponentDescriptor.Resources[0].Labels.Get(ctf.AgeEncryptedLabel)
+		Expect(ok).To(BeFalse())
+
+		var result bytes.Buffer
+		_, err = decrypted.Resolve(context.TODO(), decrypted.ComponentDescriptor.Resources[0], &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(original))
+	})
+
+	It("should keep the plaintext digest only in the resource digest", func() {
+		ca.ComponentDescriptor.Resources[0].Digest = &v2.DigestSpec{
+			HashAlgorithm:          "sha256",
+			NormalisationAlgorithm: string(v2.GenericBlobDigestV1),
+			Value:                  digest.FromBytes(original).Encoded(),
+		}
+		encrypted, err := ctf.EncryptComponentArchive(ca, []age.Recipient{identity.Recipient()})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encrypted.ComponentDescriptor.Resources[0].Digest).To(Equal(ca.ComponentDescriptor.Resources[0].Digest))
+		access := &v2.LocalFilesystemBlobAccess{}
+		Expect(encrypted.ComponentDescriptor.Resources[0].Access.DecodeInto(access)).To(Succeed())
+		Expect(access.Filename).ToNot(ContainSubstring(digest.FromBytes(original).Encoded()))
+
+		checked, violations := ctf.VerifyBlobIntegrity(context.TODO(), encrypted)
+		Expect(checked).To(Equal(1))
+		Expect(violations).To(BeEmpty())
+
+		decrypted, err := ctf.DecryptComponentArchive(encrypted, identity)
+		Expect(err).ToNot(HaveOccurred())
+		checked, violations = ctf.VerifyBlobIntegrity(context.TODO(), decrypted)
+		Expect(checked).To(Equal(1))
+		Expect(violations).To(BeEmpty())
+	})
+
+	It("should not encrypt already encrypted blobs again", func() {
+		encrypted, err := ctf.EncryptComponentArchive(ca, []age.Recipient{identity.Recipient()})
+		Expect(err).ToNot(HaveOccurred())
+		twice, err := ctf.EncryptComponentArchive(encrypted, []age.Recipient{identity.Recipient()})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(twice.ComponentDescriptor.Resources[0].Labels).To(HaveLen(len(encrypted.ComponentDescriptor.Resources[0].Labels)))
+
+		decrypted, err := ctf.DecryptComponentArchive(twice, identity)
+		Expect(err).ToNot(HaveOccurred())
+		var result bytes.Buffer
+		_, err = decrypted.Resolve(context.TODO(), decrypted.ComponentDescriptor.Resources[0], &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(original))
+	})
+
+	It("should fail to decrypt with a wrong identity", func() {
+		encrypted, err := ctf.EncryptComponentArchive(ca, []age.Recipient{identity.Recipient()})
+		Expect(err).ToNot(HaveOccurred())
+		other, err := age.GenerateX25519Identity()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = ctf.DecryptComponentArchive(encrypted, other)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should require at least one recipient", func() {
+		_, err := ctf.EncryptComponentArchive(ca, nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+})
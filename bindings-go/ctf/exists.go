@@ -0,0 +1,44 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"context"
+	"errors"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// ComponentExistenceChecker is an optional extension of a ComponentResolver
+// that is able to check whether a component version exists without resolving it.
+type ComponentExistenceChecker interface {
+	ComponentVersionExists(ctx context.Context, repoCtx v2.Repository, name, version string) (bool, error)
+}
+
+// CheckComponentExists checks whether the component version can be resolved by the resolver.
+// Resolvers that do not implement the ComponentExistenceChecker interface have to resolve the component descriptor.
+// A NotFoundError of the resolver is reported as a missing component version.
+func CheckComponentExists(ctx context.Context, resolver ComponentResolver, repoCtx v2.Repository, name, version string) (bool, error) {
+	if checker, ok := resolver.(ComponentExistenceChecker); ok {
+		return checker.ComponentVersionExists(ctx, repoCtx, name, version)
+	}
+	if _, err := resolver.Resolve(ctx, repoCtx, name, version); err != nil {
+		if errors.Is(err, NotFoundError) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
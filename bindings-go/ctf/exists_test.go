@@ -0,0 +1,98 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// resolveOnlyResolver hides all methods of the wrapped resolver except the ComponentResolver ones.
+type resolveOnlyResolver struct {
+	ctf.ComponentResolver
+	resolved int
+}
+
+func (r *resolveOnlyResolver) Resolve(ctx context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, error) {
+	r.resolved++
+	return r.ComponentResolver.Resolve(ctx, repoCtx, name, version)
+}
+
+// failingResolver fails all resolve calls with its error.
+type failingResolver struct {
+	ctf.ComponentResolver
+	err error
+}
+
+func (r failingResolver) Resolve(_ context.Context, _ cdv2.Repository, _, _ string) (*cdv2.ComponentDescriptor, error) {
+	return nil, r.err
+}
+
+var _ = Describe("component existence", func() {
+
+	var (
+		repoCtx cdv2.UnstructuredTypedObject
+		lr      *ctf.ListResolver
+	)
+
+	BeforeEach(func() {
+		var err error
+		repoCtx, err = cdv2.NewUnstructured(cdv2.NewOCIRegistryRepository("example.com/registry", ""))
+		Expect(err).ToNot(HaveOccurred())
+		cd := cdv2.ComponentDescriptor{}
+		cd.Name = "example.com/a"
+		cd.Version = "0.0.1"
+		cd.RepositoryContexts = append(cd.RepositoryContexts, &repoCtx)
+		lr, err = ctf.NewListResolver(&cdv2.ComponentDescriptorList{
+			Components: []cdv2.ComponentDescriptor{cd},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should use the existence check of the resolver", func() {
+		var _ ctf.ComponentExistenceChecker = lr
+		exists, err := ctf.CheckComponentExists(context.TODO(), lr, &repoCtx, "example.com/a", "0.0.1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeTrue())
+
+		exists, err = ctf.CheckComponentExists(context.TODO(), lr, &repoCtx, "example.com/a", "0.0.2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("should fall back to resolve the component descriptor", func() {
+		resolver := &resolveOnlyResolver{ComponentResolver: lr}
+		exists, err := ctf.CheckComponentExists(context.TODO(), resolver, &repoCtx, "example.com/a", "0.0.1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeTrue())
+
+		exists, err = ctf.CheckComponentExists(context.TODO(), resolver, &repoCtx, "example.com/b", "0.0.1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeFalse())
+		Expect(resolver.resolved).To(Equal(2))
+	})
+
+	It("should return errors of the fallback that are not not found errors", func() {
+		resolveErr := errors.New("connection refused")
+		_, err := ctf.CheckComponentExists(context.TODO(), failingResolver{err: resolveErr}, &repoCtx, "example.com/a", "0.0.1")
+		Expect(err).To(MatchError(resolveErr))
+	})
+})
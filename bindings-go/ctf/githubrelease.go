@@ -0,0 +1,168 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// DefaultGitHubAPIURL is the url of the public github api.
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// GitHubReleaseBlobResolver implements the BlobResolver interface for "githubRelease" access types.
+// The release is looked up via the github api and the asset is downloaded from its api url.
+type GitHubReleaseBlobResolver struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// NewGitHubReleaseBlobResolver creates a new github release blob resolver that uses the given http client.
+// The default http client is used if no client is given.
+func NewGitHubReleaseBlobResolver(client *http.Client) *GitHubReleaseBlobResolver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &GitHubReleaseBlobResolver{
+		client:  client,
+		baseURL: DefaultGitHubAPIURL,
+	}
+}
+
+// WithBaseURL configures the url of the github api, e.g. for github enterprise installations.
+func (g *GitHubReleaseBlobResolver) WithBaseURL(baseURL string) *GitHubReleaseBlobResolver {
+	g.baseURL = strings.TrimSuffix(baseURL, "/")
+	return g
+}
+
+// WithGitHubToken configures a token that is used to authenticate against the github api.
+// A token is needed to access releases of private repositories.
+func (g *GitHubReleaseBlobResolver) WithGitHubToken(token string) *GitHubReleaseBlobResolver {
+	g.token = token
+	return g
+}
+
+// githubRelease describes the relevant parts of a github release api response.
+type githubRelease struct {
+	Assets []githubReleaseAsset `json:"assets"`
+}
+
+// githubReleaseAsset describes an asset of a github release.
+type githubReleaseAsset struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Digest      string `json:"digest"`
+}
+
+func (g *GitHubReleaseBlobResolver) CanResolve(res v2.Resource) bool {
+	return res.Access != nil && res.Access.GetType() == v2.GitHubReleaseType
+}
+
+// Info fetches the release from the github api and returns the size of the asset.
+// The digest is only set if it is published by github.
+func (g *GitHubReleaseBlobResolver) Info(ctx context.Context, res v2.Resource) (*BlobInfo, error) {
+	asset, err := g.getAsset(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	return &BlobInfo{
+		MediaType: asset.ContentType,
+		Digest:    asset.Digest,
+		Size:      asset.Size,
+	}, nil
+}
+
+// Resolve fetches the release asset of the resource and writes it to the given writer.
+func (g *GitHubReleaseBlobResolver) Resolve(ctx context.Context, res v2.Resource, writer io.Writer) (*BlobInfo, error) {
+	asset, err := g.getAsset(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.get(ctx, asset.URL, "application/octet-stream")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(writer, digester.Hash()), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read github release asset from %q: %w", asset.URL, err)
+	}
+	metrics.BlobsResolved.Add(1)
+	return &BlobInfo{
+		MediaType: asset.ContentType,
+		Digest:    digester.Digest().String(),
+		Size:      size,
+	}, nil
+}
+
+// getAsset fetches the release of the resource and returns the referenced asset.
+func (g *GitHubReleaseBlobResolver) getAsset(ctx context.Context, res v2.Resource) (*githubReleaseAsset, error) {
+	ghAccess, err := g.decodeAccess(res)
+	if err != nil {
+		return nil, err
+	}
+	releaseURL := fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", g.baseURL, url.PathEscape(ghAccess.Owner), url.PathEscape(ghAccess.Repository), url.PathEscape(ghAccess.Tag))
+	resp, err := g.get(ctx, releaseURL, "application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	release := &githubRelease{}
+	if err := json.NewDecoder(resp.Body).Decode(release); err != nil {
+		return nil, fmt.Errorf("unable to decode github release from %q: %w", releaseURL, err)
+	}
+	for i, asset := range release.Assets {
+		if asset.Name == ghAccess.AssetName {
+			return &release.Assets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("asset %q of github release %q: %w", ghAccess.AssetName, releaseURL, NotFoundError)
+}
+
+func (g *GitHubReleaseBlobResolver) decodeAccess(res v2.Resource) (*v2.GitHubReleaseAccess, error) {
+	if !g.CanResolve(res) {
+		return nil, UnsupportedResolveType
+	}
+	ghAccess := &v2.GitHubReleaseAccess{}
+	if err := res.Access.DecodeInto(ghAccess); err != nil {
+		return nil, fmt.Errorf("unable to decode access to type '%s': %w", res.Access.GetType(), err)
+	}
+	return ghAccess, nil
+}
+
+// get performs an authenticated get request and returns the response if the request was successful.
+func (g *GitHubReleaseBlobResolver) get(ctx context.Context, url, accept string) (*http.Response, error) {
+	return doHTTPRequest(ctx, g.client, http.MethodGet, url, "github release", func(req *http.Request) {
+		req.Header.Set("Accept", accept)
+		if len(g.token) != 0 {
+			req.Header.Set("Authorization", "Bearer "+g.token)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("GitHub Releases", func() {
+
+	var (
+		server *httptest.Server
+		asset  = []byte("release asset")
+		token  = "my-token"
+	)
+
+	newReleaseResource := func(access *v2.GitHubReleaseAccess) v2.Resource {
+		unstructured, err := v2.NewUnstructured(access)
+		Expect(err).ToNot(HaveOccurred())
+		return v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:    "cli",
+				Version: "v1.2.3",
+				Type:    "executable",
+			},
+			Relation: v2.ExternalRelation,
+			Access:   &unstructured,
+		}
+	}
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/gardener/example/releases/tags/v1.2.3", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+token {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"tag_name": "v1.2.3", "assets": [
+				{"name": "cli-linux-amd64", "url": "http://%s/repos/gardener/example/releases/assets/1", "content_type": "application/octet-stream", "size": %d}
+			]}`, r.Host, len(asset))
+		})
+		mux.HandleFunc("/repos/gardener/example/releases/assets/1", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+token || r.Header.Get("Accept") != "application/octet-stream" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(asset)
+		})
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should resolve a release asset of a private repository", func() {
+		resolver := ctf.NewGitHubReleaseBlobResolver(server.Client()).WithBaseURL(server.URL).WithGitHubToken(token)
+		res := newReleaseResource(v2.NewGitHubReleaseAccess("gardener", "example", "v1.2.3", "cli-linux-amd64"))
+		Expect(resolver.CanResolve(res)).To(BeTrue())
+
+		var result bytes.Buffer
+		info, err := resolver.Resolve(context.TODO(), res, &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(asset))
+		Expect(info.Digest).To(Equal(digest.FromBytes(asset).String()))
+		Expect(info.Size).To(Equal(int64(len(asset))))
+	})
+
+	It("should return the asset size from the release", func() {
+		resolver := ctf.NewGitHubReleaseBlobResolver(server.Client()).WithBaseURL(server.URL).WithGitHubToken(token)
+		info, err := resolver.Info(context.TODO(), newReleaseResource(v2.NewGitHubReleaseAccess("gardener", "example", "v1.2.3", "cli-linux-amd64")))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Size).To(Equal(int64(len(asset))))
+		Expect(info.MediaType).To(Equal("application/octet-stream"))
+	})
+
+	It("should return a not found error for unknown assets", func() {
+		resolver := ctf.NewGitHubReleaseBlobResolver(server.Client()).WithBaseURL(server.URL).WithGitHubToken(token)
+		_, err := resolver.Info(context.TODO(), newReleaseResource(v2.NewGitHubReleaseAccess("gardener", "example", "v1.2.3", "cli-darwin-arm64")))
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+	It("should escape the owner, repository and tag in the api path", func() {
+		var requested []string
+		escaping := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = append(requested, r.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer escaping.Close()
+		resolver := ctf.NewGitHubReleaseBlobResolver(escaping.Client()).WithBaseURL(escaping.URL)
+		_, err := resolver.Info(context.TODO(), newReleaseResource(v2.NewGitHubReleaseAccess("gardener", "exa/mple", "a/../../x?y", "cli-linux-amd64")))
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+		Expect(requested).To(Equal([]string{"/repos/gardener/exa%2Fmple/releases/tags/a%2F..%2F..%2Fx%3Fy"}))
+	})
+
+	It("should not find releases of private repositories without a token", func() {
+		resolver := ctf.NewGitHubReleaseBlobResolver(server.Client()).WithBaseURL(server.URL)
+		_, err := resolver.Info(context.TODO(), newReleaseResource(v2.NewGitHubReleaseAccess("gardener", "example", "v1.2.3", "cli-linux-amd64")))
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+})
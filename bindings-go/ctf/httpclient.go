@@ -0,0 +1,48 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// doHTTPRequest performs a http request with the given client and returns the response if the request was successful.
+// The request can be modified with prepare before it is sent, e.g. to add authentication headers.
+// A NotFoundError is returned if the server responds with status 404, kind describes the requested object in that error.
+// The body of the response has to be closed by the caller.
+func doHTTPRequest(ctx context.Context, client *http.Client, method, url, kind string, prepare func(req *http.Request)) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request for %q: %w", url, err)
+	}
+	if prepare != nil {
+		prepare(req)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch %q: %w", url, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s %q: %w", kind, url, NotFoundError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unable to fetch %q: unexpected status code %d", url, resp.StatusCode)
+	}
+	return resp, nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// CertificatePinError is returned if the certificate of a server does not match any of the pinned certificates.
+type CertificatePinError struct {
+	// Expected contains the comma separated list of the pinned base64 encoded DER certificates.
+	Expected string
+	// Got is the base64 encoded DER leaf certificate that was presented by the server.
+	Got string
+}
+
+func (e *CertificatePinError) Error() string {
+	return fmt.Sprintf("server certificate %q does not match any of the pinned certificates %q", e.Got, e.Expected)
+}
+
+// HTTPSPinnedBlobResolver implements the BlobResolver interface for "httpsWithPinnedCert" access types.
+// The blob is fetched via https and the connection is only established
+// if the leaf certificate of the server matches one of the pinned certificates of the access.
+// Redirects are only followed to https urls of the same host.
+// As the pinned certificate is trusted explicitly, self-signed certificates are supported.
+type HTTPSPinnedBlobResolver struct{}
+
+// NewHTTPSPinnedBlobResolver creates a new https blob resolver with certificate pinning.
+func NewHTTPSPinnedBlobResolver() *HTTPSPinnedBlobResolver {
+	return &HTTPSPinnedBlobResolver{}
+}
+
+func (h *HTTPSPinnedBlobResolver) CanResolve(res v2.Resource) bool {
+	return res.Access != nil && res.Access.GetType() == v2.HTTPSPinnedCertType
+}
+
+// Info returns the media type and size of the blob from a head request without downloading the blob.
+// The digest is not returned as it cannot be known without downloading the blob.
+func (h *HTTPSPinnedBlobResolver) Info(ctx context.Context, res v2.Resource) (*BlobInfo, error) {
+	resp, _, err := h.request(ctx, res, http.MethodHead)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	info := &BlobInfo{
+		MediaType: mediaTypeOrDefault(resp.Header.Get("Content-Type"), res),
+	}
+	if resp.ContentLength > 0 {
+		info.Size = resp.ContentLength
+	}
+	return info, nil
+}
+
+// Resolve fetches the blob of the resource and writes it to the given writer.
+func (h *HTTPSPinnedBlobResolver) Resolve(ctx context.Context, res v2.Resource, writer io.Writer) (*BlobInfo, error) {
+	resp, httpsAccess, err := h.request(ctx, res, http.MethodGet)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(writer, digester.Hash()), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read blob from %q: %w", httpsAccess.URL, err)
+	}
+
+	metrics.BlobsResolved.Add(1)
+	return &BlobInfo{
+		MediaType: mediaTypeOrDefault(resp.Header.Get("Content-Type"), res),
+		Digest:    digester.Digest().String(),
+		Size:      size,
+	}, nil
+}
+
+// request sends a request with the given method to the url of the https access of the resource
+// using a http client that only accepts the pinned certificates of the access.
+// The body of the response has to be closed by the caller.
+func (h *HTTPSPinnedBlobResolver) request(ctx context.Context, res v2.Resource, method string) (*http.Response, *v2.HTTPSAccess, error) {
+	if !h.CanResolve(res) {
+		return nil, nil, UnsupportedResolveType
+	}
+	httpsAccess := &v2.HTTPSAccess{}
+	if err := res.Access.DecodeInto(httpsAccess); err != nil {
+		return nil, nil, fmt.Errorf("unable to decode access to type '%s': %w", res.Access.GetType(), err)
+	}
+	if !strings.HasPrefix(httpsAccess.URL, "https://") {
+		return nil, nil, fmt.Errorf("url %q is expected to use the https scheme", httpsAccess.URL)
+	}
+	client, err := newPinnedHTTPClient(httpsAccess.PinnedCertificates)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := doHTTPRequest(ctx, client, method, httpsAccess.URL, "blob", func(req *http.Request) {
+		for key, val := range httpsAccess.Headers {
+			req.Header.Set(key, val)
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, httpsAccess, nil
+}
+
+// newPinnedHTTPClient creates a http client that only accepts servers
+// whose leaf certificate matches one of the given base64 encoded DER certificates.
+func newPinnedHTTPClient(pinnedCertificates []string) (*http.Client, error) {
+	if len(pinnedCertificates) == 0 {
+		return nil, fmt.Errorf("at least one pinned certificate has to be defined")
+	}
+	pins := make([][]byte, len(pinnedCertificates))
+	for i, cert := range pinnedCertificates {
+		der, err := base64.StdEncoding.DecodeString(cert)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode pinned certificate %d: %w", i, err)
+		}
+		pins[i] = der
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		// the default verification is replaced by the certificate pinning,
+		// which also allows the usage of self-signed certificates.
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return &CertificatePinError{
+					Expected: strings.Join(pinnedCertificates, ","),
+				}
+			}
+			for _, pin := range pins {
+				if bytes.Equal(rawCerts[0], pin) {
+					return nil
+				}
+			}
+			return &CertificatePinError{
+				Expected: strings.Join(pinnedCertificates, ","),
+				Got:      base64.StdEncoding.EncodeToString(rawCerts[0]),
+			}
+		},
+	}
+	return &http.Client{Transport: transport, CheckRedirect: checkPinnedRedirect}, nil
+}
+
+// checkPinnedRedirect only follows redirects to https urls of the originally requested host,
+// as the certificate pinning of the access is only defined for that host.
+func checkPinnedRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	if req.URL.Scheme != "https" {
+		return fmt.Errorf("redirect to %q is rejected as it does not use the https scheme", req.URL.Redacted())
+	}
+	if !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+		return fmt.Errorf("redirect to %q is rejected as it leaves the host %q", req.URL.Redacted(), via[0].URL.Host)
+	}
+	return nil
+}
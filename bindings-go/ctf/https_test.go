@@ -0,0 +1,190 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("HTTPS with pinned certificates", func() {
+
+	var (
+		server  *httptest.Server
+		pinned  string
+		methods []string
+		data    = []byte("blob content")
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			methods = append(methods, r.Method)
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+			_, _ = w.Write(data)
+		})
+	)
+
+	newSelfSignedCertificate := func() tls.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "example.com"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).ToNot(HaveOccurred())
+		return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	}
+
+	newHTTPSResource := func(access *v2.HTTPSAccess) v2.Resource {
+		access.Headers = map[string]string{"Authorization": "Bearer token"}
+		unstructured, err := v2.NewUnstructured(access)
+		Expect(err).ToNot(HaveOccurred())
+		return v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:    "blob",
+				Version: "1.2.3",
+				Type:    "plain",
+			},
+			Relation: v2.ExternalRelation,
+			Access:   &unstructured,
+		}
+	}
+
+	BeforeEach(func() {
+		cert := newSelfSignedCertificate()
+		pinned = base64.StdEncoding.EncodeToString(cert.Certificate[0])
+		methods = nil
+		server = httptest.NewUnstartedServer(handler)
+		server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+		server.StartTLS()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should resolve a blob from a server with a pinned certificate", func() {
+		resolver := ctf.NewHTTPSPinnedBlobResolver()
+		res := newHTTPSResource(v2.NewHTTPSAccess(server.URL, pinned))
+		Expect(resolver.CanResolve(res)).To(BeTrue())
+
+		var result bytes.Buffer
+		info, err := resolver.Resolve(context.TODO(), res, &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(data))
+		Expect(info.Size).To(Equal(int64(len(data))))
+	})
+
+	It("should get the blob info with a head request", func() {
+		resolver := ctf.NewHTTPSPinnedBlobResolver()
+		info, err := resolver.Info(context.TODO(), newHTTPSResource(v2.NewHTTPSAccess(server.URL, pinned)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info).To(Equal(&ctf.BlobInfo{
+			MediaType: "application/octet-stream",
+			Size:      int64(len(data)),
+		}))
+		Expect(methods).To(Equal([]string{http.MethodHead}))
+	})
+
+	It("should reject a server whose certificate does not match the pinned certificate", func() {
+		// the mitm server presents a certificate that differs from the pinned one
+		mitm := httptest.NewTLSServer(handler)
+		defer mitm.Close()
+
+		resolver := ctf.NewHTTPSPinnedBlobResolver()
+		res := newHTTPSResource(v2.NewHTTPSAccess(mitm.URL, pinned))
+		_, err := resolver.Resolve(context.TODO(), res, &bytes.Buffer{})
+		Expect(err).To(HaveOccurred())
+
+		pinErr := &ctf.CertificatePinError{}
+		Expect(errors.As(err, &pinErr)).To(BeTrue())
+		Expect(pinErr.Expected).To(Equal(pinned))
+		Expect(pinErr.Got).To(Equal(base64.StdEncoding.EncodeToString(mitm.Certificate().Raw)))
+	})
+
+	It("should follow redirects on the same host", func() {
+		redirecting := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/redirect" {
+				http.Redirect(w, r, "/blob", http.StatusFound)
+				return
+			}
+			handler(w, r)
+		}))
+		redirecting.TLS = server.TLS
+		redirecting.StartTLS()
+		defer redirecting.Close()
+
+		resolver := ctf.NewHTTPSPinnedBlobResolver()
+		var result bytes.Buffer
+		_, err := resolver.Resolve(context.TODO(), newHTTPSResource(v2.NewHTTPSAccess(redirecting.URL+"/redirect", pinned)), &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(data))
+	})
+
+	It("should reject redirects that downgrade the scheme or leave the host", func() {
+		plain := httptest.NewServer(handler)
+		defer plain.Close()
+
+		for _, target := range []string{plain.URL, server.URL} {
+			target := target
+			redirecting := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, target, http.StatusFound)
+			}))
+			redirecting.TLS = server.TLS
+			redirecting.StartTLS()
+
+			resolver := ctf.NewHTTPSPinnedBlobResolver()
+			_, err := resolver.Resolve(context.TODO(), newHTTPSResource(v2.NewHTTPSAccess(redirecting.URL, pinned)), &bytes.Buffer{})
+			redirecting.Close()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("redirect to"))
+		}
+	})
+
+	It("should not resolve plain http urls", func() {
+		resolver := ctf.NewHTTPSPinnedBlobResolver()
+		res := newHTTPSResource(v2.NewHTTPSAccess("http://example.com/blob", pinned))
+		_, err := resolver.Resolve(context.TODO(), res, &bytes.Buffer{})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,206 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"context"
+	"crypto"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// blobHashFunctionsMux guards BlobHashFunctions against concurrent registrations.
+var blobHashFunctionsMux sync.RWMutex
+
+// RegisterBlobHashAlgorithm registers a hash function in BlobHashFunctions that is used to hash and verify blobs with the given algorithm name.
+// Algorithm names are case-insensitive, an already registered algorithm is replaced.
+func RegisterBlobHashAlgorithm(name string, hashFunc crypto.Hash) {
+	blobHashFunctionsMux.Lock()
+	defer blobHashFunctionsMux.Unlock()
+	BlobHashFunctions[strings.ToLower(name)] = hashFunc
+}
+
+// getBlobHashAlgorithm returns the hash function of BlobHashFunctions with the given name.
+// Hash functions that are not linked into the binary are not returned.
+func getBlobHashAlgorithm(name string) (func() hash.Hash, bool) {
+	blobHashFunctionsMux.RLock()
+	defer blobHashFunctionsMux.RUnlock()
+	hashFunc, ok := BlobHashFunctions[strings.ToLower(name)]
+	if !ok || !hashFunc.Available() {
+		return nil, false
+	}
+	return hashFunc.New, true
+}
+
+// UnsupportedHashAlgorithmError is returned if a blob digest uses a hash algorithm that is not registered.
+type UnsupportedHashAlgorithmError struct {
+	Algorithm string
+}
+
+func (e UnsupportedHashAlgorithmError) Error() string {
+	return fmt.Sprintf("hash algorithm %q is not supported", e.Algorithm)
+}
+
+// IntegrityViolation describes a blob of a component archive whose content does not match its expected digest.
+type IntegrityViolation struct {
+	// Archive is the filename of the component archive in the ctf.
+	// It is empty if a single component archive is verified.
+	Archive string
+	// Resource is the name of the resource whose blob is violated.
+	Resource string
+	// Expected is the expected digest of the blob.
+	Expected string
+	// Actual is the digest of the blob content.
+	// It is empty if the blob could not be read.
+	Actual string
+	// Err describes why the blob or archive could not be verified.
+	Err error
+}
+
+// CTFIntegrityReport is the result of an integrity check of all component archives of a ctf.
+type CTFIntegrityReport struct {
+	ArchivesChecked int
+	BlobsChecked    int
+	Violations      []IntegrityViolation
+}
+
+// OK returns true if no integrity violations have been found.
+func (r *CTFIntegrityReport) OK() bool {
+	return len(r.Violations) == 0
+}
+
+// VerifyBlobIntegrity verifies the local blobs of all resources of the component archive.
+// The expected digest of a blob is the digest defined by its generic blob digest
+// or the blob's filename if the filename is a digest.
+// The blob is hashed with the hash algorithm of the expected digest, see RegisterBlobHashAlgorithm.
+// Digests with an unregistered hash algorithm are reported with an UnsupportedHashAlgorithmError.
+// Blobs without expected digest are not verified.
+// The number of verified blobs and all found violations are returned.
+func VerifyBlobIntegrity(ctx context.Context, ca *ComponentArchive) (int, []IntegrityViolation) {
+	resolver := NewComponentArchiveBlobResolver(ca.fs)
+	checked := 0
+	violations := make([]IntegrityViolation, 0)
+	for _, res := range ca.ComponentDescriptor.Resources {
+		if res.Access == nil || res.Access.GetType() != v2.LocalFilesystemBlobType {
+			continue
+		}
+		algorithm, value, err := expectedBlobDigest(res)
+		if err != nil {
+			violations = append(violations, IntegrityViolation{Resource: res.Name, Err: err})
+			continue
+		}
+		if len(value) == 0 {
+			continue
+		}
+		expected := algorithm + ":" + value
+		newHash, ok := getBlobHashAlgorithm(algorithm)
+		if !ok {
+			violations = append(violations, IntegrityViolation{
+				Resource: res.Name,
+				Expected: expected,
+				Err:      UnsupportedHashAlgorithmError{Algorithm: algorithm},
+			})
+			continue
+		}
+		checked++
+
+		_, file, err := resolver.resolve(ctx, res)
+		if err != nil {
+			violations = append(violations, IntegrityViolation{Resource: res.Name, Expected: expected, Err: err})
+			continue
+		}
+		h := newHash()
+		_, err = io.Copy(h, file)
+		_ = file.Close()
+		if err != nil {
+			violations = append(violations, IntegrityViolation{
+				Resource: res.Name,
+				Expected: expected,
+				Err:      fmt.Errorf("unable to hash blob: %w", err),
+			})
+			continue
+		}
+		actual := hex.EncodeToString(h.Sum(nil))
+		if !strings.EqualFold(actual, value) {
+			violations = append(violations, IntegrityViolation{
+				Resource: res.Name,
+				Expected: expected,
+				Actual:   algorithm + ":" + actual,
+			})
+		}
+	}
+	return checked, violations
+}
+
+// expectedBlobDigest returns the hash algorithm and the encoded value of the expected digest of the local blob of a resource.
+// An empty value is returned if the resource does not define an expected digest.
+// The digest of an encrypted resource is the digest of the plaintext, so only the filename is used for encrypted blobs.
+func expectedBlobDigest(res v2.Resource) (string, string, error) {
+	if res.Digest != nil && res.Digest.NormalisationAlgorithm == string(v2.GenericBlobDigestV1) && !isEncrypted(res) {
+		return strings.ToLower(res.Digest.HashAlgorithm), res.Digest.Value, nil
+	}
+	localFSAccess := &v2.LocalFilesystemBlobAccess{}
+	if err := res.Access.DecodeInto(localFSAccess); err != nil {
+		return "", "", fmt.Errorf("unable to decode access to type '%s': %w", res.Access.GetType(), err)
+	}
+	if dig, err := digest.Parse(localFSAccess.Filename); err == nil {
+		return dig.Algorithm().String(), dig.Encoded(), nil
+	}
+	return "", "", nil
+}
+
+// IntegrityCheck verifies the blobs of all component archives of the ctf.
+// Archives that cannot be read are reported as violations.
+func (ctf *CTF) IntegrityCheck(ctx context.Context) *CTFIntegrityReport {
+	report := &CTFIntegrityReport{
+		Violations: make([]IntegrityViolation, 0),
+	}
+	ctf.mux.RLock()
+	archives, err := ctf.listArchiveFiles()
+	ctf.mux.RUnlock()
+	if err != nil {
+		report.Violations = append(report.Violations, IntegrityViolation{Err: fmt.Errorf("unable to list component archives: %w", err)})
+		return report
+	}
+	for _, archive := range archives {
+		filename := strings.TrimPrefix(archive, "/")
+		if err := ctx.Err(); err != nil {
+			report.Violations = append(report.Violations, IntegrityViolation{Archive: filename, Err: err})
+			return report
+		}
+		ca, err := ctf.readArchive(archive)
+		if err != nil {
+			report.Violations = append(report.Violations, IntegrityViolation{Archive: filename, Err: err})
+			continue
+		}
+		report.ArchivesChecked++
+
+		checked, violations := VerifyBlobIntegrity(ctx, ca)
+		report.BlobsChecked += checked
+		for _, violation := range violations {
+			violation.Archive = filename
+			report.Violations = append(report.Violations, violation)
+		}
+	}
+	return report
+}
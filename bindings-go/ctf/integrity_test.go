@@ -0,0 +1,161 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("IntegrityCheck", func() {
+
+	addBlob := func(ca *ctf.ComponentArchive, name string, data []byte, dig digest.Digest) {
+		res := &v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:    name,
+				Version: ca.ComponentDescriptor.GetVersion(),
+				Type:    "plain-text",
+			},
+			Relation: v2.LocalRelation,
+		}
+		Expect(ca.AddResource(res, ctf.BlobInfo{
+			MediaType: "text/plain",
+			Digest:    dig.String(),
+			Size:      int64(len(data)),
+		}, bytes.NewReader(data))).To(Succeed())
+	}
+
+	It("should only report the blobs of the corrupted archive", func() {
+		c := newEmptyCTF(memoryfs.New(), "/ctf.tar")
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+
+		clean := newComponentArchive("example.com/clean", "1.0.0")
+		addBlob(clean, "blob", []byte("clean"), digest.FromString("clean"))
+		Expect(c.AddComponentArchiveWithName("clean.tar", clean, ctf.ArchiveFormatTar)).To(Succeed())
+
+		corrupted := newComponentArchive("example.com/corrupted", "1.0.0")
+		addBlob(corrupted, "valid", []byte("valid"), digest.FromString("valid"))
+		addBlob(corrupted, "tampered", []byte("tampered"), digest.FromString("original"))
+		Expect(c.AddComponentArchiveWithName("corrupted.tar", corrupted, ctf.ArchiveFormatTar)).To(Succeed())
+
+		report := c.IntegrityCheck(context.TODO())
+		Expect(report.OK()).To(BeFalse())
+		Expect(report.ArchivesChecked).To(Equal(2))
+		Expect(report.BlobsChecked).To(Equal(3))
+		Expect(report.Violations).To(Equal([]ctf.IntegrityViolation{
+			{
+				Archive:  "corrupted.tar",
+				Resource: "tampered",
+				Expected: digest.FromString("original").String(),
+				Actual:   digest.FromString("tampered").String(),
+			},
+		}))
+	})
+
+	It("should succeed for a ctf without corrupted blobs", func() {
+		c := newEmptyCTF(memoryfs.New(), "/ctf.tar")
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+		ca := newComponentArchive("example.com/clean", "1.0.0")
+		addBlob(ca, "blob", []byte("clean"), digest.FromString("clean"))
+		Expect(c.AddComponentArchive(ca, ctf.ArchiveFormatTar)).To(Succeed())
+
+		report := c.IntegrityCheck(context.TODO())
+		Expect(report.OK()).To(BeTrue())
+		Expect(report.BlobsChecked).To(Equal(1))
+	})
+
+	Context("hash algorithms", func() {
+
+		// withGenericDigest sets the generic blob digest of the resource with the given hash algorithm.
+		withGenericDigest := func(ca *ctf.ComponentArchive, name, algorithm, value string) {
+			for i := range ca.ComponentDescriptor.Resources {
+				if ca.ComponentDescriptor.Resources[i].Name == name {
+					ca.ComponentDescriptor.Resources[i].Digest = &v2.DigestSpec{
+						HashAlgorithm:          algorithm,
+						NormalisationAlgorithm: string(v2.GenericBlobDigestV1),
+						Value:                  value,
+					}
+				}
+			}
+		}
+
+		sha512Hex := func(data string) string {
+			sum := sha512.Sum512([]byte(data))
+			return hex.EncodeToString(sum[:])
+		}
+
+		It("should verify blobs with the hash algorithm of their digest", func() {
+			ca := newComponentArchive("example.com/comp", "1.0.0")
+			addBlob(ca, "valid", []byte("valid"), digest.FromString("valid"))
+			withGenericDigest(ca, "valid", "SHA512", sha512Hex("valid"))
+			addBlob(ca, "tampered", []byte("tampered"), digest.FromString("tampered"))
+			withGenericDigest(ca, "tampered", "sha512", sha512Hex("original"))
+
+			checked, violations := ctf.VerifyBlobIntegrity(context.TODO(), ca)
+			Expect(checked).To(Equal(2))
+			Expect(violations).To(Equal([]ctf.IntegrityViolation{
+				{
+					Resource: "tampered",
+					Expected: "sha512:" + sha512Hex("original"),
+					Actual:   "sha512:" + sha512Hex("tampered"),
+				},
+			}))
+		})
+
+		It("should report digests with unsupported hash algorithms", func() {
+			ca := newComponentArchive("example.com/comp", "1.0.0")
+			addBlob(ca, "blob", []byte("data"), digest.FromString("data"))
+			withGenericDigest(ca, "blob", "unknown-hash", "00")
+
+			checked, violations := ctf.VerifyBlobIntegrity(context.TODO(), ca)
+			Expect(checked).To(Equal(0))
+			Expect(violations).To(HaveLen(1))
+			unsupportedErr := ctf.UnsupportedHashAlgorithmError{}
+			Expect(errors.As(violations[0].Err, &unsupportedErr)).To(BeTrue())
+			Expect(unsupportedErr.Algorithm).To(Equal("unknown-hash"))
+		})
+
+		It("should verify blobs with registered hash algorithms", func() {
+			ca := newComponentArchive("example.com/comp", "1.0.0")
+			addBlob(ca, "blob", []byte("data"), digest.FromString("data"))
+			sum := sha1.Sum([]byte("data"))
+			withGenericDigest(ca, "blob", "test-sha1", hex.EncodeToString(sum[:]))
+
+			ctf.RegisterBlobHashAlgorithm("test-sha1", crypto.SHA1)
+			checked, violations := ctf.VerifyBlobIntegrity(context.TODO(), ca)
+			Expect(checked).To(Equal(1))
+			Expect(violations).To(BeEmpty())
+		})
+
+	})
+
+})
@@ -32,18 +32,32 @@ func NewListResolver(list *cdv2.ComponentDescriptorList, resolvers ...BlobResolv
 }
 
 var _ ComponentResolver = &ListResolver{}
+var _ ComponentExistenceChecker = &ListResolver{}
 
 func (l ListResolver) Resolve(_ context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, error) {
-	for _, comp := range l.List.Components {
+	comp := l.find(repoCtx, name, version)
+	if comp == nil {
+		return nil, NotFoundError
+	}
+	return comp.DeepCopy(), nil
+}
+
+// ComponentVersionExists checks whether the list contains the component version without copying it.
+func (l ListResolver) ComponentVersionExists(_ context.Context, repoCtx cdv2.Repository, name, version string) (bool, error) {
+	return l.find(repoCtx, name, version) != nil, nil
+}
+
+// find returns the component of the list with the given repository context, name and version.
+func (l ListResolver) find(repoCtx cdv2.Repository, name, version string) *cdv2.ComponentDescriptor {
+	for i, comp := range l.List.Components {
 		if !cdv2.TypedObjectEqual(repoCtx, comp.GetEffectiveRepositoryContext()) {
 			continue
 		}
 		if comp.Name == name && comp.Version == version {
-			return comp.DeepCopy(), nil
+			return &l.List.Components[i]
 		}
 	}
-
-	return nil, NotFoundError
+	return nil
 }
 
 func (l ListResolver) ResolveWithBlobResolver(ctx context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, BlobResolver, error) {
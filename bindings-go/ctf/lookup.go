@@ -0,0 +1,72 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"path"
+)
+
+// LookupByBlobDigest returns the first component archive of the ctf that contains a blob with the given digest.
+// Blobs are stored by their digest in the blob directory of a component archive,
+// so only the tar headers of the component archives are read until a matching blob is found.
+// Blobs that are de-duplicated into the shared blobs of the ctf are found as well.
+// A NotFoundError is returned if no component archive contains the blob.
+func (ctf *CTF) LookupByBlobDigest(digest string) (*ComponentArchive, error) {
+	ctf.mux.RLock()
+	defer ctf.mux.RUnlock()
+	archives, err := ctf.listArchiveFiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, archive := range archives {
+		ok, err := ctf.containsBlob(archive, digest)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return ctf.readComponentArchive(archive)
+		}
+	}
+	return nil, NotFoundError
+}
+
+// containsBlob returns whether the component archive contains a blob or a de-duplication record with the given name.
+func (ctf *CTF) containsBlob(archive, name string) (bool, error) {
+	file, err := ctf.tempFs.Open(archive)
+	if err != nil {
+		return false, fmt.Errorf("unable to open component archive %q: %w", archive, err)
+	}
+	defer file.Close()
+
+	tr := tar.NewReader(file)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return false, nil
+			}
+			return false, fmt.Errorf("unable to read component archive %q: %w", archive, err)
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeSymlink {
+			continue
+		}
+		if isBlobPath(header.Name) && path.Base(header.Name) == name {
+			return true, nil
+		}
+	}
+}
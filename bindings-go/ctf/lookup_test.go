@@ -0,0 +1,108 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("lookup by blob digest", func() {
+
+	addBlob := func(ca *ctf.ComponentArchive, name string, data []byte) string {
+		dig := digest.FromBytes(data).String()
+		res := &v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:    name,
+				Version: "1.0.0",
+				Type:    "txt",
+			},
+			Relation: v2.ExternalRelation,
+		}
+		Expect(ca.AddResource(res, ctf.BlobInfo{
+			MediaType: "txt",
+			Digest:    dig,
+			Size:      int64(len(data)),
+		}, bytes.NewBuffer(data))).To(Succeed())
+		return dig
+	}
+
+	var (
+		c                     *ctf.CTF
+		digest1, digest2      string
+		digest3, sharedDigest string
+	)
+
+	BeforeEach(func() {
+		c = newEmptyCTF(memoryfs.New(), "/ctf.tar")
+		ca1 := newComponentArchive("example.com/comp-1", "1.0.0")
+		digest1 = addBlob(ca1, "blob-1", []byte("blob 1"))
+		sharedDigest = addBlob(ca1, "shared", []byte("shared blob"))
+		ca2 := newComponentArchive("example.com/comp-2", "1.0.0")
+		digest2 = addBlob(ca2, "blob-2", []byte("blob 2"))
+		addBlob(ca2, "shared", []byte("shared blob"))
+		ca3 := newComponentArchive("example.com/comp-3", "1.0.0")
+		digest3 = addBlob(ca3, "blob-3", []byte("blob 3"))
+		for _, ca := range []*ctf.ComponentArchive{ca1, ca2, ca3} {
+			Expect(c.AddComponentArchive(ca, ctf.ArchiveFormatTar)).To(Succeed())
+		}
+	})
+
+	AfterEach(func() {
+		Expect(c.Close()).To(Succeed())
+	})
+
+	It("should return the component archive that contains the blob", func() {
+		for dig, name := range map[string]string{
+			digest1: "example.com/comp-1",
+			digest2: "example.com/comp-2",
+			digest3: "example.com/comp-3",
+		} {
+			ca, err := c.LookupByBlobDigest(dig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ca.ComponentDescriptor.GetName()).To(Equal(name))
+		}
+	})
+
+	It("should find blobs that are de-duplicated into the shared blobs", func() {
+		_, err := ctf.DeduplicateCTFBlobs(c)
+		Expect(err).ToNot(HaveOccurred())
+
+		ca, err := c.LookupByBlobDigest(sharedDigest)
+		Expect(err).ToNot(HaveOccurred())
+		Expect([]string{"example.com/comp-1", "example.com/comp-2"}).To(ContainElement(ca.ComponentDescriptor.GetName()))
+		var data bytes.Buffer
+		res, err := ca.ComponentDescriptor.GetResourceByIdentity(v2.Identity{v2.SystemIdentityName: "shared"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = ca.Resolve(context.TODO(), res, &data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data.String()).To(Equal("shared blob"))
+	})
+
+	It("should return a not found error if no component archive contains the blob", func() {
+		_, err := c.LookupByBlobDigest(digest.FromString("unknown").String())
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+})
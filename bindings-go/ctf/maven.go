@@ -0,0 +1,167 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// MavenBlobResolver implements the BlobResolver interface for "maven" access types.
+// The artifacts are fetched via http from the maven repository.
+type MavenBlobResolver struct {
+	client *http.Client
+}
+
+// NewMavenBlobResolver creates a new maven blob resolver that uses the given http client.
+// The default http client is used if no client is given.
+func NewMavenBlobResolver(client *http.Client) *MavenBlobResolver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &MavenBlobResolver{
+		client: client,
+	}
+}
+
+// MavenArtifactURL returns the url of the artifact in the maven repository.
+// The url has the form "<repository>/<group path>/<artifactId>/<version>/<artifactId>-<version>[-<classifier>].<extension>".
+func MavenArtifactURL(access *v2.MavenAccess) string {
+	extension := access.Extension
+	if len(extension) == 0 {
+		extension = "jar"
+	}
+	filename := access.ArtifactID + "-" + access.Version
+	if len(access.Classifier) != 0 {
+		filename += "-" + access.Classifier
+	}
+	filename += "." + extension
+	return strings.Join([]string{
+		strings.TrimSuffix(access.Repository, "/"),
+		strings.ReplaceAll(access.GroupID, ".", "/"),
+		access.ArtifactID,
+		access.Version,
+		filename,
+	}, "/")
+}
+
+func (m *MavenBlobResolver) CanResolve(res v2.Resource) bool {
+	return res.Access != nil && res.Access.GetType() == v2.MavenAccessType
+}
+
+// Info returns the media type and size of the maven artifact without downloading it.
+// The digest is read from the sha256 checksum file of the artifact and is empty if the repository does not provide it.
+func (m *MavenBlobResolver) Info(ctx context.Context, res v2.Resource) (*BlobInfo, error) {
+	mavenAccess, err := m.decodeAccess(res)
+	if err != nil {
+		return nil, err
+	}
+	url := MavenArtifactURL(mavenAccess)
+	resp, err := doHTTPRequest(ctx, m.client, http.MethodHead, url, "maven artifact", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	info := &BlobInfo{
+		MediaType: mediaTypeOrDefault(resp.Header.Get("Content-Type"), res),
+	}
+	if resp.ContentLength >= 0 {
+		info.Size = resp.ContentLength
+	}
+	dig, err := m.getChecksum(ctx, url+".sha256")
+	if err != nil {
+		if errors.Is(err, NotFoundError) {
+			return info, nil
+		}
+		return nil, err
+	}
+	info.Digest = dig.String()
+	return info, nil
+}
+
+// Resolve fetches the maven artifact of the resource and writes it to the given writer.
+func (m *MavenBlobResolver) Resolve(ctx context.Context, res v2.Resource, writer io.Writer) (*BlobInfo, error) {
+	mavenAccess, err := m.decodeAccess(res)
+	if err != nil {
+		return nil, err
+	}
+
+	url := MavenArtifactURL(mavenAccess)
+	resp, err := doHTTPRequest(ctx, m.client, http.MethodGet, url, "maven artifact", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(writer, digester.Hash()), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read maven artifact from %q: %w", url, err)
+	}
+
+	mediaType := resp.Header.Get("Content-Type")
+	if len(mediaType) == 0 {
+		mediaType = res.GetType()
+	}
+	metrics.BlobsResolved.Add(1)
+	return &BlobInfo{
+		MediaType: mediaType,
+		Digest:    digester.Digest().String(),
+		Size:      size,
+	}, nil
+}
+
+func (m *MavenBlobResolver) decodeAccess(res v2.Resource) (*v2.MavenAccess, error) {
+	if !m.CanResolve(res) {
+		return nil, UnsupportedResolveType
+	}
+	mavenAccess := &v2.MavenAccess{}
+	if err := res.Access.DecodeInto(mavenAccess); err != nil {
+		return nil, fmt.Errorf("unable to decode access to type '%s': %w", res.Access.GetType(), err)
+	}
+	return mavenAccess, nil
+}
+
+// getChecksum fetches a sha256 checksum file of the maven repository.
+// The file contains the hex encoded checksum that is optionally followed by the filename.
+func (m *MavenBlobResolver) getChecksum(ctx context.Context, url string) (digest.Digest, error) {
+	resp, err := doHTTPRequest(ctx, m.client, http.MethodGet, url, "maven checksum", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", fmt.Errorf("unable to read maven checksum from %q: %w", url, err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("maven checksum from %q is empty", url)
+	}
+	dig := digest.NewDigestFromEncoded(digest.SHA256, strings.ToLower(fields[0]))
+	if err := dig.Validate(); err != nil {
+		return "", fmt.Errorf("invalid maven checksum from %q: %w", url, err)
+	}
+	return dig, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("Maven", func() {
+
+	var (
+		server   *httptest.Server
+		data     = []byte("jar content")
+		checksum string
+		methods  []string
+	)
+
+	newMavenResource := func(access *v2.MavenAccess) v2.Resource {
+		unstructured, err := v2.NewUnstructured(access)
+		Expect(err).ToNot(HaveOccurred())
+		return v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:    "lib",
+				Version: "1.2.3",
+				Type:    "jar",
+			},
+			Relation: v2.ExternalRelation,
+			Access:   &unstructured,
+		}
+	}
+
+	BeforeEach(func() {
+		checksum = digest.FromBytes(data).Encoded() + "  lib-1.2.3.jar\n"
+		methods = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/maven2/org/example/lib/1.2.3/lib-1.2.3.jar":
+				methods = append(methods, r.Method)
+				w.Header().Set("Content-Type", "application/java-archive")
+				w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+				_, _ = w.Write(data)
+			case "/maven2/org/example/lib/1.2.3/lib-1.2.3.jar.sha256":
+				if len(checksum) == 0 {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write([]byte(checksum))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should construct the maven artifact url", func() {
+		access := v2.NewMavenAccess("https://repo.example.com/maven2/", "org.example", "lib", "1.2.3")
+		Expect(ctf.MavenArtifactURL(access)).To(Equal("https://repo.example.com/maven2/org/example/lib/1.2.3/lib-1.2.3.jar"))
+		access.Classifier = "sources"
+		access.Extension = "zip"
+		Expect(ctf.MavenArtifactURL(access)).To(Equal("https://repo.example.com/maven2/org/example/lib/1.2.3/lib-1.2.3-sources.zip"))
+	})
+
+	It("should resolve a maven artifact", func() {
+		resolver := ctf.NewMavenBlobResolver(server.Client())
+		res := newMavenResource(v2.NewMavenAccess(server.URL+"/maven2", "org.example", "lib", "1.2.3"))
+		Expect(resolver.CanResolve(res)).To(BeTrue())
+
+		var result bytes.Buffer
+		info, err := resolver.Resolve(context.TODO(), res, &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(data))
+		Expect(info.Digest).To(Equal(digest.FromBytes(data).String()))
+		Expect(info.Size).To(Equal(int64(len(data))))
+	})
+
+	It("should return the info of a maven artifact without downloading it", func() {
+		resolver := ctf.NewMavenBlobResolver(server.Client())
+		res := newMavenResource(v2.NewMavenAccess(server.URL+"/maven2", "org.example", "lib", "1.2.3"))
+		ctf.ResetMetrics()
+
+		info, err := resolver.Info(context.TODO(), res)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info).To(Equal(&ctf.BlobInfo{
+			MediaType: "application/java-archive",
+			Digest:    digest.FromBytes(data).String(),
+			Size:      int64(len(data)),
+		}))
+		Expect(methods).To(Equal([]string{http.MethodHead}))
+		Expect(ctf.GetMetrics().BlobsResolved.Value()).To(BeZero())
+	})
+
+	It("should return no digest if the repository has no checksum file", func() {
+		checksum = ""
+		resolver := ctf.NewMavenBlobResolver(server.Client())
+		info, err := resolver.Info(context.TODO(), newMavenResource(v2.NewMavenAccess(server.URL+"/maven2", "org.example", "lib", "1.2.3")))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Digest).To(BeEmpty())
+		Expect(info.Size).To(Equal(int64(len(data))))
+	})
+
+	It("should return a not found error if the artifact does not exist", func() {
+		resolver := ctf.NewMavenBlobResolver(server.Client())
+		res := newMavenResource(v2.NewMavenAccess(server.URL+"/maven2", "org.example", "lib", "0.0.1"))
+		_, err := resolver.Info(context.TODO(), res)
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+})
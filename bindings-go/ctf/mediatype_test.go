@@ -0,0 +1,89 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/blob"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("media type detection", func() {
+
+	const manifest = `{"schemaVersion": 2, "mediaType": "application/vnd.oci.image.manifest.v1+json", "layers": []}`
+
+	// resolveManifest adds an oci manifest with the given media type to a ctf
+	// and resolves it from the ctf that is opened with the given options.
+	resolveManifest := func(mediaType string, opts ...ctf.CTFOption) *ctf.BlobInfo {
+		ca := newComponentArchive("example.com/comp", "1.0.0")
+		Expect(ca.AddResource(&v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{Name: "manifest", Version: "1.0.0", Type: "manifest"},
+			Relation:           v2.LocalRelation,
+		}, ctf.BlobInfo{
+			MediaType: mediaType,
+			Digest:    digest.FromString(manifest).String(),
+			Size:      int64(len(manifest)),
+		}, strings.NewReader(manifest))).To(Succeed())
+
+		fs := memoryfs.New()
+		file, err := fs.Create("/ctf.tar")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tar.NewWriter(file).Close()).To(Succeed())
+		Expect(file.Close()).To(Succeed())
+		c, err := ctf.OpenCTF(fs, "/ctf.tar", opts...)
+		Expect(err).ToNot(HaveOccurred())
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+		Expect(c.AddComponentArchive(ca, ctf.ArchiveFormatTar)).To(Succeed())
+
+		var info *ctf.BlobInfo
+		Expect(c.Walk(func(ca *ctf.ComponentArchive) error {
+			var buf bytes.Buffer
+			info, err = ca.Resolve(context.TODO(), ca.ComponentDescriptor.Resources[0], &buf)
+			Expect(buf.String()).To(Equal(manifest))
+			return err
+		})).To(Succeed())
+		return info
+	}
+
+	It("should detect the media type of blobs without a media type", func() {
+		Expect(resolveManifest("", ctf.WithAutoDetectMediaType()).MediaType).To(Equal(blob.JSONMediaType))
+	})
+
+	It("should detect the media type of blobs with the default media type", func() {
+		Expect(resolveManifest(blob.DefaultMediaType, ctf.WithAutoDetectMediaType()).MediaType).To(Equal(blob.JSONMediaType))
+	})
+
+	It("should keep specific media types", func() {
+		info := resolveManifest("application/vnd.oci.image.manifest.v1+json", ctf.WithAutoDetectMediaType())
+		Expect(info.MediaType).To(Equal("application/vnd.oci.image.manifest.v1+json"))
+	})
+
+	It("should not detect media types by default", func() {
+		Expect(resolveManifest(blob.DefaultMediaType).MediaType).To(Equal(blob.DefaultMediaType))
+	})
+
+})
@@ -0,0 +1,76 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"expvar"
+	"io"
+)
+
+// Metrics contains counters of the ctf operations.
+// The counters are published as expvar map "ctf".
+type Metrics struct {
+	// CTFOpens is the number of opened ctf archives.
+	CTFOpens expvar.Int
+	// CTFWalks is the number of traversals through ctf archives.
+	CTFWalks expvar.Int
+	// CTFWrites is the number of ctf archives that have been written.
+	CTFWrites expvar.Int
+	// ComponentArchivesOpened is the number of component archives that have been read.
+	ComponentArchivesOpened expvar.Int
+	// BlobsResolved is the number of blobs that have been resolved by the blob resolvers of this package.
+	BlobsResolved expvar.Int
+	// BytesWritten is the number of bytes that have been written to ctf archives.
+	BytesWritten expvar.Int
+}
+
+var metrics = &Metrics{}
+
+func init() {
+	m := expvar.NewMap("ctf")
+	m.Set("ctfOpens", &metrics.CTFOpens)
+	m.Set("ctfWalks", &metrics.CTFWalks)
+	m.Set("ctfWrites", &metrics.CTFWrites)
+	m.Set("componentArchivesOpened", &metrics.ComponentArchivesOpened)
+	m.Set("blobsResolved", &metrics.BlobsResolved)
+	m.Set("bytesWritten", &metrics.BytesWritten)
+}
+
+// GetMetrics returns the metrics of the ctf package.
+func GetMetrics() *Metrics {
+	return metrics
+}
+
+// ResetMetrics resets all counters of the ctf package to zero.
+func ResetMetrics() {
+	metrics.CTFOpens.Set(0)
+	metrics.CTFWalks.Set(0)
+	metrics.CTFWrites.Set(0)
+	metrics.ComponentArchivesOpened.Set(0)
+	metrics.BlobsResolved.Set(0)
+	metrics.BytesWritten.Set(0)
+}
+
+// countingWriter counts the bytes that are written to the underlying writer.
+type countingWriter struct {
+	io.Writer
+	counter *expvar.Int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.counter.Add(int64(n))
+	return n, err
+}
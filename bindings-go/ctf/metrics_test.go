@@ -0,0 +1,78 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"context"
+	"expvar"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("Metrics", func() {
+
+	var fs vfs.FileSystem
+
+	BeforeEach(func() {
+		fs = memoryfs.New()
+		ctf.ResetMetrics()
+	})
+
+	It("should count ctf operations", func() {
+		c := newEmptyCTF(fs, "/ctf.tar")
+		defer c.Close()
+		Expect(ctf.GetMetrics().CTFOpens.Value()).To(Equal(int64(1)))
+
+		Expect(c.AddComponentArchive(newComponentArchive("example.com/a", "1.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+		Expect(c.Write()).To(Succeed())
+		Expect(ctf.GetMetrics().CTFWrites.Value()).To(Equal(int64(1)))
+		info, err := fs.Stat("/ctf.tar")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctf.GetMetrics().BytesWritten.Value()).To(Equal(info.Size()))
+
+		Expect(c.Walk(func(ca *ctf.ComponentArchive) error { return nil })).To(Succeed())
+		Expect(ctf.GetMetrics().CTFWalks.Value()).To(Equal(int64(1)))
+		Expect(ctf.GetMetrics().ComponentArchivesOpened.Value()).To(Equal(int64(1)))
+	})
+
+	It("should count resolved blobs", func() {
+		ca, err := ctf.ComponentArchiveFromPath("./testdata/component-01")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctf.GetMetrics().ComponentArchivesOpened.Value()).To(Equal(int64(1)))
+
+		_, err = ca.Resolve(context.TODO(), ca.ComponentDescriptor.Resources[0], &bytes.Buffer{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctf.GetMetrics().BlobsResolved.Value()).To(Equal(int64(1)))
+	})
+
+	It("should publish the metrics as expvar", func() {
+		newEmptyCTF(fs, "/ctf.tar").Close()
+		published, ok := expvar.Get("ctf").(*expvar.Map)
+		Expect(ok).To(BeTrue())
+		Expect(published.Get("ctfOpens").String()).To(Equal("1"))
+	})
+
+	It("should reset the metrics", func() {
+		newEmptyCTF(fs, "/ctf.tar").Close()
+		ctf.ResetMetrics()
+		Expect(ctf.GetMetrics().CTFOpens.Value()).To(BeZero())
+	})
+})
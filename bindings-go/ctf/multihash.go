@@ -0,0 +1,178 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"crypto"
+	// register the sha256 and sha512 hash functions
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// BlobHashFunctions defines the hash functions that can be used by a MultiHashWriter and to verify blob digests.
+// Additional hash functions should be added with RegisterBlobHashAlgorithm.
+var BlobHashFunctions = map[string]crypto.Hash{
+	"sha256": crypto.SHA256,
+	"sha512": crypto.SHA512,
+}
+
+// DefaultBlobHashAlgorithms are the hash algorithms that are calculated when a blob is added from a reader.
+// The first algorithm is used to address the blob in the component archive.
+var DefaultBlobHashAlgorithms = []string{"sha256", "sha512"}
+
+// MultiHashWriter is a writer that hashes the written data with multiple hash algorithms
+// while passing it to the underlying writer.
+type MultiHashWriter struct {
+	algorithms []string
+	hashes     map[string]hash.Hash
+	w          io.Writer
+}
+
+var _ io.Writer = &MultiHashWriter{}
+
+// NewMultiHashWriter creates a new writer that hashes all data written to w with the given algorithms.
+func NewMultiHashWriter(w io.Writer, algorithms ...string) (*MultiHashWriter, error) {
+	if len(algorithms) == 0 {
+		return nil, errors.New("at least one hash algorithm has to be defined")
+	}
+	hashes := make(map[string]hash.Hash, len(algorithms))
+	for _, algo := range algorithms {
+		newHash, ok := getBlobHashAlgorithm(algo)
+		if !ok {
+			return nil, fmt.Errorf("hash algorithm %s not found/implemented", algo)
+		}
+		hashes[algo] = newHash()
+	}
+	if w == nil {
+		w = io.Discard
+	}
+	return &MultiHashWriter{
+		algorithms: algorithms,
+		hashes:     hashes,
+		w:          w,
+	}, nil
+}
+
+func (m *MultiHashWriter) Write(p []byte) (int, error) {
+	n, err := m.w.Write(p)
+	// only the data that has been written to the underlying writer is hashed.
+	for _, h := range m.hashes {
+		_, _ = h.Write(p[:n])
+	}
+	return n, err
+}
+
+// DigestFor returns the digest of the written data for the given algorithm.
+func (m *MultiHashWriter) DigestFor(algo string) (v2.DigestSpec, error) {
+	h, ok := m.hashes[algo]
+	if !ok {
+		return v2.DigestSpec{}, fmt.Errorf("hash algorithm %s is not calculated by the writer", algo)
+	}
+	return v2.DigestSpec{
+		HashAlgorithm:          algo,
+		NormalisationAlgorithm: string(v2.GenericBlobDigestV1),
+		Value:                  hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
+// Digests returns the digests of the written data for all algorithms in the configured order.
+func (m *MultiHashWriter) Digests() []v2.DigestSpec {
+	digests := make([]v2.DigestSpec, 0, len(m.algorithms))
+	for _, algo := range m.algorithms {
+		digest, _ := m.DigestFor(algo)
+		digests = append(digests, digest)
+	}
+	return digests
+}
+
+// AddBlobFromReader reads the blob from the reader and stores it in the blobs directory of the component archive.
+// The blob is hashed with all DefaultBlobHashAlgorithms while it is written.
+// The returned digests are in the order of the DefaultBlobHashAlgorithms.
+func (ca *ComponentArchive) AddBlobFromReader(mediaType string, r io.Reader) (BlobInfo, []v2.DigestSpec, error) {
+	if err := ca.ensureBlobsPath(); err != nil {
+		return BlobInfo{}, nil, err
+	}
+	file, err := vfs.TempFile(ca.fs, BlobsDirectoryName, "blob-")
+	if err != nil {
+		return BlobInfo{}, nil, fmt.Errorf("unable to create blob file: %w", err)
+	}
+	tempName := file.Name()
+	hw, err := NewMultiHashWriter(file, DefaultBlobHashAlgorithms...)
+	if err != nil {
+		_ = file.Close()
+		_ = ca.fs.Remove(tempName)
+		return BlobInfo{}, nil, err
+	}
+	size, err := io.Copy(hw, r)
+	if closeErr := file.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = ca.fs.Remove(tempName)
+		return BlobInfo{}, nil, fmt.Errorf("unable to write blob to file: %w", err)
+	}
+
+	digests := hw.Digests()
+	info := BlobInfo{
+		MediaType: mediaType,
+		Digest:    fmt.Sprintf("%s:%s", digests[0].HashAlgorithm, digests[0].Value),
+		Size:      size,
+	}
+	blobpath := BlobPath(info.Digest)
+	if _, err := ca.fs.Stat(blobpath); err == nil {
+		// the blob already exists in the archive
+		_ = ca.fs.Remove(tempName)
+		return info, digests, nil
+	} else if !os.IsNotExist(err) {
+		_ = ca.fs.Remove(tempName)
+		return BlobInfo{}, nil, fmt.Errorf("unable to get file info for %s", blobpath)
+	}
+	if err := ca.fs.Rename(tempName, blobpath); err != nil {
+		_ = ca.fs.Remove(tempName)
+		return BlobInfo{}, nil, fmt.Errorf("unable to rename blob file: %w", err)
+	}
+	return info, digests, nil
+}
+
+// AddResourceFromReader reads the blob of the resource from the reader and adds the resource to the current archive.
+// All calculated digests are stored as additional digests of the resource.
+// The digest of the resource is set to the first digest if the resource does not define a digest.
+// If the specified resource already exists it will be overwritten.
+func (ca *ComponentArchive) AddResourceFromReader(res *v2.Resource, mediaType string, r io.Reader) error {
+	if res == nil {
+		return errors.New("a resource has to be defined")
+	}
+	info, digests, err := ca.AddBlobFromReader(mediaType, r)
+	if err != nil {
+		return err
+	}
+	res.AdditionalDigests = digests
+	if res.Digest == nil {
+		digest := digests[0]
+		res.Digest = &digest
+	}
+	// the blob is already stored, so AddResource only updates the access and the component descriptor.
+	return ca.AddResource(res, info, nil)
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("multi hash", func() {
+
+	data := []byte("some blob data")
+	sha256Sum := sha256.Sum256(data)
+	sha512Sum := sha512.Sum512(data)
+
+	It("should hash the written data with all algorithms", func() {
+		var buf bytes.Buffer
+		hw, err := ctf.NewMultiHashWriter(&buf, "sha256", "sha512")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = hw.Write(data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.Bytes()).To(Equal(data))
+
+		digest, err := hw.DigestFor("sha256")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest).To(Equal(v2.DigestSpec{
+			HashAlgorithm:          "sha256",
+			NormalisationAlgorithm: string(v2.GenericBlobDigestV1),
+			Value:                  hex.EncodeToString(sha256Sum[:]),
+		}))
+		digest, err = hw.DigestFor("sha512")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest.Value).To(Equal(hex.EncodeToString(sha512Sum[:])))
+
+		_, err = hw.DigestFor("md5")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail for unknown algorithms", func() {
+		_, err := ctf.NewMultiHashWriter(nil, "md5")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should hash the written data with registered algorithms", func() {
+		ctf.RegisterBlobHashAlgorithm("test-sha384", crypto.SHA384)
+		hw, err := ctf.NewMultiHashWriter(nil, "test-sha384")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = hw.Write(data)
+		Expect(err).ToNot(HaveOccurred())
+
+		sum := sha512.Sum384(data)
+		digest, err := hw.DigestFor("test-sha384")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest.Value).To(Equal(hex.EncodeToString(sum[:])))
+	})
+
+	It("should add a resource with the digests of all default algorithms", func() {
+		cd := &v2.ComponentDescriptor{}
+		cd.Name = "example.com/a"
+		cd.Version = "1.0.0"
+		cd.Provider = "internal"
+		Expect(v2.DefaultComponent(cd)).To(Succeed())
+		fs := memoryfs.New()
+		ca := ctf.NewComponentArchive(cd, fs)
+
+		res := &v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:    "blob",
+				Version: "1.0.0",
+				Type:    "plain-text",
+			},
+			Relation: v2.LocalRelation,
+		}
+		Expect(ca.AddResourceFromReader(res, "text/plain", bytes.NewReader(data))).To(Succeed())
+
+		Expect(cd.Resources).To(HaveLen(1))
+		added := cd.Resources[0]
+		Expect(added.Digest).ToNot(BeNil())
+		Expect(added.Digest.Value).To(Equal(hex.EncodeToString(sha256Sum[:])))
+		Expect(added.AdditionalDigests).To(HaveLen(2))
+		Expect(added.AdditionalDigests[0].HashAlgorithm).To(Equal("sha256"))
+		Expect(added.AdditionalDigests[1].HashAlgorithm).To(Equal("sha512"))
+		Expect(added.AdditionalDigests[1].Value).To(Equal(hex.EncodeToString(sha512Sum[:])))
+
+		blobName := "sha256:" + hex.EncodeToString(sha256Sum[:])
+		access := &v2.LocalFilesystemBlobAccess{}
+		Expect(added.Access.DecodeInto(access)).To(Succeed())
+		Expect(access.Filename).To(Equal(blobName))
+		blob, err := vfs.ReadFile(fs, ctf.BlobPath(blobName))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(blob).To(Equal(data))
+
+		// adding the same blob again does not create another file
+		_, _, err = ca.AddBlobFromReader("text/plain", bytes.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		files, err := vfs.ReadDir(fs, ctf.BlobsDirectoryName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+	})
+})
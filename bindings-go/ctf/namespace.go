@@ -0,0 +1,64 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FilterCTFByNamespace copies all component archives of the given namespace from the ctf to the destination ctf.
+// A component is part of a namespace if its name starts with "<namespace>/".
+func FilterCTFByNamespace(ctf *CTF, namespace string, destCTF *CTF) error {
+	prefix := namespacePrefix(namespace)
+	return ctf.Walk(func(ca *ComponentArchive) error {
+		if !strings.HasPrefix(ca.ComponentDescriptor.GetName(), prefix) {
+			return nil
+		}
+		if err := destCTF.AddComponentArchive(ca, ArchiveFormatTar); err != nil {
+			return fmt.Errorf("unable to add component archive %q: %w", ca.ComponentDescriptor.GetName(), err)
+		}
+		return nil
+	})
+}
+
+// RenameCTFNamespace copies all component archives of the old namespace from the ctf to the destination ctf
+// and moves them into the new namespace.
+// Component references to components of the old namespace are also moved to the new namespace.
+func RenameCTFNamespace(ctf *CTF, oldNS, newNS string, destCTF *CTF) error {
+	oldPrefix := namespacePrefix(oldNS)
+	newPrefix := namespacePrefix(newNS)
+	return ctf.Walk(func(ca *ComponentArchive) error {
+		cd := ca.ComponentDescriptor
+		if !strings.HasPrefix(cd.GetName(), oldPrefix) {
+			return nil
+		}
+		oldName := cd.GetName()
+		cd.SetName(newPrefix + strings.TrimPrefix(oldName, oldPrefix))
+		for i, ref := range cd.ComponentReferences {
+			if strings.HasPrefix(ref.ComponentName, oldPrefix) {
+				cd.ComponentReferences[i].ComponentName = newPrefix + strings.TrimPrefix(ref.ComponentName, oldPrefix)
+			}
+		}
+		if err := destCTF.AddComponentArchive(ca, ArchiveFormatTar); err != nil {
+			return fmt.Errorf("unable to add renamed component archive %q: %w", oldName, err)
+		}
+		return nil
+	})
+}
+
+func namespacePrefix(namespace string) string {
+	return strings.TrimSuffix(namespace, "/") + "/"
+}
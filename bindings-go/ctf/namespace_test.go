@@ -0,0 +1,110 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"archive/tar"
+	"fmt"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("Namespace", func() {
+
+	var (
+		fs   vfs.FileSystem
+		src  *ctf.CTF
+		dest *ctf.CTF
+	)
+
+	BeforeEach(func() {
+		fs = memoryfs.New()
+		src = newEmptyCTF(fs, "/src.tar")
+		dest = newEmptyCTF(fs, "/dest.tar")
+
+		Expect(src.AddComponentArchive(newComponentArchive("tenant-a.example.com/comp-1", "1.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+		Expect(src.AddComponentArchive(newComponentArchive("tenant-a.example.com/comp-2", "1.0.0", "tenant-a.example.com/comp-1"), ctf.ArchiveFormatTar)).To(Succeed())
+		Expect(src.AddComponentArchive(newComponentArchive("tenant-b.example.com/comp-1", "1.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+		Expect(src.AddComponentArchive(newComponentArchive("tenant-ab.example.com/comp-1", "1.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(src.Close()).To(Succeed())
+		Expect(dest.Close()).To(Succeed())
+	})
+
+	It("should only copy the component archives of the namespace", func() {
+		Expect(ctf.FilterCTFByNamespace(src, "tenant-a.example.com", dest)).To(Succeed())
+		Expect(componentNames(dest)).To(ConsistOf("tenant-a.example.com/comp-1", "tenant-a.example.com/comp-2"))
+	})
+
+	It("should copy and rename the component archives of the namespace", func() {
+		Expect(ctf.RenameCTFNamespace(src, "tenant-a.example.com", "tenant-c.example.com", dest)).To(Succeed())
+		Expect(componentNames(dest)).To(ConsistOf("tenant-c.example.com/comp-1", "tenant-c.example.com/comp-2"))
+
+		Expect(dest.Walk(func(ca *ctf.ComponentArchive) error {
+			for _, ref := range ca.ComponentDescriptor.ComponentReferences {
+				Expect(ref.ComponentName).To(Equal("tenant-c.example.com/comp-1"))
+			}
+			return nil
+		})).To(Succeed())
+	})
+
+})
+
+// newEmptyCTF creates a new ctf from an empty tar at the given path.
+func newEmptyCTF(fs vfs.FileSystem, path string) *ctf.CTF {
+	file, err := fs.Create(path)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(tar.NewWriter(file).Close()).To(Succeed())
+	Expect(file.Close()).To(Succeed())
+
+	c, err := ctf.NewCTF(fs, path)
+	Expect(err).ToNot(HaveOccurred())
+	return c
+}
+
+// newComponentArchive creates a new in-memory component archive that references the given components in the same version.
+func newComponentArchive(name, version string, refs ...string) *ctf.ComponentArchive {
+	cd := &v2.ComponentDescriptor{}
+	cd.Name = name
+	cd.Version = version
+	cd.Provider = "internal"
+	for i, ref := range refs {
+		cd.ComponentReferences = append(cd.ComponentReferences, v2.ComponentReference{
+			Name:          fmt.Sprintf("ref-%d", i),
+			ComponentName: ref,
+			Version:       version,
+		})
+	}
+	Expect(v2.DefaultComponent(cd)).To(Succeed())
+	return ctf.NewComponentArchive(cd, memoryfs.New())
+}
+
+// componentNames returns the names of all components in the ctf.
+func componentNames(c *ctf.CTF) []string {
+	names := make([]string, 0)
+	Expect(c.Walk(func(ca *ctf.ComponentArchive) error {
+		names = append(names, ca.ComponentDescriptor.GetName())
+		return nil
+	})).To(Succeed())
+	return names
+}
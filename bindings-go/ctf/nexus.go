@@ -0,0 +1,238 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// NexusOptions defines the options of a Nexus blob resolver.
+type NexusOptions struct {
+	// Server is the base url of the Nexus server the credentials belong to.
+	// Requests to other servers are refused if credentials are configured,
+	// so that a component descriptor cannot direct the credentials to another server.
+	Server string
+	// Username is the name of the user that is used to authenticate the requests.
+	// The requests are not authenticated if no username is defined.
+	Username string
+	// Password is the password of the user.
+	Password string
+}
+
+// ApplyOptions applies the given options on these options,
+// and then returns itself (for convenient chaining).
+func (o *NexusOptions) ApplyOptions(opts []NexusOption) *NexusOptions {
+	for _, opt := range opts {
+		if opt != nil {
+			opt.ApplyOption(o)
+		}
+	}
+	return o
+}
+
+// NexusOption is the interface to specify different Nexus blob resolver options
+type NexusOption interface {
+	ApplyOption(options *NexusOptions)
+}
+
+// NexusCredentials are the basic auth credentials of a Nexus user.
+type NexusCredentials struct {
+	Server   string
+	Username string
+	Password string
+}
+
+// ApplyOption applies the configured credentials.
+func (c NexusCredentials) ApplyOption(options *NexusOptions) {
+	options.Server = c.Server
+	options.Username = c.Username
+	options.Password = c.Password
+}
+
+// WithNexusCredentials authenticates the requests to the given Nexus server with the given user.
+// Assets of other servers cannot be resolved.
+func WithNexusCredentials(server, user, password string) NexusOption {
+	return NexusCredentials{
+		Server:   server,
+		Username: user,
+		Password: password,
+	}
+}
+
+// NexusBlobResolver implements the BlobResolver interface for "nexus" access types.
+// The assets are fetched via http from a raw repository of a Nexus Repository Manager.
+type NexusBlobResolver struct {
+	client *http.Client
+	opts   NexusOptions
+}
+
+var _ TypedBlobResolver = &NexusBlobResolver{}
+
+// NewNexusBlobResolver creates a new Nexus blob resolver that uses the given http client.
+// The default http client is used if no client is given.
+func NewNexusBlobResolver(client *http.Client, opts ...NexusOption) *NexusBlobResolver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	r := &NexusBlobResolver{
+		client: client,
+	}
+	r.opts.ApplyOptions(opts)
+	return r
+}
+
+// NexusAssetURL returns the download url of the asset in the Nexus repository.
+// The url has the form "<server>/repository/<repository>/<path>".
+func NexusAssetURL(access *v2.NexusAccess) string {
+	return strings.Join([]string{
+		strings.TrimSuffix(access.Server, "/"),
+		"repository",
+		strings.Trim(access.Repository, "/"),
+		strings.TrimPrefix(access.Path, "/"),
+	}, "/")
+}
+
+// NexusSearchURL returns the url of the Nexus search api that searches the asset of the access.
+// The url has the form "<server>/service/rest/v1/search/assets?repository=<repository>&name=<path>".
+func NexusSearchURL(access *v2.NexusAccess) string {
+	query := url.Values{}
+	query.Set("repository", strings.Trim(access.Repository, "/"))
+	query.Set("name", strings.TrimPrefix(access.Path, "/"))
+	return strings.TrimSuffix(access.Server, "/") + "/service/rest/v1/search/assets?" + query.Encode()
+}
+
+// nexusSearchResult is the response of the Nexus asset search api.
+type nexusSearchResult struct {
+	Items []struct {
+		Path        string `json:"path"`
+		ContentType string `json:"contentType"`
+		FileSize    int64  `json:"fileSize"`
+		Checksum    struct {
+			SHA256 string `json:"sha256"`
+		} `json:"checksum"`
+	} `json:"items"`
+}
+
+func (n *NexusBlobResolver) CanResolve(res v2.Resource) bool {
+	return res.Access != nil && res.Access.GetType() == v2.NexusAccessType
+}
+
+// Info returns the blob info of the asset from the Nexus search api without downloading the asset.
+func (n *NexusBlobResolver) Info(ctx context.Context, res v2.Resource) (*BlobInfo, error) {
+	access, err := n.decodeAccess(res)
+	if err != nil {
+		return nil, err
+	}
+	searchURL := NexusSearchURL(access)
+	resp, err := n.get(ctx, searchURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &nexusSearchResult{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("unable to decode search result from %q: %w", searchURL, err)
+	}
+	path := strings.TrimPrefix(access.Path, "/")
+	for _, item := range result.Items {
+		if strings.TrimPrefix(item.Path, "/") != path {
+			continue
+		}
+		if len(item.Checksum.SHA256) == 0 {
+			return nil, fmt.Errorf("search result from %q contains no sha256 checksum", searchURL)
+		}
+		return &BlobInfo{
+			MediaType: mediaTypeOrDefault(item.ContentType, res),
+			Digest:    digest.NewDigestFromEncoded(digest.SHA256, item.Checksum.SHA256).String(),
+			Size:      item.FileSize,
+		}, nil
+	}
+	return nil, fmt.Errorf("nexus asset %q: %w", NexusAssetURL(access), NotFoundError)
+}
+
+// Resolve fetches the asset of the resource and writes it to the given writer.
+func (n *NexusBlobResolver) Resolve(ctx context.Context, res v2.Resource, writer io.Writer) (*BlobInfo, error) {
+	access, err := n.decodeAccess(res)
+	if err != nil {
+		return nil, err
+	}
+	assetURL := NexusAssetURL(access)
+	resp, err := n.get(ctx, assetURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(writer, digester.Hash()), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read nexus asset from %q: %w", assetURL, err)
+	}
+	metrics.BlobsResolved.Add(1)
+	return &BlobInfo{
+		MediaType: mediaTypeOrDefault(resp.Header.Get("Content-Type"), res),
+		Digest:    digester.Digest().String(),
+		Size:      size,
+	}, nil
+}
+
+func (n *NexusBlobResolver) decodeAccess(res v2.Resource) (*v2.NexusAccess, error) {
+	if !n.CanResolve(res) {
+		return nil, UnsupportedResolveType
+	}
+	access := &v2.NexusAccess{}
+	if err := res.Access.DecodeInto(access); err != nil {
+		return nil, fmt.Errorf("unable to decode access to type '%s': %w", res.Access.GetType(), err)
+	}
+	return access, nil
+}
+
+// get performs a get request that is authenticated with the configured credentials
+// and returns the response if the request was successful.
+// Requests to other servers than the one of the credentials are refused.
+func (n *NexusBlobResolver) get(ctx context.Context, url string) (*http.Response, error) {
+	if len(n.opts.Username) != 0 && !sameServer(n.opts.Server, url) {
+		return nil, fmt.Errorf("nexus server of %q is not allowed, the credentials are configured for %q", url, n.opts.Server)
+	}
+	return doHTTPRequest(ctx, n.client, http.MethodGet, url, "nexus asset", func(req *http.Request) {
+		if len(n.opts.Username) != 0 {
+			req.SetBasicAuth(n.opts.Username, n.opts.Password)
+		}
+	})
+}
+
+// sameServer checks whether the url uses the scheme and host of the server url.
+func sameServer(server, rawURL string) bool {
+	serverURL, err := url.Parse(server)
+	if err != nil || len(serverURL.Host) == 0 {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, serverURL.Scheme) && strings.EqualFold(u.Host, serverURL.Host)
+}
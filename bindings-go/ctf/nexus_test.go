@@ -0,0 +1,172 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("Nexus", func() {
+
+	var (
+		server *httptest.Server
+		data   = []byte("nexus asset content")
+	)
+
+	newNexusResource := func(path string) v2.Resource {
+		unstructured, err := v2.NewUnstructured(v2.NewNexusAccess(server.URL, "raw-hosted", path))
+		Expect(err).ToNot(HaveOccurred())
+		return v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:    "tool",
+				Version: "1.2.3",
+				Type:    "tgz",
+			},
+			Relation: v2.ExternalRelation,
+			Access:   &unstructured,
+		}
+	}
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if user, password, ok := r.BasicAuth(); !ok || user != "reader" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch r.URL.Path {
+			case "/repository/raw-hosted/tools/tool-1.2.3.tgz":
+				w.Header().Set("Content-Type", "application/gzip")
+				_, _ = w.Write(data)
+			case "/service/rest/v1/search/assets":
+				items := []map[string]interface{}{}
+				if r.URL.Query().Get("repository") == "raw-hosted" && r.URL.Query().Get("name") == "tools/tool-1.2.3.tgz" {
+					items = append(items, map[string]interface{}{
+						"downloadUrl": server.URL + "/repository/raw-hosted/tools/tool-1.2.3.tgz",
+						"path":        "tools/tool-1.2.3.tgz",
+						"repository":  "raw-hosted",
+						"format":      "raw",
+						"contentType": "application/gzip",
+						"fileSize":    len(data),
+						"checksum": map[string]string{
+							"sha1":   "0000",
+							"sha256": digest.FromBytes(data).Encoded(),
+						},
+					})
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"items":             items,
+					"continuationToken": nil,
+				})
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should construct the nexus urls", func() {
+		access := v2.NewNexusAccess("https://nexus.example.com/", "raw-hosted", "/tools/tool.tgz")
+		Expect(ctf.NexusAssetURL(access)).To(Equal("https://nexus.example.com/repository/raw-hosted/tools/tool.tgz"))
+		Expect(ctf.NexusSearchURL(access)).To(Equal("https://nexus.example.com/service/rest/v1/search/assets?name=tools%2Ftool.tgz&repository=raw-hosted"))
+	})
+
+	It("should resolve a nexus asset", func() {
+		resolver := ctf.NewNexusBlobResolver(server.Client(), ctf.WithNexusCredentials(server.URL, "reader", "secret"))
+		res := newNexusResource("tools/tool-1.2.3.tgz")
+		Expect(resolver.CanResolve(res)).To(BeTrue())
+
+		var result bytes.Buffer
+		info, err := resolver.Resolve(context.TODO(), res, &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(data))
+		Expect(info.MediaType).To(Equal("application/gzip"))
+		Expect(info.Digest).To(Equal(digest.FromBytes(data).String()))
+		Expect(info.Size).To(Equal(int64(len(data))))
+	})
+
+	It("should return the blob info from the search api", func() {
+		resolver := ctf.NewNexusBlobResolver(server.Client(), ctf.WithNexusCredentials(server.URL, "reader", "secret"))
+		info, err := resolver.Info(context.TODO(), newNexusResource("/tools/tool-1.2.3.tgz"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info).To(Equal(&ctf.BlobInfo{
+			MediaType: "application/gzip",
+			Digest:    digest.FromBytes(data).String(),
+			Size:      int64(len(data)),
+		}))
+	})
+
+	It("should return a not found error if the asset does not exist", func() {
+		resolver := ctf.NewNexusBlobResolver(server.Client(), ctf.WithNexusCredentials(server.URL, "reader", "secret"))
+		_, err := resolver.Info(context.TODO(), newNexusResource("tools/unknown.tgz"))
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+		_, err = resolver.Resolve(context.TODO(), newNexusResource("tools/unknown.tgz"), &bytes.Buffer{})
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+	It("should fail without valid credentials", func() {
+		resolver := ctf.NewNexusBlobResolver(server.Client())
+		_, err := resolver.Resolve(context.TODO(), newNexusResource("tools/tool-1.2.3.tgz"), &bytes.Buffer{})
+		Expect(err).To(HaveOccurred())
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeFalse())
+	})
+
+	It("should not send the credentials to other servers", func() {
+		var requests int32
+		other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+		}))
+		defer other.Close()
+		resolver := ctf.NewNexusBlobResolver(other.Client(), ctf.WithNexusCredentials(server.URL, "reader", "secret"))
+		res := newNexusResource("tools/tool-1.2.3.tgz")
+		access, err := v2.NewUnstructured(v2.NewNexusAccess(other.URL, "raw-hosted", "tools/tool-1.2.3.tgz"))
+		Expect(err).ToNot(HaveOccurred())
+		res.Access = &access
+
+		_, err = resolver.Info(context.TODO(), res)
+		Expect(err).To(HaveOccurred())
+		_, err = resolver.Resolve(context.TODO(), res, &bytes.Buffer{})
+		Expect(err).To(HaveOccurred())
+		Expect(atomic.LoadInt32(&requests)).To(BeZero())
+	})
+
+	It("should not resolve other access types", func() {
+		resolver := ctf.NewNexusBlobResolver(server.Client())
+		res := newNexusResource("tools/tool-1.2.3.tgz")
+		web, err := v2.NewUnstructured(v2.NewWebAccess(server.URL))
+		Expect(err).ToNot(HaveOccurred())
+		res.Access = &web
+		Expect(resolver.CanResolve(res)).To(BeFalse())
+		_, err = resolver.Info(context.TODO(), res)
+		Expect(errors.Is(err, ctf.UnsupportedResolveType)).To(BeTrue())
+	})
+
+})
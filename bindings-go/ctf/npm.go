@@ -0,0 +1,176 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// NPMTarballMediaType is the media type of npm package tarballs.
+const NPMTarballMediaType = "application/gzip"
+
+// NPMBlobResolver implements the BlobResolver interface for "npmPackage" access types.
+// The package tarballs are fetched via http from the npm registry.
+type NPMBlobResolver struct {
+	client *http.Client
+}
+
+// NewNPMBlobResolver creates a new npm blob resolver that uses the given http client.
+// The default http client is used if no client is given.
+func NewNPMBlobResolver(client *http.Client) *NPMBlobResolver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &NPMBlobResolver{
+		client: client,
+	}
+}
+
+// NPMTarballURL returns the url of the package tarball in the npm registry.
+// The url has the form "<registry>/<package>/-/<package name>-<version>.tgz"
+// where the package name does not contain the scope of scoped packages.
+func NPMTarballURL(access *v2.NPMAccess) string {
+	return fmt.Sprintf("%s/%s/-/%s-%s.tgz", strings.TrimSuffix(access.Registry, "/"), access.Package, path.Base(access.Package), access.Version)
+}
+
+// npmVersionMetadata describes the relevant parts of the metadata of a package version in a npm registry.
+type npmVersionMetadata struct {
+	Dist struct {
+		Shasum    string `json:"shasum"`
+		Integrity string `json:"integrity"`
+	} `json:"dist"`
+}
+
+func (n *NPMBlobResolver) CanResolve(res v2.Resource) bool {
+	return res.Access != nil && res.Access.GetType() == v2.NPMPackageType
+}
+
+// Info fetches the metadata of the package version from the npm registry
+// and returns the sha512 digest of the package tarball that is defined by the integrity of the package.
+// The sha1 digest of the shasum is returned for packages that do not publish a sha512 integrity.
+// The size is read with a head request of the tarball url that is also used by Resolve.
+func (n *NPMBlobResolver) Info(ctx context.Context, res v2.Resource) (*BlobInfo, error) {
+	npmAccess, err := n.decodeAccess(res)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(npmAccess.Registry, "/"), npmAccess.Package, npmAccess.Version)
+	resp, err := doHTTPRequest(ctx, n.client, http.MethodGet, url, "npm package", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	metadata := &npmVersionMetadata{}
+	if err := json.NewDecoder(resp.Body).Decode(metadata); err != nil {
+		return nil, fmt.Errorf("unable to decode npm package metadata from %q: %w", url, err)
+	}
+	dig, err := npmDistDigest(metadata.Dist.Integrity, metadata.Dist.Shasum)
+	if err != nil {
+		return nil, fmt.Errorf("npm package metadata from %q: %w", url, err)
+	}
+
+	info := &BlobInfo{
+		MediaType: NPMTarballMediaType,
+		Digest:    dig.String(),
+	}
+	tarballURL := NPMTarballURL(npmAccess)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, tarballURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request for %q: %w", tarballURL, err)
+	}
+	headResp, err := n.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get npm package tarball info from %q: %w", tarballURL, err)
+	}
+	defer headResp.Body.Close()
+	if headResp.StatusCode == http.StatusOK && headResp.ContentLength >= 0 {
+		info.Size = headResp.ContentLength
+	}
+	return info, nil
+}
+
+// Resolve fetches the package tarball of the resource and writes it to the given writer.
+// The returned digest is the sha512 digest of the tarball so that it matches the integrity digest returned by Info.
+func (n *NPMBlobResolver) Resolve(ctx context.Context, res v2.Resource, writer io.Writer) (*BlobInfo, error) {
+	npmAccess, err := n.decodeAccess(res)
+	if err != nil {
+		return nil, err
+	}
+	url := NPMTarballURL(npmAccess)
+	resp, err := doHTTPRequest(ctx, n.client, http.MethodGet, url, "npm package", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	digester := digest.SHA512.Digester()
+	size, err := io.Copy(io.MultiWriter(writer, digester.Hash()), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read npm package from %q: %w", url, err)
+	}
+	metrics.BlobsResolved.Add(1)
+	return &BlobInfo{
+		MediaType: NPMTarballMediaType,
+		Digest:    digester.Digest().String(),
+		Size:      size,
+	}, nil
+}
+
+// npmDistDigest returns the sha512 digest of a npm integrity string or the sha1 digest of the shasum if no sha512 integrity is defined.
+// The integrity is a space separated list of "<algorithm>-<base64 encoded hash>" entries, the shasum is the hex encoded sha1 hash.
+func npmDistDigest(integrity, shasum string) (digest.Digest, error) {
+	for _, entry := range strings.Fields(integrity) {
+		if !strings.HasPrefix(entry, "sha512-") {
+			continue
+		}
+		sum, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(entry, "sha512-"))
+		if err != nil {
+			return "", fmt.Errorf("unable to decode integrity %q: %w", entry, err)
+		}
+		return digest.NewDigestFromEncoded(digest.SHA512, hex.EncodeToString(sum)), nil
+	}
+	if len(shasum) == 0 {
+		return "", fmt.Errorf("neither a sha512 integrity nor a shasum is defined")
+	}
+	if sum, err := hex.DecodeString(shasum); err != nil || len(sum) != sha1.Size {
+		return "", fmt.Errorf("invalid shasum %q", shasum)
+	}
+	return digest.NewDigestFromEncoded("sha1", strings.ToLower(shasum)), nil
+}
+
+func (n *NPMBlobResolver) decodeAccess(res v2.Resource) (*v2.NPMAccess, error) {
+	if !n.CanResolve(res) {
+		return nil, UnsupportedResolveType
+	}
+	npmAccess := &v2.NPMAccess{}
+	if err := res.Access.DecodeInto(npmAccess); err != nil {
+		return nil, fmt.Errorf("unable to decode access to type '%s': %w", res.Access.GetType(), err)
+	}
+	return npmAccess, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("NPM", func() {
+
+	var (
+		server    *httptest.Server
+		tarball   = []byte("tgz content")
+		integrity string
+		shasum    string
+	)
+
+	newNPMResource := func(access *v2.NPMAccess) v2.Resource {
+		unstructured, err := v2.NewUnstructured(access)
+		Expect(err).ToNot(HaveOccurred())
+		return v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:    "pkg",
+				Version: "1.2.3",
+				Type:    "npmPackage",
+			},
+			Relation: v2.ExternalRelation,
+			Access:   &unstructured,
+		}
+	}
+
+	BeforeEach(func() {
+		sum := sha512.Sum512(tarball)
+		integrity = "sha512-" + base64.StdEncoding.EncodeToString(sum[:])
+		shasum = fmt.Sprintf("%x", sha1.Sum(tarball))
+		mux := http.NewServeMux()
+		mux.HandleFunc("/@example/pkg/1.2.3", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprintf(w, `{"name": "@example/pkg", "version": "1.2.3", "dist": {"tarball": "http://%s/@example/pkg/-/pkg-1.2.3.tgz", "shasum": "%s", "integrity": "%s"}}`, r.Host, shasum, integrity)
+		})
+		mux.HandleFunc("/@example/pkg/-/pkg-1.2.3.tgz", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(tarball)
+		})
+		// the legacy package only publishes a shasum and a tarball url of another host.
+		mux.HandleFunc("/legacy/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprintf(w, `{"name": "legacy", "version": "1.0.0", "dist": {"tarball": "http://mirror.example.com/legacy/-/legacy-1.0.0.tgz", "shasum": "%s"}}`, shasum)
+		})
+		mux.HandleFunc("/legacy/-/legacy-1.0.0.tgz", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(tarball)
+		})
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should construct the tarball url", func() {
+		Expect(ctf.NPMTarballURL(v2.NewNPMAccess("https://registry.npmjs.org/", "left-pad", "1.3.0"))).
+			To(Equal("https://registry.npmjs.org/left-pad/-/left-pad-1.3.0.tgz"))
+		Expect(ctf.NPMTarballURL(v2.NewNPMAccess("https://registry.npmjs.org", "@example/pkg", "1.2.3"))).
+			To(Equal("https://registry.npmjs.org/@example/pkg/-/pkg-1.2.3.tgz"))
+	})
+
+	It("should resolve a npm package tarball", func() {
+		resolver := ctf.NewNPMBlobResolver(server.Client())
+		res := newNPMResource(v2.NewNPMAccess(server.URL, "@example/pkg", "1.2.3"))
+		Expect(resolver.CanResolve(res)).To(BeTrue())
+
+		var result bytes.Buffer
+		info, err := resolver.Resolve(context.TODO(), res, &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(tarball))
+		Expect(info.Digest).To(Equal(digest.SHA512.FromBytes(tarball).String()))
+		Expect(info.Size).To(Equal(int64(len(tarball))))
+	})
+
+	It("should return the info from the package metadata", func() {
+		resolver := ctf.NewNPMBlobResolver(server.Client())
+		info, err := resolver.Info(context.TODO(), newNPMResource(v2.NewNPMAccess(server.URL, "@example/pkg", "1.2.3")))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Digest).To(Equal(digest.SHA512.FromBytes(tarball).String()))
+		Expect(info.Size).To(Equal(int64(len(tarball))))
+		Expect(info.MediaType).To(Equal(ctf.NPMTarballMediaType))
+	})
+
+	It("should return the sha1 digest of the shasum if no integrity is defined", func() {
+		resolver := ctf.NewNPMBlobResolver(server.Client())
+		info, err := resolver.Info(context.TODO(), newNPMResource(v2.NewNPMAccess(server.URL, "legacy", "1.0.0")))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Digest).To(Equal("sha1:" + shasum))
+		Expect(info.Size).To(Equal(int64(len(tarball))))
+	})
+
+	It("should return a not found error for unknown packages", func() {
+		resolver := ctf.NewNPMBlobResolver(server.Client())
+		_, err := resolver.Resolve(context.TODO(), newNPMResource(v2.NewNPMAccess(server.URL, "@example/pkg", "2.0.0")), &bytes.Buffer{})
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+})
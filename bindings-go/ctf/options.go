@@ -0,0 +1,104 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import "time"
+
+// CTFOptions defines the options of a ctf.
+type CTFOptions struct {
+	// MaxSize is the maximum size of all files of the ctf in bytes.
+	// The size is not limited if the max size is not greater than 0.
+	MaxSize int64
+	// Timeout is the duration after which the ctf is closed automatically.
+	// The ctf is not closed automatically if the timeout is not greater than 0.
+	Timeout time.Duration
+	// WatchInterval is the interval in which Watch polls ctf archives that are not stored on the os filesystem.
+	// The DefaultWatchInterval is used if the interval is not greater than 0.
+	WatchInterval time.Duration
+	// AutoDetectMediaType detects the media type of local blobs of the ctf's component archives
+	// whose media type is empty or "application/octet-stream".
+	AutoDetectMediaType bool
+}
+
+// ApplyOptions applies the given options on these options,
+// and then returns itself (for convenient chaining).
+func (o *CTFOptions) ApplyOptions(opts []CTFOption) *CTFOptions {
+	for _, opt := range opts {
+		if opt != nil {
+			opt.ApplyOption(o)
+		}
+	}
+	return o
+}
+
+// CTFOption is the interface to specify different ctf options
+type CTFOption interface {
+	ApplyOption(options *CTFOptions)
+}
+
+// MaxSize limits the size of a ctf in bytes.
+type MaxSize int64
+
+// ApplyOption applies the configured max size.
+func (s MaxSize) ApplyOption(options *CTFOptions) {
+	options.MaxSize = int64(s)
+}
+
+// WithMaxSize limits the size of all files of the ctf to the given number of bytes.
+func WithMaxSize(bytes int64) CTFOption {
+	return MaxSize(bytes)
+}
+
+// Timeout closes a ctf automatically after the given duration.
+type Timeout time.Duration
+
+// ApplyOption applies the configured timeout.
+func (t Timeout) ApplyOption(options *CTFOptions) {
+	options.Timeout = time.Duration(t)
+}
+
+// WithTimeout closes the ctf automatically if it has not been written or changed for the given duration.
+// All operations on an expired ctf fail with ErrCTFExpired.
+func WithTimeout(d time.Duration) CTFOption {
+	return Timeout(d)
+}
+
+// WatchInterval defines the interval in which a ctf archive is polled for changes.
+type WatchInterval time.Duration
+
+// ApplyOption applies the configured watch interval.
+func (i WatchInterval) ApplyOption(options *CTFOptions) {
+	options.WatchInterval = time.Duration(i)
+}
+
+// WithWatchInterval polls the ctf archive for changes in the given interval if it is watched.
+// The interval is only used for ctfs that are not stored on the os filesystem as those are watched with filesystem notifications.
+func WithWatchInterval(d time.Duration) CTFOption {
+	return WatchInterval(d)
+}
+
+// AutoDetectMediaType detects the media type of unspecific resolved blobs.
+type AutoDetectMediaType bool
+
+// ApplyOption applies the configured media type detection.
+func (d AutoDetectMediaType) ApplyOption(options *CTFOptions) {
+	options.AutoDetectMediaType = bool(d)
+}
+
+// WithAutoDetectMediaType detects the media type of blobs that are resolved from the component archives of the ctf
+// if the media type in the component descriptor is empty or "application/octet-stream".
+func WithAutoDetectMediaType() CTFOption {
+	return AutoDetectMediaType(true)
+}
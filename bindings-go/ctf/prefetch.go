@@ -0,0 +1,233 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// PreFetchBlobInfo fetches the blob info of all resources of the component descriptor
+// that have a digest without size information and stores the size in the digest of the resource.
+// This allows to report the size of remote blobs, e.g. from the manifest of an oci artifact, without downloading them.
+// The size is not part of the normalised component descriptor, so existing signatures stay valid.
+// Resources without access or digest, with a digest that is excluded from the signature
+// or with an access that cannot be handled by the resolver are skipped.
+func PreFetchBlobInfo(ctx context.Context, cd *v2.ComponentDescriptor, resolver BlobResolver) error {
+	for i, res := range cd.Resources {
+		if res.Access == nil || res.Digest == nil || res.Digest.Size != 0 || res.Digest.NormalisationAlgorithm == v2.ExcludeFromSignature {
+			continue
+		}
+		if typed, ok := resolver.(TypedBlobResolver); ok && !typed.CanResolve(res) {
+			continue
+		}
+		info, err := resolver.Info(ctx, res)
+		if err != nil {
+			if errors.Is(err, UnsupportedResolveType) {
+				continue
+			}
+			return fmt.Errorf("unable to get blob info of resource %s:%s: %w", res.Name, res.Version, err)
+		}
+		cd.Resources[i].Digest.Size = info.Size
+	}
+	return nil
+}
+
+// PrefetchHandle tracks the background downloads that are started by PrefetchBlobs.
+type PrefetchHandle struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	errs   []error
+}
+
+// Wait blocks until all downloads have completed and returns the errors of the failed downloads.
+func (h *PrefetchHandle) Wait() []error {
+	<-h.done
+	return h.errs
+}
+
+// CancelAndWait cancels all in-flight downloads and blocks until they have stopped.
+// The errors of the failed and canceled downloads are returned.
+func (h *PrefetchHandle) CancelAndWait() []error {
+	h.cancel()
+	return h.Wait()
+}
+
+// PrefetchBlobs starts downloading the blobs of all resources of the component descriptor in the background.
+// The blobs are stored as "blobs/<digest>" in the target filesystem, at most concurrency blobs are downloaded in parallel.
+// Resources without access or with an access that cannot be handled by the resolver are skipped.
+// The returned handle has to be used to wait for the downloads to complete.
+func PrefetchBlobs(ctx context.Context, cd *v2.ComponentDescriptor, resolver BlobResolver, targetFS vfs.FileSystem, concurrency int) *PrefetchHandle {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	h := &PrefetchHandle{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	resources := make([]v2.Resource, 0, len(cd.Resources))
+	for _, res := range cd.Resources {
+		if res.Access == nil {
+			continue
+		}
+		if typed, ok := resolver.(TypedBlobResolver); ok && !typed.CanResolve(res) {
+			continue
+		}
+		resources = append(resources, *res.DeepCopy())
+	}
+
+	go func() {
+		defer close(h.done)
+		defer cancel()
+		if err := targetFS.MkdirAll(BlobsDirectoryName, os.ModePerm); err != nil {
+			h.errs = []error{fmt.Errorf("unable to create blob directory: %w", err)}
+			return
+		}
+
+		var (
+			wg  sync.WaitGroup
+			mux sync.Mutex
+			sem = make(chan struct{}, concurrency)
+		)
+		for i, res := range resources {
+			wg.Add(1)
+			go func(i int, res v2.Resource) {
+				defer wg.Done()
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					mux.Lock()
+					h.errs = append(h.errs, fmt.Errorf("unable to prefetch blob of resource %s:%s: %w", res.Name, res.Version, ctx.Err()))
+					mux.Unlock()
+					return
+				}
+				defer func() { <-sem }()
+
+				if err := prefetchBlob(ctx, res, resolver, targetFS, i); err != nil {
+					mux.Lock()
+					h.errs = append(h.errs, fmt.Errorf("unable to prefetch blob of resource %s:%s: %w", res.Name, res.Version, err))
+					mux.Unlock()
+				}
+			}(i, res)
+		}
+		wg.Wait()
+	}()
+	return h
+}
+
+// prefetchBlob downloads the blob of the resource into a temporary file of the target filesystem
+// and moves it to the blob path of its computed digest once the download succeeded.
+// The download fails if the computed digest does not match the digest of the blob info
+// or the generic blob digest of the resource.
+// Blobs that cannot be handled by the resolver are skipped.
+func prefetchBlob(ctx context.Context, res v2.Resource, resolver BlobResolver, targetFS vfs.FileSystem, i int) error {
+	tmpPath := BlobPath(fmt.Sprintf(".prefetch-%d", i))
+	file, err := targetFS.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("unable to create file %q: %w", tmpPath, err)
+	}
+	digester := digest.Canonical.Digester()
+	info, err := resolver.Resolve(ctx, res, io.MultiWriter(file, digester.Hash()))
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("unable to close file %q: %w", tmpPath, closeErr)
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err == nil {
+		err = verifyPrefetchedBlob(res, info, targetFS, tmpPath, digester.Digest())
+	}
+	if err != nil {
+		_ = targetFS.Remove(tmpPath)
+		if errors.Is(err, UnsupportedResolveType) {
+			return nil
+		}
+		return err
+	}
+
+	dig := digester.Digest().String()
+	if err := targetFS.Rename(tmpPath, BlobPath(dig)); err != nil {
+		_ = targetFS.Remove(tmpPath)
+		// the same blob may be used by multiple resources and has already been stored by another download.
+		if exists, _ := vfs.FileExists(targetFS, BlobPath(dig)); exists {
+			return nil
+		}
+		return fmt.Errorf("unable to store blob %q: %w", dig, err)
+	}
+	return nil
+}
+
+// verifyPrefetchedBlob checks that the downloaded blob matches the digest of the blob info
+// and the generic blob digest of the resource.
+// Digests that do not use the canonical algorithm are verified by hashing the downloaded file again.
+func verifyPrefetchedBlob(res v2.Resource, info *BlobInfo, targetFS vfs.FileSystem, tmpPath string, computed digest.Digest) error {
+	expected := make([]string, 0, 2)
+	if info != nil && len(info.Digest) != 0 {
+		expected = append(expected, info.Digest)
+	}
+	if res.Digest != nil && res.Digest.NormalisationAlgorithm == string(v2.GenericBlobDigestV1) && !isEncrypted(res) {
+		expected = append(expected, strings.ToLower(res.Digest.HashAlgorithm)+":"+res.Digest.Value)
+	}
+	for _, exp := range expected {
+		algorithm, value, ok := strings.Cut(exp, ":")
+		if !ok || len(value) == 0 {
+			return fmt.Errorf("unable to parse expected digest %q", exp)
+		}
+		algorithm = strings.ToLower(algorithm)
+		actual := computed
+		if algorithm != computed.Algorithm().String() {
+			var err error
+			actual, err = hashFile(targetFS, tmpPath, algorithm)
+			if err != nil {
+				return err
+			}
+		}
+		if !strings.EqualFold(actual.Encoded(), value) {
+			return fmt.Errorf("digest %q of the downloaded blob does not match the expected digest %q", actual, exp)
+		}
+	}
+	return nil
+}
+
+// hashFile returns the digest of the file with the given registered hash algorithm.
+func hashFile(fs vfs.FileSystem, path, algorithm string) (digest.Digest, error) {
+	newHash, ok := getBlobHashAlgorithm(algorithm)
+	if !ok {
+		return "", UnsupportedHashAlgorithmError{Algorithm: algorithm}
+	}
+	file, err := fs.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to open file %q: %w", path, err)
+	}
+	defer file.Close()
+	h := newHash()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("unable to hash file %q: %w", path, err)
+	}
+	return digest.NewDigestFromEncoded(digest.Algorithm(algorithm), hex.EncodeToString(h.Sum(nil))), nil
+}
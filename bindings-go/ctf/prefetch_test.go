@@ -0,0 +1,275 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/signatures"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("PreFetchBlobInfo", func() {
+
+	newResource := func(name string, digest *v2.DigestSpec) v2.Resource {
+		access, err := v2.NewUnstructured(v2.NewOCIRegistryAccess("example.com/" + name + ":1.0.0"))
+		Expect(err).ToNot(HaveOccurred())
+		return v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{Name: name, Version: "1.0.0"},
+			Access:             &access,
+			Digest:             digest,
+		}
+	}
+	newDigest := func(size int64) *v2.DigestSpec {
+		return &v2.DigestSpec{
+			HashAlgorithm:          "sha256",
+			NormalisationAlgorithm: string(v2.OciArtifactDigestV1),
+			Value:                  "00",
+			Size:                   size,
+		}
+	}
+
+	var (
+		calls    []string
+		resolver *testBlobResolver
+	)
+
+	BeforeEach(func() {
+		calls = nil
+		sizes := map[string]int64{"a": 100, "b": 200, "c": 300}
+		resolver = &testBlobResolver{
+			info: func(_ context.Context, res v2.Resource) (*ctf.BlobInfo, error) {
+				calls = append(calls, res.Name)
+				return &ctf.BlobInfo{Size: sizes[res.Name]}, nil
+			},
+			resolve: func(_ context.Context, _ v2.Resource, _ io.Writer) (*ctf.BlobInfo, error) {
+				return nil, errors.New("blobs should not be resolved")
+			},
+		}
+	})
+
+	It("should store the size of all resources without size information", func() {
+		cd := &v2.ComponentDescriptor{}
+		cd.Resources = []v2.Resource{
+			newResource("a", newDigest(0)),
+			newResource("b", newDigest(0)),
+			newResource("c", newDigest(42)),
+			newResource("d", nil),
+			newResource("e", v2.NewExcludeFromSignatureDigest()),
+		}
+		Expect(ctf.PreFetchBlobInfo(context.TODO(), cd, resolver)).To(Succeed())
+
+		Expect(calls).To(ConsistOf("a", "b"))
+		Expect(cd.Resources[0].Digest.Size).To(Equal(int64(100)))
+		Expect(cd.Resources[1].Digest.Size).To(Equal(int64(200)))
+		Expect(cd.Resources[2].Digest.Size).To(Equal(int64(42)))
+		Expect(cd.Resources[3].Digest).To(BeNil())
+		Expect(cd.Resources[4].Digest).To(Equal(v2.NewExcludeFromSignatureDigest()))
+	})
+
+	It("should not invalidate the digest of the component descriptor", func() {
+		cd := &v2.ComponentDescriptor{}
+		cd.Name = "example.com/a"
+		cd.Version = "1.0.0"
+		cd.Resources = []v2.Resource{newResource("a", newDigest(0))}
+		hasher := signatures.Hasher{HashFunction: sha256.New(), AlgorithmName: signatures.SHA256}
+		digests := func() []string {
+			var values []string
+			for _, algorithm := range []v2.NormalisationAlgorithm{v2.JsonNormalisationV1, v2.JsonNormalisationV2} {
+				digest, err := signatures.HashForComponentDescriptorWithNormalisation(*cd, hasher, algorithm)
+				Expect(err).ToNot(HaveOccurred())
+				values = append(values, digest.Value)
+			}
+			return values
+		}
+		before := digests()
+		Expect(ctf.PreFetchBlobInfo(context.TODO(), cd, resolver)).To(Succeed())
+		Expect(cd.Resources[0].Digest.Size).To(Equal(int64(100)))
+		Expect(digests()).To(Equal(before))
+	})
+
+	It("should return an error if the blob info cannot be fetched", func() {
+		resolver.info = func(_ context.Context, _ v2.Resource) (*ctf.BlobInfo, error) {
+			return nil, errors.New("unavailable")
+		}
+		cd := &v2.ComponentDescriptor{}
+		cd.Resources = []v2.Resource{newResource("a", newDigest(0))}
+		Expect(ctf.PreFetchBlobInfo(context.TODO(), cd, resolver)).ToNot(Succeed())
+	})
+
+	It("should skip resources that are not supported by the resolver", func() {
+		resolver.info = func(_ context.Context, _ v2.Resource) (*ctf.BlobInfo, error) {
+			return nil, ctf.UnsupportedResolveType
+		}
+		cd := &v2.ComponentDescriptor{}
+		cd.Resources = []v2.Resource{newResource("a", newDigest(0))}
+		Expect(ctf.PreFetchBlobInfo(context.TODO(), cd, resolver)).To(Succeed())
+		Expect(cd.Resources[0].Digest.Size).To(BeZero())
+	})
+
+})
+
+var _ = Describe("PrefetchBlobs", func() {
+
+	newResource := func(name string) v2.Resource {
+		access, err := v2.NewUnstructured(v2.NewOCIRegistryAccess("example.com/" + name + ":1.0.0"))
+		Expect(err).ToNot(HaveOccurred())
+		return v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{Name: name, Version: "1.0.0"},
+			Access:             &access,
+		}
+	}
+
+	var (
+		cd       *v2.ComponentDescriptor
+		targetFS vfs.FileSystem
+		blobs    map[string][]byte
+	)
+
+	BeforeEach(func() {
+		cd = &v2.ComponentDescriptor{}
+		cd.Resources = []v2.Resource{newResource("a"), newResource("b"), newResource("c"), newResource("d")}
+		cd.Resources = append(cd.Resources, v2.Resource{IdentityObjectMeta: v2.IdentityObjectMeta{Name: "no-access", Version: "1.0.0"}})
+		targetFS = memoryfs.New()
+		blobs = map[string][]byte{
+			"a": []byte("blob a"),
+			"b": []byte("blob b"),
+			"c": []byte("blob c"),
+			// d uses the same blob as a
+			"d": []byte("blob a"),
+		}
+	})
+
+	It("should download the blobs of all resources", func() {
+		resolver := &testBlobResolver{
+			resolve: func(_ context.Context, res v2.Resource, writer io.Writer) (*ctf.BlobInfo, error) {
+				if _, err := writer.Write(blobs[res.Name]); err != nil {
+					return nil, err
+				}
+				return &ctf.BlobInfo{Digest: digest.FromBytes(blobs[res.Name]).String(), Size: int64(len(blobs[res.Name]))}, nil
+			},
+		}
+
+		h := ctf.PrefetchBlobs(context.TODO(), cd, resolver, targetFS, 2)
+		Expect(h.Wait()).To(BeEmpty())
+
+		for _, data := range blobs {
+			stored, err := vfs.ReadFile(targetFS, ctf.BlobPath(digest.FromBytes(data).String()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stored).To(Equal(data))
+		}
+		files, err := vfs.ReadDir(targetFS, ctf.BlobsDirectoryName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(HaveLen(3))
+	})
+
+	It("should return the errors of failed downloads", func() {
+		resolver := &testBlobResolver{
+			resolve: func(_ context.Context, res v2.Resource, writer io.Writer) (*ctf.BlobInfo, error) {
+				if res.Name == "b" {
+					return nil, errors.New("download failed")
+				}
+				_, err := writer.Write(blobs[res.Name])
+				return &ctf.BlobInfo{}, err
+			},
+		}
+
+		errs := ctf.PrefetchBlobs(context.TODO(), cd, resolver, targetFS, 2).Wait()
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Error()).To(ContainSubstring("download failed"))
+		// the digest is computed if the resolver does not return it.
+		Expect(vfs.FileExists(targetFS, ctf.BlobPath(digest.FromBytes(blobs["c"]).String()))).To(BeTrue())
+		Expect(vfs.FileExists(targetFS, ctf.BlobPath(digest.FromBytes(blobs["b"]).String()))).To(BeFalse())
+	})
+
+	It("should store blobs under their computed digest and reject mismatching digests", func() {
+		resolver := &testBlobResolver{
+			resolve: func(_ context.Context, res v2.Resource, writer io.Writer) (*ctf.BlobInfo, error) {
+				if _, err := writer.Write([]byte("tampered")); err != nil {
+					return nil, err
+				}
+				return &ctf.BlobInfo{Digest: digest.FromBytes(blobs[res.Name]).String()}, nil
+			},
+		}
+		cd.Resources = cd.Resources[:1]
+
+		errs := ctf.PrefetchBlobs(context.TODO(), cd, resolver, targetFS, 1).Wait()
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Error()).To(ContainSubstring("does not match the expected digest"))
+		files, err := vfs.ReadDir(targetFS, ctf.BlobsDirectoryName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(BeEmpty())
+	})
+
+	It("should reject blobs that do not match the generic blob digest of the resource", func() {
+		resolver := &testBlobResolver{
+			resolve: func(_ context.Context, res v2.Resource, writer io.Writer) (*ctf.BlobInfo, error) {
+				_, err := writer.Write(blobs[res.Name])
+				return &ctf.BlobInfo{}, err
+			},
+		}
+		sha512Digest := digest.SHA512.FromBytes(blobs["b"])
+		cd.Resources = cd.Resources[:2]
+		cd.Resources[0].Digest = &v2.DigestSpec{
+			HashAlgorithm:          signatures.SHA256,
+			NormalisationAlgorithm: string(v2.GenericBlobDigestV1),
+			Value:                  digest.FromBytes(blobs["b"]).Encoded(),
+		}
+		cd.Resources[1].Digest = &v2.DigestSpec{
+			HashAlgorithm:          "sha512",
+			NormalisationAlgorithm: string(v2.GenericBlobDigestV1),
+			Value:                  sha512Digest.Encoded(),
+		}
+
+		errs := ctf.PrefetchBlobs(context.TODO(), cd, resolver, targetFS, 1).Wait()
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Error()).To(ContainSubstring("resource a:1.0.0"))
+		Expect(vfs.FileExists(targetFS, ctf.BlobPath(digest.FromBytes(blobs["a"]).String()))).To(BeFalse())
+		Expect(vfs.FileExists(targetFS, ctf.BlobPath(digest.FromBytes(blobs["b"]).String()))).To(BeTrue())
+	})
+
+	It("should cancel in-flight downloads", func() {
+		started := make(chan struct{}, len(cd.Resources))
+		resolver := &testBlobResolver{
+			resolve: func(ctx context.Context, _ v2.Resource, _ io.Writer) (*ctf.BlobInfo, error) {
+				started <- struct{}{}
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		}
+
+		h := ctf.PrefetchBlobs(context.TODO(), cd, resolver, targetFS, 2)
+		<-started
+		errs := h.CancelAndWait()
+		Expect(errs).To(HaveLen(4))
+		for _, err := range errs {
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		}
+		files, err := vfs.ReadDir(targetFS, ctf.BlobsDirectoryName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(BeEmpty())
+	})
+
+})
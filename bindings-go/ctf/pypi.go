@@ -0,0 +1,182 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+const (
+	// PyPIWheelMediaType is the media type of python wheel packages.
+	PyPIWheelMediaType = "application/zip"
+	// PyPISdistMediaType is the media type of python source distributions.
+	PyPISdistMediaType = "application/gzip"
+)
+
+// PyPIBlobResolver implements the BlobResolver interface for "pypiPackage" access types.
+// The package files are fetched via http from the url that is published in the json api of the package index.
+// Pure python wheels that are compatible with all python 3 platforms are preferred over source distributions,
+// wheels that depend on a platform or abi are not used.
+type PyPIBlobResolver struct {
+	client *http.Client
+}
+
+// NewPyPIBlobResolver creates a new python package blob resolver that uses the given http client.
+// The default http client is used if no client is given.
+func NewPyPIBlobResolver(client *http.Client) *PyPIBlobResolver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &PyPIBlobResolver{
+		client: client,
+	}
+}
+
+// pypiVersionMetadata describes the relevant parts of the json api response for a package version.
+type pypiVersionMetadata struct {
+	URLs []pypiFile `json:"urls"`
+}
+
+// pypiFile describes a downloadable file of a python package version.
+type pypiFile struct {
+	Filename    string `json:"filename"`
+	URL         string `json:"url"`
+	PackageType string `json:"packagetype"`
+	Size        int64  `json:"size"`
+	Digests     struct {
+		SHA256 string `json:"sha256"`
+	} `json:"digests"`
+}
+
+func (p *PyPIBlobResolver) CanResolve(res v2.Resource) bool {
+	return res.Access != nil && res.Access.GetType() == v2.PyPIPackageType
+}
+
+// Info fetches the metadata of the package version from the package index
+// and returns the sha256 digest and size of the package file without downloading it.
+func (p *PyPIBlobResolver) Info(ctx context.Context, res v2.Resource) (*BlobInfo, error) {
+	_, info, err := p.resolveFile(ctx, res)
+	return info, err
+}
+
+// Resolve fetches the package file of the resource and writes it to the given writer.
+// An error is returned if the sha256 digest of the file does not match the digest declared by the package index.
+func (p *PyPIBlobResolver) Resolve(ctx context.Context, res v2.Resource, writer io.Writer) (*BlobInfo, error) {
+	file, info, err := p.resolveFile(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := doHTTPRequest(ctx, p.client, http.MethodGet, file.URL, "python package", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(writer, digester.Hash()), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read python package from %q: %w", file.URL, err)
+	}
+	if digester.Digest().String() != info.Digest {
+		return nil, fmt.Errorf("digest of python package %q is %q but expected %q", file.URL, digester.Digest().String(), info.Digest)
+	}
+	info.Size = size
+	metrics.BlobsResolved.Add(1)
+	return info, nil
+}
+
+// resolveFile fetches the metadata of the package version and returns the package file that should be used.
+func (p *PyPIBlobResolver) resolveFile(ctx context.Context, res v2.Resource) (*pypiFile, *BlobInfo, error) {
+	pypiAccess, err := p.decodeAccess(res)
+	if err != nil {
+		return nil, nil, err
+	}
+	url := fmt.Sprintf("%s/%s/%s/json", strings.TrimSuffix(pypiAccess.Index, "/"), pypiAccess.Package, pypiAccess.Version)
+	resp, err := doHTTPRequest(ctx, p.client, http.MethodGet, url, "python package", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	metadata := &pypiVersionMetadata{}
+	if err := json.NewDecoder(resp.Body).Decode(metadata); err != nil {
+		return nil, nil, fmt.Errorf("unable to decode python package metadata from %q: %w", url, err)
+	}
+
+	var (
+		file      *pypiFile
+		mediaType string
+	)
+	for i, f := range metadata.URLs {
+		if isPurePython3Wheel(f.Filename) {
+			file, mediaType = &metadata.URLs[i], PyPIWheelMediaType
+			break
+		}
+		if file == nil && strings.HasSuffix(f.Filename, ".tar.gz") {
+			file, mediaType = &metadata.URLs[i], PyPISdistMediaType
+		}
+	}
+	if file == nil {
+		return nil, nil, fmt.Errorf("python package metadata from %q does not contain a pure python wheel or source distribution: %w", url, NotFoundError)
+	}
+	dig := digest.NewDigestFromEncoded(digest.SHA256, file.Digests.SHA256)
+	if err := dig.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("python package metadata from %q does not contain a valid sha256 digest for %q: %w", url, file.Filename, err)
+	}
+	return file, &BlobInfo{
+		MediaType: mediaType,
+		Digest:    dig.String(),
+		Size:      file.Size,
+	}, nil
+}
+
+// isPurePython3Wheel checks whether the filename is a wheel that does not depend on an abi or platform
+// and supports python 3, e.g. "example-1.2.3-py3-none-any.whl" or "example-1.2.3-py2.py3-none-any.whl".
+func isPurePython3Wheel(filename string) bool {
+	if !strings.HasSuffix(filename, ".whl") {
+		return false
+	}
+	// {distribution}-{version}(-{build tag})?-{python tag}-{abi tag}-{platform tag}.whl
+	parts := strings.Split(strings.TrimSuffix(filename, ".whl"), "-")
+	if len(parts) < 5 || parts[len(parts)-2] != "none" || parts[len(parts)-1] != "any" {
+		return false
+	}
+	for _, tag := range strings.Split(parts[len(parts)-3], ".") {
+		if tag == "py3" {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *PyPIBlobResolver) decodeAccess(res v2.Resource) (*v2.PyPIAccess, error) {
+	if !p.CanResolve(res) {
+		return nil, UnsupportedResolveType
+	}
+	pypiAccess := &v2.PyPIAccess{}
+	if err := res.Access.DecodeInto(pypiAccess); err != nil {
+		return nil, fmt.Errorf("unable to decode access to type '%s': %w", res.Access.GetType(), err)
+	}
+	return pypiAccess, nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("PyPI", func() {
+
+	var (
+		server      *httptest.Server
+		wheel       = []byte("wheel content")
+		wheelDigest string
+	)
+
+	newPyPIResource := func(access *v2.PyPIAccess) v2.Resource {
+		unstructured, err := v2.NewUnstructured(access)
+		Expect(err).ToNot(HaveOccurred())
+		return v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:    "pkg",
+				Version: "1.2.3",
+				Type:    "pythonPackage",
+			},
+			Relation: v2.ExternalRelation,
+			Access:   &unstructured,
+		}
+	}
+
+	BeforeEach(func() {
+		wheelDigest = digest.FromBytes(wheel).Encoded()
+		mux := http.NewServeMux()
+		mux.HandleFunc("/pypi/example/1.2.3/json", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprintf(w, `{"urls": [
+				{"filename": "example-1.2.3.tar.gz", "packagetype": "sdist", "url": "http://%[1]s/files/example-1.2.3.tar.gz", "size": 100, "digests": {"sha256": "%[2]s"}},
+				{"filename": "example-1.2.3-py3-none-any.whl", "packagetype": "bdist_wheel", "url": "http://%[1]s/files/example-1.2.3-py3-none-any.whl", "size": %[3]d, "digests": {"sha256": "%[4]s"}}
+			]}`, r.Host, digest.FromString("sdist").Encoded(), len(wheel), wheelDigest)
+		})
+		mux.HandleFunc("/pypi/native/1.2.3/json", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprintf(w, `{"urls": [
+				{"filename": "native-1.2.3-cp311-cp311-manylinux_2_17_x86_64.whl", "packagetype": "bdist_wheel", "url": "http://%[1]s/files/native-1.2.3-cp311-cp311-manylinux_2_17_x86_64.whl", "size": 100, "digests": {"sha256": "%[2]s"}},
+				{"filename": "native-1.2.3-py2-none-any.whl", "packagetype": "bdist_wheel", "url": "http://%[1]s/files/native-1.2.3-py2-none-any.whl", "size": 100, "digests": {"sha256": "%[2]s"}},
+				{"filename": "native-1.2.3.tar.gz", "packagetype": "sdist", "url": "http://%[1]s/files/native-1.2.3.tar.gz", "size": 42, "digests": {"sha256": "%[3]s"}},
+				{"filename": "native-1.2.3-1-py2.py3-none-any.whl", "packagetype": "bdist_wheel", "url": "http://%[1]s/files/native-1.2.3-1-py2.py3-none-any.whl", "size": %[4]d, "digests": {"sha256": "%[5]s"}}
+			]}`, r.Host, digest.FromString("platform").Encoded(), digest.FromString("sdist").Encoded(), len(wheel), wheelDigest)
+		})
+		mux.HandleFunc("/pypi/native/2.0.0/json", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprintf(w, `{"urls": [
+				{"filename": "native-2.0.0-cp311-cp311-manylinux_2_17_x86_64.whl", "packagetype": "bdist_wheel", "url": "http://%[1]s/files/native-2.0.0-cp311-cp311-manylinux_2_17_x86_64.whl", "size": 100, "digests": {"sha256": "%[2]s"}},
+				{"filename": "native-2.0.0.tar.gz", "packagetype": "sdist", "url": "http://%[1]s/files/native-2.0.0.tar.gz", "size": 42, "digests": {"sha256": "%[3]s"}}
+			]}`, r.Host, digest.FromString("platform").Encoded(), digest.FromString("sdist").Encoded())
+		})
+		mux.HandleFunc("/pypi/tampered/1.2.3/json", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprintf(w, `{"urls": [
+				{"filename": "tampered-1.2.3-py3-none-any.whl", "packagetype": "bdist_wheel", "url": "http://%s/files/example-1.2.3-py3-none-any.whl", "size": %d, "digests": {"sha256": "%s"}}
+			]}`, r.Host, len(wheel), digest.FromString("other").Encoded())
+		})
+		mux.HandleFunc("/files/example-1.2.3-py3-none-any.whl", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(wheel)
+		})
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should resolve the wheel of a python package", func() {
+		resolver := ctf.NewPyPIBlobResolver(server.Client())
+		res := newPyPIResource(v2.NewPyPIAccess(server.URL+"/pypi", "example", "1.2.3"))
+		Expect(resolver.CanResolve(res)).To(BeTrue())
+
+		var result bytes.Buffer
+		info, err := resolver.Resolve(context.TODO(), res, &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(wheel))
+		Expect(info.Digest).To(Equal("sha256:" + wheelDigest))
+		Expect(info.Size).To(Equal(int64(len(wheel))))
+		Expect(info.MediaType).To(Equal(ctf.PyPIWheelMediaType))
+	})
+
+	It("should return the info from the package metadata", func() {
+		resolver := ctf.NewPyPIBlobResolver(server.Client())
+		info, err := resolver.Info(context.TODO(), newPyPIResource(v2.NewPyPIAccess(server.URL+"/pypi", "example", "1.2.3")))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Digest).To(Equal("sha256:" + wheelDigest))
+		Expect(info.Size).To(Equal(int64(len(wheel))))
+	})
+
+	It("should prefer pure python 3 wheels over platform specific wheels", func() {
+		resolver := ctf.NewPyPIBlobResolver(server.Client())
+		info, err := resolver.Info(context.TODO(), newPyPIResource(v2.NewPyPIAccess(server.URL+"/pypi", "native", "1.2.3")))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Digest).To(Equal("sha256:" + wheelDigest))
+		Expect(info.MediaType).To(Equal(ctf.PyPIWheelMediaType))
+	})
+
+	It("should use the source distribution if there is no pure python 3 wheel", func() {
+		resolver := ctf.NewPyPIBlobResolver(server.Client())
+		info, err := resolver.Info(context.TODO(), newPyPIResource(v2.NewPyPIAccess(server.URL+"/pypi", "native", "2.0.0")))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Digest).To(Equal(digest.FromString("sdist").String()))
+		Expect(info.Size).To(Equal(int64(42)))
+		Expect(info.MediaType).To(Equal(ctf.PyPISdistMediaType))
+	})
+
+	It("should fail if the package digest does not match the declared digest", func() {
+		resolver := ctf.NewPyPIBlobResolver(server.Client())
+		_, err := resolver.Resolve(context.TODO(), newPyPIResource(v2.NewPyPIAccess(server.URL+"/pypi", "tampered", "1.2.3")), &bytes.Buffer{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return a not found error for unknown packages", func() {
+		resolver := ctf.NewPyPIBlobResolver(server.Client())
+		_, err := resolver.Info(context.TODO(), newPyPIResource(v2.NewPyPIAccess(server.URL+"/pypi", "example", "2.0.0")))
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+})
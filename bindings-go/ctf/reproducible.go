@@ -0,0 +1,136 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/codec"
+)
+
+// reproducibleModTime is the modification time of all entries of reproducible tars.
+var reproducibleModTime = time.Unix(0, 0)
+
+// tarEntry is a file or directory of a tar that is written by WriteTarReproducible.
+type tarEntry struct {
+	header *tar.Header
+	// open returns the content of a file entry.
+	open func() (io.ReadCloser, error)
+}
+
+// WriteTarReproducible tars the current components descriptor and its artifacts so that archives with the same content
+// result in byte-identical tars.
+// All tar entries are sorted by their path and written with zero timestamps and owner ids.
+// The resources of an unsigned component descriptor are written sorted by their identity, which changes its digest.
+// Signed component descriptors are written unchanged as reordering the resources would invalidate their signatures.
+// The component descriptor of the archive itself is not modified.
+func (ca *ComponentArchive) WriteTarReproducible(writer io.Writer) error {
+	cd := ca.ComponentDescriptor.DeepCopy()
+	if len(cd.Signatures) == 0 {
+		sortResources(cd.Resources)
+	}
+	cdBytes, err := codec.Encode(cd)
+	if err != nil {
+		return fmt.Errorf("unable to encode component descriptor: %w", err)
+	}
+
+	entries := []tarEntry{
+		{
+			header: &tar.Header{
+				Name: ComponentDescriptorFileName,
+				Size: int64(len(cdBytes)),
+				Mode: 0644,
+			},
+			open: func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(cdBytes)), nil
+			},
+		},
+		{
+			header: &tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     BlobsDirectoryName,
+				Mode:     0644,
+			},
+		},
+	}
+	blobs, err := vfs.ReadDir(ca.fs, BlobsDirectoryName)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("unable to read blob directory: %w", err)
+	}
+	for _, blobInfo := range blobs {
+		blobpath := BlobPath(blobInfo.Name())
+		entries = append(entries, tarEntry{
+			header: &tar.Header{
+				Name: blobpath,
+				Size: blobInfo.Size(),
+				Mode: 0644,
+			},
+			open: func() (io.ReadCloser, error) {
+				return ca.fs.Open(blobpath)
+			},
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].header.Name < entries[j].header.Name
+	})
+
+	tw := tar.NewWriter(writer)
+	for _, entry := range entries {
+		entry.header.ModTime = reproducibleModTime
+		entry.header.Uid = 0
+		entry.header.Gid = 0
+		if err := tw.WriteHeader(entry.header); err != nil {
+			return fmt.Errorf("unable to write header of %s: %w", entry.header.Name, err)
+		}
+		if entry.open == nil {
+			continue
+		}
+		content, err := entry.open()
+		if err != nil {
+			return fmt.Errorf("unable to open %s: %w", entry.header.Name, err)
+		}
+		if _, err := io.Copy(tw, content); err != nil {
+			_ = content.Close()
+			return fmt.Errorf("unable to write content of %s: %w", entry.header.Name, err)
+		}
+		if err := content.Close(); err != nil {
+			return fmt.Errorf("unable to close %s: %w", entry.header.Name, err)
+		}
+	}
+	return tw.Close()
+}
+
+// sortResources sorts the resources by their name, version and identity.
+func sortResources(resources []v2.Resource) {
+	sort.SliceStable(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if a.GetName() != b.GetName() {
+			return a.GetName() < b.GetName()
+		}
+		if a.GetVersion() != b.GetVersion() {
+			return a.GetVersion() < b.GetVersion()
+		}
+		return bytes.Compare(a.GetIdentityDigest(), b.GetIdentityDigest()) < 0
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("reproducible tar", func() {
+
+	addResource := func(ca *ctf.ComponentArchive, name, content string) {
+		Expect(ca.AddResource(&v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{Name: name, Version: "1.0.0", Type: "blob"},
+			Relation:           v2.LocalRelation,
+		}, ctf.BlobInfo{
+			MediaType: "text/plain",
+			Digest:    digest.FromString(content).String(),
+			Size:      int64(len(content)),
+		}, strings.NewReader(content))).To(Succeed())
+	}
+
+	It("should write byte-identical tars for archives with resources in different orders", func() {
+		ca1 := newComponentArchive("example.com/comp", "1.0.0")
+		addResource(ca1, "res-a", "content a")
+		addResource(ca1, "res-b", "content b")
+		ca2 := newComponentArchive("example.com/comp", "1.0.0")
+		addResource(ca2, "res-b", "content b")
+		addResource(ca2, "res-a", "content a")
+
+		var tar1, tar2 bytes.Buffer
+		Expect(ca1.WriteTarReproducible(&tar1)).To(Succeed())
+		Expect(ca2.WriteTarReproducible(&tar2)).To(Succeed())
+		Expect(tar1.Bytes()).To(Equal(tar2.Bytes()))
+
+		// the original archive is not modified
+		Expect(ca2.ComponentDescriptor.Resources[0].Name).To(Equal("res-b"))
+	})
+
+	It("should write sorted entries without timestamps and owners", func() {
+		ca := newComponentArchive("example.com/comp", "1.0.0")
+		addResource(ca, "res-b", "content b")
+		addResource(ca, "res-a", "content a")
+
+		var buf bytes.Buffer
+		Expect(ca.WriteTarReproducible(&buf)).To(Succeed())
+		tr := tar.NewReader(bytes.NewReader(buf.Bytes()))
+		var names []string
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).ToNot(HaveOccurred())
+			Expect(header.ModTime.Unix()).To(BeZero())
+			Expect(header.Uid).To(BeZero())
+			Expect(header.Gid).To(BeZero())
+			names = append(names, header.Name)
+		}
+		Expect(names).To(Equal([]string{
+			ctf.BlobsDirectoryName,
+			ctf.BlobPath(digest.FromString("content a").String()),
+			ctf.BlobPath(digest.FromString("content b").String()),
+			ctf.ComponentDescriptorFileName,
+		}))
+
+		read, err := ctf.NewComponentArchiveFromTarReader(bytes.NewReader(buf.Bytes()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(read.ComponentDescriptor.Resources).To(HaveLen(2))
+		Expect(read.ComponentDescriptor.Resources[0].Name).To(Equal("res-a"))
+	})
+
+	It("should not reorder the resources of signed component descriptors", func() {
+		ca := newComponentArchive("example.com/comp", "1.0.0")
+		addResource(ca, "res-b", "content b")
+		addResource(ca, "res-a", "content a")
+		ca.ComponentDescriptor.Signatures = []v2.Signature{{Name: "sig"}}
+
+		var buf bytes.Buffer
+		Expect(ca.WriteTarReproducible(&buf)).To(Succeed())
+		read, err := ctf.NewComponentArchiveFromTarReader(bytes.NewReader(buf.Bytes()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(read.ComponentDescriptor.Resources[0].Name).To(Equal("res-b"))
+		Expect(read.ComponentDescriptor.Resources[1].Name).To(Equal("res-a"))
+	})
+
+})
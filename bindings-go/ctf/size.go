@@ -0,0 +1,111 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+)
+
+// ErrCTFSizeLimitExceeded is returned if a component archive cannot be added to a ctf
+// because the ctf would exceed its maximum size.
+type ErrCTFSizeLimitExceeded struct {
+	// Limit is the maximum size of the ctf.
+	Limit int64
+	// Current is the current size of the ctf.
+	Current int64
+	// Requested is the size of the component archive that should be added.
+	Requested int64
+}
+
+func (e ErrCTFSizeLimitExceeded) Error() string {
+	return fmt.Sprintf("adding %d bytes to the ctf of size %d would exceed the size limit of %d bytes", e.Requested, e.Current, e.Limit)
+}
+
+// SizeBytes returns the size of the component archive serialized as tar in bytes.
+func (ca *ComponentArchive) SizeBytes() (int64, error) {
+	var counter byteCounter
+	if err := ca.WriteTar(&counter); err != nil {
+		return 0, fmt.Errorf("unable to compute size of component archive: %w", err)
+	}
+	return int64(counter), nil
+}
+
+// SizeBytes returns the size of all files of the ctf in bytes.
+func (ctf *CTF) SizeBytes() (int64, error) {
+	ctf.mux.RLock()
+	defer ctf.mux.RUnlock()
+	return ctf.sizeBytes()
+}
+
+// sizeBytes returns the size of all files of the ctf in bytes.
+// The caller has to hold the lock of the ctf.
+func (ctf *CTF) sizeBytes() (int64, error) {
+	if err := ctf.checkExpired(); err != nil {
+		return 0, err
+	}
+	var size int64
+	err := vfs.Walk(ctf.tempFs, "/", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to compute size of ctf: %w", err)
+	}
+	return size, nil
+}
+
+// checkSizeLimit checks whether the component archive can be written to the given file of the ctf
+// without exceeding the configured maximum size.
+// The size of an existing file is not counted as the file is replaced.
+func (ctf *CTF) checkSizeLimit(filename string, ca *ComponentArchive) error {
+	if ctf.opts.MaxSize <= 0 {
+		return nil
+	}
+	current, err := ctf.sizeBytes()
+	if err != nil {
+		return err
+	}
+	if info, err := ctf.tempFs.Stat(filename); err == nil && info.Mode().IsRegular() {
+		current -= info.Size()
+	}
+	requested, err := ca.SizeBytes()
+	if err != nil {
+		return err
+	}
+	if current+requested > ctf.opts.MaxSize {
+		return ErrCTFSizeLimitExceeded{
+			Limit:     ctf.opts.MaxSize,
+			Current:   current,
+			Requested: requested,
+		}
+	}
+	return nil
+}
+
+// byteCounter is a writer that only counts the written bytes.
+type byteCounter int64
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
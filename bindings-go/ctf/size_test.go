@@ -0,0 +1,80 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"archive/tar"
+	"errors"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("size limit", func() {
+
+	It("should reject component archives that exceed the size limit", func() {
+		ca1 := newComponentArchive("example.com/comp-1", "1.0.0")
+		ca2 := newComponentArchive("example.com/comp-2", "1.0.0")
+		ca3 := newComponentArchive("example.com/comp-3", "1.0.0")
+		size1, err := ca1.SizeBytes()
+		Expect(err).ToNot(HaveOccurred())
+		size2, err := ca2.SizeBytes()
+		Expect(err).ToNot(HaveOccurred())
+		size3, err := ca3.SizeBytes()
+		Expect(err).ToNot(HaveOccurred())
+
+		fs := memoryfs.New()
+		file, err := fs.Create("/ctf.tar")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tar.NewWriter(file).Close()).To(Succeed())
+		Expect(file.Close()).To(Succeed())
+		limit := size1 + size2
+		c, err := ctf.OpenCTF(fs, "/ctf.tar", ctf.WithMaxSize(limit))
+		Expect(err).ToNot(HaveOccurred())
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+
+		Expect(c.AddComponentArchive(ca1, ctf.ArchiveFormatTar)).To(Succeed())
+		Expect(c.AddComponentArchive(ca2, ctf.ArchiveFormatTar)).To(Succeed())
+		Expect(c.SizeBytes()).To(Equal(limit))
+
+		err = c.AddComponentArchive(ca3, ctf.ArchiveFormatTar)
+		Expect(err).To(HaveOccurred())
+		limitErr := ctf.ErrCTFSizeLimitExceeded{}
+		Expect(errors.As(err, &limitErr)).To(BeTrue())
+		Expect(limitErr).To(Equal(ctf.ErrCTFSizeLimitExceeded{
+			Limit:     limit,
+			Current:   limit,
+			Requested: size3,
+		}))
+		Expect(componentNames(c)).To(ConsistOf("example.com/comp-1", "example.com/comp-2"))
+
+		// replacing an existing archive does not count its current size
+		Expect(c.AddComponentArchive(ca2, ctf.ArchiveFormatTar)).To(Succeed())
+	})
+
+	It("should not limit the size by default", func() {
+		c := newEmptyCTF(memoryfs.New(), "/ctf.tar")
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+		Expect(c.AddComponentArchive(newComponentArchive("example.com/comp-1", "1.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+	})
+
+})
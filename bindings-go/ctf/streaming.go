@@ -0,0 +1,143 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// DefaultStreamingBufferThreshold is the default size in bytes up to which
+// component archives are buffered in memory by the streaming ctf writer.
+const DefaultStreamingBufferThreshold int64 = 32 * 1024 * 1024
+
+// errBufferThresholdExceeded is returned by the threshold buffer if the content exceeds the threshold.
+var errBufferThresholdExceeded = errors.New("buffer threshold exceeded")
+
+// StreamingCTFWriter writes a ctf sequentially to a writer without a temporary directory.
+// The ctf can be read with NewCTF.
+type StreamingCTFWriter struct {
+	tw              *tar.Writer
+	format          ArchiveFormat
+	bufferThreshold int64
+}
+
+// NewStreamingCTFWriter creates a new streaming ctf writer that writes the ctf as tar to the given writer.
+// The component archives are added to the ctf in the given format.
+// The user has to call "Close" to finish the ctf.
+func NewStreamingCTFWriter(w io.Writer, format ArchiveFormat) *StreamingCTFWriter {
+	return &StreamingCTFWriter{
+		tw:              tar.NewWriter(w),
+		format:          format,
+		bufferThreshold: DefaultStreamingBufferThreshold,
+	}
+}
+
+// WithBufferThreshold configures the size in bytes up to which component archives are buffered in memory.
+// Larger component archives are serialized twice: once to compute their size and once to write them.
+func (w *StreamingCTFWriter) WithBufferThreshold(bytes int64) *StreamingCTFWriter {
+	w.bufferThreshold = bytes
+	return w
+}
+
+// AddComponentArchive writes the component archive to the ctf.
+// The archive is named by its digest as done by CTF.AddComponentArchive.
+func (w *StreamingCTFWriter) AddComponentArchive(ca *ComponentArchive) error {
+	if w.format != ArchiveFormatTar && w.format != ArchiveFormatTarGzip {
+		return fmt.Errorf("unsupported archive format %q", w.format)
+	}
+	filename, err := ca.Digest()
+	if err != nil {
+		return err
+	}
+	// use the same modification time for all writes so that every serialization has the same size.
+	modTime := time.Now()
+
+	buf := &thresholdBuffer{threshold: w.bufferThreshold}
+	err = w.writeArchive(buf, ca, modTime)
+	if err == nil {
+		if err := w.writeHeader(filename, int64(buf.Len()), modTime); err != nil {
+			return err
+		}
+		if _, err := io.Copy(w.tw, &buf.Buffer); err != nil {
+			return fmt.Errorf("unable to write component archive %q: %w", filename, err)
+		}
+		return nil
+	}
+	if !errors.Is(err, errBufferThresholdExceeded) {
+		return fmt.Errorf("unable to serialize component archive %q: %w", filename, err)
+	}
+
+	var counter byteCounter
+	if err := w.writeArchive(&counter, ca, modTime); err != nil {
+		return fmt.Errorf("unable to compute size of component archive %q: %w", filename, err)
+	}
+	if err := w.writeHeader(filename, int64(counter), modTime); err != nil {
+		return err
+	}
+	if err := w.writeArchive(w.tw, ca, modTime); err != nil {
+		return fmt.Errorf("unable to write component archive %q: %w", filename, err)
+	}
+	return nil
+}
+
+// Close writes the end of the ctf.
+// The underlying writer is not closed.
+func (w *StreamingCTFWriter) Close() error {
+	return w.tw.Close()
+}
+
+func (w *StreamingCTFWriter) writeHeader(filename string, size int64, modTime time.Time) error {
+	err := w.tw.WriteHeader(&tar.Header{
+		Name:    filename,
+		Size:    size,
+		Mode:    0644,
+		ModTime: modTime,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write header for %q: %w", filename, err)
+	}
+	return nil
+}
+
+// writeArchive serializes the component archive in the configured format.
+func (w *StreamingCTFWriter) writeArchive(writer io.Writer, ca *ComponentArchive, modTime time.Time) error {
+	if w.format == ArchiveFormatTar {
+		return ca.writeTar(writer, modTime)
+	}
+	gw := gzip.NewWriter(writer)
+	if err := ca.writeTar(gw, modTime); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// thresholdBuffer is a buffer that fails if more than the threshold bytes are written.
+type thresholdBuffer struct {
+	bytes.Buffer
+	threshold int64
+}
+
+func (b *thresholdBuffer) Write(p []byte) (int, error) {
+	if int64(b.Len()+len(p)) > b.threshold {
+		return 0, errBufferThresholdExceeded
+	}
+	return b.Buffer.Write(p)
+}
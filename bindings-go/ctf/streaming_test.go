@@ -0,0 +1,101 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("streaming ctf writer", func() {
+
+	It("should write a ctf that contains all added component archives", func() {
+		blob := []byte("blob content")
+		ca1 := newComponentArchive("example.com/comp-1", "1.0.0")
+		Expect(ca1.AddResource(&v2.Resource{
+			IdentityObjectMeta: v2.IdentityObjectMeta{
+				Name:    "res",
+				Version: "1.0.0",
+				Type:    "plain",
+			},
+			Relation: v2.LocalRelation,
+		}, ctf.BlobInfo{
+			MediaType: "text/plain",
+			Digest:    digest.FromBytes(blob).String(),
+			Size:      int64(len(blob)),
+		}, bytes.NewReader(blob))).To(Succeed())
+		ca2 := newComponentArchive("example.com/comp-2", "1.0.0")
+		size1, err := ca1.SizeBytes()
+		Expect(err).ToNot(HaveOccurred())
+
+		var buf bytes.Buffer
+		// the first archive exceeds the threshold and is not buffered
+		w := ctf.NewStreamingCTFWriter(&buf, ctf.ArchiveFormatTar).WithBufferThreshold(size1 - 1)
+		Expect(w.AddComponentArchive(ca1)).To(Succeed())
+		Expect(w.AddComponentArchive(ca2)).To(Succeed())
+		Expect(w.Close()).To(Succeed())
+
+		fs := memoryfs.New()
+		Expect(vfs.WriteFile(fs, "/ctf.tar", buf.Bytes(), 0644)).To(Succeed())
+		c, err := ctf.NewCTF(fs, "/ctf.tar")
+		Expect(err).ToNot(HaveOccurred())
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+
+		Expect(componentNames(c)).To(ConsistOf("example.com/comp-1", "example.com/comp-2"))
+		Expect(c.Walk(func(ca *ctf.ComponentArchive) error {
+			if ca.ComponentDescriptor.GetName() != "example.com/comp-1" {
+				return nil
+			}
+			res, err := ca.ComponentDescriptor.GetResourcesByName("res")
+			Expect(err).ToNot(HaveOccurred())
+			var content bytes.Buffer
+			_, err = ca.Resolve(context.TODO(), res[0], &content)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(content.Bytes()).To(Equal(blob))
+			return nil
+		})).To(Succeed())
+	})
+
+	It("should write component archives as gzipped tar", func() {
+		var buf bytes.Buffer
+		w := ctf.NewStreamingCTFWriter(&buf, ctf.ArchiveFormatTarGzip).WithBufferThreshold(0)
+		Expect(w.AddComponentArchive(newComponentArchive("example.com/comp-1", "1.0.0"))).To(Succeed())
+		Expect(w.Close()).To(Succeed())
+
+		fs := memoryfs.New()
+		Expect(vfs.WriteFile(fs, "/ctf.tar", buf.Bytes(), 0644)).To(Succeed())
+		c, err := ctf.NewCTF(fs, "/ctf.tar")
+		Expect(err).ToNot(HaveOccurred())
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+	})
+
+	It("should reject unsupported archive formats", func() {
+		w := ctf.NewStreamingCTFWriter(&bytes.Buffer{}, ctf.ArchiveFormatFilesystem)
+		Expect(w.AddComponentArchive(newComponentArchive("example.com/comp-1", "1.0.0"))).ToNot(Succeed())
+	})
+})
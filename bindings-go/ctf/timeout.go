@@ -0,0 +1,104 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCTFExpired is returned by all operations on a ctf that has been closed because its timeout expired.
+var ErrCTFExpired = errors.New("CTFExpired")
+
+// ctfExpiry tracks the timeout of a ctf.
+type ctfExpiry struct {
+	mux     sync.Mutex
+	timer   *time.Timer
+	expired bool
+}
+
+// ExtendTimeout resets the timeout of the ctf so that it is closed after the given duration.
+// Later writes and changes of the ctf reset the timeout to the configured timeout again.
+func (ctf *CTF) ExtendTimeout(d time.Duration) error {
+	ctf.expiry.mux.Lock()
+	defer ctf.expiry.mux.Unlock()
+	if ctf.expiry.expired {
+		return ErrCTFExpired
+	}
+	if ctf.expiry.timer == nil {
+		return errors.New("the ctf has no timeout")
+	}
+	ctf.expiry.timer.Reset(d)
+	return nil
+}
+
+// startTimeout starts the configured timeout of the ctf.
+func (ctf *CTF) startTimeout() {
+	if ctf.opts.Timeout <= 0 {
+		return
+	}
+	ctf.expiry.mux.Lock()
+	defer ctf.expiry.mux.Unlock()
+	ctf.expiry.timer = time.AfterFunc(ctf.opts.Timeout, ctf.expire)
+}
+
+// resetTimeout resets the timeout of the ctf to the configured timeout.
+// ErrCTFExpired is returned if the ctf is already expired.
+func (ctf *CTF) resetTimeout() error {
+	ctf.expiry.mux.Lock()
+	defer ctf.expiry.mux.Unlock()
+	if ctf.expiry.expired {
+		return ErrCTFExpired
+	}
+	if ctf.expiry.timer != nil {
+		ctf.expiry.timer.Reset(ctf.opts.Timeout)
+	}
+	return nil
+}
+
+// stopTimeout stops the timeout of the ctf and returns whether the ctf is already expired.
+func (ctf *CTF) stopTimeout() (expired bool) {
+	ctf.expiry.mux.Lock()
+	defer ctf.expiry.mux.Unlock()
+	if ctf.expiry.timer != nil {
+		ctf.expiry.timer.Stop()
+	}
+	return ctf.expiry.expired
+}
+
+// checkExpired returns ErrCTFExpired if the ctf is expired.
+func (ctf *CTF) checkExpired() error {
+	ctf.expiry.mux.Lock()
+	defer ctf.expiry.mux.Unlock()
+	if ctf.expiry.expired {
+		return ErrCTFExpired
+	}
+	return nil
+}
+
+// expire closes the ctf and marks it as expired.
+// The extracted content is removed while holding the lock of the ctf, so that concurrent operations are completed before.
+func (ctf *CTF) expire() {
+	ctf.mux.Lock()
+	defer ctf.mux.Unlock()
+	ctf.expiry.mux.Lock()
+	defer ctf.expiry.mux.Unlock()
+	if ctf.expiry.expired {
+		return
+	}
+	ctf.expiry.expired = true
+	_ = ctf.fs.RemoveAll(ctf.tempDir)
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("timeout", func() {
+
+	openCTF := func(fs vfs.FileSystem, opts ...ctf.CTFOption) *ctf.CTF {
+		file, err := fs.Create("/ctf.tar")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tar.NewWriter(file).Close()).To(Succeed())
+		Expect(file.Close()).To(Succeed())
+		c, err := ctf.OpenCTF(fs, "/ctf.tar", opts...)
+		Expect(err).ToNot(HaveOccurred())
+		return c
+	}
+
+	It("should close the ctf after the timeout", func() {
+		fs := memoryfs.New()
+		c := openCTF(fs, ctf.WithTimeout(50*time.Millisecond))
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+		Expect(c.AddComponentArchive(newComponentArchive("example.com/comp", "1.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+
+		Eventually(func() error {
+			return c.Walk(func(ca *ctf.ComponentArchive) error { return nil })
+		}, time.Second, 10*time.Millisecond).Should(MatchError(ctf.ErrCTFExpired))
+
+		err := c.AddComponentArchive(newComponentArchive("example.com/comp", "1.0.0"), ctf.ArchiveFormatTar)
+		Expect(errors.Is(err, ctf.ErrCTFExpired)).To(BeTrue())
+		Expect(errors.Is(c.Write(), ctf.ErrCTFExpired)).To(BeTrue())
+		Expect(errors.Is(c.ExtendTimeout(time.Minute), ctf.ErrCTFExpired)).To(BeTrue())
+
+		// only the ctf file is left
+		entries, err := vfs.ReadDir(fs, "/")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("should not remove the content of the ctf during a running operation", func() {
+		fs := memoryfs.New()
+		c := openCTF(fs, ctf.WithTimeout(50*time.Millisecond))
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+		Expect(c.AddComponentArchive(newComponentArchive("example.com/comp", "1.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+
+		// the writer blocks the operation until the timeout has expired
+		w := &slowWriter{delay: 200 * time.Millisecond}
+		Expect(ctf.WriteCTFChecksumManifest(c, w)).To(Succeed())
+		Expect(w.String()).To(ContainSubstring("sha256:"))
+		Eventually(func() error {
+			return c.Walk(func(ca *ctf.ComponentArchive) error { return nil })
+		}, time.Second, 10*time.Millisecond).Should(MatchError(ctf.ErrCTFExpired))
+	})
+
+	It("should not expire a ctf whose timeout has been extended", func() {
+		c := openCTF(memoryfs.New(), ctf.WithTimeout(50*time.Millisecond))
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+		Expect(c.ExtendTimeout(time.Minute)).To(Succeed())
+		Consistently(func() error {
+			return c.Walk(func(ca *ctf.ComponentArchive) error { return nil })
+		}, 200*time.Millisecond, 10*time.Millisecond).Should(Succeed())
+	})
+
+	It("should not expire a ctf without timeout", func() {
+		c := openCTF(memoryfs.New())
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+		Expect(c.ExtendTimeout(time.Minute)).ToNot(Succeed())
+		Expect(c.Write()).To(Succeed())
+	})
+})
+
+// slowWriter delays every write.
+type slowWriter struct {
+	bytes.Buffer
+	delay time.Duration
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	return w.Buffer.Write(p)
+}
@@ -0,0 +1,267 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+)
+
+// DefaultWatchInterval is the default interval in which a ctf archive is polled for changes.
+const DefaultWatchInterval = 2 * time.Second
+
+// watchDebounce is the time that a ctf watch waits for further notifications before the archive is read.
+// This prevents reading the archive while it is still written.
+const watchDebounce = 100 * time.Millisecond
+
+// EventType describes the kind of change of a file in the ctf.
+type EventType string
+
+const (
+	// EventTypeAdded describes a file that has been added to the ctf.
+	EventTypeAdded EventType = "added"
+	// EventTypeRemoved describes a file that has been removed from the ctf.
+	EventTypeRemoved EventType = "removed"
+	// EventTypeModified describes a file whose content has changed.
+	EventTypeModified EventType = "modified"
+)
+
+// CTFEvent describes a change of a component archive file in the ctf.
+type CTFEvent struct {
+	Type EventType
+	// Filename is the name of the component archive file in the ctf.
+	Filename string
+}
+
+// ErrUnwrittenChanges is returned by Watch if the watched archive has been changed
+// while the ctf contains changes that have not been written.
+var ErrUnwrittenChanges = errors.New("the ctf contains changes that have not been written")
+
+// Watch monitors the ctf archive for changes by other processes until the context is canceled.
+// Archives on the os filesystem are watched with filesystem notifications, all others are polled with the configured WatchInterval.
+// On a change, the extracted content of the ctf is replaced with the content of the changed archive
+// and an event is sent for every added, removed and modified component archive.
+// The content is replaced while holding the lock of the ctf, so concurrent operations see either the old or the new content.
+// If the ctf contains changes that have not been written, the content is not replaced and ErrUnwrittenChanges is returned.
+func (ctf *CTF) Watch(ctx context.Context, events chan<- CTFEvent) error {
+	if err := ctf.checkExpired(); err != nil {
+		return err
+	}
+	current, err := ctf.readArchiveSnapshot()
+	if err != nil {
+		return fmt.Errorf("unable to read ctf archive: %w", err)
+	}
+	onChange := func() error {
+		changed, err := ctf.readArchiveSnapshot()
+		if err != nil {
+			// the archive is probably still written, it is read again on the next change.
+			return nil
+		}
+		changes := diffArchiveSnapshots(current, changed)
+		if len(changes) == 0 {
+			return nil
+		}
+		current = changed
+		if err := ctf.reload(); err != nil {
+			return fmt.Errorf("unable to reload ctf: %w", err)
+		}
+		for _, event := range changes {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	}
+
+	if ctf.fs.Name() == osfs.New().Name() {
+		return ctf.watchNotify(ctx, onChange)
+	}
+	return ctf.watchPoll(ctx, onChange)
+}
+
+// watchNotify calls onChange whenever the archive is changed according to the os filesystem notifications.
+func (ctf *CTF) watchNotify(ctx context.Context, onChange func() error) error {
+	archivePath, err := filepath.Abs(ctf.ctfPath)
+	if err != nil {
+		return fmt.Errorf("unable to get absolute path of %q: %w", ctf.ctfPath, err)
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to create filesystem watcher: %w", err)
+	}
+	defer watcher.Close()
+	// the directory is watched so that atomically replaced archives are detected.
+	if err := watcher.Add(filepath.Dir(archivePath)); err != nil {
+		return fmt.Errorf("unable to watch %q: %w", archivePath, err)
+	}
+
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return errors.New("filesystem watcher has been closed")
+			}
+			if filepath.Clean(event.Name) == archivePath {
+				debounce.Reset(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return errors.New("filesystem watcher has been closed")
+			}
+			return fmt.Errorf("unable to watch %q: %w", archivePath, err)
+		case <-debounce.C:
+			if err := ctf.checkExpired(); err != nil {
+				return err
+			}
+			if err := onChange(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchPoll calls onChange whenever the size or modification time of the archive has changed
+// and has been stable for one interval.
+func (ctf *CTF) watchPoll(ctx context.Context, onChange func() error) error {
+	interval := ctf.opts.WatchInterval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	stat := func() (int64, time.Time) {
+		info, err := ctf.fs.Stat(ctf.ctfPath)
+		if err != nil {
+			return -1, time.Time{}
+		}
+		return info.Size(), info.ModTime()
+	}
+	handledSize, handledModTime := stat()
+	lastSize, lastModTime := handledSize, handledModTime
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := ctf.checkExpired(); err != nil {
+				return err
+			}
+			size, modTime := stat()
+			stable := size == lastSize && modTime.Equal(lastModTime)
+			lastSize, lastModTime = size, modTime
+			if !stable || (size == handledSize && modTime.Equal(handledModTime)) {
+				continue
+			}
+			handledSize, handledModTime = size, modTime
+			if err := onChange(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readArchiveSnapshot returns the sha256 digests of all component archive files of the ctf archive by their filename.
+func (ctf *CTF) readArchiveSnapshot() (map[string]string, error) {
+	file, err := ctf.fs.Open(ctf.ctfPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	snapshot := map[string]string{}
+	tr := tar.NewReader(file)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return snapshot, nil
+			}
+			return nil, err
+		}
+		path := filepath.Join("/", header.Name)
+		if header.Typeflag != tar.TypeReg || isSharedBlobPath(path) || path == checksumManifestPath {
+			continue
+		}
+		h := sha256.New()
+		if _, err := io.Copy(h, tr); err != nil {
+			return nil, err
+		}
+		snapshot[header.Name] = hex.EncodeToString(h.Sum(nil))
+	}
+}
+
+// diffArchiveSnapshots returns the events that describe the changes from the old to the new snapshot sorted by filename.
+func diffArchiveSnapshots(old, new map[string]string) []CTFEvent {
+	events := make([]CTFEvent, 0)
+	for name, dgst := range new {
+		oldDgst, ok := old[name]
+		if !ok {
+			events = append(events, CTFEvent{Type: EventTypeAdded, Filename: name})
+		} else if oldDgst != dgst {
+			events = append(events, CTFEvent{Type: EventTypeModified, Filename: name})
+		}
+	}
+	for name := range old {
+		if _, ok := new[name]; !ok {
+			events = append(events, CTFEvent{Type: EventTypeRemoved, Filename: name})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Filename < events[j].Filename
+	})
+	return events
+}
+
+// reload replaces the extracted content of the ctf with the content of the archive.
+func (ctf *CTF) reload() error {
+	ctf.mux.Lock()
+	defer ctf.mux.Unlock()
+	if err := ctf.checkExpired(); err != nil {
+		return err
+	}
+	if ctf.dirty {
+		return ErrUnwrittenChanges
+	}
+	entries, err := vfs.ReadDir(ctf.tempFs, "/")
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := ctf.tempFs.RemoveAll(filepath.Join("/", entry.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return ctf.extract()
+}
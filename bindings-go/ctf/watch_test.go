@@ -0,0 +1,159 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctf_test
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+var _ = Describe("watch", func() {
+
+	// createCTF creates an empty ctf archive at the given path.
+	createCTF := func(fs vfs.FileSystem, path string) {
+		file, err := fs.Create(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tar.NewWriter(file).Close()).To(Succeed())
+		Expect(file.Close()).To(Succeed())
+	}
+
+	// addComponent adds a component archive to the ctf archive at the given path like another process would do.
+	addComponent := func(fs vfs.FileSystem, path, filename string) {
+		defer GinkgoRecover()
+		c, err := ctf.NewCTF(fs, path)
+		Expect(err).ToNot(HaveOccurred())
+		defer c.Close()
+		Expect(c.AddComponentArchiveWithName(filename, newComponentArchive("example.com/a", "1.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+		Expect(c.Write()).To(Succeed())
+	}
+
+	watch := func(fs vfs.FileSystem, path string, opts ...ctf.CTFOption) {
+		c, err := ctf.OpenCTF(fs, path, opts...)
+		Expect(err).ToNot(HaveOccurred())
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		events := make(chan ctf.CTFEvent, 10)
+		watchErr := make(chan error, 1)
+		go func() {
+			watchErr <- c.Watch(ctx, events)
+		}()
+		// give the watch some time to start
+		time.Sleep(100 * time.Millisecond)
+		go addComponent(fs, path, "comp-a")
+
+		var event ctf.CTFEvent
+		Eventually(events, 5*time.Second).Should(Receive(&event))
+		Expect(event).To(Equal(ctf.CTFEvent{Type: ctf.EventTypeAdded, Filename: "comp-a"}))
+		Expect(componentNames(c)).To(ConsistOf("example.com/a"))
+
+		cancel()
+		Eventually(watchErr).Should(Receive(MatchError(context.Canceled)))
+	}
+
+	It("should notify about added component archives of a ctf on the os filesystem", func() {
+		dir, err := ioutil.TempDir("", "ctf-watch-")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		fs := osfs.New()
+		path := filepath.Join(dir, "ctf.tar")
+		createCTF(fs, path)
+		watch(fs, path)
+	})
+
+	It("should poll for added component archives of a ctf on other filesystems", func() {
+		fs := memoryfs.New()
+		createCTF(fs, "/ctf.tar")
+		watch(fs, "/ctf.tar", ctf.WithWatchInterval(10*time.Millisecond))
+	})
+
+	It("should not discard changes that have not been written", func() {
+		fs := memoryfs.New()
+		createCTF(fs, "/ctf.tar")
+		c, err := ctf.OpenCTF(fs, "/ctf.tar", ctf.WithWatchInterval(10*time.Millisecond))
+		Expect(err).ToNot(HaveOccurred())
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+		Expect(c.AddComponentArchiveWithName("comp-b", newComponentArchive("example.com/b", "1.0.0"), ctf.ArchiveFormatTar)).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		watchErr := make(chan error, 1)
+		go func() {
+			watchErr <- c.Watch(ctx, make(chan ctf.CTFEvent, 10))
+		}()
+		time.Sleep(100 * time.Millisecond)
+		go addComponent(fs, "/ctf.tar", "comp-a")
+
+		Eventually(watchErr, 5*time.Second).Should(Receive(MatchError(ctf.ErrUnwrittenChanges)))
+		Expect(componentNames(c)).To(ConsistOf("example.com/b"))
+	})
+
+	It("should reload the ctf without interfering with concurrent reads", func() {
+		fs := memoryfs.New()
+		createCTF(fs, "/ctf.tar")
+		c, err := ctf.OpenCTF(fs, "/ctf.tar", ctf.WithWatchInterval(time.Millisecond))
+		Expect(err).ToNot(HaveOccurred())
+		defer func() {
+			Expect(c.Close()).To(Succeed())
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		events := make(chan ctf.CTFEvent, 100)
+		go func() {
+			_ = c.Watch(ctx, events)
+		}()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 20; i++ {
+				addComponent(fs, "/ctf.tar", fmt.Sprintf("comp-%d", i))
+				time.Sleep(5 * time.Millisecond)
+			}
+		}()
+
+	reads:
+		for {
+			select {
+			case <-done:
+				break reads
+			default:
+				_, err := c.GetAllComponentDescriptors()
+				Expect(err).ToNot(HaveOccurred())
+				_, err = c.SizeBytes()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		}
+		Eventually(func() []string { return componentNames(c) }, 5*time.Second).Should(HaveLen(20))
+	})
+})
@@ -0,0 +1,138 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// VerifyCommitSignature verifies the signed commit body of the git access.
+// The commit of the access is required and has to match the hash of the commit body
+// and the gpg signature of the commit has to be signed by one of the given armored trusted public keys.
+func VerifyCommitSignature(access *cdv2.GitHubAccess, trustedKeys []string) error {
+	if len(access.SignedCommitBody) == 0 {
+		return errors.New("no signed commit body defined")
+	}
+	if len(trustedKeys) == 0 {
+		return errors.New("no trusted keys defined")
+	}
+	if len(access.Commit) == 0 {
+		return errors.New("no commit defined")
+	}
+
+	commitHash := CommitHash(access.SignedCommitBody)
+	if access.Commit != commitHash {
+		return fmt.Errorf("the hash %s of the signed commit body does not match the commit %s", commitHash, access.Commit)
+	}
+
+	obj := &plumbing.MemoryObject{}
+	obj.SetType(plumbing.CommitObject)
+	if _, err := obj.Write([]byte(access.SignedCommitBody)); err != nil {
+		return fmt.Errorf("unable to read signed commit body: %w", err)
+	}
+	commit := &object.Commit{}
+	if err := commit.Decode(obj); err != nil {
+		return fmt.Errorf("unable to decode signed commit body: %w", err)
+	}
+	if len(commit.PGPSignature) == 0 {
+		return fmt.Errorf("commit %s is not signed", commitHash)
+	}
+
+	for _, key := range trustedKeys {
+		if _, err := commit.Verify(key); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("the signature of commit %s is not signed by a trusted key", commitHash)
+}
+
+// CommitHash calculates the git hash of a raw commit object.
+func CommitHash(commitBody string) string {
+	h := sha1.New()
+	_, _ = fmt.Fprintf(h, "commit %d\x00%s", len(commitBody), commitBody)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// FetchCommitHash fetches the commit hash the ref of the git access currently points to from the remote repository.
+// The ref can be a fully qualified reference, e.g. "refs/heads/main", a branch or a tag name.
+func FetchCommitHash(ctx context.Context, access *cdv2.GitHubAccess) (string, error) {
+	remote := gogit.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: gogit.DefaultRemoteName,
+		URLs: []string{access.RepoURL},
+	})
+	refs, err := remote.ListContext(ctx, &gogit.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to list references of %q: %w", access.RepoURL, err)
+	}
+
+	hash, ok := resolveReference(refs, referenceNames(access.Ref))
+	if !ok {
+		return "", fmt.Errorf("reference %q not found in %q", access.Ref, access.RepoURL)
+	}
+	return hash, nil
+}
+
+// resolveReference returns the hash of the first of the given names that is found in the refs.
+// Symbolic references are resolved by appending their target to the names,
+// targets that have already been visited are skipped so that cyclic references terminate.
+func resolveReference(refs []*plumbing.Reference, names []plumbing.ReferenceName) (string, bool) {
+	visited := make(map[plumbing.ReferenceName]bool, len(names))
+	for _, name := range names {
+		visited[name] = true
+	}
+	for i := 0; i < len(names); i++ {
+		for _, ref := range refs {
+			if ref.Name() != names[i] {
+				continue
+			}
+			if ref.Type() == plumbing.SymbolicReference {
+				if !visited[ref.Target()] {
+					visited[ref.Target()] = true
+					names = append(names, ref.Target())
+				}
+				continue
+			}
+			return ref.Hash().String(), true
+		}
+	}
+	return "", false
+}
+
+// referenceNames returns the possible fully qualified reference names of a ref in the order of their precedence.
+func referenceNames(ref string) []plumbing.ReferenceName {
+	if len(ref) == 0 {
+		return []plumbing.ReferenceName{plumbing.HEAD}
+	}
+	if strings.HasPrefix(ref, "refs/") || ref == string(plumbing.HEAD) {
+		return []plumbing.ReferenceName{plumbing.ReferenceName(ref)}
+	}
+	return []plumbing.ReferenceName{
+		plumbing.NewBranchReferenceName(ref),
+		plumbing.NewTagReferenceName(ref),
+	}
+}
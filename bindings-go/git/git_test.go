@@ -0,0 +1,131 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/git"
+)
+
+// signedCommitHash is the hash of the commit in ./testdata/signed-commit
+const signedCommitHash = "ef3cf03ad1dd5ee6e78b8c108ff8b13d24544c20"
+
+var _ = Describe("Git", func() {
+
+	readFile := func(path string) string {
+		data, err := ioutil.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		return string(data)
+	}
+
+	Context("VerifyCommitSignature", func() {
+
+		var access *cdv2.GitHubAccess
+
+		BeforeEach(func() {
+			access = cdv2.NewGitHubAccess("https://github.com/example/repo", "refs/heads/main", signedCommitHash)
+			access.SignedCommitBody = readFile("./testdata/signed-commit")
+		})
+
+		It("should calculate the commit hash of the signed commit body", func() {
+			Expect(git.CommitHash(access.SignedCommitBody)).To(Equal(signedCommitHash))
+		})
+
+		It("should verify a commit that is signed by a trusted key", func() {
+			trustedKeys := []string{readFile("./testdata/untrusted-key.asc"), readFile("./testdata/trusted-key.asc")}
+			Expect(git.VerifyCommitSignature(access, trustedKeys)).To(Succeed())
+		})
+
+		It("should reject a commit that is not signed by a trusted key", func() {
+			Expect(git.VerifyCommitSignature(access, []string{readFile("./testdata/untrusted-key.asc")})).ToNot(Succeed())
+		})
+
+		It("should reject a commit body that does not match the commit hash", func() {
+			access.SignedCommitBody = strings.Replace(access.SignedCommitBody, "signed commit", "tampered commit", 1)
+			Expect(git.VerifyCommitSignature(access, []string{readFile("./testdata/trusted-key.asc")})).ToNot(Succeed())
+		})
+
+		It("should reject a signed commit body if no commit is defined", func() {
+			access.Commit = ""
+			Expect(git.VerifyCommitSignature(access, []string{readFile("./testdata/trusted-key.asc")})).ToNot(Succeed())
+		})
+	})
+
+	Context("FetchCommitHash", func() {
+
+		var (
+			repoDir string
+			head    plumbing.Hash
+		)
+
+		BeforeEach(func() {
+			var err error
+			repoDir, err = ioutil.TempDir("", "git-")
+			Expect(err).ToNot(HaveOccurred())
+			repo, err := gogit.PlainInit(repoDir, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ioutil.WriteFile(filepath.Join(repoDir, "README"), []byte("test"), os.ModePerm)).To(Succeed())
+			worktree, err := repo.Worktree()
+			Expect(err).ToNot(HaveOccurred())
+			_, err = worktree.Add("README")
+			Expect(err).ToNot(HaveOccurred())
+			head, err = worktree.Commit("initial commit", &gogit.CommitOptions{
+				Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = repo.CreateTag("v1.0.0", head, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(repoDir)).To(Succeed())
+		})
+
+		It("should fetch the commit hash of a branch", func() {
+			hash, err := git.FetchCommitHash(context.TODO(), cdv2.NewGitHubAccess(repoDir, "master", ""))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hash).To(Equal(head.String()))
+		})
+
+		It("should fetch the commit hash of a tag and of HEAD", func() {
+			hash, err := git.FetchCommitHash(context.TODO(), cdv2.NewGitHubAccess(repoDir, "v1.0.0", ""))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hash).To(Equal(head.String()))
+
+			hash, err = git.FetchCommitHash(context.TODO(), cdv2.NewGitHubAccess(repoDir, "", ""))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hash).To(Equal(head.String()))
+		})
+
+		It("should return an error for an unknown reference", func() {
+			_, err := git.FetchCommitHash(context.TODO(), cdv2.NewGitHubAccess(repoDir, "unknown", ""))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+})
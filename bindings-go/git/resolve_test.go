@@ -0,0 +1,46 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"github.com/go-git/go-git/v5/plumbing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("resolveReference", func() {
+
+	hash := plumbing.NewHash("ef3cf03ad1dd5ee6e78b8c108ff8b13d24544c20")
+
+	It("should resolve symbolic references", func() {
+		refs := []*plumbing.Reference{
+			plumbing.NewSymbolicReference(plumbing.HEAD, "refs/heads/main"),
+			plumbing.NewHashReference("refs/heads/main", hash),
+		}
+		resolved, ok := resolveReference(refs, []plumbing.ReferenceName{plumbing.HEAD})
+		Expect(ok).To(BeTrue())
+		Expect(resolved).To(Equal(hash.String()))
+	})
+
+	It("should terminate for cyclic symbolic references", func() {
+		refs := []*plumbing.Reference{
+			plumbing.NewSymbolicReference(plumbing.HEAD, "refs/heads/a"),
+			plumbing.NewSymbolicReference("refs/heads/a", "refs/heads/b"),
+			plumbing.NewSymbolicReference("refs/heads/b", "refs/heads/a"),
+		}
+		_, ok := resolveReference(refs, []plumbing.ReferenceName{plumbing.HEAD})
+		Expect(ok).To(BeFalse())
+	})
+})
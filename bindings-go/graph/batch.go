@@ -0,0 +1,75 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// BatchResolve resolves all given component references in parallel.
+// At most concurrency references are resolved at the same time, a concurrency < 1 resolves the references sequentially.
+//
+// The returned component descriptors and errors are aligned with the given references:
+// for every reference either the component descriptor or the error at the same index is set.
+// A partial success therefore results in some nil descriptors with a corresponding non-nil error.
+func BatchResolve(ctx context.Context, repoCtx cdv2.Repository, refs []cdv2.ComponentReference, resolver ctf.ComponentResolver, concurrency int) ([]*cdv2.ComponentDescriptor, []error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	cds := make([]*cdv2.ComponentDescriptor, len(refs))
+	errs := make([]error, len(refs))
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+	for i, ref := range refs {
+		wg.Add(1)
+		go func(i int, ref cdv2.ComponentReference) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = fmt.Errorf("unable to resolve component descriptor for %q %q: %w", ref.ComponentName, ref.Version, ctx.Err())
+				return
+			}
+			defer func() { <-sem }()
+
+			cd, err := resolveReference(ctx, resolver, repoCtx, ref)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			cds[i] = cd
+		}(i, ref)
+	}
+	wg.Wait()
+	return cds, errs
+}
+
+// HasErrors returns true if at least one error of a batch resolve is set.
+func HasErrors(errs []error) bool {
+	for _, err := range errs {
+		if err != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/graph"
+)
+
+var _ = Describe("BatchResolve", func() {
+
+	var repoCtx cdv2.UnstructuredTypedObject
+
+	BeforeEach(func() {
+		repoCtx, _ = cdv2.NewUnstructured(cdv2.NewOCIRegistryRepository("example.com/registry", ""))
+	})
+
+	It("should resolve all references and align the results with the references", func() {
+		lr, err := ctf.NewListResolver(&cdv2.ComponentDescriptorList{
+			Components: []cdv2.ComponentDescriptor{
+				newComponentDescriptor(&repoCtx, "example.com/a", "1.0.0"),
+				newComponentDescriptor(&repoCtx, "example.com/b", "1.0.0"),
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		refs := []cdv2.ComponentReference{
+			newComponentReference("b", "example.com/b", "1.0.0"),
+			newComponentReference("missing", "example.com/missing", "1.0.0"),
+			newComponentReference("a", "example.com/a", "1.0.0"),
+		}
+		cds, errs := graph.BatchResolve(context.TODO(), &repoCtx, refs, lr, 2)
+		Expect(cds).To(HaveLen(3))
+		Expect(errs).To(HaveLen(3))
+		Expect(graph.HasErrors(errs)).To(BeTrue())
+
+		Expect(errs[0]).ToNot(HaveOccurred())
+		Expect(cds[0].Name).To(Equal("example.com/b"))
+		Expect(cds[1]).To(BeNil())
+		Expect(errors.Is(errs[1], ctf.NotFoundError)).To(BeTrue())
+		Expect(errs[2]).ToNot(HaveOccurred())
+		Expect(cds[2].Name).To(Equal("example.com/a"))
+	})
+
+	It("should resolve the references in parallel", func() {
+		refs := []cdv2.ComponentReference{
+			newComponentReference("a", "example.com/a", "1.0.0"),
+			newComponentReference("b", "example.com/b", "1.0.0"),
+			newComponentReference("c", "example.com/c", "1.0.0"),
+		}
+		// every resolve blocks until all references are currently being resolved.
+		var wg sync.WaitGroup
+		wg.Add(len(refs))
+		resolver := &testResolver{
+			resolve: func(ctx context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, error) {
+				wg.Done()
+				done := make(chan struct{})
+				go func() {
+					wg.Wait()
+					close(done)
+				}()
+				select {
+				case <-done:
+				case <-time.After(5 * time.Second):
+					return nil, errors.New("references are not resolved in parallel")
+				}
+				cd := newComponentDescriptor(repoCtx, name, version)
+				return &cd, nil
+			},
+		}
+
+		cds, errs := graph.BatchResolve(context.TODO(), &repoCtx, refs, resolver, len(refs))
+		Expect(graph.HasErrors(errs)).To(BeFalse())
+		for i, ref := range refs {
+			Expect(cds[i].Name).To(Equal(ref.ComponentName))
+		}
+	})
+
+	It("should not exceed the configured concurrency", func() {
+		refs := make([]cdv2.ComponentReference, 0)
+		for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+			refs = append(refs, newComponentReference(name, "example.com/"+name, "1.0.0"))
+		}
+		var current, max int32
+		resolver := &testResolver{
+			resolve: func(ctx context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, error) {
+				n := atomic.AddInt32(&current, 1)
+				defer atomic.AddInt32(&current, -1)
+				for {
+					m := atomic.LoadInt32(&max)
+					if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				cd := newComponentDescriptor(repoCtx, name, version)
+				return &cd, nil
+			},
+		}
+
+		_, errs := graph.BatchResolve(context.TODO(), &repoCtx, refs, resolver, 2)
+		Expect(graph.HasErrors(errs)).To(BeFalse())
+		Expect(atomic.LoadInt32(&max)).To(BeNumerically("<=", 2))
+	})
+
+})
+
+type testResolver struct {
+	resolve func(ctx context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, error)
+}
+
+var _ ctf.ComponentResolver = &testResolver{}
+
+func (t *testResolver) Resolve(ctx context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, error) {
+	return t.resolve(ctx, repoCtx, name, version)
+}
+
+func (t *testResolver) ResolveWithBlobResolver(ctx context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, ctf.BlobResolver, error) {
+	cd, err := t.resolve(ctx, repoCtx, name, version)
+	return cd, nil, err
+}
+
+func newComponentDescriptor(repoCtx cdv2.Repository, name, version string, refs ...cdv2.ComponentReference) cdv2.ComponentDescriptor {
+	cd := cdv2.ComponentDescriptor{}
+	cd.Name = name
+	cd.Version = version
+	cd.Provider = "internal"
+	if u, ok := repoCtx.(*cdv2.UnstructuredTypedObject); ok {
+		cd.RepositoryContexts = append(cd.RepositoryContexts, u)
+	}
+	cd.ComponentReferences = refs
+	_ = cdv2.DefaultComponent(&cd)
+	return cd
+}
+
+func newComponentReference(name, componentName, version string) cdv2.ComponentReference {
+	return cdv2.ComponentReference{
+		Name:          name,
+		ComponentName: componentName,
+		Version:       version,
+	}
+}
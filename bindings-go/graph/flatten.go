@@ -0,0 +1,103 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// Flatten returns a copy of the component descriptor that contains all transitive component references as direct references.
+// The references are resolved with the effective repository context of the component descriptor.
+//
+// The depth defines how many levels of references are expanded: a depth of 1 only expands the direct references,
+// a depth of 0 expands all references.
+// Transitive references are deduplicated by their component name and version, existing direct references are kept.
+func Flatten(ctx context.Context, cd *cdv2.ComponentDescriptor, resolver ctf.ComponentResolver, depth int) (*cdv2.ComponentDescriptor, error) {
+	repoCtx := cd.GetEffectiveRepositoryContext()
+	if repoCtx == nil {
+		return nil, errors.New("the component descriptor does not define a repository context")
+	}
+
+	flattened := cd.DeepCopy()
+	known := map[string]struct{}{}
+	names := map[string]struct{}{}
+	for _, ref := range flattened.ComponentReferences {
+		known[referenceKey(ref)] = struct{}{}
+		names[ref.Name] = struct{}{}
+	}
+
+	current := cd.ComponentReferences
+	for level := 1; len(current) != 0 && (depth == 0 || level <= depth); level++ {
+		next := make([]cdv2.ComponentReference, 0)
+		for _, ref := range current {
+			refCD, err := resolveReference(ctx, resolver, repoCtx, ref)
+			if err != nil {
+				return nil, err
+			}
+			for _, transitiveRef := range refCD.ComponentReferences {
+				key := referenceKey(transitiveRef)
+				if _, ok := known[key]; ok {
+					continue
+				}
+				known[key] = struct{}{}
+				next = append(next, transitiveRef)
+
+				transitiveRef = *transitiveRef.DeepCopy()
+				transitiveRef.Name = uniqueReferenceName(names, transitiveRef.Name)
+				flattened.ComponentReferences = append(flattened.ComponentReferences, transitiveRef)
+			}
+		}
+		current = next
+	}
+	return flattened, nil
+}
+
+// resolveReference resolves the component descriptor of the component reference.
+// The context is checked before the resolver is called so that a canceled context stops the traversal of
+// a component graph even if the resolver itself does not respect the context.
+func resolveReference(ctx context.Context, resolver ctf.ComponentResolver, repoCtx cdv2.Repository, ref cdv2.ComponentReference) (*cdv2.ComponentDescriptor, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("unable to resolve component descriptor for %q %q: %w", ref.ComponentName, ref.Version, err)
+	}
+	cd, err := resolver.Resolve(ctx, repoCtx, ref.ComponentName, ref.Version)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve component descriptor for %q %q: %w", ref.ComponentName, ref.Version, err)
+	}
+	return cd, nil
+}
+
+// referenceKey returns the key of a component reference that is used to deduplicate references.
+func referenceKey(ref cdv2.ComponentReference) string {
+	return ref.ComponentName + ":" + ref.Version
+}
+
+// uniqueReferenceName returns a reference name based on the given name that is not yet included in the names.
+// The returned name is added to the names.
+func uniqueReferenceName(names map[string]struct{}, name string) string {
+	unique := name
+	for i := 1; ; i++ {
+		if _, ok := names[unique]; !ok {
+			break
+		}
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+	names[unique] = struct{}{}
+	return unique
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/graph"
+)
+
+var _ = Describe("Flatten", func() {
+
+	var (
+		repoCtx  cdv2.UnstructuredTypedObject
+		root     cdv2.ComponentDescriptor
+		resolver ctf.ComponentResolver
+	)
+
+	componentNames := func(cd *cdv2.ComponentDescriptor) []string {
+		names := make([]string, 0)
+		for _, ref := range cd.ComponentReferences {
+			names = append(names, ref.ComponentName)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		repoCtx, _ = cdv2.NewUnstructured(cdv2.NewOCIRegistryRepository("example.com/registry", ""))
+		// root -> a -> c -> d
+		// root -> b -> c
+		root = newComponentDescriptor(&repoCtx, "example.com/root", "1.0.0",
+			newComponentReference("a", "example.com/a", "1.0.0"),
+			newComponentReference("b", "example.com/b", "1.0.0"))
+		var err error
+		resolver, err = ctf.NewListResolver(&cdv2.ComponentDescriptorList{
+			Components: []cdv2.ComponentDescriptor{
+				root,
+				newComponentDescriptor(&repoCtx, "example.com/a", "1.0.0",
+					newComponentReference("c", "example.com/c", "1.0.0")),
+				newComponentDescriptor(&repoCtx, "example.com/b", "1.0.0",
+					newComponentReference("c", "example.com/c", "1.0.0")),
+				newComponentDescriptor(&repoCtx, "example.com/c", "1.0.0",
+					newComponentReference("d", "example.com/d", "1.0.0")),
+				newComponentDescriptor(&repoCtx, "example.com/d", "1.0.0"),
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should add all transitive references only once", func() {
+		flattened, err := graph.Flatten(context.TODO(), &root, resolver, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(componentNames(flattened)).To(Equal([]string{"example.com/a", "example.com/b", "example.com/c", "example.com/d"}))
+		Expect(root.ComponentReferences).To(HaveLen(2), "the original component descriptor should not be modified")
+	})
+
+	It("should only expand the direct references with a depth of 1", func() {
+		flattened, err := graph.Flatten(context.TODO(), &root, resolver, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(componentNames(flattened)).To(Equal([]string{"example.com/a", "example.com/b", "example.com/c"}))
+	})
+
+	It("should generate unique reference names", func() {
+		root.ComponentReferences = append(root.ComponentReferences, newComponentReference("c", "example.com/d", "1.0.0"))
+		flattened, err := graph.Flatten(context.TODO(), &root, resolver, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(flattened.ComponentReferences).To(HaveLen(4))
+		Expect(flattened.ComponentReferences[3].Name).To(Equal("c-1"))
+		Expect(flattened.ComponentReferences[3].ComponentName).To(Equal("example.com/c"))
+	})
+
+})
@@ -0,0 +1,65 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// PropagateLabels resolves all transitive component references of the root component descriptor and
+// lets every referenced component descriptor inherit the labels with the given names from its referencing component descriptor.
+// Labels that are already defined by a referenced component descriptor are not overwritten.
+//
+// The labels are applied to copies of the resolved component descriptors so that the resolver is not modified.
+// A component version that is referenced multiple times only inherits the labels of the first referencing
+// component descriptor in breadth-first order.
+// The returned list starts with the root followed by the resolved component descriptors in breadth-first order.
+func PropagateLabels(ctx context.Context, root *cdv2.ComponentDescriptor, resolver ctf.ComponentResolver, keys []string) ([]*cdv2.ComponentDescriptor, error) {
+	repoCtx := root.GetEffectiveRepositoryContext()
+	if repoCtx == nil {
+		return nil, errors.New("the component descriptor does not define a repository context")
+	}
+
+	cds := []*cdv2.ComponentDescriptor{root}
+	known := map[string]struct{}{
+		root.GetName() + ":" + root.GetVersion(): {},
+	}
+	for i := 0; i < len(cds); i++ {
+		parent := cds[i]
+		for _, ref := range parent.ComponentReferences {
+			key := referenceKey(ref)
+			if _, ok := known[key]; ok {
+				continue
+			}
+			known[key] = struct{}{}
+			refCD, err := resolveReference(ctx, resolver, repoCtx, ref)
+			if err != nil {
+				return nil, err
+			}
+			child := refCD.DeepCopy()
+			if err := cdutils.ApplyReferenceLabels(parent, child, keys); err != nil {
+				return nil, fmt.Errorf("unable to apply labels of %s:%s to %s:%s: %w", parent.GetName(), parent.GetVersion(), child.GetName(), child.GetVersion(), err)
+			}
+			cds = append(cds, child)
+		}
+	}
+	return cds, nil
+}
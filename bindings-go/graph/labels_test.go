@@ -0,0 +1,85 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/graph"
+)
+
+var _ = Describe("label propagation", func() {
+
+	var repoCtx cdv2.UnstructuredTypedObject
+
+	BeforeEach(func() {
+		repoCtx, _ = cdv2.NewUnstructured(cdv2.NewOCIRegistryRepository("example.com/registry", ""))
+	})
+
+	newLabel := func(name, value string) cdv2.Label {
+		return cdv2.Label{Name: name, Value: json.RawMessage(value)}
+	}
+
+	labelsByComponent := func(cds []*cdv2.ComponentDescriptor) map[string]cdv2.Labels {
+		labels := map[string]cdv2.Labels{}
+		for _, cd := range cds {
+			labels[cd.GetName()] = cd.Labels
+		}
+		return labels
+	}
+
+	It("should recursively propagate the labels to all referenced component descriptors", func() {
+		root := newComponentDescriptor(&repoCtx, "example.com/root", "1.0.0",
+			newComponentReference("mid", "example.com/mid", "1.0.0"))
+		root.Labels = cdv2.Labels{newLabel("team", `"root"`), newLabel("tier", `"root"`), newLabel("other", `"root"`)}
+		mid := newComponentDescriptor(&repoCtx, "example.com/mid", "1.0.0",
+			newComponentReference("leaf", "example.com/leaf", "1.0.0"))
+		mid.Labels = cdv2.Labels{newLabel("tier", `"mid"`)}
+		leaf := newComponentDescriptor(&repoCtx, "example.com/leaf", "1.0.0")
+
+		resolver, err := ctf.NewListResolver(&cdv2.ComponentDescriptorList{Components: []cdv2.ComponentDescriptor{mid, leaf}})
+		Expect(err).ToNot(HaveOccurred())
+
+		cds, err := graph.PropagateLabels(context.TODO(), &root, resolver, []string{"team", "tier"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(labelsByComponent(cds)).To(Equal(map[string]cdv2.Labels{
+			"example.com/root": root.Labels,
+			"example.com/mid":  {newLabel("tier", `"mid"`), newLabel("team", `"root"`)},
+			"example.com/leaf": {newLabel("tier", `"mid"`), newLabel("team", `"root"`)},
+		}))
+
+		// the resolved component descriptors are not modified
+		resolved, err := resolver.Resolve(context.TODO(), &repoCtx, "example.com/leaf", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resolved.Labels).To(BeEmpty())
+	})
+
+	It("should fail if a reference cannot be resolved", func() {
+		root := newComponentDescriptor(&repoCtx, "example.com/root", "1.0.0",
+			newComponentReference("mid", "example.com/mid", "1.0.0"))
+		resolver, err := ctf.NewListResolver(&cdv2.ComponentDescriptorList{})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = graph.PropagateLabels(context.TODO(), &root, resolver, []string{"team"})
+		Expect(err).To(HaveOccurred())
+	})
+
+})
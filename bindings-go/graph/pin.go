@@ -0,0 +1,75 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// PinnedFromLabel is the name of the label that contains the original version of a pinned component reference.
+const PinnedFromLabel = "gardener.cloud/pinned-from"
+
+// PinComponentReferences resolves all component references of the component descriptor
+// and sets the version of each reference to the exact version of the resolved component descriptor.
+// The original version of a changed reference is stored in the PinnedFromLabel;
+// an already existing label is kept so that the reference can always be restored to its first version.
+func PinComponentReferences(ctx context.Context, cd *cdv2.ComponentDescriptor, resolver ctf.ComponentResolver) error {
+	repoCtx := cd.GetEffectiveRepositoryContext()
+	if repoCtx == nil {
+		return errors.New("the component descriptor does not define a repository context")
+	}
+	for i, ref := range cd.ComponentReferences {
+		refCD, err := resolveReference(ctx, resolver, repoCtx, ref)
+		if err != nil {
+			return err
+		}
+		if refCD.GetVersion() == ref.Version {
+			continue
+		}
+		if _, ok := cdutils.GetLabel(ref.Labels, PinnedFromLabel); !ok {
+			labels, err := cdutils.SetLabel(ref.Labels, PinnedFromLabel, ref.Version)
+			if err != nil {
+				return fmt.Errorf("unable to set label %q of component reference %q: %w", PinnedFromLabel, ref.Name, err)
+			}
+			cd.ComponentReferences[i].Labels = labels
+		}
+		cd.ComponentReferences[i].Version = refCD.GetVersion()
+	}
+	return nil
+}
+
+// UnpinComponentReferences restores the original versions of all pinned component references
+// and removes the PinnedFromLabel.
+func UnpinComponentReferences(cd *cdv2.ComponentDescriptor) {
+	for i, ref := range cd.ComponentReferences {
+		idx := cdutils.GetLabelIdx(ref.Labels, PinnedFromLabel)
+		if idx == -1 {
+			continue
+		}
+		var version string
+		if err := json.Unmarshal(ref.Labels[idx].Value, &version); err != nil {
+			continue
+		}
+		cd.ComponentReferences[i].Version = version
+		cd.ComponentReferences[i].Labels = append(ref.Labels[:idx], ref.Labels[idx+1:]...)
+	}
+}
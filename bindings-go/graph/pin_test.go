@@ -0,0 +1,91 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/graph"
+)
+
+// inMemoryComponentResolver resolves component versions from a static map of "<name>:<version>" to component descriptors.
+type inMemoryComponentResolver map[string]cdv2.ComponentDescriptor
+
+func (r inMemoryComponentResolver) Resolve(_ context.Context, _ cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, error) {
+	cd, ok := r[name+":"+version]
+	if !ok {
+		return nil, ctf.NotFoundError
+	}
+	return cd.DeepCopy(), nil
+}
+
+func (r inMemoryComponentResolver) ResolveWithBlobResolver(ctx context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, ctf.BlobResolver, error) {
+	cd, err := r.Resolve(ctx, repoCtx, name, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cd, nil, ctf.BlobResolverNotDefinedError
+}
+
+var _ = Describe("Pin", func() {
+
+	var (
+		repoCtx  cdv2.UnstructuredTypedObject
+		root     cdv2.ComponentDescriptor
+		resolver inMemoryComponentResolver
+	)
+
+	BeforeEach(func() {
+		repoCtx, _ = cdv2.NewUnstructured(cdv2.NewOCIRegistryRepository("example.com/registry", ""))
+		root = newComponentDescriptor(&repoCtx, "example.com/root", "1.0.0",
+			newComponentReference("a", "example.com/a", "v1.0"),
+			newComponentReference("b", "example.com/b", "v2.0.0"))
+		// the version v1.0 of a resolves to the exact version v1.0.3+build.5
+		resolver = inMemoryComponentResolver{
+			"example.com/a:v1.0":   newComponentDescriptor(&repoCtx, "example.com/a", "v1.0.3+build.5"),
+			"example.com/b:v2.0.0": newComponentDescriptor(&repoCtx, "example.com/b", "v2.0.0"),
+		}
+	})
+
+	It("should pin references to the exact resolved version", func() {
+		Expect(graph.PinComponentReferences(context.TODO(), &root, resolver)).To(Succeed())
+		Expect(root.ComponentReferences[0].Version).To(Equal("v1.0.3+build.5"))
+		value, ok := root.ComponentReferences[0].Labels.Get(graph.PinnedFromLabel)
+		Expect(ok).To(BeTrue())
+		Expect(string(value)).To(Equal(`"v1.0"`))
+
+		Expect(root.ComponentReferences[1].Version).To(Equal("v2.0.0"))
+		Expect(root.ComponentReferences[1].Labels).To(BeEmpty())
+	})
+
+	It("should restore the original versions when unpinning", func() {
+		Expect(graph.PinComponentReferences(context.TODO(), &root, resolver)).To(Succeed())
+		graph.UnpinComponentReferences(&root)
+		Expect(root.ComponentReferences[0].Version).To(Equal("v1.0"))
+		Expect(root.ComponentReferences[0].Labels).To(BeEmpty())
+		Expect(root.ComponentReferences[1].Version).To(Equal("v2.0.0"))
+	})
+
+	It("should fail if a reference cannot be resolved", func() {
+		delete(resolver, "example.com/b:v2.0.0")
+		Expect(graph.PinComponentReferences(context.TODO(), &root, resolver)).ToNot(Succeed())
+	})
+
+})
@@ -0,0 +1,70 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"errors"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// PruneReference removes all component references of the component descriptor that point to the given component version.
+// The number of removed references is returned.
+func PruneReference(cd *cdv2.ComponentDescriptor, name, version string) (int, error) {
+	if cd == nil {
+		return 0, errors.New("a component descriptor has to be defined")
+	}
+	if len(name) == 0 || len(version) == 0 {
+		return 0, errors.New("the name and version of the pruned component have to be defined")
+	}
+	return pruneReferences(cd, func(ref cdv2.ComponentReference) bool {
+		return ref.ComponentName == name && ref.Version == version
+	}), nil
+}
+
+// PruneByLabel removes all component references of the component descriptor that have a label with the given name and value.
+// The value matches json string labels by their unquoted value and all other labels by their raw json value.
+// The number of removed references is returned.
+func PruneByLabel(cd *cdv2.ComponentDescriptor, labelName, labelValue string) (int, error) {
+	if cd == nil {
+		return 0, errors.New("a component descriptor has to be defined")
+	}
+	if len(labelName) == 0 {
+		return 0, errors.New("the name of the label has to be defined")
+	}
+	matching := map[string]struct{}{}
+	for _, ref := range cd.ComponentReferencesByLabel(labelName, labelValue) {
+		matching[cdv2.NormalizeExtraIdentity(ref.GetIdentity())] = struct{}{}
+	}
+	return pruneReferences(cd, func(ref cdv2.ComponentReference) bool {
+		_, ok := matching[cdv2.NormalizeExtraIdentity(ref.GetIdentity())]
+		return ok
+	}), nil
+}
+
+// pruneReferences removes all component references that match the given function and returns the number of removed references.
+func pruneReferences(cd *cdv2.ComponentDescriptor, prune func(ref cdv2.ComponentReference) bool) int {
+	kept := make([]cdv2.ComponentReference, 0, len(cd.ComponentReferences))
+	for _, ref := range cd.ComponentReferences {
+		if !prune(ref) {
+			kept = append(kept, ref)
+		}
+	}
+	removed := len(cd.ComponentReferences) - len(kept)
+	if removed != 0 {
+		cd.ComponentReferences = kept
+	}
+	return removed
+}
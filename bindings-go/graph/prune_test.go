@@ -0,0 +1,93 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/graph"
+)
+
+var _ = Describe("Prune", func() {
+
+	var (
+		repoCtx cdv2.UnstructuredTypedObject
+		cd      cdv2.ComponentDescriptor
+	)
+
+	withLabel := func(ref cdv2.ComponentReference, name, value string) cdv2.ComponentReference {
+		ref.Labels = append(ref.Labels, cdv2.Label{Name: name, Value: json.RawMessage(value)})
+		return ref
+	}
+
+	referenceNames := func() []string {
+		names := make([]string, 0)
+		for _, ref := range cd.ComponentReferences {
+			names = append(names, ref.Name)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		repoCtx, _ = cdv2.NewUnstructured(cdv2.NewOCIRegistryRepository("example.com/registry", ""))
+		cd = newComponentDescriptor(&repoCtx, "example.com/root", "1.0.0",
+			withLabel(newComponentReference("a-1", "example.com/a", "1.0.0"), "team", `"x"`),
+			newComponentReference("a-1-copy", "example.com/a", "1.0.0"),
+			withLabel(newComponentReference("a-2", "example.com/a", "2.0.0"), "team", `"y"`),
+			withLabel(newComponentReference("b-1", "example.com/b", "1.0.0"), "team", `"x"`),
+			withLabel(newComponentReference("c-1", "example.com/c", "1.0.0"), "deprecated", `true`),
+		)
+	})
+
+	It("should remove exactly the references to the given component version", func() {
+		removed, err := graph.PruneReference(&cd, "example.com/a", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(removed).To(Equal(2))
+		Expect(referenceNames()).To(Equal([]string{"a-2", "b-1", "c-1"}))
+	})
+
+	It("should not remove references if no reference points to the component version", func() {
+		removed, err := graph.PruneReference(&cd, "example.com/a", "3.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(removed).To(Equal(0))
+		Expect(referenceNames()).To(HaveLen(5))
+	})
+
+	It("should remove the references with a matching label", func() {
+		removed, err := graph.PruneByLabel(&cd, "team", "x")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(removed).To(Equal(2))
+		Expect(referenceNames()).To(Equal([]string{"a-1-copy", "a-2", "c-1"}))
+
+		removed, err = graph.PruneByLabel(&cd, "deprecated", "true")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(removed).To(Equal(1))
+		Expect(referenceNames()).To(Equal([]string{"a-1-copy", "a-2"}))
+	})
+
+	It("should fail for undefined components or labels", func() {
+		_, err := graph.PruneReference(&cd, "", "1.0.0")
+		Expect(err).To(HaveOccurred())
+		_, err = graph.PruneByLabel(&cd, "", "x")
+		Expect(err).To(HaveOccurred())
+		_, err = graph.PruneReference(nil, "example.com/a", "1.0.0")
+		Expect(err).To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,105 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// DefaultMaxDepth is the default maximum depth of component references that are resolved by ResolveAll.
+const DefaultMaxDepth = 10
+
+// ResolveOptions limits the recursive resolution of component references.
+type ResolveOptions struct {
+	// MaxDepth is the maximum depth of component references that are resolved.
+	// The direct references of the root component descriptor have a depth of 1.
+	// Defaults to DefaultMaxDepth if not set.
+	MaxDepth int
+	// MaxDescriptors is the maximum number of component descriptors that are resolved, including the root.
+	// The number of component descriptors is not limited if not set.
+	MaxDescriptors int
+}
+
+// MaxDepthExceededError is returned if the component references are nested deeper than the configured maximum depth.
+type MaxDepthExceededError struct {
+	Depth int
+}
+
+func (e MaxDepthExceededError) Error() string {
+	return fmt.Sprintf("maximum component reference depth of %d exceeded", e.Depth)
+}
+
+// MaxDescriptorsExceededError is returned if more than the configured maximum number of component descriptors would be resolved.
+type MaxDescriptorsExceededError struct {
+	Count int
+}
+
+func (e MaxDescriptorsExceededError) Error() string {
+	return fmt.Sprintf("maximum number of %d component descriptors exceeded", e.Count)
+}
+
+// ResolveAll resolves all transitive component references of the root component descriptor.
+// The references are resolved with the effective repository context of the root component descriptor
+// and every component version is only resolved once.
+//
+// The returned list starts with the root followed by the resolved component descriptors in breadth-first order.
+// If a limit is hit, the component descriptors that have been resolved so far are returned together with
+// a MaxDepthExceededError or MaxDescriptorsExceededError.
+func ResolveAll(ctx context.Context, root *cdv2.ComponentDescriptor, resolver ctf.ComponentResolver, opts ResolveOptions) ([]*cdv2.ComponentDescriptor, error) {
+	repoCtx := root.GetEffectiveRepositoryContext()
+	if repoCtx == nil {
+		return nil, errors.New("the component descriptor does not define a repository context")
+	}
+	maxDepth := opts.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+
+	cds := []*cdv2.ComponentDescriptor{root}
+	known := map[string]struct{}{
+		root.GetName() + ":" + root.GetVersion(): {},
+	}
+	current := root.ComponentReferences
+	for depth := 1; len(current) != 0; depth++ {
+		if depth > maxDepth {
+			return cds, MaxDepthExceededError{Depth: maxDepth}
+		}
+		next := make([]cdv2.ComponentReference, 0)
+		for _, ref := range current {
+			key := referenceKey(ref)
+			if _, ok := known[key]; ok {
+				continue
+			}
+			known[key] = struct{}{}
+			if opts.MaxDescriptors > 0 && len(cds) >= opts.MaxDescriptors {
+				return cds, MaxDescriptorsExceededError{Count: opts.MaxDescriptors}
+			}
+
+			refCD, err := resolveReference(ctx, resolver, repoCtx, ref)
+			if err != nil {
+				return cds, err
+			}
+			cds = append(cds, refCD)
+			next = append(next, refCD.ComponentReferences...)
+		}
+		current = next
+	}
+	return cds, nil
+}
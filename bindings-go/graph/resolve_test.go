@@ -0,0 +1,81 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/graph"
+)
+
+var _ = Describe("ResolveAll", func() {
+
+	var (
+		repoCtx  cdv2.UnstructuredTypedObject
+		root     cdv2.ComponentDescriptor
+		resolver ctf.ComponentResolver
+	)
+
+	BeforeEach(func() {
+		repoCtx, _ = cdv2.NewUnstructured(cdv2.NewOCIRegistryRepository("example.com/registry", ""))
+		// chain of 15 component descriptors: c0 -> c1 -> ... -> c14
+		components := make([]cdv2.ComponentDescriptor, 15)
+		for i := range components {
+			var refs []cdv2.ComponentReference
+			if i < len(components)-1 {
+				refs = append(refs, newComponentReference(fmt.Sprintf("c%d", i+1), fmt.Sprintf("example.com/c%d", i+1), "1.0.0"))
+			}
+			components[i] = newComponentDescriptor(&repoCtx, fmt.Sprintf("example.com/c%d", i), "1.0.0", refs...)
+		}
+		root = components[0]
+		var err error
+		resolver, err = ctf.NewListResolver(&cdv2.ComponentDescriptorList{Components: components})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should resolve all component descriptors if no limit is hit", func() {
+		cds, err := graph.ResolveAll(context.TODO(), &root, resolver, graph.ResolveOptions{MaxDepth: 20})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cds).To(HaveLen(15))
+		Expect(cds[14].GetName()).To(Equal("example.com/c14"))
+	})
+
+	It("should stop the resolution at the default depth of 10", func() {
+		cds, err := graph.ResolveAll(context.TODO(), &root, resolver, graph.ResolveOptions{})
+		Expect(err).To(HaveOccurred())
+		depthErr := graph.MaxDepthExceededError{}
+		Expect(errors.As(err, &depthErr)).To(BeTrue())
+		Expect(depthErr.Depth).To(Equal(10))
+		Expect(cds).To(HaveLen(11))
+		Expect(cds[10].GetName()).To(Equal("example.com/c10"))
+	})
+
+	It("should stop the resolution if the maximum number of descriptors is reached", func() {
+		cds, err := graph.ResolveAll(context.TODO(), &root, resolver, graph.ResolveOptions{MaxDescriptors: 5})
+		Expect(err).To(HaveOccurred())
+		countErr := graph.MaxDescriptorsExceededError{}
+		Expect(errors.As(err, &countErr)).To(BeTrue())
+		Expect(countErr.Count).To(Equal(5))
+		Expect(cds).To(HaveLen(5))
+	})
+
+})
@@ -0,0 +1,147 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// CycleError is returned if the component references of a component graph contain a cycle.
+type CycleError struct {
+	// Cycle contains the "<name>:<version>" keys of the components of the cycle.
+	// The first component is repeated at the end.
+	Cycle []string
+}
+
+func (e CycleError) Error() string {
+	return fmt.Sprintf("component reference cycle detected: %s", strings.Join(e.Cycle, " -> "))
+}
+
+// DetectCycles returns all cycles of component references that are reachable from the root component descriptor.
+// Every cycle contains the "<name>:<version>" keys of its components, the first component is repeated at the end.
+// The references are resolved with the effective repository context of the root component descriptor.
+// The traversal stops if the context is canceled or its deadline is exceeded.
+func DetectCycles(ctx context.Context, root *cdv2.ComponentDescriptor, resolver ctf.ComponentResolver) ([][]string, error) {
+	w, err := newGraphWalker(ctx, root, resolver)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.visit(root, root.GetName()+":"+root.GetVersion()); err != nil {
+		return nil, err
+	}
+	return w.cycles, nil
+}
+
+// TopologicalSort returns the root component descriptor and all transitively referenced component descriptors
+// so that every component descriptor is listed after all component descriptors it references.
+// The root component descriptor is therefore always the last element.
+// A CycleError is returned if the component references contain a cycle.
+// The traversal stops if the context is canceled or its deadline is exceeded.
+func TopologicalSort(ctx context.Context, root *cdv2.ComponentDescriptor, resolver ctf.ComponentResolver) ([]*cdv2.ComponentDescriptor, error) {
+	w, err := newGraphWalker(ctx, root, resolver)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.visit(root, root.GetName()+":"+root.GetVersion()); err != nil {
+		return nil, err
+	}
+	if len(w.cycles) != 0 {
+		return nil, CycleError{Cycle: w.cycles[0]}
+	}
+	return w.sorted, nil
+}
+
+type visitState int
+
+const (
+	notVisited visitState = iota
+	visiting
+	visited
+)
+
+// graphWalker traverses a component graph depth-first and resolves every component version once.
+type graphWalker struct {
+	ctx      context.Context
+	resolver ctf.ComponentResolver
+	repoCtx  cdv2.Repository
+
+	state  map[string]visitState
+	stack  []string
+	sorted []*cdv2.ComponentDescriptor
+	cycles [][]string
+}
+
+func newGraphWalker(ctx context.Context, root *cdv2.ComponentDescriptor, resolver ctf.ComponentResolver) (*graphWalker, error) {
+	repoCtx := root.GetEffectiveRepositoryContext()
+	if repoCtx == nil {
+		return nil, errors.New("the component descriptor does not define a repository context")
+	}
+	return &graphWalker{
+		ctx:      ctx,
+		resolver: resolver,
+		repoCtx:  repoCtx,
+		state:    map[string]visitState{},
+	}, nil
+}
+
+// visit traverses all references of the component descriptor and adds it to the sorted list afterwards.
+// The state of the component descriptor is recorded under the given key, which is the key of the reference
+// that resolved to the component descriptor, so that later references to the same component are looked up by the same key.
+// A reference to a component that is currently visited is recorded as cycle.
+func (w *graphWalker) visit(cd *cdv2.ComponentDescriptor, key string) error {
+	w.state[key] = visiting
+	w.stack = append(w.stack, key)
+
+	for _, ref := range cd.ComponentReferences {
+		refKey := referenceKey(ref)
+		switch w.state[refKey] {
+		case visited:
+			continue
+		case visiting:
+			w.cycles = append(w.cycles, w.cycleTo(refKey))
+			continue
+		}
+		refCD, err := resolveReference(w.ctx, w.resolver, w.repoCtx, ref)
+		if err != nil {
+			return err
+		}
+		if err := w.visit(refCD, refKey); err != nil {
+			return err
+		}
+	}
+
+	w.stack = w.stack[:len(w.stack)-1]
+	w.state[key] = visited
+	w.sorted = append(w.sorted, cd)
+	return nil
+}
+
+// cycleTo returns the path of the current stack from the given key back to the key.
+func (w *graphWalker) cycleTo(key string) []string {
+	for i, cur := range w.stack {
+		if cur == key {
+			cycle := make([]string, 0, len(w.stack)-i+1)
+			cycle = append(cycle, w.stack[i:]...)
+			return append(cycle, key)
+		}
+	}
+	return []string{key, key}
+}
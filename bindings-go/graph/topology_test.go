@@ -0,0 +1,214 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/graph"
+)
+
+// blockingResolver blocks the resolution of the given component until the context is canceled.
+type blockingResolver struct {
+	ctf.ComponentResolver
+	component string
+	blocked   chan struct{}
+}
+
+func (r *blockingResolver) Resolve(ctx context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, error) {
+	if name == r.component {
+		close(r.blocked)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return r.ComponentResolver.Resolve(ctx, repoCtx, name, version)
+}
+
+// renamingResolver returns the resolved component descriptors with another version and counts the resolutions.
+type renamingResolver struct {
+	ctf.ComponentResolver
+	version  string
+	resolved map[string]int
+}
+
+func (r *renamingResolver) Resolve(ctx context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, error) {
+	r.resolved[name+":"+version]++
+	cd, err := r.ComponentResolver.Resolve(ctx, repoCtx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	cd = cd.DeepCopy()
+	cd.Version = r.version
+	return cd, nil
+}
+
+var _ = Describe("component graph", func() {
+
+	var (
+		repoCtx cdv2.UnstructuredTypedObject
+		newList func(components ...cdv2.ComponentDescriptor) ctf.ComponentResolver
+	)
+
+	BeforeEach(func() {
+		repoCtx, _ = cdv2.NewUnstructured(cdv2.NewOCIRegistryRepository("example.com/registry", ""))
+		newList = func(components ...cdv2.ComponentDescriptor) ctf.ComponentResolver {
+			resolver, err := ctf.NewListResolver(&cdv2.ComponentDescriptorList{Components: components})
+			Expect(err).ToNot(HaveOccurred())
+			return resolver
+		}
+	})
+
+	Context("context propagation", func() {
+
+		var (
+			root     cdv2.ComponentDescriptor
+			resolver *blockingResolver
+		)
+
+		BeforeEach(func() {
+			// a -> b -> c, where c blocks until the context is canceled
+			root = newComponentDescriptor(&repoCtx, "example.com/a", "1.0.0", newComponentReference("b", "example.com/b", "1.0.0"))
+			b := newComponentDescriptor(&repoCtx, "example.com/b", "1.0.0", newComponentReference("c", "example.com/c", "1.0.0"))
+			c := newComponentDescriptor(&repoCtx, "example.com/c", "1.0.0")
+			resolver = &blockingResolver{
+				ComponentResolver: newList(root, b, c),
+				component:         "example.com/c",
+				blocked:           make(chan struct{}),
+			}
+		})
+
+		expectCanceled := func(run func(ctx context.Context) error) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			result := make(chan error, 1)
+			go func() {
+				result <- run(ctx)
+			}()
+
+			Eventually(resolver.blocked).Should(BeClosed())
+			cancel()
+			var err error
+			Eventually(result, 100*time.Millisecond).Should(Receive(&err))
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		}
+
+		It("should stop the recursive resolution of all references if the context is canceled", func() {
+			expectCanceled(func(ctx context.Context) error {
+				_, err := graph.ResolveAll(ctx, &root, resolver, graph.ResolveOptions{})
+				return err
+			})
+		})
+
+		It("should stop flattening if the context is canceled", func() {
+			expectCanceled(func(ctx context.Context) error {
+				_, err := graph.Flatten(ctx, &root, resolver, 0)
+				return err
+			})
+		})
+
+		It("should stop the cycle detection if the context is canceled", func() {
+			expectCanceled(func(ctx context.Context) error {
+				_, err := graph.DetectCycles(ctx, &root, resolver)
+				return err
+			})
+		})
+
+		It("should stop the topological sort if the context is canceled", func() {
+			expectCanceled(func(ctx context.Context) error {
+				_, err := graph.TopologicalSort(ctx, &root, resolver)
+				return err
+			})
+		})
+
+		It("should not call the resolver with an already canceled context", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err := graph.ResolveAll(ctx, &root, resolver.ComponentResolver, graph.ResolveOptions{})
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+			_, err = graph.TopologicalSort(ctx, &root, resolver.ComponentResolver)
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		})
+
+		It("should stop the traversal if the deadline of the context is exceeded", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			_, err := graph.DetectCycles(ctx, &root, resolver)
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		})
+	})
+
+	Context("cycles", func() {
+
+		It("should sort the component descriptors topologically", func() {
+			// a -> b, a -> c, b -> c
+			root := newComponentDescriptor(&repoCtx, "example.com/a", "1.0.0",
+				newComponentReference("b", "example.com/b", "1.0.0"),
+				newComponentReference("c", "example.com/c", "1.0.0"))
+			b := newComponentDescriptor(&repoCtx, "example.com/b", "1.0.0", newComponentReference("c", "example.com/c", "1.0.0"))
+			c := newComponentDescriptor(&repoCtx, "example.com/c", "1.0.0")
+
+			cds, err := graph.TopologicalSort(context.TODO(), &root, newList(root, b, c))
+			Expect(err).ToNot(HaveOccurred())
+			names := make([]string, 0, len(cds))
+			for _, cd := range cds {
+				names = append(names, cd.GetName())
+			}
+			Expect(names).To(Equal([]string{"example.com/c", "example.com/b", "example.com/a"}))
+
+			cycles, err := graph.DetectCycles(context.TODO(), &root, newList(root, b, c))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cycles).To(BeEmpty())
+		})
+
+		It("should visit every reference once if the resolved component descriptor has another version", func() {
+			// a -> b, a -> c, b -> c
+			root := newComponentDescriptor(&repoCtx, "example.com/a", "1.0.0",
+				newComponentReference("b", "example.com/b", "1.0.0"),
+				newComponentReference("c", "example.com/c", "1.0.0"))
+			b := newComponentDescriptor(&repoCtx, "example.com/b", "1.0.0", newComponentReference("c", "example.com/c", "1.0.0"))
+			c := newComponentDescriptor(&repoCtx, "example.com/c", "1.0.0")
+			resolver := &renamingResolver{ComponentResolver: newList(root, b, c), version: "v1.0.0", resolved: map[string]int{}}
+
+			cds, err := graph.TopologicalSort(context.TODO(), &root, resolver)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cds).To(HaveLen(3))
+			Expect(resolver.resolved).To(Equal(map[string]int{"example.com/b:1.0.0": 1, "example.com/c:1.0.0": 1}))
+		})
+
+		It("should detect cycles of component references", func() {
+			// a -> b -> c -> b
+			root := newComponentDescriptor(&repoCtx, "example.com/a", "1.0.0", newComponentReference("b", "example.com/b", "1.0.0"))
+			b := newComponentDescriptor(&repoCtx, "example.com/b", "1.0.0", newComponentReference("c", "example.com/c", "1.0.0"))
+			c := newComponentDescriptor(&repoCtx, "example.com/c", "1.0.0", newComponentReference("b", "example.com/b", "1.0.0"))
+
+			cycles, err := graph.DetectCycles(context.TODO(), &root, newList(root, b, c))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cycles).To(Equal([][]string{{"example.com/b:1.0.0", "example.com/c:1.0.0", "example.com/b:1.0.0"}}))
+
+			_, err = graph.TopologicalSort(context.TODO(), &root, newList(root, b, c))
+			cycleErr := graph.CycleError{}
+			Expect(errors.As(err, &cycleErr)).To(BeTrue())
+			Expect(cycleErr.Cycle).To(Equal([]string{"example.com/b:1.0.0", "example.com/c:1.0.0", "example.com/b:1.0.0"}))
+		})
+	})
+
+})
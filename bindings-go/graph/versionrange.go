@@ -0,0 +1,76 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+// ComponentVersionLister lists all available versions of a component.
+type ComponentVersionLister interface {
+	ListVersions(ctx context.Context, name string) ([]string, error)
+}
+
+// ExpandVersionRanges pins all component references whose version is a semver constraint (e.g. ">=1.0.0 <2.0.0")
+// to the highest version of the referenced component that satisfies the constraint.
+// The component descriptor is not modified, the expanded versions are set on a deep copy
+// which is returned as the only element of the list.
+// References with a concrete version or a version that is no semver constraint are not changed.
+// The original version of an expanded reference is stored in the PinnedFromLabel.
+func ExpandVersionRanges(ctx context.Context, cd *cdv2.ComponentDescriptor, lister ComponentVersionLister) ([]*cdv2.ComponentDescriptor, error) {
+	expanded := cd.DeepCopy()
+	for i, ref := range expanded.ComponentReferences {
+		if _, err := semver.NewVersion(ref.Version); err == nil {
+			continue
+		}
+		constraint, err := semver.NewConstraint(ref.Version)
+		if err != nil {
+			continue
+		}
+		versions, err := lister.ListVersions(ctx, ref.ComponentName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list versions of %q: %w", ref.ComponentName, err)
+		}
+		var highest *semver.Version
+		for _, version := range versions {
+			v, err := semver.NewVersion(version)
+			if err != nil {
+				continue
+			}
+			if constraint.Check(v) && (highest == nil || v.GreaterThan(highest)) {
+				highest = v
+			}
+		}
+		if highest == nil {
+			return nil, fmt.Errorf("no version of %q matches %q: %w", ref.ComponentName, ref.Version, cdv2.NotFound)
+		}
+
+		if _, ok := cdutils.GetLabel(ref.Labels, PinnedFromLabel); !ok {
+			labels, err := cdutils.SetLabel(ref.Labels, PinnedFromLabel, ref.Version)
+			if err != nil {
+				return nil, fmt.Errorf("unable to set label %q of component reference %q: %w", PinnedFromLabel, ref.Name, err)
+			}
+			expanded.ComponentReferences[i].Labels = labels
+		}
+		expanded.ComponentReferences[i].Version = highest.Original()
+	}
+	return []*cdv2.ComponentDescriptor{expanded}, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/graph"
+)
+
+// staticVersionLister lists the versions of a static map of component names to versions.
+type staticVersionLister map[string][]string
+
+func (l staticVersionLister) ListVersions(_ context.Context, name string) ([]string, error) {
+	return l[name], nil
+}
+
+var _ = Describe("version range expansion", func() {
+
+	lister := staticVersionLister{
+		"example.com/a": {"v0.9.0", "v1.0.0", "v1.4.2", "v1.10.0", "v2.0.0", "invalid"},
+		"example.com/b": {"1.0.0", "1.1.0"},
+	}
+
+	newComponentDescriptor := func(refs ...cdv2.ComponentReference) *cdv2.ComponentDescriptor {
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Name = "example.com/root"
+		cd.Version = "v1.0.0"
+		cd.ComponentReferences = refs
+		return cd
+	}
+
+	It("should pin version ranges to the highest matching version", func() {
+		cd := newComponentDescriptor(
+			cdv2.ComponentReference{Name: "a", ComponentName: "example.com/a", Version: ">=1.0.0 <2.0.0"},
+			cdv2.ComponentReference{Name: "b", ComponentName: "example.com/b", Version: "1.0.0"},
+		)
+		expanded, err := graph.ExpandVersionRanges(context.TODO(), cd, lister)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expanded).To(HaveLen(1))
+
+		refs := expanded[0].ComponentReferences
+		Expect(refs[0].Version).To(Equal("v1.10.0"))
+		Expect(refs[0].Labels).To(HaveLen(1))
+		Expect(refs[0].Labels[0].Name).To(Equal(graph.PinnedFromLabel))
+		var pinnedFrom string
+		Expect(json.Unmarshal(refs[0].Labels[0].Value, &pinnedFrom)).To(Succeed())
+		Expect(pinnedFrom).To(Equal(">=1.0.0 <2.0.0"))
+		Expect(refs[1].Version).To(Equal("1.0.0"))
+		Expect(refs[1].Labels).To(BeEmpty())
+
+		// the original component descriptor is not modified
+		Expect(cd.ComponentReferences[0].Version).To(Equal(">=1.0.0 <2.0.0"))
+		Expect(cd.ComponentReferences[0].Labels).To(BeEmpty())
+	})
+
+	It("should return a not found error if no version matches", func() {
+		cd := newComponentDescriptor(
+			cdv2.ComponentReference{Name: "b", ComponentName: "example.com/b", Version: "^2.0.0"},
+		)
+		_, err := graph.ExpandVersionRanges(context.TODO(), cd, lister)
+		Expect(errors.Is(err, cdv2.NotFound)).To(BeTrue())
+	})
+})
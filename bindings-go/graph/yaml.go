@@ -0,0 +1,170 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// Dependency is a component of a dependency file.
+type Dependency struct {
+	// Name is the name of the component.
+	Name string `json:"name"`
+	// Version is the version of the component.
+	Version string `json:"version"`
+	// ReferenceName is the name of the component reference of the referencing component.
+	// It is empty for the root component.
+	ReferenceName string `json:"referenceName,omitempty"`
+	// Dependencies are the components that are referenced by the component.
+	// The dependencies of a component are only listed at its first occurrence in the file.
+	Dependencies []Dependency `json:"dependencies,omitempty"`
+}
+
+// GraphToYAML exports the component graph of the root component descriptor as yaml dependency file
+// that contains the list of the root component with its recursively referenced components, e.g.
+//
+//	# dependencies of example.com/a:1.0.0
+//	- name: example.com/a
+//	  version: 1.0.0
+//	  dependencies:
+//	  - name: example.com/b
+//	    version: 1.0.0
+//	    referenceName: b
+//
+// The dependencies of a component are only listed at its first occurrence so that shared components and cycles
+// are exported only once.
+// The references are resolved with the effective repository context of the root component descriptor.
+// The traversal stops if the context is canceled or its deadline is exceeded.
+func GraphToYAML(ctx context.Context, root *cdv2.ComponentDescriptor, resolver ctf.ComponentResolver) ([]byte, error) {
+	repoCtx := root.GetEffectiveRepositoryContext()
+	if repoCtx == nil {
+		return nil, errors.New("the component descriptor does not define a repository context")
+	}
+	e := &dependencyExporter{
+		ctx:      ctx,
+		resolver: resolver,
+		repoCtx:  repoCtx,
+		exported: map[string]bool{},
+	}
+	rootDep, err := e.export(root, "")
+	if err != nil {
+		return nil, err
+	}
+	data, err := yaml.Marshal([]Dependency{rootDep})
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode dependencies: %w", err)
+	}
+	return data, nil
+}
+
+// GraphFromYAML reconstructs the component descriptors of a yaml dependency file that is written by GraphToYAML.
+// The component descriptors are defaulted and only contain their name, version and component references,
+// the root component descriptor is the first element.
+func GraphFromYAML(data []byte) ([]*cdv2.ComponentDescriptor, error) {
+	deps := []Dependency{}
+	if err := yaml.Unmarshal(data, &deps); err != nil {
+		return nil, fmt.Errorf("unable to decode dependencies: %w", err)
+	}
+	var (
+		list       []*cdv2.ComponentDescriptor
+		components = map[string]*cdv2.ComponentDescriptor{}
+		add        func(dep Dependency) error
+	)
+	add = func(dep Dependency) error {
+		if len(dep.Name) == 0 || len(dep.Version) == 0 {
+			return fmt.Errorf("dependency %q:%q must define a name and a version", dep.Name, dep.Version)
+		}
+		key := dep.Name + ":" + dep.Version
+		cd, ok := components[key]
+		if !ok {
+			cd = &cdv2.ComponentDescriptor{}
+			cd.Metadata.Version = cdv2.SchemaVersion
+			cd.Name = dep.Name
+			cd.Version = dep.Version
+			components[key] = cd
+			list = append(list, cd)
+		}
+		if len(dep.Dependencies) != 0 && len(cd.ComponentReferences) == 0 {
+			for _, child := range dep.Dependencies {
+				cd.ComponentReferences = append(cd.ComponentReferences, cdv2.ComponentReference{
+					Name:          child.ReferenceName,
+					ComponentName: child.Name,
+					Version:       child.Version,
+				})
+			}
+		}
+		for _, child := range dep.Dependencies {
+			if err := add(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, dep := range deps {
+		if err := add(dep); err != nil {
+			return nil, err
+		}
+	}
+	for _, cd := range list {
+		if err := cdv2.DefaultComponent(cd); err != nil {
+			return nil, fmt.Errorf("unable to default component descriptor %q:%q: %w", cd.GetName(), cd.GetVersion(), err)
+		}
+	}
+	return list, nil
+}
+
+// dependencyExporter converts a component graph into dependencies and lists the dependencies of every component once.
+type dependencyExporter struct {
+	ctx      context.Context
+	resolver ctf.ComponentResolver
+	repoCtx  cdv2.Repository
+	exported map[string]bool
+}
+
+func (e *dependencyExporter) export(cd *cdv2.ComponentDescriptor, referenceName string) (Dependency, error) {
+	dep := Dependency{
+		Name:          cd.GetName(),
+		Version:       cd.GetVersion(),
+		ReferenceName: referenceName,
+	}
+	e.exported[dep.Name+":"+dep.Version] = true
+	for _, ref := range cd.ComponentReferences {
+		if e.exported[referenceKey(ref)] {
+			dep.Dependencies = append(dep.Dependencies, Dependency{
+				Name:          ref.ComponentName,
+				Version:       ref.Version,
+				ReferenceName: ref.Name,
+			})
+			continue
+		}
+		refCD, err := resolveReference(e.ctx, e.resolver, e.repoCtx, ref)
+		if err != nil {
+			return Dependency{}, err
+		}
+		child, err := e.export(refCD, ref.Name)
+		if err != nil {
+			return Dependency{}, err
+		}
+		dep.Dependencies = append(dep.Dependencies, child)
+	}
+	return dep, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/graph"
+)
+
+var _ = Describe("yaml dependency file", func() {
+
+	var repoCtx cdv2.UnstructuredTypedObject
+
+	BeforeEach(func() {
+		repoCtx, _ = cdv2.NewUnstructured(cdv2.NewOCIRegistryRepository("example.com/registry", ""))
+	})
+
+	// structure returns the references of all component descriptors by their "<name>:<version>" key.
+	structure := func(cds []*cdv2.ComponentDescriptor) map[string][]cdv2.ComponentReference {
+		s := map[string][]cdv2.ComponentReference{}
+		for _, cd := range cds {
+			s[cd.GetName()+":"+cd.GetVersion()] = cd.ComponentReferences
+		}
+		return s
+	}
+
+	newGraph := func(components ...cdv2.ComponentDescriptor) ([]*cdv2.ComponentDescriptor, ctf.ComponentResolver) {
+		resolver, err := ctf.NewListResolver(&cdv2.ComponentDescriptorList{Components: components})
+		Expect(err).ToNot(HaveOccurred())
+		cds := make([]*cdv2.ComponentDescriptor, len(components))
+		for i := range components {
+			cds[i] = &components[i]
+		}
+		return cds, resolver
+	}
+
+	It("should round-trip a component graph with shared components", func() {
+		// a -> b, c; b -> d; c -> d, e
+		cds, resolver := newGraph(
+			newComponentDescriptor(&repoCtx, "example.com/a", "1.0.0",
+				newComponentReference("b", "example.com/b", "1.0.0"),
+				newComponentReference("c", "example.com/c", "2.0.0")),
+			newComponentDescriptor(&repoCtx, "example.com/b", "1.0.0",
+				newComponentReference("d", "example.com/d", "1.0.0")),
+			newComponentDescriptor(&repoCtx, "example.com/c", "2.0.0",
+				newComponentReference("d", "example.com/d", "1.0.0"),
+				newComponentReference("e", "example.com/e", "1.0.0")),
+			newComponentDescriptor(&repoCtx, "example.com/d", "1.0.0"),
+			newComponentDescriptor(&repoCtx, "example.com/e", "1.0.0"),
+		)
+
+		data, err := graph.GraphToYAML(context.TODO(), cds[0], resolver)
+		Expect(err).ToNot(HaveOccurred())
+		result, err := graph.GraphFromYAML(data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveLen(5))
+		Expect(result[0].GetName()).To(Equal("example.com/a"))
+		Expect(structure(result)).To(Equal(structure(cds)))
+	})
+
+	It("should export cycles once", func() {
+		cds, resolver := newGraph(
+			newComponentDescriptor(&repoCtx, "example.com/a", "1.0.0", newComponentReference("b", "example.com/b", "1.0.0")),
+			newComponentDescriptor(&repoCtx, "example.com/b", "1.0.0", newComponentReference("a", "example.com/a", "1.0.0")),
+		)
+
+		data, err := graph.GraphToYAML(context.TODO(), cds[0], resolver)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(MatchYAML(`
+- name: example.com/a
+  version: 1.0.0
+  dependencies:
+  - name: example.com/b
+    version: 1.0.0
+    referenceName: b
+    dependencies:
+    - name: example.com/a
+      version: 1.0.0
+      referenceName: a
+`))
+		result, err := graph.GraphFromYAML(data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(structure(result)).To(Equal(structure(cds)))
+	})
+
+	It("should reject dependencies without a version", func() {
+		_, err := graph.GraphFromYAML([]byte(`[{"name": "example.com/a"}]`))
+		Expect(err).To(HaveOccurred())
+	})
+
+})
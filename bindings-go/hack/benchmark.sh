@@ -0,0 +1,33 @@
+#!/usr/bin/env bash
+#
+# Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+set -e
+
+# Runs the codec and ctf benchmarks and writes the result to the given file (default: bench.txt).
+# If a baseline file is given as second argument, the results are compared with benchstat.
+# usage: benchmark.sh [<result file>] [<baseline file>]
+
+REPO_ROOT="$(cd "$(dirname "$0")/.." && pwd)"
+RESULT="${1:-bench.txt}"
+BASELINE="$2"
+
+echo "> Benchmark"
+
+go test -run '^$' -bench . -benchmem -count "${BENCH_COUNT:-10}" \
+  "${REPO_ROOT}/codec" "${REPO_ROOT}/ctf" | tee "${RESULT}"
+
+if [[ -n "${BASELINE}" ]]; then
+  echo "> Compare with ${BASELINE}"
+  benchstat "${BASELINE}" "${RESULT}"
+fi
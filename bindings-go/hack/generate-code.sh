@@ -22,4 +22,9 @@ echo "> Generating deepcopy functions for Component Descriptor"
 
 pushd ${PROJECT_ROOT}
 "${GOPATH}"/bin/deepcopy-gen -i ./apis/v2 -O zz_generated_deepcopy --go-header-file ./hack/boilerplate.go.txt
-popd
\ No newline at end of file
+popd
+echo "> Generating protobuf bindings for Component Descriptor"
+
+pushd ${PROJECT_ROOT}
+protoc --go_out=paths=source_relative:. ./codec/cdv2pb/componentdescriptor.proto
+popd
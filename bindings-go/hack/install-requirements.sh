@@ -21,3 +21,5 @@ echo "> Install requirements"
 GO111MODULE=off go get golang.org/x/tools/cmd/goimports
 GO111MODULE=on curl -sSfL https://raw.githubusercontent.com/golangci/golangci-lint/master/install.sh | sh -s -- -b $(go env GOPATH)/bin v1.52.2
 GO111MODULE=off go get -u github.com/go-bindata/go-bindata/...
+# newer versions of benchstat require a more recent go version than the one of the bindings.
+GO111MODULE=on go install golang.org/x/perf/cmd/benchstat@v0.0.0-20230113213139-801c7ef9e5c5
@@ -0,0 +1,103 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+// ToHelmValues converts all oci image resources of the component descriptor into helm values.
+// The valueKeyTemplate is a go template that is rendered with the resource
+// and results in a dot separated key path, e.g. "images.{{ .Name }}".
+// The image repository and tag of the resource are set as "repository" and "tag" below that key.
+// Images that are referenced by digest get a "digest" instead of a "tag".
+//
+// The returned map is nested according to the key path and can be directly used as values file for helm.
+func ToHelmValues(cd *cdv2.ComponentDescriptor, valueKeyTemplate string) (map[string]interface{}, error) {
+	tmpl, err := template.New("key").Option("missingkey=error").Parse(valueKeyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse value key template: %w", err)
+	}
+
+	values := map[string]interface{}{}
+	for _, res := range cd.Resources {
+		if res.Access == nil || res.Access.GetType() != cdv2.OCIRegistryType {
+			continue
+		}
+		ociAccess := &cdv2.OCIRegistryAccess{}
+		if err := res.Access.DecodeInto(ociAccess); err != nil {
+			return nil, fmt.Errorf("unable to decode access of resource %q: %w", res.Name, err)
+		}
+		repo, version, sep, err := cdutils.ParseImageReference(ociAccess.ImageReference)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse image reference of resource %q: %w", res.Name, err)
+		}
+
+		var key bytes.Buffer
+		if err := tmpl.Execute(&key, res); err != nil {
+			return nil, fmt.Errorf("unable to template value key for resource %q: %w", res.Name, err)
+		}
+		path := strings.Split(key.String(), ".")
+
+		imageValues := map[string]interface{}{
+			"repository": repo,
+		}
+		if sep == "@" {
+			imageValues["digest"] = version
+		} else {
+			imageValues["tag"] = version
+		}
+		for k, v := range imageValues {
+			if err := setValue(values, append(path, k), v); err != nil {
+				return nil, fmt.Errorf("unable to set value for resource %q: %w", res.Name, err)
+			}
+		}
+	}
+	return values, nil
+}
+
+// setValue sets the value at the given key path in the nested values.
+func setValue(values map[string]interface{}, path []string, value interface{}) error {
+	current := values
+	for i, key := range path {
+		if len(key) == 0 {
+			return fmt.Errorf("key %q contains an empty element", strings.Join(path, "."))
+		}
+		if i == len(path)-1 {
+			if _, ok := current[key]; ok {
+				return fmt.Errorf("key %q is already defined", strings.Join(path, "."))
+			}
+			current[key] = value
+			return nil
+		}
+		next, ok := current[key]
+		if !ok {
+			next = map[string]interface{}{}
+			current[key] = next
+		}
+		nextMap, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("key %q is already defined", strings.Join(path[:i+1], "."))
+		}
+		current = nextMap
+	}
+	return nil
+}
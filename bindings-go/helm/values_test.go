@@ -0,0 +1,82 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/helm"
+)
+
+var _ = Describe("Values", func() {
+
+	newOCIResource := func(name, ref string) cdv2.Resource {
+		access, err := cdv2.NewUnstructured(cdv2.NewOCIRegistryAccess(ref))
+		Expect(err).ToNot(HaveOccurred())
+		return cdv2.Resource{
+			IdentityObjectMeta: cdv2.IdentityObjectMeta{
+				Name:    name,
+				Version: "1.0.0",
+				Type:    cdv2.OCIImageType,
+			},
+			Relation: cdv2.ExternalRelation,
+			Access:   &access,
+		}
+	}
+
+	It("should produce a values file with the repository and tag of all oci images", func() {
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Resources = []cdv2.Resource{
+			newOCIResource("apiserver", "eu.gcr.io/gardener-project/apiserver:v1.0.0"),
+			newOCIResource("proxy", "localhost:5000/proxy:v2.0.0"),
+			newOCIResource("pinned", "example.com/pinned@sha256:abc"),
+		}
+
+		values, err := helm.ToHelmValues(cd, "images.{{ .Name }}")
+		Expect(err).ToNot(HaveOccurred())
+		data, err := yaml.Marshal(values)
+		Expect(err).ToNot(HaveOccurred())
+
+		parsed := map[string]map[string]map[string]string{}
+		Expect(yaml.Unmarshal(data, &parsed)).To(Succeed())
+		Expect(parsed).To(HaveKey("images"))
+		Expect(parsed["images"]).To(HaveKeyWithValue("apiserver", map[string]string{
+			"repository": "eu.gcr.io/gardener-project/apiserver",
+			"tag":        "v1.0.0",
+		}))
+		Expect(parsed["images"]).To(HaveKeyWithValue("proxy", map[string]string{
+			"repository": "localhost:5000/proxy",
+			"tag":        "v2.0.0",
+		}))
+		Expect(parsed["images"]).To(HaveKeyWithValue("pinned", map[string]string{
+			"repository": "example.com/pinned",
+			"digest":     "sha256:abc",
+		}))
+	})
+
+	It("should return an error if two resources result in the same key", func() {
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Resources = []cdv2.Resource{
+			newOCIResource("a", "example.com/a:v1"),
+			newOCIResource("b", "example.com/b:v1"),
+		}
+		_, err := helm.ToHelmValues(cd, "image")
+		Expect(err).To(HaveOccurred())
+	})
+
+})
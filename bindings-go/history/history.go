@@ -0,0 +1,228 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	imagespec "github.com/opencontainers/image-spec/specs-go"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"sigs.k8s.io/yaml"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/codec"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/oci"
+)
+
+// HistoryTag is the tag of the oci artifact that contains the history of a component.
+const HistoryTag = "history"
+
+// HistoryConfigMimeType is the mimetype of the config of a history oci artifact.
+const HistoryConfigMimeType = "application/vnd.gardener.cloud.cnudie.component-history.config.v1+json"
+
+// ComponentDescriptorYAMLMimeType is the mimetype of a history layer that contains a yaml encoded component descriptor.
+const ComponentDescriptorYAMLMimeType = "application/vnd.gardener.cloud.cnudie.component-descriptor.v2+yaml"
+
+// Client defines a oci client that is able to read and write oci artifacts.
+// GetManifest is expected to return an error that wraps ctf.NotFoundError if the reference does not exist.
+type Client interface {
+	oci.Client
+
+	// PushBlob uploads the blob described by the ocispec Descriptor to the repository of the reference.
+	PushBlob(ctx context.Context, ref string, desc ocispecv1.Descriptor, reader io.Reader) error
+
+	// PushManifest uploads the manifest for the given reference.
+	PushManifest(ctx context.Context, ref string, manifest *ocispecv1.Manifest) error
+}
+
+// HistoryEntry describes a historical version of a component descriptor.
+type HistoryEntry struct {
+	// Timestamp is the time the component descriptor was added to the history.
+	Timestamp time.Time
+	// Name is the name of the component.
+	Name string
+	// Version is the version of the component.
+	Version string
+	// Digest is the digest of the layer that contains the component descriptor.
+	Digest digest.Digest
+}
+
+// HistoryArchive stores the change history of a component descriptor as oci artifact at "<base>/<name>:history".
+// The artifact contains one layer per version of the component descriptor.
+type HistoryArchive struct {
+	client     Client
+	ref        string
+	decodeOpts []codec.DecodeOption
+}
+
+// NewHistoryArchive creates a new history archive for the component with the given name.
+func NewHistoryArchive(client Client, base, name string, decodeOpts ...codec.DecodeOption) *HistoryArchive {
+	return &HistoryArchive{
+		client:     client,
+		ref:        fmt.Sprintf("%s:%s", path.Join(base, name), HistoryTag),
+		decodeOpts: decodeOpts,
+	}
+}
+
+// Ref returns the oci reference of the history artifact.
+func (h *HistoryArchive) Ref() string {
+	return h.ref
+}
+
+// Push appends the component descriptor to the history.
+func (h *HistoryArchive) Push(ctx context.Context, cd *cdv2.ComponentDescriptor) error {
+	manifest, err := h.getManifest(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := codec.Encode(cd)
+	if err != nil {
+		return fmt.Errorf("unable to encode component descriptor: %w", err)
+	}
+	data, err = yaml.JSONToYAML(data)
+	if err != nil {
+		return fmt.Errorf("unable to convert component descriptor to yaml: %w", err)
+	}
+	layer := ocispecv1.Descriptor{
+		MediaType: ComponentDescriptorYAMLMimeType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+		Annotations: map[string]string{
+			ocispecv1.AnnotationCreated: time.Now().UTC().Format(time.RFC3339Nano),
+			ocispecv1.AnnotationTitle:   cd.GetName(),
+			ocispecv1.AnnotationVersion: cd.GetVersion(),
+		},
+	}
+	if err := h.client.PushBlob(ctx, h.ref, layer, bytes.NewBuffer(data)); err != nil {
+		return fmt.Errorf("unable to push component descriptor layer: %w", err)
+	}
+
+	if manifest == nil {
+		config := []byte("{}")
+		manifest = &ocispecv1.Manifest{
+			Versioned: imagespec.Versioned{SchemaVersion: 2},
+			Config: ocispecv1.Descriptor{
+				MediaType: HistoryConfigMimeType,
+				Digest:    digest.FromBytes(config),
+				Size:      int64(len(config)),
+			},
+		}
+		if err := h.client.PushBlob(ctx, h.ref, manifest.Config, bytes.NewBuffer(config)); err != nil {
+			return fmt.Errorf("unable to push history config: %w", err)
+		}
+	}
+	manifest.Layers = append(manifest.Layers, layer)
+	if err := h.client.PushManifest(ctx, h.ref, manifest); err != nil {
+		return fmt.Errorf("unable to push history manifest: %w", err)
+	}
+	return nil
+}
+
+// List returns all entries of the history sorted by their timestamp.
+func (h *HistoryArchive) List(ctx context.Context) ([]*HistoryEntry, error) {
+	layers, err := h.sortedLayers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*HistoryEntry, len(layers))
+	for i, layer := range layers {
+		entries[i] = &HistoryEntry{
+			Timestamp: layer.timestamp,
+			Name:      layer.desc.Annotations[ocispecv1.AnnotationTitle],
+			Version:   layer.desc.Annotations[ocispecv1.AnnotationVersion],
+			Digest:    layer.desc.Digest,
+		}
+	}
+	return entries, nil
+}
+
+// Get returns the component descriptor of the history entry with the given index.
+// The index refers to the entries as returned by List.
+func (h *HistoryArchive) Get(ctx context.Context, index int) (*cdv2.ComponentDescriptor, error) {
+	layers, err := h.sortedLayers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(layers) {
+		return nil, fmt.Errorf("history entry %d: %w", index, ctf.NotFoundError)
+	}
+
+	var data bytes.Buffer
+	if err := h.client.Fetch(ctx, h.ref, layers[index].desc, &data); err != nil {
+		return nil, fmt.Errorf("unable to fetch history entry %d: %w", index, err)
+	}
+	cd := &cdv2.ComponentDescriptor{}
+	if err := codec.Decode(data.Bytes(), cd, h.decodeOpts...); err != nil {
+		return nil, fmt.Errorf("unable to decode history entry %d: %w", index, err)
+	}
+	return cd, nil
+}
+
+type historyLayer struct {
+	desc      ocispecv1.Descriptor
+	timestamp time.Time
+}
+
+// sortedLayers returns the layers of the history manifest sorted by their creation timestamp.
+func (h *HistoryArchive) sortedLayers(ctx context.Context) ([]historyLayer, error) {
+	manifest, err := h.getManifest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil {
+		return []historyLayer{}, nil
+	}
+	layers := make([]historyLayer, 0, len(manifest.Layers))
+	for _, desc := range manifest.Layers {
+		if desc.MediaType != ComponentDescriptorYAMLMimeType {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339Nano, desc.Annotations[ocispecv1.AnnotationCreated])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse timestamp of history layer %q: %w", desc.Digest, err)
+		}
+		layers = append(layers, historyLayer{
+			desc:      desc,
+			timestamp: timestamp,
+		})
+	}
+	sort.SliceStable(layers, func(i, j int) bool {
+		return layers[i].timestamp.Before(layers[j].timestamp)
+	})
+	return layers, nil
+}
+
+// getManifest returns the current history manifest.
+// Nil is returned if no history exists yet which is expected to be indicated by a ctf.NotFoundError.
+func (h *HistoryArchive) getManifest(ctx context.Context) (*ocispecv1.Manifest, error) {
+	manifest, err := h.client.GetManifest(ctx, h.ref)
+	if err != nil {
+		if errors.Is(err, ctf.NotFoundError) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get history manifest %q: %w", h.ref, err)
+	}
+	return manifest, nil
+}
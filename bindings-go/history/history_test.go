@@ -0,0 +1,151 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/history"
+)
+
+var _ = Describe("HistoryArchive", func() {
+
+	var (
+		client  *inMemoryClient
+		archive *history.HistoryArchive
+	)
+
+	newComponentDescriptor := func(version string) *cdv2.ComponentDescriptor {
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Name = "example.com/comp"
+		cd.Version = version
+		cd.Provider = "internal"
+		Expect(cdv2.DefaultComponent(cd)).To(Succeed())
+		return cd
+	}
+
+	BeforeEach(func() {
+		client = newInMemoryClient()
+		archive = history.NewHistoryArchive(client, "example.com/history", "example.com/comp")
+	})
+
+	It("should store the history at the history reference", func() {
+		Expect(archive.Ref()).To(Equal("example.com/history/example.com/comp:history"))
+		Expect(archive.Push(context.TODO(), newComponentDescriptor("1.0.0"))).To(Succeed())
+		Expect(client.manifests).To(HaveKey("example.com/history/example.com/comp:history"))
+	})
+
+	It("should return an empty list if no history exists", func() {
+		entries, err := archive.List(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("should list and get all pushed versions in order", func() {
+		for _, version := range []string{"1.0.0", "1.1.0", "2.0.0"} {
+			Expect(archive.Push(context.TODO(), newComponentDescriptor(version))).To(Succeed())
+		}
+
+		entries, err := archive.List(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(HaveLen(3))
+		for i, version := range []string{"1.0.0", "1.1.0", "2.0.0"} {
+			Expect(entries[i].Version).To(Equal(version))
+			if i > 0 {
+				Expect(entries[i].Timestamp.Before(entries[i-1].Timestamp)).To(BeFalse())
+			}
+		}
+
+		cd, err := archive.Get(context.TODO(), 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cd.Name).To(Equal("example.com/comp"))
+		Expect(cd.Version).To(Equal("1.1.0"))
+	})
+
+	It("should return a not found error for an unknown index", func() {
+		Expect(archive.Push(context.TODO(), newComponentDescriptor("1.0.0"))).To(Succeed())
+		_, err := archive.Get(context.TODO(), 1)
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+})
+
+// inMemoryClient is a oci client that stores all manifests and blobs in memory.
+type inMemoryClient struct {
+	mux       sync.Mutex
+	manifests map[string]*ocispecv1.Manifest
+	blobs     map[string][]byte
+}
+
+var _ history.Client = &inMemoryClient{}
+
+func newInMemoryClient() *inMemoryClient {
+	return &inMemoryClient{
+		manifests: map[string]*ocispecv1.Manifest{},
+		blobs:     map[string][]byte{},
+	}
+}
+
+func (c *inMemoryClient) GetManifest(_ context.Context, ref string) (*ocispecv1.Manifest, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	manifest, ok := c.manifests[ref]
+	if !ok {
+		return nil, ctf.NotFoundError
+	}
+	copied := *manifest
+	copied.Layers = append([]ocispecv1.Descriptor{}, manifest.Layers...)
+	return &copied, nil
+}
+
+func (c *inMemoryClient) Fetch(_ context.Context, _ string, desc ocispecv1.Descriptor, writer io.Writer) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	data, ok := c.blobs[desc.Digest.String()]
+	if !ok {
+		return fmt.Errorf("blob %q: %w", desc.Digest, ctf.NotFoundError)
+	}
+	_, err := io.Copy(writer, bytes.NewBuffer(data))
+	return err
+}
+
+func (c *inMemoryClient) PushBlob(_ context.Context, _ string, desc ocispecv1.Descriptor, reader io.Reader) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.blobs[desc.Digest.String()] = data
+	return nil
+}
+
+func (c *inMemoryClient) PushManifest(_ context.Context, ref string, manifest *ocispecv1.Manifest) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.manifests[ref] = manifest
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// MediaTypeDockerV2S2Manifest is the media type of docker v2 schema 2 image manifests.
+const MediaTypeDockerV2S2Manifest = "application/vnd.docker.distribution.manifest.v2+json"
+
+// manifestAccept is the accept header of manifest requests that accepts all supported manifest media types.
+var manifestAccept = strings.Join([]string{ocispecv1.MediaTypeImageManifest, ocispecv1.MediaTypeImageIndex, MediaTypeDockerV2S2Manifest}, ", ")
+
+// OCIBlobResolver implements the BlobResolver interface for "authenticatedOciRegistry" access types.
+// The manifest that is referenced by the image reference is fetched from the registry
+// using basic authentication with the credentials of the credential store.
+// The credentials are requested for the registry host of the image reference
+// so that a component descriptor cannot direct them to another registry.
+type OCIBlobResolver struct {
+	client *http.Client
+	store  CredentialStore
+}
+
+var _ ctf.BlobResolver = &OCIBlobResolver{}
+
+// NewOCIBlobResolverWithCredentials creates a new blob resolver that authenticates with credentials of the given store.
+func NewOCIBlobResolverWithCredentials(store CredentialStore) *OCIBlobResolver {
+	return &OCIBlobResolver{
+		client: http.DefaultClient,
+		store:  store,
+	}
+}
+
+// WithHTTPClient configures the http client that is used to access the registry.
+func (r *OCIBlobResolver) WithHTTPClient(client *http.Client) *OCIBlobResolver {
+	r.client = client
+	return r
+}
+
+func (r *OCIBlobResolver) CanResolve(res v2.Resource) bool {
+	return res.Access != nil && res.Access.GetType() == v2.AuthenticatedOCIType
+}
+
+// Info returns the blob info of the manifest of the image reference from a head request without downloading the manifest.
+func (r *OCIBlobResolver) Info(ctx context.Context, res v2.Resource) (*ctf.BlobInfo, error) {
+	imageReference, authorize, err := r.authorize(res)
+	if err != nil {
+		return nil, err
+	}
+	return headManifest(ctx, r.client, imageReference, authorize)
+}
+
+// Resolve fetches the manifest of the image reference and writes it to the given writer.
+func (r *OCIBlobResolver) Resolve(ctx context.Context, res v2.Resource, writer io.Writer) (*ctf.BlobInfo, error) {
+	imageReference, authorize, err := r.authorize(res)
+	if err != nil {
+		return nil, err
+	}
+	return fetchManifest(ctx, r.client, imageReference, authorize, writer)
+}
+
+// authorize returns the image reference of the resource and a function that authorizes the requests to its registry.
+func (r *OCIBlobResolver) authorize(res v2.Resource) (string, func(req *http.Request), error) {
+	if !r.CanResolve(res) {
+		return "", nil, ctf.UnsupportedResolveType
+	}
+	access := &v2.AuthenticatedOCIAccess{}
+	if err := res.Access.DecodeInto(access); err != nil {
+		return "", nil, fmt.Errorf("unable to decode access to type '%s': %w", res.Access.GetType(), err)
+	}
+	host, _, err := splitRegistryHost(access.ImageReference)
+	if err != nil {
+		return "", nil, err
+	}
+	username, password, err := r.store.Get(host, access.AuthSecretRef)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to get credentials %q for host %q: %w", access.AuthSecretRef, host, err)
+	}
+	return access.ImageReference, func(req *http.Request) {
+		req.SetBasicAuth(username, password)
+	}, nil
+}
+
+// headManifest returns the blob info of the manifest of the image reference from the response headers of a head request.
+// The digest is only returned if the registry sends the "Docker-Content-Digest" header.
+// The authorize function is called to authorize the request to the registry.
+func headManifest(ctx context.Context, client *http.Client, imageReference string, authorize func(req *http.Request)) (*ctf.BlobInfo, error) {
+	resp, url, err := requestManifest(ctx, client, http.MethodHead, imageReference, authorize)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return headBlobInfo(resp, url)
+}
+
+// headBlobInfo returns the blob info that is described by the headers of a response of the registry api.
+func headBlobInfo(resp *http.Response, url string) (*ctf.BlobInfo, error) {
+	info := &ctf.BlobInfo{
+		MediaType: resp.Header.Get("Content-Type"),
+	}
+	if resp.ContentLength > 0 {
+		info.Size = resp.ContentLength
+	}
+	if header := resp.Header.Get("Docker-Content-Digest"); len(header) != 0 {
+		dig, err := digest.Parse(header)
+		if err != nil {
+			return nil, fmt.Errorf("invalid content digest %q of %q: %w", header, url, err)
+		}
+		info.Digest = dig.String()
+	}
+	return info, nil
+}
+
+// fetchManifest fetches the manifest of the image reference and writes it to the given writer.
+// The authorize function is called to authorize the request to the registry.
+func fetchManifest(ctx context.Context, client *http.Client, imageReference string, authorize func(req *http.Request), writer io.Writer) (*ctf.BlobInfo, error) {
+	resp, url, err := requestManifest(ctx, client, http.MethodGet, imageReference, authorize)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(writer, digester.Hash()), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read manifest from %q: %w", url, err)
+	}
+	return &ctf.BlobInfo{
+		MediaType: resp.Header.Get("Content-Type"),
+		Digest:    digester.Digest().String(),
+		Size:      size,
+	}, nil
+}
+
+// requestManifest sends a request with the given method for the manifest of the image reference
+// and returns the response and the requested url if the request was successful.
+// The body of the response has to be closed by the caller.
+func requestManifest(ctx context.Context, client *http.Client, method, imageReference string, authorize func(req *http.Request)) (*http.Response, string, error) {
+	url, err := manifestURL(imageReference)
+	if err != nil {
+		return nil, "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to create request for %q: %w", url, err)
+	}
+	authorize(req)
+	req.Header.Set("Accept", manifestAccept)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to fetch manifest from %q: %w", url, err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, url, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("manifest %q: %w", imageReference, ctf.NotFoundError)
+	default:
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("unable to fetch manifest from %q: unexpected status code %d", url, resp.StatusCode)
+	}
+}
+
+// manifestURL returns the url of the manifest of the image reference in the oci distribution api.
+func manifestURL(imageReference string) (string, error) {
+	repo, version, _, err := cdutils.ParseImageReference(imageReference)
+	if err != nil {
+		return "", err
+	}
+	split := strings.SplitN(repo, "/", 2)
+	if len(split) != 2 {
+		return "", fmt.Errorf("image reference %q does not contain a registry host", imageReference)
+	}
+	return fmt.Sprintf("https://%s/v2/%s/manifests/%s", split[0], split[1], version), nil
+}
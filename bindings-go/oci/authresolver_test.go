@@ -0,0 +1,186 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/oci"
+)
+
+// staticCredentialStore is a credential store that returns the same credentials for all names and hosts.
+type staticCredentialStore struct {
+	requested []string
+}
+
+func (s *staticCredentialStore) Get(host, ref string) (string, string, error) {
+	s.requested = append(s.requested, host+"/"+ref)
+	return "user", "pass", nil
+}
+
+// setEnvCredentials sets the environment variables of the "dev-registry" credentials of the env credential store.
+// The returned function unsets the variables.
+func setEnvCredentials(prefix, host string) func() {
+	prefix += "_DEV_REGISTRY"
+	Expect(os.Setenv(prefix+"_HOST", host)).To(Succeed())
+	Expect(os.Setenv(prefix+"_USERNAME", "user")).To(Succeed())
+	Expect(os.Setenv(prefix+"_PASSWORD", "pass")).To(Succeed())
+	return func() {
+		_ = os.Unsetenv(prefix + "_HOST")
+		_ = os.Unsetenv(prefix + "_USERNAME")
+		_ = os.Unsetenv(prefix + "_PASSWORD")
+	}
+}
+
+var _ = Describe("OCIBlobResolver", func() {
+
+	var (
+		server          *httptest.Server
+		manifest        []byte
+		manifestMethods []string
+	)
+
+	newResource := func(ref string) cdv2.Resource {
+		access, err := cdv2.NewUnstructured(cdv2.NewAuthenticatedOCIAccess(ref, "dev-registry"))
+		Expect(err).ToNot(HaveOccurred())
+		return cdv2.Resource{
+			IdentityObjectMeta: cdv2.IdentityObjectMeta{
+				Name:    "image",
+				Version: "1.0.0",
+				Type:    cdv2.OCIImageType,
+			},
+			Relation: cdv2.ExternalRelation,
+			Access:   &access,
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		manifest, err = ioutil.ReadFile("./testdata/manifest.json")
+		Expect(err).ToNot(HaveOccurred())
+		manifestMethods = nil
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			if !ok || username != "user" || password != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Path != "/v2/org/image/manifests/1.0.0" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			manifestMethods = append(manifestMethods, r.Method)
+			w.Header().Set("Content-Type", ocispecv1.MediaTypeImageManifest)
+			w.Header().Set("Docker-Content-Digest", digest.FromBytes(manifest).String())
+			_, _ = w.Write(manifest)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should fetch the manifest with the credentials of the store", func() {
+		store := &staticCredentialStore{}
+		resolver := oci.NewOCIBlobResolverWithCredentials(store).WithHTTPClient(server.Client())
+		host := strings.TrimPrefix(server.URL, "https://")
+		res := newResource(host + "/org/image:1.0.0")
+		Expect(resolver.CanResolve(res)).To(BeTrue())
+
+		var result bytes.Buffer
+		info, err := resolver.Resolve(context.TODO(), res, &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(manifest))
+		Expect(info.MediaType).To(Equal(ocispecv1.MediaTypeImageManifest))
+		Expect(info.Digest).To(Equal(digest.FromBytes(manifest).String()))
+		Expect(store.requested).To(Equal([]string{host + "/dev-registry"}))
+	})
+
+	It("should get the manifest info with a head request", func() {
+		host := strings.TrimPrefix(server.URL, "https://")
+		resolver := oci.NewOCIBlobResolverWithCredentials(&staticCredentialStore{}).WithHTTPClient(server.Client())
+		info, err := resolver.Info(context.TODO(), newResource(host+"/org/image:1.0.0"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info).To(Equal(&ctf.BlobInfo{
+			MediaType: ocispecv1.MediaTypeImageManifest,
+			Digest:    digest.FromBytes(manifest).String(),
+			Size:      int64(len(manifest)),
+		}))
+		Expect(manifestMethods).To(Equal([]string{http.MethodHead}))
+	})
+
+	It("should fail if no credentials are available", func() {
+		resolver := oci.NewOCIBlobResolverWithCredentials(oci.NewEnvCredentialStore("oci_test_unset")).WithHTTPClient(server.Client())
+		host := strings.TrimPrefix(server.URL, "https://")
+		_, err := resolver.Info(context.TODO(), newResource(host+"/org/image:1.0.0"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should read the credentials of the registry host from the environment", func() {
+		host := strings.TrimPrefix(server.URL, "https://")
+		defer setEnvCredentials("OCI_TEST", host)()
+		username, password, err := oci.NewEnvCredentialStore("oci_test").Get(host, "dev-registry")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(username).To(Equal("user"))
+		Expect(password).To(Equal("pass"))
+		_, _, err = oci.NewEnvCredentialStore("oci_test").Get("other.example.com", "dev-registry")
+		Expect(err).To(HaveOccurred())
+		_, _, err = oci.NewEnvCredentialStore("oci_test").Get(host, "other-registry")
+		Expect(err).To(HaveOccurred())
+
+		resolver := oci.NewOCIBlobResolverWithCredentials(oci.NewEnvCredentialStore("OCI_TEST")).WithHTTPClient(server.Client())
+		_, err = resolver.Info(context.TODO(), newResource(host+"/org/image:1.0.0"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not return the credentials of a registry for look-alike hosts", func() {
+		defer setEnvCredentials("OCI_TEST", "eu.gcr.io")()
+		store := oci.NewEnvCredentialStore("OCI_TEST")
+		_, _, err := store.Get("eu.gcr.io", "dev-registry")
+		Expect(err).ToNot(HaveOccurred())
+		_, _, err = store.Get("eu-gcr.io", "dev-registry")
+		Expect(err).To(HaveOccurred())
+		_, _, err = store.Get("eu.gcr.io", "../dev-registry")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not send the credentials of a registry to another host", func() {
+		defer setEnvCredentials("OCI_TEST", strings.TrimPrefix(server.URL, "https://"))()
+		requested := false
+		other := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = true
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer other.Close()
+
+		resolver := oci.NewOCIBlobResolverWithCredentials(oci.NewEnvCredentialStore("OCI_TEST")).WithHTTPClient(other.Client())
+		_, err := resolver.Info(context.TODO(), newResource(strings.TrimPrefix(other.URL, "https://")+"/org/image:1.0.0"))
+		Expect(err).To(HaveOccurred())
+		Expect(requested).To(BeFalse())
+	})
+})
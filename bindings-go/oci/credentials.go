@@ -0,0 +1,74 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// CredentialStore provides credentials to access oci registries.
+type CredentialStore interface {
+	// Get returns the username and password of the credentials with the given name for the given registry host.
+	// The credentials must only be returned if they belong to the registry host,
+	// as the host and the name are both defined by the component descriptor.
+	Get(host, ref string) (username, password string, err error)
+}
+
+// envCredentialStore reads credentials from environment variables.
+type envCredentialStore struct {
+	prefix string
+}
+
+// validCredentialName matches the names of credentials that can be read from environment variables.
+var validCredentialName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// NewEnvCredentialStore creates a credential store that reads the credentials with a name
+// from the environment variables "<PREFIX>_<NAME>_HOST", "<PREFIX>_<NAME>_USERNAME" and "<PREFIX>_<NAME>_PASSWORD".
+// The name is upper-cased and "-" is replaced by "_", e.g. the credentials "dev-registry" are read from "<PREFIX>_DEV_REGISTRY_USERNAME".
+// The credentials are only returned for the registry host that is defined by "<PREFIX>_<NAME>_HOST".
+func NewEnvCredentialStore(prefix string) CredentialStore {
+	return &envCredentialStore{
+		prefix: strings.ToUpper(prefix),
+	}
+}
+
+func (e *envCredentialStore) Get(host, ref string) (string, string, error) {
+	if len(host) == 0 {
+		return "", "", fmt.Errorf("a registry host is required")
+	}
+	if !validCredentialName.MatchString(ref) {
+		return "", "", fmt.Errorf("invalid credential name %q: only letters, digits, \"-\" and \"_\" are allowed", ref)
+	}
+	prefix := e.prefix + "_" + strings.ReplaceAll(strings.ToUpper(ref), "-", "_")
+	credentialHost, ok := os.LookupEnv(prefix + "_HOST")
+	if !ok {
+		return "", "", fmt.Errorf("environment variable %s_HOST is not set", prefix)
+	}
+	if !strings.EqualFold(credentialHost, host) {
+		return "", "", fmt.Errorf("credentials %q belong to registry host %q and not to %q", ref, credentialHost, host)
+	}
+	username, ok := os.LookupEnv(prefix + "_USERNAME")
+	if !ok {
+		return "", "", fmt.Errorf("environment variable %s_USERNAME is not set", prefix)
+	}
+	password, ok := os.LookupEnv(prefix + "_PASSWORD")
+	if !ok {
+		return "", "", fmt.Errorf("environment variable %s_PASSWORD is not set", prefix)
+	}
+	return username, password, nil
+}
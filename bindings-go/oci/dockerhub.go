@@ -0,0 +1,335 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+const (
+	// DockerHubRegistryURL is the url of the Docker Hub registry api.
+	DockerHubRegistryURL = "https://registry-1.docker.io"
+	// DockerHubAuthURL is the url of the Docker Hub token service.
+	DockerHubAuthURL = "https://auth.docker.io"
+	// DockerHubService is the service for which Docker Hub tokens are requested.
+	DockerHubService = "registry.docker.io"
+
+	// defaultDockerHubTokenTTL is the lifetime of a token whose response defines no lifetime.
+	defaultDockerHubTokenTTL = 60 * time.Second
+)
+
+// dockerHubHosts are the registry hosts that may be part of Docker Hub image references.
+var dockerHubHosts = []string{"docker.io", "index.docker.io", "registry-1.docker.io", "registry.hub.docker.com"}
+
+// DockerHubBlobResolver implements the BlobResolver interface for "dockerHub" access types.
+// A pull token is requested from the Docker Hub token service and used to fetch the manifest and layers of the image.
+// The tokens are cached per repository and refreshed before they expire.
+type DockerHubBlobResolver struct {
+	client      *http.Client
+	registryURL string
+	authURL     string
+	username    string
+	password    string
+
+	mux    sync.Mutex
+	tokens map[string]*dockerHubTokenCache
+}
+
+// dockerHubToken is a Docker Hub token.
+type dockerHubToken struct {
+	token string
+	// refreshAt is the time after which the token is refreshed.
+	refreshAt time.Time
+}
+
+// dockerHubTokenCache caches the token of a repository.
+// The mutex is held while a new token is requested so that concurrent requests for the same repository
+// share one token request without blocking other repositories.
+type dockerHubTokenCache struct {
+	mux   sync.Mutex
+	token dockerHubToken
+}
+
+var _ ctf.TypedBlobResolver = &DockerHubBlobResolver{}
+
+// NewDockerHubBlobResolver creates a new blob resolver for images of Docker Hub.
+// The images are pulled anonymously if no credentials are configured.
+func NewDockerHubBlobResolver() *DockerHubBlobResolver {
+	return &DockerHubBlobResolver{
+		client:      http.DefaultClient,
+		registryURL: DockerHubRegistryURL,
+		authURL:     DockerHubAuthURL,
+		tokens:      map[string]*dockerHubTokenCache{},
+	}
+}
+
+// WithHTTPClient configures the http client that is used to access the registry and the token service.
+func (r *DockerHubBlobResolver) WithHTTPClient(client *http.Client) *DockerHubBlobResolver {
+	r.client = client
+	return r
+}
+
+// WithURLs configures the urls of the registry api and the token service, e.g. for mirrors.
+func (r *DockerHubBlobResolver) WithURLs(registryURL, authURL string) *DockerHubBlobResolver {
+	r.registryURL = strings.TrimSuffix(registryURL, "/")
+	r.authURL = strings.TrimSuffix(authURL, "/")
+	return r
+}
+
+// WithCredentials configures the Docker Hub user that is used to request tokens.
+func (r *DockerHubBlobResolver) WithCredentials(username, password string) *DockerHubBlobResolver {
+	r.username = username
+	r.password = password
+	return r
+}
+
+func (r *DockerHubBlobResolver) CanResolve(res v2.Resource) bool {
+	return res.Access != nil && res.Access.GetType() == v2.DockerHubAccessType
+}
+
+// Info returns the blob info of the manifest of the image reference from a head request without downloading the manifest.
+// Head requests do not count against the pull rate limit of Docker Hub.
+func (r *DockerHubBlobResolver) Info(ctx context.Context, res v2.Resource) (*ctf.BlobInfo, error) {
+	repository, version, err := r.decodeAccess(res)
+	if err != nil {
+		return nil, err
+	}
+	resp, u, err := r.request(ctx, http.MethodHead, repository, "manifests/"+version, manifestAccept)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return headBlobInfo(resp, u)
+}
+
+// Resolve fetches the manifest of the image reference and writes it to the given writer.
+func (r *DockerHubBlobResolver) Resolve(ctx context.Context, res v2.Resource, writer io.Writer) (*ctf.BlobInfo, error) {
+	repository, version, err := r.decodeAccess(res)
+	if err != nil {
+		return nil, err
+	}
+	return r.fetch(ctx, repository, "manifests/"+version, manifestAccept, writer)
+}
+
+// ResolveLayer fetches the layer with the given digest from the repository of the image reference
+// and writes it to the given writer.
+// The digest of the fetched layer is verified.
+func (r *DockerHubBlobResolver) ResolveLayer(ctx context.Context, res v2.Resource, layer ocispecv1.Descriptor, writer io.Writer) (*ctf.BlobInfo, error) {
+	repository, _, err := r.decodeAccess(res)
+	if err != nil {
+		return nil, err
+	}
+	info, err := r.fetch(ctx, repository, "blobs/"+layer.Digest.String(), "", writer)
+	if err != nil {
+		return nil, err
+	}
+	if info.Digest != layer.Digest.String() {
+		return nil, fmt.Errorf("digest %q of layer of %q does not match the expected digest %q", info.Digest, repository, layer.Digest)
+	}
+	if len(layer.MediaType) != 0 {
+		info.MediaType = layer.MediaType
+	}
+	return info, nil
+}
+
+// decodeAccess returns the Docker Hub repository and the tag or digest of the image reference of the access.
+func (r *DockerHubBlobResolver) decodeAccess(res v2.Resource) (string, string, error) {
+	if !r.CanResolve(res) {
+		return "", "", ctf.UnsupportedResolveType
+	}
+	access := &v2.DockerHubAccess{}
+	if err := res.Access.DecodeInto(access); err != nil {
+		return "", "", fmt.Errorf("unable to decode access to type '%s': %w", res.Access.GetType(), err)
+	}
+	return DockerHubRepository(access.ImageReference)
+}
+
+// DockerHubRepository returns the Docker Hub repository and the tag or digest of the given image reference.
+// The registry host is removed and official images are mapped to the "library" organization,
+// e.g. "docker.io/nginx:1.23" results in "library/nginx" and "1.23".
+// An error is returned if the image reference contains a registry host that is not a Docker Hub host, e.g. "quay.io/org/image:1.0".
+func DockerHubRepository(imageReference string) (string, string, error) {
+	repo, version, _, err := cdutils.ParseImageReference(imageReference)
+	if err != nil {
+		return "", "", err
+	}
+	if split := strings.SplitN(repo, "/", 2); len(split) == 2 && isRegistryHost(split[0]) {
+		if !isDockerHubHost(split[0]) {
+			return "", "", fmt.Errorf("image reference %q does not reference a Docker Hub image", imageReference)
+		}
+		repo = split[1]
+	}
+	if !strings.Contains(repo, "/") {
+		repo = "library/" + repo
+	}
+	return repo, version, nil
+}
+
+// isRegistryHost returns whether the first component of an image repository is a registry host.
+// Like in docker, a component is a host if it contains a "." or ":" or is "localhost".
+func isRegistryHost(component string) bool {
+	return strings.ContainsAny(component, ".:") || component == "localhost"
+}
+
+// isDockerHubHost returns whether the host is one of the Docker Hub registry hosts.
+func isDockerHubHost(host string) bool {
+	for _, dockerHubHost := range dockerHubHosts {
+		if strings.EqualFold(host, dockerHubHost) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetch fetches the given path of the repository from the registry api and writes it to the given writer.
+func (r *DockerHubBlobResolver) fetch(ctx context.Context, repository, path, accept string, writer io.Writer) (*ctf.BlobInfo, error) {
+	resp, u, err := r.request(ctx, http.MethodGet, repository, path, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(writer, digester.Hash()), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %q: %w", u, err)
+	}
+	return &ctf.BlobInfo{
+		MediaType: resp.Header.Get("Content-Type"),
+		Digest:    digester.Digest().String(),
+		Size:      size,
+	}, nil
+}
+
+// token returns a cached pull token for the repository or requests a new one if the cached token is about to expire.
+// Only the cache of the repository is locked while a token is requested.
+func (r *DockerHubBlobResolver) token(ctx context.Context, repository string) (string, error) {
+	r.mux.Lock()
+	cache, ok := r.tokens[repository]
+	if !ok {
+		cache = &dockerHubTokenCache{}
+		r.tokens[repository] = cache
+	}
+	r.mux.Unlock()
+
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+	if len(cache.token.token) != 0 && time.Now().Before(cache.token.refreshAt) {
+		return cache.token.token, nil
+	}
+	token, err := r.requestToken(ctx, repository)
+	if err != nil {
+		return "", err
+	}
+	cache.token = token
+	return token.token, nil
+}
+
+// requestToken requests a pull token for the repository from the token service.
+// The token is refreshed after 90% of its lifetime.
+func (r *DockerHubBlobResolver) requestToken(ctx context.Context, repository string) (dockerHubToken, error) {
+	query := url.Values{}
+	query.Set("service", DockerHubService)
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", repository))
+	tokenURL := fmt.Sprintf("%s/token?%s", r.authURL, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return dockerHubToken{}, fmt.Errorf("unable to create request for %q: %w", tokenURL, err)
+	}
+	if len(r.username) != 0 {
+		req.SetBasicAuth(r.username, r.password)
+	}
+	requested := time.Now()
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return dockerHubToken{}, fmt.Errorf("unable to request token from %q: %w", tokenURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return dockerHubToken{}, fmt.Errorf("unable to request token from %q: unexpected status code %d", tokenURL, resp.StatusCode)
+	}
+	tokenResp := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return dockerHubToken{}, fmt.Errorf("unable to decode token response: %w", err)
+	}
+	token := tokenResp.Token
+	if len(token) == 0 {
+		token = tokenResp.AccessToken
+	}
+	if len(token) == 0 {
+		return dockerHubToken{}, errors.New("the token response does not contain a token")
+	}
+	ttl := defaultDockerHubTokenTTL
+	if tokenResp.ExpiresIn > 0 {
+		ttl = time.Duration(tokenResp.ExpiresIn) * time.Second
+	}
+	return dockerHubToken{
+		token:     token,
+		refreshAt: requested.Add(ttl - ttl/10),
+	}, nil
+}
+
+// request sends a request with the given method for the path of the repository to the registry api
+// and returns the response and the requested url if the request was successful.
+// The body of the response has to be closed by the caller.
+func (r *DockerHubBlobResolver) request(ctx context.Context, method, repository, path, accept string) (*http.Response, string, error) {
+	token, err := r.token(ctx, repository)
+	if err != nil {
+		return nil, "", err
+	}
+	u := fmt.Sprintf("%s/v2/%s/%s", r.registryURL, repository, path)
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to create request for %q: %w", u, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if len(accept) != 0 {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to fetch %q: %w", u, err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, u, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("%s of %q: %w", path, repository, ctf.NotFoundError)
+	default:
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("unable to fetch %q: unexpected status code %d", u, resp.StatusCode)
+	}
+}
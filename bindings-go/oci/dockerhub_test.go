@@ -0,0 +1,220 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/oci"
+)
+
+var _ = Describe("DockerHubBlobResolver", func() {
+
+	var (
+		server          *httptest.Server
+		manifest        []byte
+		layer           = []byte("layer content")
+		tokenRequests   int32
+		expiresIn       int64
+		manifestMethods []string
+	)
+
+	newResource := func(ref string) cdv2.Resource {
+		access, err := cdv2.NewUnstructured(cdv2.NewDockerHubAccess(ref))
+		Expect(err).ToNot(HaveOccurred())
+		return cdv2.Resource{
+			IdentityObjectMeta: cdv2.IdentityObjectMeta{
+				Name:    "image",
+				Version: "1.0.0",
+				Type:    cdv2.OCIImageType,
+			},
+			Relation: cdv2.ExternalRelation,
+			Access:   &access,
+		}
+	}
+
+	newResolver := func() *oci.DockerHubBlobResolver {
+		return oci.NewDockerHubBlobResolver().WithHTTPClient(server.Client()).WithURLs(server.URL, server.URL)
+	}
+
+	BeforeEach(func() {
+		var err error
+		manifest, err = ioutil.ReadFile("./testdata/manifest.json")
+		Expect(err).ToNot(HaveOccurred())
+		atomic.StoreInt32(&tokenRequests, 0)
+		expiresIn = 300
+		manifestMethods = nil
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/token" {
+				if r.URL.Query().Get("service") != oci.DockerHubService || r.URL.Query().Get("scope") != "repository:library/nginx:pull" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				n := atomic.AddInt32(&tokenRequests, 1)
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"token":        fmt.Sprintf("token-%d", n),
+					"access_token": fmt.Sprintf("token-%d", n),
+					"expires_in":   atomic.LoadInt64(&expiresIn),
+					"issued_at":    time.Now().Format(time.RFC3339),
+				})
+				return
+			}
+			if r.Header.Get("Authorization") != fmt.Sprintf("Bearer token-%d", atomic.LoadInt32(&tokenRequests)) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch r.URL.Path {
+			case "/v2/library/nginx/manifests/1.23":
+				manifestMethods = append(manifestMethods, r.Method)
+				w.Header().Set("Content-Type", ocispecv1.MediaTypeImageManifest)
+				w.Header().Set("Docker-Content-Digest", digest.FromBytes(manifest).String())
+				_, _ = w.Write(manifest)
+			case "/v2/library/nginx/blobs/" + digest.FromBytes(layer).String():
+				_, _ = w.Write(layer)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should map image references to Docker Hub repositories", func() {
+		for ref, expected := range map[string]string{
+			"nginx:1.23":                      "library/nginx",
+			"docker.io/nginx:1.23":            "library/nginx",
+			"docker.io/org/image:1.23":        "org/image",
+			"index.docker.io/library/app:1.0": "library/app",
+		} {
+			repo, _, err := oci.DockerHubRepository(ref)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(repo).To(Equal(expected), ref)
+		}
+	})
+
+	It("should reject image references of other registries", func() {
+		for _, ref := range []string{"quay.io/org/image:1.0", "ghcr.io/org/image:1.0", "localhost:5000/image:1.0", "localhost/org/image:1.0"} {
+			_, _, err := oci.DockerHubRepository(ref)
+			Expect(err).To(HaveOccurred(), ref)
+		}
+		_, err := newResolver().Info(context.TODO(), newResource("quay.io/library/nginx:1.23"))
+		Expect(err).To(HaveOccurred())
+		Expect(atomic.LoadInt32(&tokenRequests)).To(BeZero())
+	})
+
+	It("should not block token requests of other repositories", func() {
+		blocked := make(chan struct{})
+		release := make(chan struct{})
+		tokenServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/token" {
+				_, _ = w.Write(manifest)
+				return
+			}
+			if r.URL.Query().Get("scope") == "repository:org/slow:pull" {
+				close(blocked)
+				<-release
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"token": "token", "expires_in": 300})
+		}))
+		defer tokenServer.Close()
+		// the blocked token request has to be released before the server can be closed.
+		defer close(release)
+		resolver := oci.NewDockerHubBlobResolver().WithHTTPClient(tokenServer.Client()).WithURLs(tokenServer.URL, tokenServer.URL)
+
+		go func() {
+			defer GinkgoRecover()
+			_, _ = resolver.Info(context.TODO(), newResource("org/slow:1.0"))
+		}()
+		Eventually(blocked).Should(BeClosed())
+		_, err := resolver.Info(context.TODO(), newResource("nginx:1.23"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch the manifest and layers with a cached token", func() {
+		resolver := newResolver()
+		res := newResource("docker.io/nginx:1.23")
+		Expect(resolver.CanResolve(res)).To(BeTrue())
+
+		var result bytes.Buffer
+		info, err := resolver.Resolve(context.TODO(), res, &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(manifest))
+		Expect(info.MediaType).To(Equal(ocispecv1.MediaTypeImageManifest))
+
+		result.Reset()
+		_, err = resolver.ResolveLayer(context.TODO(), res, ocispecv1.Descriptor{Digest: digest.FromBytes(layer)}, &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(layer))
+		Expect(atomic.LoadInt32(&tokenRequests)).To(Equal(int32(1)))
+	})
+
+	It("should get the manifest info with a head request", func() {
+		info, err := newResolver().Info(context.TODO(), newResource("nginx:1.23"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info).To(Equal(&ctf.BlobInfo{
+			MediaType: ocispecv1.MediaTypeImageManifest,
+			Digest:    digest.FromBytes(manifest).String(),
+			Size:      int64(len(manifest)),
+		}))
+		Expect(manifestMethods).To(Equal([]string{http.MethodHead}))
+	})
+
+	It("should refresh the token before it expires", func() {
+		atomic.StoreInt64(&expiresIn, 1)
+		resolver := newResolver()
+		res := newResource("nginx:1.23")
+		_, err := resolver.Info(context.TODO(), res)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(atomic.LoadInt32(&tokenRequests)).To(Equal(int32(1)))
+
+		time.Sleep(time.Second)
+		_, err = resolver.Info(context.TODO(), res)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(atomic.LoadInt32(&tokenRequests)).To(Equal(int32(2)))
+	})
+
+	It("should return a not found error if the image does not exist", func() {
+		_, err := newResolver().Info(context.TODO(), newResource("nginx:0.0.1"))
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+	It("should not resolve other access types", func() {
+		access, err := cdv2.NewUnstructured(cdv2.NewOCIRegistryAccess("nginx:1.23"))
+		Expect(err).ToNot(HaveOccurred())
+		res := cdv2.Resource{Access: &access}
+		resolver := newResolver()
+		Expect(resolver.CanResolve(res)).To(BeFalse())
+		_, err = resolver.Info(context.TODO(), res)
+		Expect(err).To(MatchError(ctf.UnsupportedResolveType))
+	})
+})
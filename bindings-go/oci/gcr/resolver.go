@@ -0,0 +1,284 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcr
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/oci"
+)
+
+// ReadOnlyScope is the oauth2 scope that is requested to pull images from the Google Container Registry.
+const ReadOnlyScope = "https://www.googleapis.com/auth/devstorage.read_only"
+
+// accessTokenUsername is the username that has to be used in combination with an oauth2 access token.
+const accessTokenUsername = "oauth2accesstoken"
+
+// GCRBlobResolver implements the BlobResolver interface for "gcr" access types.
+// An oauth2 access token is obtained for the service account of the access,
+// exchanged into a registry token and used to fetch the manifest and layers that are referenced by the image reference.
+// Only images of Google registry hosts are fetched (see IsGoogleRegistryHost),
+// so that a component descriptor cannot direct the credentials to another host.
+type GCRBlobResolver struct {
+	client       *http.Client
+	allowedHosts []string
+}
+
+var _ ctf.TypedBlobResolver = &GCRBlobResolver{}
+
+// NewGCRBlobResolver creates a new blob resolver for images of the Google Container Registry.
+func NewGCRBlobResolver() *GCRBlobResolver {
+	return &GCRBlobResolver{
+		client: http.DefaultClient,
+	}
+}
+
+// WithHTTPClient configures the http client that is used to access the registry and the Google token endpoints.
+func (r *GCRBlobResolver) WithHTTPClient(client *http.Client) *GCRBlobResolver {
+	r.client = client
+	return r
+}
+
+// WithAllowedHosts configures additional registry hosts images may be fetched from.
+func (r *GCRBlobResolver) WithAllowedHosts(hosts ...string) *GCRBlobResolver {
+	r.allowedHosts = hosts
+	return r
+}
+
+// IsGoogleRegistryHost returns true if the host is a host of the Google Container Registry (gcr.io, *.gcr.io)
+// or of the Google Artifact Registry (*-docker.pkg.dev).
+func IsGoogleRegistryHost(host string) bool {
+	return host == "gcr.io" || strings.HasSuffix(host, ".gcr.io") || strings.HasSuffix(host, "-docker.pkg.dev")
+}
+
+func (r *GCRBlobResolver) isAllowedHost(host string) bool {
+	if IsGoogleRegistryHost(host) {
+		return true
+	}
+	for _, allowed := range r.allowedHosts {
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *GCRBlobResolver) CanResolve(res v2.Resource) bool {
+	return res.Access != nil && res.Access.GetType() == v2.GCRAccessType
+}
+
+func (r *GCRBlobResolver) Info(ctx context.Context, res v2.Resource) (*ctf.BlobInfo, error) {
+	return r.Resolve(ctx, res, io.Discard)
+}
+
+// Resolve fetches the manifest of the image reference and writes it to the given writer.
+func (r *GCRBlobResolver) Resolve(ctx context.Context, res v2.Resource, writer io.Writer) (*ctf.BlobInfo, error) {
+	access, token, err := r.authorize(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	repo, version, err := splitImageReference(access.ImageReference)
+	if err != nil {
+		return nil, err
+	}
+	return r.fetch(ctx, access.ImageReference, repo, "manifests/"+version, token, writer)
+}
+
+// ResolveLayer fetches the layer with the given digest from the repository of the image reference
+// and writes it to the given writer.
+// The digest of the fetched layer is verified.
+func (r *GCRBlobResolver) ResolveLayer(ctx context.Context, res v2.Resource, layer ocispecv1.Descriptor, writer io.Writer) (*ctf.BlobInfo, error) {
+	access, token, err := r.authorize(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	repo, _, err := splitImageReference(access.ImageReference)
+	if err != nil {
+		return nil, err
+	}
+	info, err := r.fetch(ctx, access.ImageReference, repo, "blobs/"+layer.Digest.String(), token, writer)
+	if err != nil {
+		return nil, err
+	}
+	if info.Digest != layer.Digest.String() {
+		return nil, fmt.Errorf("digest %q of layer of %q does not match the expected digest %q", info.Digest, access.ImageReference, layer.Digest)
+	}
+	if len(layer.MediaType) != 0 {
+		info.MediaType = layer.MediaType
+	}
+	return info, nil
+}
+
+// authorize decodes the access of the resource and returns a registry token that allows to pull the image.
+func (r *GCRBlobResolver) authorize(ctx context.Context, res v2.Resource) (*v2.GCRAccess, string, error) {
+	if !r.CanResolve(res) {
+		return nil, "", ctf.UnsupportedResolveType
+	}
+	access := &v2.GCRAccess{}
+	if err := res.Access.DecodeInto(access); err != nil {
+		return nil, "", fmt.Errorf("unable to decode access to type '%s': %w", res.Access.GetType(), err)
+	}
+	// the host is checked before any credentials are obtained
+	repo, _, err := splitImageReference(access.ImageReference)
+	if err != nil {
+		return nil, "", err
+	}
+	if host, _ := splitRepository(repo); !r.isAllowedHost(host) {
+		return nil, "", fmt.Errorf("registry host %q of %q is not a google registry host", host, access.ImageReference)
+	}
+	accessToken, err := r.accessToken(ctx, access)
+	if err != nil {
+		return nil, "", err
+	}
+	token, err := r.registryToken(ctx, access.ImageReference, accessToken)
+	if err != nil {
+		return nil, "", err
+	}
+	return access, token, nil
+}
+
+// accessToken obtains an oauth2 access token for the service account key of the access.
+// The application default credentials are used if the access defines no service account key.
+// These also support workload identity federation configurations that are referenced by GOOGLE_APPLICATION_CREDENTIALS.
+func (r *GCRBlobResolver) accessToken(ctx context.Context, access *v2.GCRAccess) (string, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, r.client)
+	var (
+		creds *google.Credentials
+		err   error
+	)
+	if len(access.ServiceAccountKey) != 0 {
+		key, decodeErr := base64.StdEncoding.DecodeString(access.ServiceAccountKey)
+		if decodeErr != nil {
+			return "", fmt.Errorf("unable to decode service account key for %q: %w", access.ImageReference, decodeErr)
+		}
+		creds, err = google.CredentialsFromJSON(ctx, key, ReadOnlyScope)
+	} else {
+		creds, err = google.FindDefaultCredentials(ctx, ReadOnlyScope)
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to get google credentials for %q: %w", access.ImageReference, err)
+	}
+	token, err := creds.TokenSource.Token()
+	if err != nil {
+		return "", fmt.Errorf("unable to get access token for %q: %w", access.ImageReference, err)
+	}
+	return token.AccessToken, nil
+}
+
+// registryToken exchanges the oauth2 access token into a registry token that allows to pull the image.
+func (r *GCRBlobResolver) registryToken(ctx context.Context, imageReference, accessToken string) (string, error) {
+	repo, _, err := splitImageReference(imageReference)
+	if err != nil {
+		return "", err
+	}
+	host, repository := splitRepository(repo)
+
+	query := url.Values{}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", repository))
+	query.Set("service", host)
+	tokenURL := fmt.Sprintf("https://%s/v2/token?%s", host, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to create request for %q: %w", tokenURL, err)
+	}
+	req.SetBasicAuth(accessTokenUsername, accessToken)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to request registry token from %q: %w", tokenURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to request registry token from %q: unexpected status code %d", tokenURL, resp.StatusCode)
+	}
+	tokenResp := struct {
+		Token string `json:"token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("unable to decode registry token response: %w", err)
+	}
+	if len(tokenResp.Token) == 0 {
+		return "", errors.New("the registry token response does not contain a token")
+	}
+	return tokenResp.Token, nil
+}
+
+// fetch fetches the given path of the repository from the oci distribution api and writes it to the given writer.
+func (r *GCRBlobResolver) fetch(ctx context.Context, imageReference, repo, path, token string, writer io.Writer) (*ctf.BlobInfo, error) {
+	host, repository := splitRepository(repo)
+	u := fmt.Sprintf("https://%s/v2/%s/%s", host, repository, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request for %q: %w", u, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", strings.Join([]string{ocispecv1.MediaTypeImageManifest, ocispecv1.MediaTypeImageIndex, oci.MediaTypeDockerV2S2Manifest}, ", "))
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch %q: %w", u, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%s of %q: %w", path, imageReference, ctf.NotFoundError)
+	default:
+		return nil, fmt.Errorf("unable to fetch %q: unexpected status code %d", u, resp.StatusCode)
+	}
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(writer, digester.Hash()), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %q: %w", u, err)
+	}
+	return &ctf.BlobInfo{
+		MediaType: resp.Header.Get("Content-Type"),
+		Digest:    digester.Digest().String(),
+		Size:      size,
+	}, nil
+}
+
+// splitImageReference returns the repository including the registry host and the tag or digest of the image reference.
+func splitImageReference(imageReference string) (string, string, error) {
+	repo, version, _, err := cdutils.ParseImageReference(imageReference)
+	if err != nil {
+		return "", "", err
+	}
+	if !strings.Contains(repo, "/") {
+		return "", "", fmt.Errorf("image reference %q does not contain a registry host", imageReference)
+	}
+	return repo, version, nil
+}
+
+// splitRepository splits the repository into the registry host and the repository path.
+func splitRepository(repo string) (string, string) {
+	split := strings.SplitN(repo, "/", 2)
+	return split[0], split[1]
+}
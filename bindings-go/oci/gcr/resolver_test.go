@@ -0,0 +1,213 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcr_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/oci/gcr"
+)
+
+var _ = Describe("GCRBlobResolver", func() {
+
+	var (
+		server   *httptest.Server
+		host     string
+		manifest []byte
+		layer    = []byte("layer content")
+		key      []byte
+	)
+
+	newResource := func(ref, serviceAccountKey string) cdv2.Resource {
+		access, err := cdv2.NewUnstructured(cdv2.NewGCRAccess(ref, serviceAccountKey))
+		Expect(err).ToNot(HaveOccurred())
+		return cdv2.Resource{
+			IdentityObjectMeta: cdv2.IdentityObjectMeta{
+				Name:    "image",
+				Version: "1.0.0",
+				Type:    cdv2.OCIImageType,
+			},
+			Relation: cdv2.ExternalRelation,
+			Access:   &access,
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		manifest, err = os.ReadFile("../testdata/manifest.json")
+		Expect(err).ToNot(HaveOccurred())
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/oauth2/token":
+				// the service account authenticates with a signed jwt assertion.
+				if r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" || len(r.FormValue("assertion")) == 0 {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "access-token", "token_type": "Bearer", "expires_in": 3600})
+			case r.URL.Path == "/v2/token":
+				username, password, ok := r.BasicAuth()
+				if !ok || username != "oauth2accesstoken" || password != "access-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				if r.URL.Query().Get("scope") != "repository:project/image:pull" || r.URL.Query().Get("service") != host {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]string{"token": "registry-token"})
+			case r.Header.Get("Authorization") != "Bearer registry-token":
+				w.WriteHeader(http.StatusUnauthorized)
+			case r.URL.Path == "/v2/project/image/manifests/1.0.0":
+				w.Header().Set("Content-Type", ocispecv1.MediaTypeImageManifest)
+				_, _ = w.Write(manifest)
+			case r.URL.Path == "/v2/project/image/blobs/"+digest.FromBytes(layer).String():
+				w.Header().Set("Content-Type", "application/octet-stream")
+				_, _ = w.Write(layer)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		host = strings.TrimPrefix(server.URL, "https://")
+
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+		key, err = json.Marshal(map[string]string{
+			"type":           "service_account",
+			"project_id":     "project",
+			"private_key_id": "key-id",
+			"private_key": string(pem.EncodeToMemory(&pem.Block{
+				Type:  "RSA PRIVATE KEY",
+				Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+			})),
+			"client_email": "puller@project.iam.gserviceaccount.com",
+			"token_uri":    server.URL + "/oauth2/token",
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should fetch the manifest with a token of the service account", func() {
+		resolver := gcr.NewGCRBlobResolver().WithHTTPClient(server.Client()).WithAllowedHosts(host)
+		res := newResource(host+"/project/image:1.0.0", base64.StdEncoding.EncodeToString(key))
+		Expect(resolver.CanResolve(res)).To(BeTrue())
+
+		var result bytes.Buffer
+		info, err := resolver.Resolve(context.TODO(), res, &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(manifest))
+		Expect(info.MediaType).To(Equal(ocispecv1.MediaTypeImageManifest))
+		Expect(info.Digest).To(Equal(digest.FromBytes(manifest).String()))
+	})
+
+	It("should fetch and verify layers of the image", func() {
+		resolver := gcr.NewGCRBlobResolver().WithHTTPClient(server.Client()).WithAllowedHosts(host)
+		res := newResource(host+"/project/image:1.0.0", base64.StdEncoding.EncodeToString(key))
+
+		var result bytes.Buffer
+		info, err := resolver.ResolveLayer(context.TODO(), res, ocispecv1.Descriptor{
+			MediaType: ocispecv1.MediaTypeImageLayerGzip,
+			Digest:    digest.FromBytes(layer),
+			Size:      int64(len(layer)),
+		}, &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(layer))
+		Expect(info.MediaType).To(Equal(ocispecv1.MediaTypeImageLayerGzip))
+
+		_, err = resolver.ResolveLayer(context.TODO(), res, ocispecv1.Descriptor{Digest: digest.FromString("other")}, &bytes.Buffer{})
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+	It("should use the credentials of GOOGLE_APPLICATION_CREDENTIALS without a service account key", func() {
+		dir, err := os.MkdirTemp("", "gcr-")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		credentialsFile := filepath.Join(dir, "credentials.json")
+		Expect(os.WriteFile(credentialsFile, key, os.ModePerm)).To(Succeed())
+		Expect(os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", credentialsFile)).To(Succeed())
+		defer func() {
+			_ = os.Unsetenv("GOOGLE_APPLICATION_CREDENTIALS")
+		}()
+		resolver := gcr.NewGCRBlobResolver().WithHTTPClient(server.Client()).WithAllowedHosts(host)
+		_, err = resolver.Info(context.TODO(), newResource(host+"/project/image:1.0.0", ""))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not send credentials to other hosts than google registries", func() {
+		requested := false
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = true
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+		resolver := gcr.NewGCRBlobResolver().WithHTTPClient(server.Client())
+		_, err := resolver.Info(context.TODO(), newResource(host+"/project/image:1.0.0", base64.StdEncoding.EncodeToString(key)))
+		Expect(err).To(HaveOccurred())
+		Expect(requested).To(BeFalse())
+	})
+
+	It("should only allow google registry hosts by default", func() {
+		Expect(gcr.IsGoogleRegistryHost("gcr.io")).To(BeTrue())
+		Expect(gcr.IsGoogleRegistryHost("eu.gcr.io")).To(BeTrue())
+		Expect(gcr.IsGoogleRegistryHost("europe-west1-docker.pkg.dev")).To(BeTrue())
+		Expect(gcr.IsGoogleRegistryHost("gcr.io.example.com")).To(BeFalse())
+		Expect(gcr.IsGoogleRegistryHost("example.com")).To(BeFalse())
+	})
+
+	It("should return a not found error if the image does not exist", func() {
+		resolver := gcr.NewGCRBlobResolver().WithHTTPClient(server.Client()).WithAllowedHosts(host)
+		_, err := resolver.Info(context.TODO(), newResource(host+"/project/image:2.0.0", base64.StdEncoding.EncodeToString(key)))
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+	It("should fail if the service account key is invalid", func() {
+		resolver := gcr.NewGCRBlobResolver().WithHTTPClient(server.Client()).WithAllowedHosts(host)
+		_, err := resolver.Info(context.TODO(), newResource(host+"/project/image:1.0.0", "invalid"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not resolve other access types", func() {
+		access, err := cdv2.NewUnstructured(cdv2.NewOCIRegistryAccess(host + "/project/image:1.0.0"))
+		Expect(err).ToNot(HaveOccurred())
+		res := cdv2.Resource{Access: &access}
+		resolver := gcr.NewGCRBlobResolver()
+		Expect(resolver.CanResolve(res)).To(BeFalse())
+		_, err = resolver.Info(context.TODO(), res)
+		Expect(err).To(MatchError(ctf.UnsupportedResolveType))
+	})
+})
@@ -0,0 +1,189 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	v2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+// GitHubTokenEnvVar is the environment variable that contains the GitHub token
+// if no token is defined by a ghcr access and the environment token is enabled (see WithEnvironmentToken).
+const GitHubTokenEnvVar = "GITHUB_TOKEN"
+
+// GHCRHost is the host of the GitHub Container Registry.
+const GHCRHost = "ghcr.io"
+
+// GHCRBlobResolver implements the BlobResolver interface for "ghcr" access types.
+// A registry token is requested from the token endpoint of the registry using the GitHub token
+// and used to fetch the manifest that is referenced by the image reference.
+// Images are only fetched from allowed registry hosts, so that a component descriptor
+// cannot direct the GitHub token to another host.
+type GHCRBlobResolver struct {
+	client       *http.Client
+	allowedHosts []string
+	tokenFromEnv bool
+}
+
+var _ ctf.TypedBlobResolver = &GHCRBlobResolver{}
+
+// NewGHCRBlobResolver creates a new blob resolver for images of the GitHub Container Registry.
+func NewGHCRBlobResolver() *GHCRBlobResolver {
+	return &GHCRBlobResolver{
+		client:       http.DefaultClient,
+		allowedHosts: []string{GHCRHost},
+	}
+}
+
+// WithAllowedHosts configures the registry hosts images may be fetched from.
+// Only the GitHub Container Registry is allowed by default.
+func (r *GHCRBlobResolver) WithAllowedHosts(hosts ...string) *GHCRBlobResolver {
+	r.allowedHosts = hosts
+	return r
+}
+
+// WithEnvironmentToken configures the resolver to use the GitHub token of the GitHubTokenEnvVar environment variable
+// for accesses that do not define a token.
+func (r *GHCRBlobResolver) WithEnvironmentToken() *GHCRBlobResolver {
+	r.tokenFromEnv = true
+	return r
+}
+
+// WithHTTPClient configures the http client that is used to access the registry.
+func (r *GHCRBlobResolver) WithHTTPClient(client *http.Client) *GHCRBlobResolver {
+	r.client = client
+	return r
+}
+
+func (r *GHCRBlobResolver) CanResolve(res v2.Resource) bool {
+	return res.Access != nil && res.Access.GetType() == v2.GHCRAccessType
+}
+
+// Info returns the blob info of the manifest of the image reference from a head request without downloading the manifest.
+func (r *GHCRBlobResolver) Info(ctx context.Context, res v2.Resource) (*ctf.BlobInfo, error) {
+	imageReference, authorize, err := r.authorize(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	return headManifest(ctx, r.client, imageReference, authorize)
+}
+
+// Resolve fetches the manifest of the image reference and writes it to the given writer.
+func (r *GHCRBlobResolver) Resolve(ctx context.Context, res v2.Resource, writer io.Writer) (*ctf.BlobInfo, error) {
+	imageReference, authorize, err := r.authorize(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	return fetchManifest(ctx, r.client, imageReference, authorize, writer)
+}
+
+// authorize returns the image reference of the resource and a function that authorizes the requests to its registry
+// with a registry token that is exchanged for the GitHub token.
+func (r *GHCRBlobResolver) authorize(ctx context.Context, res v2.Resource) (string, func(req *http.Request), error) {
+	if !r.CanResolve(res) {
+		return "", nil, ctf.UnsupportedResolveType
+	}
+	access := &v2.GHCRAccess{}
+	if err := res.Access.DecodeInto(access); err != nil {
+		return "", nil, fmt.Errorf("unable to decode access to type '%s': %w", res.Access.GetType(), err)
+	}
+	host, repository, err := splitRegistryHost(access.ImageReference)
+	if err != nil {
+		return "", nil, err
+	}
+	if !r.isAllowedHost(host) {
+		return "", nil, fmt.Errorf("registry host %q of %q is not allowed", host, access.ImageReference)
+	}
+	githubToken := access.GitHubToken
+	if len(githubToken) == 0 && r.tokenFromEnv {
+		githubToken = os.Getenv(GitHubTokenEnvVar)
+	}
+	if len(githubToken) == 0 {
+		return "", nil, fmt.Errorf("no GitHub token defined for %q", access.ImageReference)
+	}
+
+	token, err := r.registryToken(ctx, host, repository, githubToken)
+	if err != nil {
+		return "", nil, err
+	}
+	return access.ImageReference, func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}, nil
+}
+
+func (r *GHCRBlobResolver) isAllowedHost(host string) bool {
+	for _, allowed := range r.allowedHosts {
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// splitRegistryHost returns the registry host and the repository of an image reference.
+func splitRegistryHost(imageReference string) (string, string, error) {
+	repo, _, _, err := cdutils.ParseImageReference(imageReference)
+	if err != nil {
+		return "", "", err
+	}
+	split := strings.SplitN(repo, "/", 2)
+	if len(split) != 2 {
+		return "", "", fmt.Errorf("image reference %q does not contain a registry host", imageReference)
+	}
+	return split[0], split[1], nil
+}
+
+// registryToken exchanges the GitHub token into a registry token that allows to pull the repository.
+func (r *GHCRBlobResolver) registryToken(ctx context.Context, host, repository, githubToken string) (string, error) {
+	query := url.Values{}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", repository))
+	query.Set("service", host)
+	tokenURL := fmt.Sprintf("https://%s/token?%s", host, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to create request for %q: %w", tokenURL, err)
+	}
+	// the registry accepts any username in combination with a GitHub token.
+	req.SetBasicAuth("token", githubToken)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to request registry token from %q: %w", tokenURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to request registry token from %q: unexpected status code %d", tokenURL, resp.StatusCode)
+	}
+	tokenResp := struct {
+		Token string `json:"token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("unable to decode registry token response: %w", err)
+	}
+	if len(tokenResp.Token) == 0 {
+		return "", errors.New("the registry token response does not contain a token")
+	}
+	return tokenResp.Token, nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/oci"
+)
+
+var _ = Describe("GHCRBlobResolver", func() {
+
+	var (
+		server          *httptest.Server
+		host            string
+		manifest        []byte
+		manifestMethods []string
+	)
+
+	newResource := func(ref, token string) cdv2.Resource {
+		access, err := cdv2.NewUnstructured(cdv2.NewGHCRAccess(ref, token))
+		Expect(err).ToNot(HaveOccurred())
+		return cdv2.Resource{
+			IdentityObjectMeta: cdv2.IdentityObjectMeta{
+				Name:    "image",
+				Version: "1.0.0",
+				Type:    cdv2.OCIImageType,
+			},
+			Relation: cdv2.ExternalRelation,
+			Access:   &access,
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		manifest, err = ioutil.ReadFile("./testdata/manifest.json")
+		Expect(err).ToNot(HaveOccurred())
+		manifestMethods = nil
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/token" {
+				_, password, ok := r.BasicAuth()
+				if !ok || password != "gh-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				if !strings.HasSuffix(r.URL.Query().Get("scope"), ":pull") || r.URL.Query().Get("service") != host {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]string{"token": "registry-token"})
+				return
+			}
+			if r.Header.Get("Authorization") != "Bearer registry-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Path != "/v2/org/image/manifests/1.0.0" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			manifestMethods = append(manifestMethods, r.Method)
+			w.Header().Set("Content-Type", ocispecv1.MediaTypeImageManifest)
+			w.Header().Set("Docker-Content-Digest", digest.FromBytes(manifest).String())
+			_, _ = w.Write(manifest)
+		}))
+		host = strings.TrimPrefix(server.URL, "https://")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should fetch the manifest with a registry token", func() {
+		resolver := oci.NewGHCRBlobResolver().WithHTTPClient(server.Client()).WithAllowedHosts(host)
+		res := newResource(host+"/org/image:1.0.0", "gh-token")
+		Expect(resolver.CanResolve(res)).To(BeTrue())
+
+		var result bytes.Buffer
+		info, err := resolver.Resolve(context.TODO(), res, &result)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Bytes()).To(Equal(manifest))
+		Expect(info.MediaType).To(Equal(ocispecv1.MediaTypeImageManifest))
+	})
+
+	It("should get the manifest info with a head request", func() {
+		resolver := oci.NewGHCRBlobResolver().WithHTTPClient(server.Client()).WithAllowedHosts(host)
+		info, err := resolver.Info(context.TODO(), newResource(host+"/org/image:1.0.0", "gh-token"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info).To(Equal(&ctf.BlobInfo{
+			MediaType: ocispecv1.MediaTypeImageManifest,
+			Digest:    digest.FromBytes(manifest).String(),
+			Size:      int64(len(manifest)),
+		}))
+		Expect(manifestMethods).To(Equal([]string{http.MethodHead}))
+	})
+
+	It("should read the GitHub token from the environment", func() {
+		Expect(os.Setenv(oci.GitHubTokenEnvVar, "gh-token")).To(Succeed())
+		defer func() {
+			_ = os.Unsetenv(oci.GitHubTokenEnvVar)
+		}()
+		resolver := oci.NewGHCRBlobResolver().WithHTTPClient(server.Client()).WithAllowedHosts(host)
+		_, err := resolver.Info(context.TODO(), newResource(host+"/org/image:1.0.0", ""))
+		Expect(err).To(HaveOccurred())
+
+		_, err = resolver.WithEnvironmentToken().Info(context.TODO(), newResource(host+"/org/image:1.0.0", ""))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not send the GitHub token to other hosts than ghcr.io", func() {
+		Expect(os.Setenv(oci.GitHubTokenEnvVar, "gh-token")).To(Succeed())
+		defer func() {
+			_ = os.Unsetenv(oci.GitHubTokenEnvVar)
+		}()
+		requested := false
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = true
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+		resolver := oci.NewGHCRBlobResolver().WithHTTPClient(server.Client()).WithEnvironmentToken()
+		_, err := resolver.Info(context.TODO(), newResource(host+"/org/image:1.0.0", ""))
+		Expect(err).To(HaveOccurred())
+		Expect(requested).To(BeFalse())
+	})
+
+	It("should return a not found error if the image does not exist", func() {
+		resolver := oci.NewGHCRBlobResolver().WithHTTPClient(server.Client()).WithAllowedHosts(host)
+		_, err := resolver.Info(context.TODO(), newResource(host+"/org/other:1.0.0", "gh-token"))
+		Expect(errors.Is(err, ctf.NotFoundError)).To(BeTrue())
+	})
+
+	It("should fail if the GitHub token is invalid", func() {
+		resolver := oci.NewGHCRBlobResolver().WithHTTPClient(server.Client()).WithAllowedHosts(host)
+		_, err := resolver.Info(context.TODO(), newResource(host+"/org/image:1.0.0", "invalid"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not resolve other access types", func() {
+		access, err := cdv2.NewUnstructured(cdv2.NewOCIRegistryAccess(host + "/org/image:1.0.0"))
+		Expect(err).ToNot(HaveOccurred())
+		res := cdv2.Resource{Access: &access}
+		resolver := oci.NewGHCRBlobResolver()
+		Expect(resolver.CanResolve(res)).To(BeFalse())
+		_, err = resolver.Info(context.TODO(), res)
+		Expect(err).To(MatchError(ctf.UnsupportedResolveType))
+	})
+})
@@ -0,0 +1,84 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"errors"
+	"fmt"
+
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+)
+
+// ComponentDigestLabel is the name of the label that contains the config digest of the oci manifest
+// a component descriptor has been imported from.
+const ComponentDigestLabel = "gardener.cloud/component-digest"
+
+// FromOCIManifest creates a component descriptor from an oci image manifest.
+// Every layer of the manifest is added as local oci blob resource whose type is the media type of the layer.
+// The name of a resource is taken from the layer's title annotation, if defined, and defaults to "layer-<index>".
+//
+// The repository contexts and the provider of the returned component descriptor are empty and have to be set by the caller.
+func FromOCIManifest(manifest *ocispecv1.Manifest, name, version string) (*cdv2.ComponentDescriptor, error) {
+	if manifest == nil {
+		return nil, errors.New("a manifest has to be defined")
+	}
+	cd := &cdv2.ComponentDescriptor{}
+	cd.Name = name
+	cd.Version = version
+
+	if len(manifest.Config.Digest) != 0 {
+		labels, err := cdutils.SetLabel(cd.Labels, ComponentDigestLabel, manifest.Config.Digest.String())
+		if err != nil {
+			return nil, fmt.Errorf("unable to set component digest label: %w", err)
+		}
+		cd.Labels = labels
+	}
+
+	for i, layer := range manifest.Layers {
+		if err := layer.Digest.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid digest of layer %d: %w", i, err)
+		}
+		resName := fmt.Sprintf("layer-%d", i)
+		if title, ok := layer.Annotations[ocispecv1.AnnotationTitle]; ok && len(title) != 0 {
+			resName = title
+		}
+		access, err := cdv2.NewUnstructured(cdv2.NewLocalOCIBlobAccess(layer.Digest.String()))
+		if err != nil {
+			return nil, fmt.Errorf("unable to create access for layer %d: %w", i, err)
+		}
+		cd.Resources = append(cd.Resources, cdv2.Resource{
+			IdentityObjectMeta: cdv2.IdentityObjectMeta{
+				Name:    resName,
+				Version: version,
+				Type:    layer.MediaType,
+			},
+			Digest: &cdv2.DigestSpec{
+				HashAlgorithm:          layer.Digest.Algorithm().String(),
+				NormalisationAlgorithm: string(cdv2.GenericBlobDigestV1),
+				Value:                  layer.Digest.Encoded(),
+			},
+			Relation: cdv2.LocalRelation,
+			Access:   &access,
+		})
+	}
+
+	if err := cdv2.DefaultComponent(cd); err != nil {
+		return nil, err
+	}
+	return cd, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+	"github.com/gardener/component-spec/bindings-go/oci"
+)
+
+var _ = Describe("FromOCIManifest", func() {
+
+	It("should create a resource for every layer of the manifest", func() {
+		data, err := ioutil.ReadFile("./testdata/manifest.json")
+		Expect(err).ToNot(HaveOccurred())
+		manifest := &ocispecv1.Manifest{}
+		Expect(json.Unmarshal(data, manifest)).To(Succeed())
+
+		cd, err := oci.FromOCIManifest(manifest, "example.com/image", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cd.Name).To(Equal("example.com/image"))
+		Expect(cd.Version).To(Equal("1.0.0"))
+		Expect(cd.RepositoryContexts).To(BeEmpty())
+
+		label, ok := cdutils.GetLabel(cd.Labels, oci.ComponentDigestLabel)
+		Expect(ok).To(BeTrue())
+		Expect(string(label.Value)).To(Equal(`"sha256:b5b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7"`))
+
+		Expect(cd.Resources).To(HaveLen(2))
+		Expect(cd.Resources[0].Name).To(Equal("rootfs"))
+		Expect(cd.Resources[0].Type).To(Equal(ocispecv1.MediaTypeImageLayerGzip))
+		Expect(cd.Resources[0].Digest.HashAlgorithm).To(Equal("sha256"))
+		Expect(cd.Resources[0].Digest.Value).To(Equal("9834876dcfb05cb167a5c24953eba58c4ac89b1adf57f28f2f9d09af107ee8f0"))
+		Expect(cd.Resources[1].Name).To(Equal("layer-1"))
+		Expect(cd.Resources[1].Digest.Value).To(Equal("3c3a4604a545cdc127456d94e421cd355bca5b528f4a9c1905b15da2eb4a4c6b"))
+
+		access := &cdv2.LocalOCIBlobAccess{}
+		Expect(cd.Resources[1].Access.DecodeInto(access)).To(Succeed())
+		Expect(access.Digest).To(Equal("sha256:3c3a4604a545cdc127456d94e421cd355bca5b528f4a9c1905b15da2eb4a4c6b"))
+	})
+
+	It("should return an error if a layer has an invalid digest", func() {
+		manifest := &ocispecv1.Manifest{
+			Layers: []ocispecv1.Descriptor{{MediaType: ocispecv1.MediaTypeImageLayer, Digest: "invalid"}},
+		}
+		_, err := oci.FromOCIManifest(manifest, "example.com/image", "1.0.0")
+		Expect(err).To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,207 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/gardener/component-spec/bindings-go/apis/v2/cdutils"
+	"github.com/gardener/component-spec/bindings-go/codec"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+const (
+	// DefaultPoolConcurrency is the number of concurrent requests of a pooled resolver if no concurrency is configured.
+	DefaultPoolConcurrency = 10
+	// DefaultPoolDialTimeout is the dial timeout of a pooled resolver if no dial timeout is configured.
+	DefaultPoolDialTimeout = 30 * time.Second
+)
+
+// PoolConfig configures the connection pool of a PooledOCIComponentResolver.
+type PoolConfig struct {
+	// Concurrency is the maximum number of outstanding requests and the maximum number of connections per registry host.
+	// The DefaultPoolConcurrency is used if the concurrency is not greater than 0.
+	Concurrency int
+	// MaxIdleConns is the maximum number of idle connections across all registry hosts.
+	// The concurrency is used if the maximum is not greater than 0.
+	MaxIdleConns int
+	// DialTimeout is the maximum duration to establish a connection to a registry.
+	// The DefaultPoolDialTimeout is used if the timeout is not greater than 0.
+	DialTimeout time.Duration
+	// TLSClientConfig is the optional tls configuration that is used to connect to the registries.
+	TLSClientConfig *tls.Config
+}
+
+// PooledOCIComponentResolver is an oci component resolver that fetches the component descriptors
+// anonymously via https from the oci distribution api.
+// All resolutions share one pool of connections and the number of outstanding requests is limited
+// so that many components can be resolved in parallel without opening a connection per request.
+type PooledOCIComponentResolver struct {
+	*Resolver
+	transport *http.Transport
+}
+
+var _ ctf.ComponentResolver = &PooledOCIComponentResolver{}
+
+// NewPooledOCIComponentResolver creates a new oci component resolver with a shared connection pool.
+func NewPooledOCIComponentResolver(config PoolConfig, decodeOpts ...codec.DecodeOption) (*PooledOCIComponentResolver, error) {
+	if config.Concurrency < 0 || config.MaxIdleConns < 0 || config.DialTimeout < 0 {
+		return nil, errors.New("the pool configuration must not contain negative values")
+	}
+	if config.Concurrency == 0 {
+		config.Concurrency = DefaultPoolConcurrency
+	}
+	if config.MaxIdleConns == 0 {
+		config.MaxIdleConns = config.Concurrency
+	}
+	if config.DialTimeout == 0 {
+		config.DialTimeout = DefaultPoolDialTimeout
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   config.DialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:     config.TLSClientConfig,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        config.MaxIdleConns,
+		MaxIdleConnsPerHost: config.Concurrency,
+		MaxConnsPerHost:     config.Concurrency,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+	client := &pooledClient{
+		client:    &http.Client{Transport: transport},
+		semaphore: make(chan struct{}, config.Concurrency),
+	}
+	return &PooledOCIComponentResolver{
+		Resolver:  NewResolver(client, decodeOpts...),
+		transport: transport,
+	}, nil
+}
+
+// Close closes all idle connections of the pool.
+func (r *PooledOCIComponentResolver) Close() {
+	r.transport.CloseIdleConnections()
+}
+
+// pooledClient is an anonymous oci client whose requests share one http client
+// and are limited by a semaphore.
+type pooledClient struct {
+	client    *http.Client
+	semaphore chan struct{}
+}
+
+var _ Client = &pooledClient{}
+
+func (c *pooledClient) GetManifest(ctx context.Context, ref string) (*ocispecv1.Manifest, error) {
+	u, err := manifestURL(ref)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ocispecv1.Manifest{}
+	err = c.get(ctx, u, ocispecv1.MediaTypeImageManifest, func(body io.Reader) error {
+		if err := json.NewDecoder(body).Decode(manifest); err != nil {
+			return fmt.Errorf("unable to decode manifest from %q: %w", u, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (c *pooledClient) Fetch(ctx context.Context, ref string, desc ocispecv1.Descriptor, writer io.Writer) error {
+	u, err := blobURL(ref, desc.Digest)
+	if err != nil {
+		return err
+	}
+	return c.get(ctx, u, "", func(body io.Reader) error {
+		verifier := desc.Digest.Verifier()
+		if _, err := io.Copy(io.MultiWriter(writer, verifier), body); err != nil {
+			return fmt.Errorf("unable to read blob from %q: %w", u, err)
+		}
+		if !verifier.Verified() {
+			return fmt.Errorf("blob from %q does not match the digest %q", u, desc.Digest)
+		}
+		return nil
+	})
+}
+
+// get performs a get request as soon as the number of outstanding requests allows it
+// and reads the body of the successful response with the given function.
+func (c *pooledClient) get(ctx context.Context, url, accept string, read func(body io.Reader) error) error {
+	select {
+	case c.semaphore <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-c.semaphore }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create request for %q: %w", url, err)
+	}
+	if len(accept) != 0 {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to fetch %q: %w", url, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return fmt.Errorf("%q: %w", url, ctf.NotFoundError)
+	default:
+		return fmt.Errorf("unable to fetch %q: unexpected status code %d", url, resp.StatusCode)
+	}
+	if err := read(resp.Body); err != nil {
+		return err
+	}
+	// drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// blobURL returns the url of the blob with the given digest in the repository of the image reference
+// in the oci distribution api.
+func blobURL(imageReference string, dig digest.Digest) (string, error) {
+	repo, _, _, err := cdutils.ParseImageReference(imageReference)
+	if err != nil {
+		return "", err
+	}
+	split := strings.SplitN(repo, "/", 2)
+	if len(split) != 2 {
+		return "", fmt.Errorf("image reference %q does not contain a registry host", imageReference)
+	}
+	return fmt.Sprintf("https://%s/v2/%s/blobs/%s", split[0], split[1], dig), nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/codec"
+	"github.com/gardener/component-spec/bindings-go/oci"
+)
+
+var _ = Describe("PooledOCIComponentResolver", func() {
+
+	const (
+		concurrency = 4
+		components  = 10
+	)
+
+	var (
+		server      *httptest.Server
+		repoCtx     cdv2.Repository
+		connections int32
+		inFlight    int32
+		maxInFlight int32
+	)
+
+	// addBlob stores the data as blob of the registry and returns its descriptor.
+	addBlob := func(blobs map[string][]byte, mediaType string, data []byte) ocispecv1.Descriptor {
+		dig := digest.FromBytes(data)
+		blobs[dig.String()] = data
+		return ocispecv1.Descriptor{MediaType: mediaType, Digest: dig, Size: int64(len(data))}
+	}
+
+	BeforeEach(func() {
+		atomic.StoreInt32(&connections, 0)
+		atomic.StoreInt32(&maxInFlight, 0)
+		blobs := map[string][]byte{}
+		manifests := map[string][]byte{}
+		for i := 0; i < components; i++ {
+			name := fmt.Sprintf("example.com/comp-%d", i)
+			cd := &cdv2.ComponentDescriptor{}
+			cd.Metadata.Version = cdv2.SchemaVersion
+			cd.Name = name
+			cd.Version = "1.0.0"
+			cd.Provider = "internal"
+			Expect(cdv2.DefaultComponent(cd)).To(Succeed())
+			cdData, err := codec.Encode(cd)
+			Expect(err).ToNot(HaveOccurred())
+			cdLayer := addBlob(blobs, oci.ComponentDescriptorJSONMimeType, cdData)
+			configData, err := json.Marshal(oci.ComponentDescriptorConfig{
+				ComponentDescriptorLayer: &oci.OciBlobRef{MediaType: cdLayer.MediaType, Digest: cdLayer.Digest.String(), Size: cdLayer.Size},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			manifest, err := json.Marshal(ocispecv1.Manifest{
+				Config: addBlob(blobs, oci.ComponentDescriptorConfigMimeType, configData),
+				Layers: []ocispecv1.Descriptor{cdLayer},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			manifests[fmt.Sprintf("/v2/component-descriptors/%s/manifests/1.0.0", name)] = manifest
+		}
+
+		server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+					break
+				}
+			}
+			if manifest, ok := manifests[r.URL.Path]; ok {
+				w.Header().Set("Content-Type", ocispecv1.MediaTypeImageManifest)
+				_, _ = w.Write(manifest)
+				return
+			}
+			if split := strings.Split(r.URL.Path, "/blobs/"); len(split) == 2 {
+				if blob, ok := blobs[split[1]]; ok {
+					_, _ = w.Write(blob)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				atomic.AddInt32(&connections, 1)
+			}
+		}
+		server.StartTLS()
+		repoCtx = cdv2.NewOCIRegistryRepository(server.URL, "")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newResolver := func() *oci.PooledOCIComponentResolver {
+		resolver, err := oci.NewPooledOCIComponentResolver(oci.PoolConfig{
+			Concurrency:     concurrency,
+			TLSClientConfig: server.Client().Transport.(*http.Transport).TLSClientConfig,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		return resolver
+	}
+
+	It("should resolve many components concurrently with pooled connections", func() {
+		resolver := newResolver()
+		defer resolver.Close()
+
+		var (
+			wg   sync.WaitGroup
+			errs = make(chan error, 100)
+		)
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				name := fmt.Sprintf("example.com/comp-%d", i%components)
+				cd, err := resolver.Resolve(context.TODO(), repoCtx, name, "1.0.0")
+				if err == nil && cd.GetName() != name {
+					err = fmt.Errorf("resolved %q instead of %q", cd.GetName(), name)
+				}
+				errs <- err
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(atomic.LoadInt32(&maxInFlight)).To(BeNumerically("<=", concurrency))
+		Expect(atomic.LoadInt32(&connections)).To(BeNumerically("<=", concurrency))
+	})
+
+	It("should return a not found error for unknown components", func() {
+		resolver := newResolver()
+		defer resolver.Close()
+		_, err := resolver.Resolve(context.TODO(), repoCtx, "example.com/unknown", "1.0.0")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject negative pool configurations", func() {
+		_, err := oci.NewPooledOCIComponentResolver(oci.PoolConfig{Concurrency: -1})
+		Expect(err).To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,162 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package badger implements a component descriptor registry that is backed by an embedded BadgerDB.
+// The package is maintained as a separate go module to not force the badger dependency on all users of the bindings.
+package badger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v4"
+	"sigs.k8s.io/yaml"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/codec"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/registry"
+)
+
+// Registry is a component descriptor registry that stores the yaml encoded component descriptors in a BadgerDB.
+// The component descriptors are stored with the key "<name>:<version>".
+type Registry struct {
+	db         *badger.DB
+	decodeOpts []codec.DecodeOption
+}
+
+var _ registry.Registry = &Registry{}
+
+// NewBadgerRegistry opens or creates a BadgerDB in the given directory.
+// The caller should call "Close" to release the database.
+func NewBadgerRegistry(dir string, decodeOpts ...codec.DecodeOption) (*Registry, error) {
+	opts := badger.DefaultOptions(dir).WithLogger(nil)
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open badger database in %q: %w", dir, err)
+	}
+	return &Registry{
+		db:         db,
+		decodeOpts: decodeOpts,
+	}, nil
+}
+
+// SupportedSchemaVersions returns the schema versions of the component descriptors that can be stored.
+func (r *Registry) SupportedSchemaVersions(_ context.Context) []string {
+	return []string{cdv2.SchemaVersion}
+}
+
+// Push stores the component descriptor in the database.
+func (r *Registry) Push(_ context.Context, cd *cdv2.ComponentDescriptor) error {
+	if cd == nil {
+		return errors.New("a component descriptor has to be defined")
+	}
+	data, err := codec.Encode(cd)
+	if err != nil {
+		return fmt.Errorf("unable to encode component descriptor: %w", err)
+	}
+	data, err = yaml.JSONToYAML(data)
+	if err != nil {
+		return fmt.Errorf("unable to convert component descriptor to yaml: %w", err)
+	}
+	return r.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(key(cd.GetName(), cd.GetVersion()), data)
+	})
+}
+
+// Pull reads the component descriptor with the given name and version from the database.
+func (r *Registry) Pull(_ context.Context, name, version string) (*cdv2.ComponentDescriptor, error) {
+	var cd *cdv2.ComponentDescriptor
+	err := r.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key(name, version))
+		if err != nil {
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return ctf.NotFoundError
+			}
+			return err
+		}
+		cd, err = r.decodeItem(item)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cd, nil
+}
+
+// List returns all stored versions of the component with the given name.
+// All stored component descriptors are returned if the name is empty.
+func (r *Registry) List(ctx context.Context, name string) ([]*cdv2.ComponentDescriptor, error) {
+	var prefix []byte
+	if len(name) != 0 {
+		prefix = []byte(name + ":")
+	}
+	list := make([]*cdv2.ComponentDescriptor, 0)
+	err := r.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			cd, err := r.decodeItem(it.Item())
+			if err != nil {
+				return err
+			}
+			list = append(list, cd)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Delete removes the component descriptor with the given name and version from the database.
+func (r *Registry) Delete(_ context.Context, name, version string) error {
+	return r.db.Update(func(txn *badger.Txn) error {
+		k := key(name, version)
+		if _, err := txn.Get(k); err != nil {
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return ctf.NotFoundError
+			}
+			return err
+		}
+		return txn.Delete(k)
+	})
+}
+
+// Close closes the underlying database.
+func (r *Registry) Close() error {
+	return r.db.Close()
+}
+
+func (r *Registry) decodeItem(item *badger.Item) (*cdv2.ComponentDescriptor, error) {
+	cd := &cdv2.ComponentDescriptor{}
+	err := item.Value(func(val []byte) error {
+		return codec.Decode(val, cd, r.decodeOpts...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode component descriptor %q: %w", string(item.Key()), err)
+	}
+	return cd, nil
+}
+
+func key(name, version string) []byte {
+	return []byte(fmt.Sprintf("%s:%s", name, version))
+}
@@ -0,0 +1,124 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badger_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/registry/badger"
+)
+
+func TestConfig(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "badger registry Test Suite")
+}
+
+var _ = Describe("Registry", func() {
+
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "badger-registry-")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should pull a pushed component descriptor", func() {
+		ctx := context.Background()
+		reg, err := badger.NewBadgerRegistry(dir)
+		Expect(err).ToNot(HaveOccurred())
+		defer reg.Close()
+
+		Expect(reg.Push(ctx, newComponentDescriptor("example.com/a", "1.0.0"))).To(Succeed())
+		cd, err := reg.Pull(ctx, "example.com/a", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cd.Name).To(Equal("example.com/a"))
+		Expect(cd.Version).To(Equal("1.0.0"))
+
+		_, err = reg.Pull(ctx, "example.com/a", "2.0.0")
+		Expect(err).To(Equal(ctf.NotFoundError))
+	})
+
+	It("should keep component descriptors when the database is reopened", func() {
+		ctx := context.Background()
+		reg, err := badger.NewBadgerRegistry(dir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reg.Push(ctx, newComponentDescriptor("example.com/a", "1.0.0"))).To(Succeed())
+		Expect(reg.Close()).To(Succeed())
+
+		reg, err = badger.NewBadgerRegistry(dir)
+		Expect(err).ToNot(HaveOccurred())
+		defer reg.Close()
+		cd, err := reg.Pull(ctx, "example.com/a", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cd.Name).To(Equal("example.com/a"))
+	})
+
+	It("should list all versions of a component", func() {
+		ctx := context.Background()
+		reg, err := badger.NewBadgerRegistry(dir)
+		Expect(err).ToNot(HaveOccurred())
+		defer reg.Close()
+
+		Expect(reg.Push(ctx, newComponentDescriptor("example.com/a", "1.0.0"))).To(Succeed())
+		Expect(reg.Push(ctx, newComponentDescriptor("example.com/a", "1.1.0"))).To(Succeed())
+		Expect(reg.Push(ctx, newComponentDescriptor("example.com/ab", "1.0.0"))).To(Succeed())
+
+		list, err := reg.List(ctx, "example.com/a")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list).To(HaveLen(2))
+		for _, cd := range list {
+			Expect(cd.Name).To(Equal("example.com/a"))
+		}
+
+		list, err = reg.List(ctx, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list).To(HaveLen(3))
+	})
+
+	It("should delete a component descriptor", func() {
+		ctx := context.Background()
+		reg, err := badger.NewBadgerRegistry(dir)
+		Expect(err).ToNot(HaveOccurred())
+		defer reg.Close()
+
+		Expect(reg.Push(ctx, newComponentDescriptor("example.com/a", "1.0.0"))).To(Succeed())
+		Expect(reg.Delete(ctx, "example.com/a", "1.0.0")).To(Succeed())
+		_, err = reg.Pull(ctx, "example.com/a", "1.0.0")
+		Expect(err).To(Equal(ctf.NotFoundError))
+		Expect(reg.Delete(ctx, "example.com/a", "1.0.0")).To(Equal(ctf.NotFoundError))
+	})
+
+})
+
+func newComponentDescriptor(name, version string) *cdv2.ComponentDescriptor {
+	cd := &cdv2.ComponentDescriptor{}
+	cd.Name = name
+	cd.Version = version
+	cd.Provider = "internal"
+	_ = cdv2.DefaultComponent(cd)
+	return cd
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NegotiationError is returned if the client and the registry do not support a common schema version.
+type NegotiationError struct {
+	// ClientVersions are the schema versions that are supported by the client.
+	ClientVersions []string
+	// RegistryVersions are the schema versions that are supported by the registry.
+	RegistryVersions []string
+}
+
+func (e *NegotiationError) Error() string {
+	return fmt.Sprintf("no common schema version found: client supports %v, registry supports %v", e.ClientVersions, e.RegistryVersions)
+}
+
+// NegotiateSchemaVersion returns the highest component descriptor schema version
+// that is supported by the client and the registry.
+// A NegotiationError is returned if no common schema version exists.
+func NegotiateSchemaVersion(ctx context.Context, registry Registry, supportedVersions []string) (string, error) {
+	registryVersions := registry.SupportedSchemaVersions(ctx)
+	supportedByRegistry := make(map[string]bool, len(registryVersions))
+	for _, version := range registryVersions {
+		supportedByRegistry[version] = true
+	}
+
+	var negotiated string
+	for _, version := range supportedVersions {
+		if !supportedByRegistry[version] {
+			continue
+		}
+		if len(negotiated) == 0 || compareSchemaVersions(version, negotiated) > 0 {
+			negotiated = version
+		}
+	}
+	if len(negotiated) == 0 {
+		return "", &NegotiationError{
+			ClientVersions:   supportedVersions,
+			RegistryVersions: registryVersions,
+		}
+	}
+	return negotiated, nil
+}
+
+// compareSchemaVersions compares schema versions of the form "v<major>[.<minor>...]".
+// It returns a positive number if a is higher than b, a negative number if a is lower than b and 0 if both are equal.
+// Versions that cannot be parsed are compared lexicographically.
+func compareSchemaVersions(a, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart string
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+		aNum, aErr := strconv.Atoi(aPart)
+		bNum, bErr := strconv.Atoi(bPart)
+		if aErr != nil || bErr != nil {
+			if aPart != bPart {
+				return strings.Compare(aPart, bPart)
+			}
+			continue
+		}
+		if aNum != bNum {
+			return aNum - bNum
+		}
+	}
+	return 0
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/component-spec/bindings-go/registry"
+)
+
+// versionRegistry is a registry stub that only implements the supported schema versions.
+type versionRegistry struct {
+	registry.Registry
+	versions []string
+}
+
+func (r versionRegistry) SupportedSchemaVersions(_ context.Context) []string {
+	return r.versions
+}
+
+var _ = Describe("schema version negotiation", func() {
+
+	It("should select the highest common schema version", func() {
+		version, err := registry.NegotiateSchemaVersion(context.TODO(), versionRegistry{versions: []string{"v2", "v3", "v10"}}, []string{"v3", "v2"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(version).To(Equal("v3"))
+
+		version, err = registry.NegotiateSchemaVersion(context.TODO(), versionRegistry{versions: []string{"v2", "v3", "v10"}}, []string{"v2", "v10", "v3"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(version).To(Equal("v10"))
+	})
+
+	It("should select the only common schema version", func() {
+		version, err := registry.NegotiateSchemaVersion(context.TODO(), versionRegistry{versions: []string{"v2"}}, []string{"v2", "v3"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(version).To(Equal("v2"))
+	})
+
+	It("should return a negotiation error if no common schema version exists", func() {
+		_, err := registry.NegotiateSchemaVersion(context.TODO(), versionRegistry{versions: []string{"v3"}}, []string{"v2"})
+		negotiationErr := &registry.NegotiationError{}
+		Expect(errors.As(err, &negotiationErr)).To(BeTrue())
+		Expect(negotiationErr.RegistryVersions).To(Equal([]string{"v3"}))
+
+		_, err = registry.NegotiateSchemaVersion(context.TODO(), versionRegistry{}, []string{"v2"})
+		Expect(errors.As(err, &negotiationErr)).To(BeTrue())
+	})
+})
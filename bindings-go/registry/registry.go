@@ -0,0 +1,38 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// Registry describes a store for component descriptors that are identified by their name and version.
+// Implementations are expected to return a ctf.NotFoundError if a requested component descriptor is not stored.
+type Registry interface {
+	// Push stores the component descriptor.
+	// An already existing component descriptor with the same name and version is overwritten.
+	Push(ctx context.Context, cd *cdv2.ComponentDescriptor) error
+	// Pull reads the component descriptor with the given name and version.
+	Pull(ctx context.Context, name, version string) (*cdv2.ComponentDescriptor, error)
+	// List returns all versions of the component with the given name.
+	// All stored component descriptors are returned if the name is empty.
+	List(ctx context.Context, name string) ([]*cdv2.ComponentDescriptor, error)
+	// Delete removes the component descriptor with the given name and version.
+	Delete(ctx context.Context, name, version string) error
+	// SupportedSchemaVersions returns the component descriptor schema versions that can be stored in the registry.
+	SupportedSchemaVersions(ctx context.Context) []string
+}
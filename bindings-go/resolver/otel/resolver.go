@@ -0,0 +1,96 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otel provides an OpenTelemetry instrumented component resolver.
+// It is a separate module so that the core bindings do not depend on OpenTelemetry.
+package otel
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+)
+
+const (
+	// ComponentNameKey is the span attribute key of the name of the resolved component.
+	ComponentNameKey = attribute.Key("component.name")
+	// ComponentVersionKey is the span attribute key of the version of the resolved component.
+	ComponentVersionKey = attribute.Key("component.version")
+	// RepositoryTypeKey is the span attribute key of the type of the repository context.
+	RepositoryTypeKey = attribute.Key("repository.type")
+	// ResolutionDurationKey is the span attribute key of the duration of the resolution in milliseconds.
+	ResolutionDurationKey = attribute.Key("resolution.duration")
+)
+
+// TracingComponentResolver is a component resolver that creates a span for every resolution of the inner resolver.
+// The spans are created as children of the span in the context of the resolution.
+type TracingComponentResolver struct {
+	inner  ctf.ComponentResolver
+	tracer trace.Tracer
+}
+
+var _ ctf.ComponentResolver = &TracingComponentResolver{}
+
+// NewTracingComponentResolver creates a new resolver that traces the resolutions of the inner resolver with the given tracer.
+func NewTracingComponentResolver(inner ctf.ComponentResolver, tracer trace.Tracer) *TracingComponentResolver {
+	return &TracingComponentResolver{
+		inner:  inner,
+		tracer: tracer,
+	}
+}
+
+// Resolve resolves the component descriptor with the inner resolver.
+func (r *TracingComponentResolver) Resolve(ctx context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, error) {
+	ctx, span, start := r.start(ctx, "Resolve", repoCtx, name, version)
+	cd, err := r.inner.Resolve(ctx, repoCtx, name, version)
+	end(span, start, err)
+	return cd, err
+}
+
+// ResolveWithBlobResolver resolves the component descriptor and its blob resolver with the inner resolver.
+func (r *TracingComponentResolver) ResolveWithBlobResolver(ctx context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, ctf.BlobResolver, error) {
+	ctx, span, start := r.start(ctx, "ResolveWithBlobResolver", repoCtx, name, version)
+	cd, blobResolver, err := r.inner.ResolveWithBlobResolver(ctx, repoCtx, name, version)
+	end(span, start, err)
+	return cd, blobResolver, err
+}
+
+// start starts a new span for the resolution of the given component version.
+func (r *TracingComponentResolver) start(ctx context.Context, operation string, repoCtx cdv2.Repository, name, version string) (context.Context, trace.Span, time.Time) {
+	attrs := []attribute.KeyValue{
+		ComponentNameKey.String(name),
+		ComponentVersionKey.String(version),
+	}
+	if repoCtx != nil {
+		attrs = append(attrs, RepositoryTypeKey.String(repoCtx.GetType()))
+	}
+	ctx, span := r.tracer.Start(ctx, "ComponentResolver."+operation, trace.WithAttributes(attrs...))
+	return ctx, span, time.Now()
+}
+
+// end records the duration and the error of the resolution and ends the span.
+func end(span trace.Span, start time.Time, err error) {
+	span.SetAttributes(ResolutionDurationKey.Int64(time.Since(start).Milliseconds()))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
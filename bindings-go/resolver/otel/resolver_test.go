@@ -0,0 +1,127 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otel_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	"github.com/gardener/component-spec/bindings-go/resolver/otel"
+)
+
+// inMemoryComponentResolver resolves component descriptors by their "<name>:<version>" key.
+type inMemoryComponentResolver map[string]cdv2.ComponentDescriptor
+
+func (r inMemoryComponentResolver) Resolve(_ context.Context, _ cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, error) {
+	cd, ok := r[name+":"+version]
+	if !ok {
+		return nil, ctf.NotFoundError
+	}
+	return &cd, nil
+}
+
+func (r inMemoryComponentResolver) ResolveWithBlobResolver(ctx context.Context, repoCtx cdv2.Repository, name, version string) (*cdv2.ComponentDescriptor, ctf.BlobResolver, error) {
+	cd, err := r.Resolve(ctx, repoCtx, name, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	blobResolver, err := ctf.NewAggregatedBlobResolver()
+	if err != nil {
+		return nil, nil, err
+	}
+	return cd, blobResolver, nil
+}
+
+var _ = Describe("TracingComponentResolver", func() {
+
+	var (
+		recorder *tracetest.SpanRecorder
+		provider *sdktrace.TracerProvider
+		repoCtx  cdv2.UnstructuredTypedObject
+		resolver *otel.TracingComponentResolver
+	)
+
+	BeforeEach(func() {
+		recorder = tracetest.NewSpanRecorder()
+		provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		repoCtx, _ = cdv2.NewUnstructured(cdv2.NewOCIRegistryRepository("example.com/registry", ""))
+
+		cd := cdv2.ComponentDescriptor{}
+		cd.Name = "example.com/comp"
+		cd.Version = "1.0.0"
+		cd.Provider = "internal"
+		cd.RepositoryContexts = []*cdv2.UnstructuredTypedObject{&repoCtx}
+		Expect(cdv2.DefaultComponent(&cd)).To(Succeed())
+		inner := inMemoryComponentResolver{"example.com/comp:1.0.0": cd}
+		resolver = otel.NewTracingComponentResolver(inner, provider.Tracer("test"))
+	})
+
+	AfterEach(func() {
+		Expect(provider.Shutdown(context.TODO())).To(Succeed())
+	})
+
+	attributes := func(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+		attrs := map[attribute.Key]attribute.Value{}
+		for _, attr := range span.Attributes() {
+			attrs[attr.Key] = attr.Value
+		}
+		return attrs
+	}
+
+	It("should create a child span for every resolution", func() {
+		ctx, parent := provider.Tracer("test").Start(context.TODO(), "parent")
+		cd, err := resolver.Resolve(ctx, &repoCtx, "example.com/comp", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cd.Name).To(Equal("example.com/comp"))
+		_, blobResolver, err := resolver.ResolveWithBlobResolver(ctx, &repoCtx, "example.com/comp", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(blobResolver).ToNot(BeNil())
+		parent.End()
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(3))
+		for i, name := range []string{"ComponentResolver.Resolve", "ComponentResolver.ResolveWithBlobResolver"} {
+			span := spans[i]
+			Expect(span.Name()).To(Equal(name))
+			Expect(span.Parent().SpanID()).To(Equal(parent.SpanContext().SpanID()))
+			Expect(span.Status().Code).To(Equal(codes.Unset))
+			attrs := attributes(span)
+			Expect(attrs).To(HaveKeyWithValue(otel.ComponentNameKey, attribute.StringValue("example.com/comp")))
+			Expect(attrs).To(HaveKeyWithValue(otel.ComponentVersionKey, attribute.StringValue("1.0.0")))
+			Expect(attrs).To(HaveKeyWithValue(otel.RepositoryTypeKey, attribute.StringValue(cdv2.OCIRegistryType)))
+			Expect(attrs).To(HaveKey(otel.ResolutionDurationKey))
+		}
+	})
+
+	It("should set the error status if the resolution fails", func() {
+		_, err := resolver.Resolve(context.TODO(), &repoCtx, "example.com/unknown", "1.0.0")
+		Expect(err).To(HaveOccurred())
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Status().Code).To(Equal(codes.Error))
+		Expect(spans[0].Status().Description).To(Equal(err.Error()))
+		Expect(spans[0].Events()).To(HaveLen(1))
+	})
+
+})
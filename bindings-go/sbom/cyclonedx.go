@@ -0,0 +1,120 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// CycloneDXSpecVersion is the version of the CycloneDX specification of the generated boms.
+const CycloneDXSpecVersion = "1.4"
+
+// CycloneDXBOM is a minimal CycloneDX bill of materials in its json representation.
+type CycloneDXBOM struct {
+	BOMFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	Version      int                   `json:"version"`
+	Metadata     *CycloneDXMetadata    `json:"metadata,omitempty"`
+	Components   []CycloneDXComponent  `json:"components,omitempty"`
+	Dependencies []CycloneDXDependency `json:"dependencies,omitempty"`
+}
+
+// CycloneDXMetadata describes the subject of a CycloneDX bom.
+type CycloneDXMetadata struct {
+	Timestamp string              `json:"timestamp,omitempty"`
+	Component *CycloneDXComponent `json:"component,omitempty"`
+}
+
+// CycloneDXComponent is a component of a CycloneDX bom.
+type CycloneDXComponent struct {
+	BOMRef     string              `json:"bom-ref,omitempty"`
+	Type       string              `json:"type"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	Hashes     []CycloneDXHash     `json:"hashes,omitempty"`
+	Properties []CycloneDXProperty `json:"properties,omitempty"`
+}
+
+// CycloneDXHash is a hash of a CycloneDX component.
+type CycloneDXHash struct {
+	Algorithm string `json:"alg"`
+	Content   string `json:"content"`
+}
+
+// CycloneDXProperty is a name-value property of a CycloneDX component.
+type CycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CycloneDXDependency lists the components a CycloneDX component depends on.
+type CycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// ToCycloneDX converts a component descriptor into a CycloneDX bom.
+// Resources become components with the labels that are included by the mapper as properties,
+// component references become components the described component depends on.
+// The DefaultLabelMapper is used if no mapper is given.
+func ToCycloneDX(cd *cdv2.ComponentDescriptor, mapper LabelToAnnotationMapper) (*CycloneDXBOM, error) {
+	rootRef := cd.Name + "@" + cd.Version
+	bom := &CycloneDXBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: CycloneDXSpecVersion,
+		Version:     1,
+		Metadata: &CycloneDXMetadata{
+			Timestamp: creationTime(cd),
+			Component: &CycloneDXComponent{
+				BOMRef:  rootRef,
+				Type:    "application",
+				Name:    cd.Name,
+				Version: cd.Version,
+			},
+		},
+	}
+
+	for i, res := range cd.Resources {
+		comp := CycloneDXComponent{
+			BOMRef:  string(elementID("Resource", i, res.Name)),
+			Type:    "file",
+			Name:    res.Name,
+			Version: res.Version,
+		}
+		if res.Digest != nil && res.Digest.HashAlgorithm == "sha256" {
+			comp.Hashes = append(comp.Hashes, CycloneDXHash{Algorithm: "SHA-256", Content: res.Digest.Value})
+		}
+		for _, annotation := range mapLabels(res.Labels, mapper) {
+			comp.Properties = append(comp.Properties, CycloneDXProperty{Name: annotation.Key, Value: annotation.Value})
+		}
+		bom.Components = append(bom.Components, comp)
+	}
+
+	root := CycloneDXDependency{Ref: rootRef}
+	for _, ref := range cd.ComponentReferences {
+		comp := CycloneDXComponent{
+			BOMRef:  ref.ComponentName + "@" + ref.Version,
+			Type:    "application",
+			Name:    ref.ComponentName,
+			Version: ref.Version,
+		}
+		bom.Components = append(bom.Components, comp)
+		root.DependsOn = append(root.DependsOn, comp.BOMRef)
+	}
+	if len(root.DependsOn) != 0 {
+		bom.Dependencies = append(bom.Dependencies, root)
+	}
+	return bom, nil
+}
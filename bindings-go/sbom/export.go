@@ -0,0 +1,141 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// ToolName is the name of the tool that creates the generated sboms.
+const ToolName = "gardener-component-spec"
+
+// NoAssertion is the spdx value for unknown download locations.
+const NoAssertion = "NOASSERTION"
+
+// invalidElementIDChars matches all characters that are not allowed in spdx element ids.
+var invalidElementIDChars = regexp.MustCompile(`[^a-zA-Z0-9.-]`)
+
+// ToSPDX converts a component descriptor into a spdx document.
+// Resources become packages that are annotated with the labels that are included by the mapper
+// using the LabelAnnotationPrefix,
+// component references become packages that the document DEPENDS_ON.
+// The version and provider of the component are stored as document annotations with the AnnotationPrefix
+// so that the document can be converted back with FromSPDX.
+// The DefaultLabelMapper is used if no mapper is given.
+func ToSPDX(cd *cdv2.ComponentDescriptor, mapper LabelToAnnotationMapper) (*spdx.Document, error) {
+	created := creationTime(cd)
+	annotator := common.Annotator{Annotator: ToolName, AnnotatorType: "Tool"}
+	newAnnotation := func(id common.ElementID, comment string) spdx.Annotation {
+		return spdx.Annotation{
+			Annotator:                annotator,
+			AnnotationDate:           created,
+			AnnotationType:           "OTHER",
+			AnnotationSPDXIdentifier: common.MakeDocElementID("", string(id)),
+			AnnotationComment:        comment,
+		}
+	}
+
+	doc := &spdx.Document{
+		SPDXVersion:       spdx.Version,
+		DataLicense:       spdx.DataLicense,
+		SPDXIdentifier:    "DOCUMENT",
+		DocumentName:      cd.Name,
+		DocumentNamespace: fmt.Sprintf("https://%s/%s", cd.Name, cd.Version),
+		CreationInfo: &spdx.CreationInfo{
+			Creators: []common.Creator{{Creator: ToolName, CreatorType: "Tool"}},
+			Created:  created,
+		},
+	}
+	for _, field := range [][2]string{{"version", cd.Version}, {"provider", string(cd.Provider)}} {
+		if len(field[1]) == 0 {
+			continue
+		}
+		annotation := newAnnotation("DOCUMENT", fmt.Sprintf("%s%s=%s", AnnotationPrefix, field[0], field[1]))
+		doc.Annotations = append(doc.Annotations, &annotation)
+	}
+
+	for i, res := range cd.Resources {
+		id := elementID("Resource", i, res.Name)
+		pkg := &spdx.Package{
+			PackageName:             res.Name,
+			PackageSPDXIdentifier:   id,
+			PackageVersion:          res.Version,
+			PackageDownloadLocation: downloadLocation(res),
+		}
+		if res.Digest != nil && res.Digest.HashAlgorithm == "sha256" {
+			pkg.PackageChecksums = append(pkg.PackageChecksums, common.Checksum{
+				Algorithm: spdx.SHA256,
+				Value:     res.Digest.Value,
+			})
+		}
+		pkg.Annotations = append(pkg.Annotations, newAnnotation(id, fmt.Sprintf("%stype=%s", AnnotationPrefix, res.Type)))
+		for _, annotation := range mapLabels(res.Labels, mapper) {
+			pkg.Annotations = append(pkg.Annotations, newAnnotation(id, fmt.Sprintf("%s%s=%s", LabelAnnotationPrefix, annotation.Key, annotation.Value)))
+		}
+		doc.Packages = append(doc.Packages, pkg)
+	}
+
+	for i, ref := range cd.ComponentReferences {
+		id := elementID("ComponentReference", i, ref.Name)
+		pkg := &spdx.Package{
+			PackageName:             ref.ComponentName,
+			PackageSPDXIdentifier:   id,
+			PackageVersion:          ref.Version,
+			PackageDownloadLocation: NoAssertion,
+		}
+		if ref.Name != ref.ComponentName {
+			pkg.Annotations = append(pkg.Annotations, newAnnotation(id, fmt.Sprintf("%sname=%s", AnnotationPrefix, ref.Name)))
+		}
+		doc.Packages = append(doc.Packages, pkg)
+		doc.Relationships = append(doc.Relationships, &spdx.Relationship{
+			RefA:         common.MakeDocElementID("", "DOCUMENT"),
+			RefB:         common.MakeDocElementID("", string(id)),
+			Relationship: DependsOnRelationship,
+		})
+	}
+	return doc, nil
+}
+
+// creationTime returns the creation time of the component or the current time in the spdx date format.
+func creationTime(cd *cdv2.ComponentDescriptor) string {
+	if len(cd.CreationTime) != 0 {
+		return cd.CreationTime
+	}
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// elementID returns a unique spdx element id for the n-th object of the given kind.
+func elementID(kind string, n int, name string) common.ElementID {
+	return common.ElementID(fmt.Sprintf("%s-%d-%s", kind, n, invalidElementIDChars.ReplaceAllString(name, "-")))
+}
+
+// downloadLocation returns the url of resources with a web access.
+func downloadLocation(res cdv2.Resource) string {
+	if res.Access == nil || res.Access.GetType() != cdv2.WebType {
+		return NoAssertion
+	}
+	access := &cdv2.Web{}
+	if err := res.Access.DecodeInto(access); err != nil || len(access.URL) == 0 {
+		return NoAssertion
+	}
+	return access.URL
+}
@@ -0,0 +1,163 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spdx/tools-golang/spdx"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/sbom"
+)
+
+var _ = Describe("SBOM export", func() {
+
+	newComponentDescriptor := func() *cdv2.ComponentDescriptor {
+		access, err := cdv2.NewUnstructured(cdv2.NewWebAccess("https://example.com/chart.tgz"))
+		Expect(err).ToNot(HaveOccurred())
+		cd := &cdv2.ComponentDescriptor{}
+		cd.Metadata.Version = cdv2.SchemaVersion
+		cd.Name = "github.com/gardener/example"
+		cd.Version = "v1.2.3"
+		cd.Provider = "internal"
+		cd.CreationTime = "2022-01-01T00:00:00Z"
+		cd.Resources = []cdv2.Resource{
+			{
+				IdentityObjectMeta: cdv2.IdentityObjectMeta{
+					Name:    "chart",
+					Version: "v1.2.3",
+					Type:    "tgz",
+					Labels: cdv2.Labels{
+						{Name: "spdx.dev/license", Value: json.RawMessage(`"Apache-2.0"`)},
+						{Name: "spdx.dev/supplier", Value: json.RawMessage(`{"name":"gardener"}`)},
+						{Name: "gardener.cloud/purpose", Value: json.RawMessage(`"deployment"`)},
+					},
+				},
+				Relation: cdv2.ExternalRelation,
+				Access:   &access,
+				Digest: &cdv2.DigestSpec{
+					HashAlgorithm:          "sha256",
+					NormalisationAlgorithm: string(cdv2.GenericBlobDigestV1),
+					Value:                  "7d865e959b2466918c9863afca942d0fb89d7c9ac0c99bafc3749504ded97730",
+				},
+			},
+		}
+		cd.ComponentReferences = []cdv2.ComponentReference{
+			{
+				Name:          "github.com/gardener/dependency",
+				ComponentName: "github.com/gardener/dependency",
+				Version:       "v0.1.0",
+			},
+		}
+		return cd
+	}
+
+	annotationComments := func(annotations []spdx.Annotation) []string {
+		comments := make([]string, 0, len(annotations))
+		for _, annotation := range annotations {
+			comments = append(comments, annotation.AnnotationComment)
+		}
+		return comments
+	}
+
+	It("should only propagate spdx labels to the spdx annotations with the default mapper", func() {
+		doc, err := sbom.ToSPDX(newComponentDescriptor(), sbom.DefaultLabelMapper{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(doc.DocumentName).To(Equal("github.com/gardener/example"))
+		Expect(doc.Packages).To(HaveLen(2))
+		chart := doc.Packages[0]
+		Expect(chart.PackageName).To(Equal("chart"))
+		Expect(chart.PackageDownloadLocation).To(Equal("https://example.com/chart.tgz"))
+		Expect(annotationComments(chart.Annotations)).To(ConsistOf(
+			"gardener.cloud/type=tgz",
+			"labels.gardener.cloud/spdx.dev/license=Apache-2.0",
+			`labels.gardener.cloud/spdx.dev/supplier={"name":"gardener"}`,
+		))
+	})
+
+	It("should use the default mapper if no mapper is given", func() {
+		doc, err := sbom.ToSPDX(newComponentDescriptor(), nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(annotationComments(doc.Packages[0].Annotations)).ToNot(ContainElement("labels.gardener.cloud/gardener.cloud/purpose=deployment"))
+	})
+
+	It("should propagate all labels with the all labels mapper", func() {
+		doc, err := sbom.ToSPDX(newComponentDescriptor(), sbom.AllLabelsMapper{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(annotationComments(doc.Packages[0].Annotations)).To(ConsistOf(
+			"gardener.cloud/type=tgz",
+			"labels.gardener.cloud/spdx.dev/license=Apache-2.0",
+			`labels.gardener.cloud/spdx.dev/supplier={"name":"gardener"}`,
+			"labels.gardener.cloud/gardener.cloud/purpose=deployment",
+		))
+	})
+
+	It("should convert a generated spdx document back into the component descriptor", func() {
+		doc, err := sbom.ToSPDX(newComponentDescriptor(), nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		cd, err := sbom.FromSPDX(doc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cd.Name).To(Equal("github.com/gardener/example"))
+		Expect(cd.Version).To(Equal("v1.2.3"))
+		Expect(cd.Provider).To(Equal(cdv2.ProviderType("internal")))
+		Expect(cd.ComponentReferences).To(ConsistOf(newComponentDescriptor().ComponentReferences))
+		Expect(cd.Resources).To(HaveLen(1))
+		Expect(cd.Resources[0].Type).To(Equal("tgz"))
+		Expect(cd.Resources[0].Digest).To(Equal(newComponentDescriptor().Resources[0].Digest))
+		Expect(cd.Resources[0].Access.GetType()).To(Equal(cdv2.WebType))
+	})
+
+	It("should not apply labels as resource fields on round trip", func() {
+		cd := newComponentDescriptor()
+		cd.Resources[0].Labels = append(cd.Resources[0].Labels,
+			cdv2.Label{Name: "gardener.cloud/version", Value: json.RawMessage(`"v9.9.9"`)},
+			cdv2.Label{Name: "gardener.cloud/type", Value: json.RawMessage(`"zip"`)},
+		)
+		doc, err := sbom.ToSPDX(cd, sbom.AllLabelsMapper{})
+		Expect(err).ToNot(HaveOccurred())
+
+		result, err := sbom.FromSPDX(doc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Resources).To(HaveLen(1))
+		Expect(result.Resources[0].Version).To(Equal("v1.2.3"))
+		Expect(result.Resources[0].Type).To(Equal("tgz"))
+	})
+
+	It("should propagate the mapped labels to the cyclonedx properties", func() {
+		bom, err := sbom.ToCycloneDX(newComponentDescriptor(), sbom.DefaultLabelMapper{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(bom.Metadata.Component.Name).To(Equal("github.com/gardener/example"))
+		Expect(bom.Components).To(HaveLen(2))
+		Expect(bom.Components[0].Properties).To(ConsistOf(
+			sbom.CycloneDXProperty{Name: "spdx.dev/license", Value: "Apache-2.0"},
+			sbom.CycloneDXProperty{Name: "spdx.dev/supplier", Value: `{"name":"gardener"}`},
+		))
+		Expect(bom.Components[0].Hashes).To(ConsistOf(sbom.CycloneDXHash{
+			Algorithm: "SHA-256",
+			Content:   "7d865e959b2466918c9863afca942d0fb89d7c9ac0c99bafc3749504ded97730",
+		}))
+		Expect(bom.Dependencies).To(ConsistOf(sbom.CycloneDXDependency{
+			Ref:       "github.com/gardener/example@v1.2.3",
+			DependsOn: []string{"github.com/gardener/dependency@v0.1.0"},
+		}))
+	})
+
+})
@@ -0,0 +1,85 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"strings"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// SPDXLabelPrefix is the prefix of resource labels that are mapped to sbom annotations by the DefaultLabelMapper.
+const SPDXLabelPrefix = "spdx.dev/"
+
+// LabelToAnnotationMapper maps resource labels to annotations of a generated sbom.
+type LabelToAnnotationMapper interface {
+	// Map returns the key and value of the annotation for the label.
+	// The label is dropped if include is false.
+	Map(label cdv2.Label) (key, value string, include bool)
+}
+
+// DefaultLabelMapper includes all labels with the SPDXLabelPrefix and drops all others.
+type DefaultLabelMapper struct{}
+
+var _ LabelToAnnotationMapper = DefaultLabelMapper{}
+
+func (DefaultLabelMapper) Map(label cdv2.Label) (string, string, bool) {
+	if !strings.HasPrefix(label.Name, SPDXLabelPrefix) {
+		return "", "", false
+	}
+	return label.Name, labelValue(label), true
+}
+
+// AllLabelsMapper includes every label.
+type AllLabelsMapper struct{}
+
+var _ LabelToAnnotationMapper = AllLabelsMapper{}
+
+func (AllLabelsMapper) Map(label cdv2.Label) (string, string, bool) {
+	return label.Name, labelValue(label), true
+}
+
+// labelValue returns the value of the label as string.
+// Json strings are unquoted, all other values are returned as json.
+func labelValue(label cdv2.Label) string {
+	var value string
+	if err := json.Unmarshal(label.Value, &value); err == nil {
+		return value
+	}
+	return string(label.Value)
+}
+
+// labelAnnotation is a label that has been mapped to an sbom annotation.
+type labelAnnotation struct {
+	Key   string
+	Value string
+}
+
+// mapLabels returns the annotations of all labels that are included by the mapper.
+// The DefaultLabelMapper is used if no mapper is given.
+func mapLabels(labels cdv2.Labels, mapper LabelToAnnotationMapper) []labelAnnotation {
+	if mapper == nil {
+		mapper = DefaultLabelMapper{}
+	}
+	annotations := make([]labelAnnotation, 0)
+	for _, label := range labels {
+		key, value, include := mapper.Map(label)
+		if include {
+			annotations = append(annotations, labelAnnotation{Key: key, Value: value})
+		}
+	}
+	return annotations
+}
@@ -0,0 +1,216 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+)
+
+// AnnotationPrefix is the prefix of spdx annotations that contain component descriptor fields
+// that are not representable in the spdx model.
+// The comment of such an annotation has the form "gardener.cloud/<fieldname>=<value>",
+// where the value is either json or a plain string.
+const AnnotationPrefix = "gardener.cloud/"
+
+// LabelAnnotationPrefix is the prefix of spdx annotations that contain the resource labels that are included by a LabelToAnnotationMapper.
+// The comment of such an annotation has the form "labels.gardener.cloud/<key>=<value>".
+// Labels use a separate prefix so that they are never applied as component descriptor fields by FromSPDX.
+const LabelAnnotationPrefix = "labels.gardener.cloud/"
+
+// DependsOnRelationship is the spdx relationship type of packages that are component references.
+const DependsOnRelationship = "DEPENDS_ON"
+
+// DefaultResourceType is the resource type of packages without a known file extension.
+const DefaultResourceType = "blob"
+
+// ResourceTypesByFileExtension maps the file extension of a package filename to the type of the resource.
+var ResourceTypesByFileExtension = map[string]string{
+	".tar":    "tar",
+	".tar.gz": "tgz",
+	".tgz":    "tgz",
+	".zip":    "zip",
+	".jar":    "jar",
+	".whl":    "wheel",
+	".json":   "json",
+	".yaml":   "yaml",
+	".yml":    "yaml",
+}
+
+// FromSPDX converts a spdx document into a component descriptor.
+// The document name is the name of the component.
+// Packages that are the target of a DEPENDS_ON relationship become component references,
+// all other packages become resources.
+// Document annotations with the AnnotationPrefix set the fields of the component, e.g. its version or repository contexts.
+// Package annotations with the AnnotationPrefix set the fields of the resulting resource or component reference.
+func FromSPDX(doc *spdx.Document) (*cdv2.ComponentDescriptor, error) {
+	cd := &cdv2.ComponentDescriptor{}
+	cd.Metadata.Version = cdv2.SchemaVersion
+	cd.Name = doc.DocumentName
+
+	dependencies := map[common.ElementID]struct{}{}
+	for _, rel := range doc.Relationships {
+		if rel == nil || rel.Relationship != DependsOnRelationship || len(rel.RefB.DocumentRefID) != 0 {
+			continue
+		}
+		dependencies[rel.RefB.ElementRefID] = struct{}{}
+	}
+
+	for _, pkg := range doc.Packages {
+		if pkg == nil {
+			continue
+		}
+		if _, ok := dependencies[pkg.PackageSPDXIdentifier]; ok {
+			ref := cdv2.ComponentReference{
+				Name:          pkg.PackageName,
+				ComponentName: pkg.PackageName,
+				Version:       pkg.PackageVersion,
+			}
+			if err := applyAnnotations(&ref, pkg.Annotations); err != nil {
+				return nil, fmt.Errorf("unable to apply annotations of package %q: %w", pkg.PackageName, err)
+			}
+			cd.ComponentReferences = append(cd.ComponentReferences, ref)
+			continue
+		}
+
+		res, err := packageToResource(pkg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert package %q: %w", pkg.PackageName, err)
+		}
+		cd.Resources = append(cd.Resources, res)
+	}
+
+	docAnnotations := make([]spdx.Annotation, 0)
+	for _, annotation := range doc.Annotations {
+		if annotation != nil {
+			docAnnotations = append(docAnnotations, *annotation)
+		}
+	}
+	if err := applyAnnotations(&cd.ComponentSpec, docAnnotations); err != nil {
+		return nil, fmt.Errorf("unable to apply document annotations: %w", err)
+	}
+
+	if err := cdv2.DefaultComponent(cd); err != nil {
+		return nil, err
+	}
+	return cd, nil
+}
+
+// packageToResource converts a spdx package into a resource.
+func packageToResource(pkg *spdx.Package) (cdv2.Resource, error) {
+	res := cdv2.Resource{
+		IdentityObjectMeta: cdv2.IdentityObjectMeta{
+			Name:    pkg.PackageName,
+			Version: pkg.PackageVersion,
+			Type:    resourceTypeForFilename(pkg.PackageFileName),
+		},
+		Relation: cdv2.ExternalRelation,
+	}
+	for _, checksum := range pkg.PackageChecksums {
+		if checksum.Algorithm != spdx.SHA256 {
+			continue
+		}
+		res.Digest = &cdv2.DigestSpec{
+			HashAlgorithm:          "sha256",
+			NormalisationAlgorithm: string(cdv2.GenericBlobDigestV1),
+			Value:                  strings.ToLower(checksum.Value),
+		}
+	}
+	if strings.HasPrefix(pkg.PackageDownloadLocation, "http://") || strings.HasPrefix(pkg.PackageDownloadLocation, "https://") {
+		access, err := cdv2.NewUnstructured(cdv2.NewWebAccess(pkg.PackageDownloadLocation))
+		if err != nil {
+			return cdv2.Resource{}, err
+		}
+		res.Access = &access
+	}
+	if err := applyAnnotations(&res, pkg.Annotations); err != nil {
+		return cdv2.Resource{}, err
+	}
+	return res, nil
+}
+
+// resourceTypeForFilename returns the resource type for the file extension of the given filename.
+func resourceTypeForFilename(filename string) string {
+	for ext, ttype := range ResourceTypesByFileExtension {
+		// multi part extensions like ".tar.gz" are not returned by path.Ext
+		if strings.Contains(ext[1:], ".") && strings.HasSuffix(filename, ext) {
+			return ttype
+		}
+	}
+	if ttype, ok := ResourceTypesByFileExtension[path.Ext(filename)]; ok {
+		return ttype
+	}
+	return DefaultResourceType
+}
+
+// applyAnnotations sets the fields of the given object that are defined by annotations with the AnnotationPrefix.
+// The object is serialized to json, the fields are set by their json name and the result is decoded into the object.
+func applyAnnotations(obj interface{}, annotations []spdx.Annotation) error {
+	fields := map[string]json.RawMessage{}
+	for _, annotation := range annotations {
+		if !strings.HasPrefix(annotation.AnnotationComment, AnnotationPrefix) {
+			continue
+		}
+		keyValue := strings.SplitN(strings.TrimPrefix(annotation.AnnotationComment, AnnotationPrefix), "=", 2)
+		if len(keyValue) != 2 {
+			return fmt.Errorf("annotation %q has no value", annotation.AnnotationComment)
+		}
+		value, err := annotationValue(keyValue[1])
+		if err != nil {
+			return fmt.Errorf("unable to decode value of annotation %q: %w", keyValue[0], err)
+		}
+		fields[keyValue[0]] = value
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	current := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &current); err != nil {
+		return err
+	}
+	for key, value := range fields {
+		current[key] = value
+	}
+	data, err = json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, obj)
+}
+
+// annotationValue returns the json representation of an annotation value.
+// Json objects, arrays and strings are used as they are, all other values are treated as plain strings.
+func annotationValue(value string) (json.RawMessage, error) {
+	trimmed := strings.TrimSpace(value)
+	if len(trimmed) != 0 && strings.ContainsAny(trimmed[:1], `{["`) {
+		if !json.Valid([]byte(trimmed)) {
+			return nil, fmt.Errorf("invalid json %q", trimmed)
+		}
+		return json.RawMessage(trimmed), nil
+	}
+	return json.Marshal(value)
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom_test
+
+import (
+	"encoding/json"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	spdxjson "github.com/spdx/tools-golang/json"
+	"github.com/spdx/tools-golang/spdx"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/sbom"
+)
+
+var _ = Describe("SPDX import", func() {
+
+	readDocument := func(path string) *spdx.Document {
+		file, err := os.Open(path)
+		Expect(err).ToNot(HaveOccurred())
+		defer file.Close()
+		doc, err := spdxjson.Read(file)
+		Expect(err).ToNot(HaveOccurred())
+		return doc
+	}
+
+	It("should convert a spdx document into a component descriptor", func() {
+		cd, err := sbom.FromSPDX(readDocument("./testdata/component.spdx.json"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(cd.Name).To(Equal("github.com/gardener/example"))
+		Expect(cd.Version).To(Equal("v1.2.3"))
+		Expect(cd.Provider).To(Equal(cdv2.ProviderType("internal")))
+		Expect(cd.RepositoryContexts).To(HaveLen(1))
+		Expect(cd.RepositoryContexts[0].GetType()).To(Equal(cdv2.OCIRegistryType))
+		Expect(cd.RepositoryContexts[0].Object).To(HaveKeyWithValue("baseUrl", "eu.gcr.io/gardener-project/components"))
+
+		Expect(cd.ComponentReferences).To(ConsistOf(cdv2.ComponentReference{
+			Name:          "github.com/gardener/dependency",
+			ComponentName: "github.com/gardener/dependency",
+			Version:       "v0.1.0",
+		}))
+
+		Expect(cd.Resources).To(HaveLen(2))
+		chart := cd.Resources[0]
+		Expect(chart.Name).To(Equal("chart"))
+		Expect(chart.Version).To(Equal("v1.2.3"))
+		Expect(chart.Type).To(Equal("tgz"))
+		Expect(chart.Relation).To(Equal(cdv2.ExternalRelation))
+		Expect(chart.Digest).To(Equal(&cdv2.DigestSpec{
+			HashAlgorithm:          "sha256",
+			NormalisationAlgorithm: string(cdv2.GenericBlobDigestV1),
+			Value:                  "7d865e959b2466918c9863afca942d0fb89d7c9ac0c99bafc3749504ded97730",
+		}))
+		Expect(chart.Access).ToNot(BeNil())
+		Expect(chart.Access.GetType()).To(Equal(cdv2.WebType))
+		Expect(chart.Labels).To(ConsistOf(cdv2.Label{
+			Name:  "example.com/purpose",
+			Value: json.RawMessage(`"deployment"`),
+		}))
+
+		config := cd.Resources[1]
+		Expect(config.Name).To(Equal("config"))
+		Expect(config.Type).To(Equal("yaml"))
+		Expect(config.Access).To(BeNil())
+		Expect(config.Digest).To(BeNil())
+	})
+
+	It("should use the default resource type for unknown file extensions", func() {
+		cd, err := sbom.FromSPDX(&spdx.Document{
+			DocumentName: "example.com/component",
+			Packages: []*spdx.Package{
+				{
+					PackageName:     "binary",
+					PackageFileName: "binary.bin",
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cd.Resources).To(HaveLen(1))
+		Expect(cd.Resources[0].Type).To(Equal(sbom.DefaultResourceType))
+	})
+
+	It("should fail on invalid json annotations", func() {
+		_, err := sbom.FromSPDX(&spdx.Document{
+			DocumentName: "example.com/component",
+			Annotations: []*spdx.Annotation{
+				{AnnotationComment: "gardener.cloud/repositoryContexts=[invalid"},
+			},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})